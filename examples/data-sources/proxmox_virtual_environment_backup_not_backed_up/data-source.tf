@@ -0,0 +1,8 @@
+data "proxmox_virtual_environment_backup_not_backed_up" "example" {}
+
+check "all_guests_backed_up" {
+  assert {
+    condition     = length(data.proxmox_virtual_environment_backup_not_backed_up.example.guests) == 0
+    error_message = "Guests without a backup job: ${join(", ", data.proxmox_virtual_environment_backup_not_backed_up.example.guests[*].vm_id)}"
+  }
+}
@@ -0,0 +1,11 @@
+data "proxmox_virtual_environment_replication_status" "database" {
+  id = proxmox_virtual_environment_replication_job.database.id
+}
+
+output "database_replication_last_sync" {
+  value = data.proxmox_virtual_environment_replication_status.database.last_sync
+}
+
+output "database_replication_fail_count" {
+  value = data.proxmox_virtual_environment_replication_status.database.fail_count
+}
@@ -0,0 +1,11 @@
+data "proxmox_virtual_environment_sdn_subnet" "example" {
+  vnet = "vnet100"
+  cidr = "10.10.0.0/24"
+}
+
+output "data_proxmox_virtual_environment_sdn_subnet" {
+  value = {
+    gateway     = data.proxmox_virtual_environment_sdn_subnet.example.gateway
+    dhcp_ranges = data.proxmox_virtual_environment_sdn_subnet.example.dhcp_ranges
+  }
+}
@@ -0,0 +1,10 @@
+data "proxmox_virtual_environment_sdn_vnet" "example" {
+  name = "vnet100"
+}
+
+output "data_proxmox_virtual_environment_sdn_vnet" {
+  value = {
+    zone = data.proxmox_virtual_environment_sdn_vnet.example.zone
+    tag  = data.proxmox_virtual_environment_sdn_vnet.example.tag
+  }
+}
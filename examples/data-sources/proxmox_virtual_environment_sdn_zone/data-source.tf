@@ -0,0 +1,11 @@
+data "proxmox_virtual_environment_sdn_zone" "example" {
+  name = "vlan"
+}
+
+output "data_proxmox_virtual_environment_sdn_zone" {
+  value = {
+    type    = data.proxmox_virtual_environment_sdn_zone.example.type
+    bridge  = data.proxmox_virtual_environment_sdn_zone.example.bridge
+    pending = data.proxmox_virtual_environment_sdn_zone.example.pending
+  }
+}
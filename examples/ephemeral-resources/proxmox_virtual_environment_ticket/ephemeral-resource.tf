@@ -0,0 +1,12 @@
+# a ticket of a user with limited privileges, for the resources managed on their behalf
+ephemeral "proxmox_virtual_environment_ticket" "operator" {
+  username = "operator@pve"
+  password = var.operator_password
+}
+
+provider "proxmox" {
+  alias                 = "operator"
+  endpoint              = "https://pve.example.com:8006/"
+  auth_ticket           = ephemeral.proxmox_virtual_environment_ticket.operator.ticket
+  csrf_prevention_token = ephemeral.proxmox_virtual_environment_ticket.operator.csrf_prevention_token
+}
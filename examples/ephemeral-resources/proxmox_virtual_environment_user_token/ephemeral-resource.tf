@@ -0,0 +1,12 @@
+# a short-lived token for the deployment, deleted once the run is complete
+ephemeral "proxmox_virtual_environment_user_token" "deploy" {
+  comment  = "Managed by Terraform"
+  user_id  = "deploy@pve"
+  lifetime = "30m"
+}
+
+provider "proxmox" {
+  alias     = "deploy"
+  endpoint  = "https://pve.example.com:8006/"
+  api_token = ephemeral.proxmox_virtual_environment_user_token.deploy.value
+}
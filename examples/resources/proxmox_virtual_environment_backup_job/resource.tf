@@ -0,0 +1,28 @@
+resource "proxmox_virtual_environment_backup_job" "nightly" {
+  name     = "nightly"
+  schedule = "*-*-* 02:00"
+  storage  = "pbs"
+  all      = true
+  exclude  = [9000]
+  mode     = "snapshot"
+  compress = "zstd"
+
+  prune_backups = {
+    keep_daily   = 7
+    keep_weekly  = 4
+    keep_monthly = 6
+  }
+
+  notification_mode = "notification-system"
+  comment           = "Nightly backup of all guests except the templates"
+}
+
+resource "proxmox_virtual_environment_backup_job" "databases" {
+  name     = "databases"
+  schedule = "mon..fri 12:00"
+  storage  = "pbs"
+  vm_ids   = [110, 111]
+
+  mail_to           = ["dba@example.com"]
+  mail_notification = "failure"
+}
@@ -0,0 +1,3 @@
+#!/usr/bin/env sh
+# the ID of a replication job is <vm_id>-<job_number>
+terraform import proxmox_virtual_environment_replication_job.database 100-0
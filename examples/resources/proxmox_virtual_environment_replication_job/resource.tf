@@ -0,0 +1,15 @@
+resource "proxmox_virtual_environment_replication_job" "database" {
+  vm_id    = 100
+  target   = "pve2"
+  schedule = "*/5"
+  rate     = 100
+  comment  = "Replication for the failover of the database"
+}
+
+# a second job of the same guest, replicating to another node
+resource "proxmox_virtual_environment_replication_job" "database_offsite" {
+  vm_id      = 100
+  job_number = 1
+  target     = "pve3"
+  schedule   = "22:00"
+}
@@ -0,0 +1,14 @@
+# apply the pending changes once, after all SDN resources have been changed
+resource "proxmox_virtual_environment_sdn_applier" "example" {
+  triggers = {
+    zone   = proxmox_virtual_environment_sdn_zone.vlan.mtu
+    vnet   = proxmox_virtual_environment_sdn_vnet.example.tag
+    subnet = proxmox_virtual_environment_sdn_subnet.example.gateway
+  }
+
+  depends_on = [
+    proxmox_virtual_environment_sdn_zone.vlan,
+    proxmox_virtual_environment_sdn_vnet.example,
+    proxmox_virtual_environment_sdn_subnet.example,
+  ]
+}
@@ -0,0 +1,23 @@
+resource "proxmox_virtual_environment_sdn_controller" "evpn" {
+  name  = "evpn"
+  type  = "evpn"
+  asn   = 65000
+  peers = ["10.0.0.1", "10.0.0.2", "10.0.0.3"]
+}
+
+resource "proxmox_virtual_environment_sdn_controller" "bgp" {
+  name     = "bgp-pve1"
+  type     = "bgp"
+  node     = "pve1"
+  asn      = 65001
+  peers    = ["192.168.0.254"]
+  ebgp     = true
+  loopback = "dummy0"
+}
+
+resource "proxmox_virtual_environment_sdn_zone" "evpn" {
+  name       = "evpn"
+  type       = "evpn"
+  controller = proxmox_virtual_environment_sdn_controller.evpn.name
+  vrf_vxlan  = 10000
+}
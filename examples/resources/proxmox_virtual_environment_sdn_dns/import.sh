@@ -0,0 +1,3 @@
+#!/usr/bin/env sh
+# the key is not imported, and is updated on the next apply
+terraform import proxmox_virtual_environment_sdn_dns.example powerdns
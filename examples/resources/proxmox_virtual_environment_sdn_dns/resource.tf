@@ -0,0 +1,14 @@
+resource "proxmox_virtual_environment_sdn_dns" "powerdns" {
+  name = "powerdns"
+  type = "powerdns"
+  url  = "https://powerdns.example.com:8081/api/v1/servers/localhost"
+  key  = var.powerdns_key
+  ttl  = 3600
+}
+
+resource "proxmox_virtual_environment_sdn_zone" "example" {
+  name     = "example"
+  type     = "simple"
+  dns      = proxmox_virtual_environment_sdn_dns.powerdns.name
+  dns_zone = "example.com"
+}
@@ -0,0 +1,24 @@
+resource "proxmox_virtual_environment_sdn_ipam" "netbox" {
+  name  = "netbox"
+  type  = "netbox"
+  url   = "https://netbox.example.com/api"
+  token = var.netbox_token
+}
+
+resource "proxmox_virtual_environment_sdn_ipam" "phpipam" {
+  name    = "phpipam"
+  type    = "phpipam"
+  url     = "https://phpipam.example.com/api/app"
+  section = 1
+
+  # the token is not stored in the state, increase the version to update it
+  token_wo         = var.phpipam_token
+  token_wo_version = 1
+}
+
+resource "proxmox_virtual_environment_sdn_zone" "example" {
+  name   = "example"
+  type   = "vlan"
+  bridge = "vmbr0"
+  ipam   = proxmox_virtual_environment_sdn_ipam.netbox.name
+}
@@ -0,0 +1,3 @@
+#!/usr/bin/env sh
+# the subnet is identified by its VNet and its ID, `<zone>-<network>-<prefix length>`
+terraform import proxmox_virtual_environment_sdn_subnet.example vnet100/vlan-10.10.0.0-24
@@ -0,0 +1,13 @@
+resource "proxmox_virtual_environment_sdn_subnet" "example" {
+  vnet    = proxmox_virtual_environment_sdn_vnet.example.name
+  cidr    = "10.10.0.0/24"
+  gateway = "10.10.0.1"
+  snat    = true
+
+  dhcp_ranges = [
+    {
+      start_address = "10.10.0.100"
+      end_address   = "10.10.0.200"
+    }
+  ]
+}
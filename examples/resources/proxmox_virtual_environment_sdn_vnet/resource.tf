@@ -0,0 +1,6 @@
+resource "proxmox_virtual_environment_sdn_vnet" "example" {
+  name  = "vnet100"
+  zone  = proxmox_virtual_environment_sdn_zone.vlan.name
+  tag   = 100
+  alias = "Guests VLAN"
+}
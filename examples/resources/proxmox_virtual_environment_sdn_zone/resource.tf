@@ -0,0 +1,19 @@
+resource "proxmox_virtual_environment_sdn_zone" "simple" {
+  name = "simple"
+  type = "simple"
+  dhcp = "dnsmasq"
+}
+
+resource "proxmox_virtual_environment_sdn_zone" "vlan" {
+  name   = "vlan"
+  type   = "vlan"
+  bridge = "vmbr0"
+  mtu    = 1496
+}
+
+resource "proxmox_virtual_environment_sdn_zone" "vxlan" {
+  name  = "vxlan"
+  type  = "vxlan"
+  peers = ["10.0.0.1", "10.0.0.2", "10.0.0.3"]
+  mtu   = 1450
+}
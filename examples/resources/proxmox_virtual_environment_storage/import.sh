@@ -0,0 +1,3 @@
+#!/usr/bin/env sh
+# the credentials are not imported, and are updated on the next apply
+terraform import proxmox_virtual_environment_storage.example nfs-backups
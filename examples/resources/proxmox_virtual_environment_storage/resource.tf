@@ -0,0 +1,36 @@
+resource "proxmox_virtual_environment_storage" "nfs" {
+  name    = "nfs-backups"
+  type    = "nfs"
+  server  = "10.0.0.10"
+  export  = "/export/backups"
+  content = ["backup", "iso", "vztmpl"]
+  options = "vers=4.2"
+
+  prune_backups = {
+    keep_last  = 3
+    keep_daily = 7
+  }
+}
+
+resource "proxmox_virtual_environment_storage" "zfs" {
+  name    = "tank"
+  type    = "zfspool"
+  pool    = "tank/vms"
+  content = ["images", "rootdir"]
+  nodes   = ["pve1", "pve2"]
+  sparse  = true
+}
+
+resource "proxmox_virtual_environment_storage" "pbs" {
+  name           = "pbs"
+  type           = "pbs"
+  server         = "pbs.example.com"
+  datastore      = "store1"
+  username       = "backup@pbs"
+  fingerprint    = var.pbs_fingerprint
+  encryption_key = "autogen"
+
+  # the password is not stored in the state, increase the version to update it
+  password_wo         = var.pbs_password
+  password_wo_version = 1
+}
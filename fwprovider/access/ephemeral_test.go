@@ -0,0 +1,135 @@
+//go:build acceptance || all
+
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package access_test
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/test"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/fake"
+)
+
+func TestAccEphemeralTicket(t *testing.T) {
+	t.Parallel()
+
+	te := test.InitFakeEnvironment(t)
+	te.AddTemplateVars(map[string]any{
+		"Username": fake.DefaultUsername,
+		"Password": fake.DefaultPassword,
+	})
+
+	providers := maps.Clone(te.AccProviders)
+	providers["echo"] = echoprovider.NewProviderServer()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: providers,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: te.RenderConfig(`
+				ephemeral "proxmox_virtual_environment_ticket" "test" {
+					username = "{{.Username}}"
+					password = "{{.Password}}"
+				}
+
+				provider "echo" {
+					data = ephemeral.proxmox_virtual_environment_ticket.test
+				}
+
+				resource "echo" "test" {}`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("echo.test", "data.ticket", regexp.MustCompile(`^PVE:root@pam:`)),
+					resource.TestCheckResourceAttrSet("echo.test", "data.csrf_prevention_token"),
+					resource.TestCheckResourceAttrSet("echo.test", "data.expiration_date"),
+				),
+			},
+			{
+				Config: te.RenderConfig(`
+				ephemeral "proxmox_virtual_environment_ticket" "test" {
+					username = "{{.Username}}"
+					password = "wrong"
+				}`),
+				ExpectError: regexp.MustCompile(`Error creating ticket`),
+			},
+		},
+	})
+}
+
+func TestAccEphemeralUserToken(t *testing.T) {
+	t.Parallel()
+
+	te := test.InitFakeEnvironment(t)
+	te.AddTemplateVars(map[string]any{
+		"UserID": fake.DefaultUsername,
+	})
+
+	providers := maps.Clone(te.AccProviders)
+	providers["echo"] = echoprovider.NewProviderServer()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: providers,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: te.RenderConfig(`
+				ephemeral "proxmox_virtual_environment_user_token" "test" {
+					user_id    = "{{.UserID}}"
+					token_name = "tmp"
+					lifetime   = "30m"
+				}
+
+				provider "echo" {
+					data = ephemeral.proxmox_virtual_environment_user_token.test
+				}
+
+				resource "echo" "test" {}`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("echo.test", "data.id", fake.DefaultUsername+"!tmp"),
+					resource.TestCheckResourceAttr("echo.test", "data.privileges_separation", "true"),
+					resource.TestMatchResourceAttr("echo.test", "data.value",
+						regexp.MustCompile(`^root@pam!tmp=[0-9a-f-]{36}$`)),
+					resource.TestCheckResourceAttrSet("echo.test", "data.expiration_date"),
+					testAccCheckUserTokensDeleted(te),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckUserTokensDeleted checks that only the pre-existing token of the user is left,
+// as the temporary tokens are deleted once Terraform no longer needs them.
+func testAccCheckUserTokensDeleted(te *test.Environment) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		tokens, err := te.AccessClient().ListUserTokens(context.Background(), fake.DefaultUsername)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		for _, tk := range tokens {
+			if tk.TokenID != "fake" {
+				return fmt.Errorf("the temporary token %q has not been deleted", tk.TokenID)
+			}
+		}
+
+		return nil
+	}
+}
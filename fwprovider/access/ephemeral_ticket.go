@@ -0,0 +1,150 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package access
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/validators"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/access"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+var (
+	_ ephemeral.EphemeralResource              = &ticketEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &ticketEphemeralResource{}
+)
+
+type ticketEphemeralResource struct {
+	client proxmox.Client
+}
+
+type ticketModel struct {
+	CSRFPreventionToken types.String `tfsdk:"csrf_prevention_token"`
+	ExpirationDate      types.String `tfsdk:"expiration_date"`
+	OTP                 types.String `tfsdk:"otp"`
+	Password            types.String `tfsdk:"password"`
+	Ticket              types.String `tfsdk:"ticket"`
+	Username            types.String `tfsdk:"username"`
+}
+
+// NewTicketEphemeralResource creates a new ticket ephemeral resource.
+func NewTicketEphemeralResource() ephemeral.EphemeralResource {
+	return &ticketEphemeralResource{}
+}
+
+func (r *ticketEphemeralResource) Schema(
+	_ context.Context,
+	_ ephemeral.SchemaRequest,
+	resp *ephemeral.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Authentication ticket of a user.",
+		MarkdownDescription: "Authentication ticket of a user, with the CSRF prevention token required " +
+			"to send write requests with the ticket. The ticket is valid for two hours.",
+		Attributes: map[string]schema.Attribute{
+			"csrf_prevention_token": schema.StringAttribute{
+				Description: "CSRF prevention token, sent in the `CSRFPreventionToken` header of write requests.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"expiration_date": schema.StringAttribute{
+				Description: "Expiration date of the ticket, in RFC3339 format.",
+				Computed:    true,
+			},
+			"otp": schema.StringAttribute{
+				Description: "One-time password of the user, if two-factor authentication is enabled.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"password": schema.StringAttribute{
+				Description: "Password of the user.",
+				Required:    true,
+				Sensitive:   true,
+			},
+			"ticket": schema.StringAttribute{
+				Description: "Authentication ticket, sent in the `PVEAuthCookie` cookie.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"username": schema.StringAttribute{
+				Description: "User identifier, e.g. `terraform@pve`.",
+				Required:    true,
+				Validators: []validator.String{
+					validators.NonEmptyString(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ticketEphemeralResource) Configure(
+	_ context.Context,
+	req ephemeral.ConfigureRequest,
+	resp *ephemeral.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.EphemeralResource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected config.EphemeralResource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = cfg.Client
+}
+
+func (r *ticketEphemeralResource) Metadata(
+	_ context.Context,
+	req ephemeral.MetadataRequest,
+	resp *ephemeral.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_ticket"
+}
+
+func (r *ticketEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data ticketModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	issuedAt := time.Now()
+
+	ticket, err := r.client.Access().CreateTicket(ctx, &access.TicketCreateRequestBody{
+		Username: data.Username.ValueString(),
+		Password: data.Password.ValueString(),
+		OTP:      data.OTP.ValueStringPointer(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating ticket", err.Error())
+		return
+	}
+
+	data.Ticket = types.StringPointerValue(ticket.Ticket)
+	data.CSRFPreventionToken = types.StringPointerValue(ticket.CSRFPreventionToken)
+	data.ExpirationDate = types.StringValue(issuedAt.Add(api.TicketLifetime).UTC().Format(time.RFC3339))
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, data)...)
+}
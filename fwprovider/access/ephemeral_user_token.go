@@ -0,0 +1,256 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package access
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/validators"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/access"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	proxmoxtypes "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+const (
+	// defaultEphemeralTokenLifetime is the lifetime of a temporary token when none is configured.
+	defaultEphemeralTokenLifetime = time.Hour
+
+	// ephemeralTokenPrivateKey is the key of the private data holding the token to delete on close.
+	ephemeralTokenPrivateKey = "token"
+)
+
+var (
+	_ ephemeral.EphemeralResource              = &userTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &userTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithClose     = &userTokenEphemeralResource{}
+)
+
+type userTokenEphemeralResource struct {
+	client proxmox.Client
+}
+
+type userTokenEphemeralModel struct {
+	Comment        types.String `tfsdk:"comment"`
+	ExpirationDate types.String `tfsdk:"expiration_date"`
+	ID             types.String `tfsdk:"id"`
+	Lifetime       types.String `tfsdk:"lifetime"`
+	PrivSeparation types.Bool   `tfsdk:"privileges_separation"`
+	TokenName      types.String `tfsdk:"token_name"`
+	UserID         types.String `tfsdk:"user_id"`
+	Value          types.String `tfsdk:"value"`
+}
+
+// userTokenPrivateData identifies the token to delete on close.
+type userTokenPrivateData struct {
+	UserID    string `json:"user_id"`
+	TokenName string `json:"token_name"`
+}
+
+// NewUserTokenEphemeralResource creates a new user token ephemeral resource.
+func NewUserTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &userTokenEphemeralResource{}
+}
+
+func (r *userTokenEphemeralResource) Schema(
+	_ context.Context,
+	_ ephemeral.SchemaRequest,
+	resp *ephemeral.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Temporary user API token.",
+		MarkdownDescription: "Temporary user API token. The token expires after its lifetime, " +
+			"and is deleted once Terraform no longer needs it.",
+		Attributes: map[string]schema.Attribute{
+			"comment": schema.StringAttribute{
+				Description: "Comment for the token.",
+				Optional:    true,
+			},
+			"expiration_date": schema.StringAttribute{
+				Description: "Expiration date of the token, in RFC3339 format.",
+				Computed:    true,
+			},
+			"id": schema.StringAttribute{
+				Description: "Unique token identifier with format `<user_id>!<token_name>`.",
+				Computed:    true,
+			},
+			"lifetime": schema.StringAttribute{
+				Description: "Lifetime of the token, e.g. `30m` or `2h` (defaults to `1h`).",
+				MarkdownDescription: "Lifetime of the token, e.g. `30m` or `2h` (defaults to `1h`). " +
+					"The token expires after its lifetime, even if it could not be deleted.",
+				Optional: true,
+				Validators: []validator.String{
+					validators.DurationValidator(),
+				},
+			},
+			"privileges_separation": schema.BoolAttribute{
+				Description: "Restrict API token privileges with separate ACLs (default)",
+				MarkdownDescription: "Restrict API token privileges with separate ACLs (default), " +
+					"or give full privileges of corresponding user.",
+				Optional: true,
+			},
+			"token_name": schema.StringAttribute{
+				Description: "User-specific token identifier.",
+				MarkdownDescription: "User-specific token identifier. A unique name prefixed with `terraform-` " +
+					"is generated if not set.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`[A-Za-z][A-Za-z0-9.\-_]+`), "must be a valid token identifier"),
+				},
+			},
+			"user_id": schema.StringAttribute{
+				Description: "User identifier.",
+				Required:    true,
+			},
+			"value": schema.StringAttribute{
+				Description: "API token value used for authentication, with format `<user_id>!<token_name>=<secret>`.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+func (r *userTokenEphemeralResource) Configure(
+	_ context.Context,
+	req ephemeral.ConfigureRequest,
+	resp *ephemeral.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.EphemeralResource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected config.EphemeralResource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = cfg.Client
+}
+
+func (r *userTokenEphemeralResource) Metadata(
+	_ context.Context,
+	req ephemeral.MetadataRequest,
+	resp *ephemeral.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_user_token"
+}
+
+func (r *userTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data userTokenEphemeralModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	lifetime := defaultEphemeralTokenLifetime
+
+	if !data.Lifetime.IsNull() {
+		d, err := time.ParseDuration(data.Lifetime.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Error parsing lifetime", err.Error())
+			return
+		}
+
+		lifetime = d
+	}
+
+	if data.TokenName.IsNull() || data.TokenName.IsUnknown() {
+		data.TokenName = types.StringValue("terraform-" + strings.Split(uuid.NewString(), "-")[0])
+	}
+
+	privSeparate := true
+	if !data.PrivSeparation.IsNull() {
+		privSeparate = data.PrivSeparation.ValueBool()
+	}
+
+	// the expiration date has a precision of one second, rounding up avoids an expiration in the past
+	expirationDate := time.Now().Add(lifetime).Truncate(time.Second).Add(time.Second)
+	expire := expirationDate.Unix()
+
+	userID := data.UserID.ValueString()
+	tokenName := data.TokenName.ValueString()
+
+	value, err := r.client.Access().CreateUserToken(ctx, userID, tokenName, &access.UserTokenCreateRequestBody{
+		Comment:        data.Comment.ValueStringPointer(),
+		ExpirationDate: &expire,
+		PrivSeparate:   proxmoxtypes.CustomBool(privSeparate).Pointer(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating user token", err.Error())
+		return
+	}
+
+	private, err := json.Marshal(userTokenPrivateData{UserID: userID, TokenName: tokenName})
+	if err != nil {
+		resp.Diagnostics.AddError("Error encoding user token private data", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, ephemeralTokenPrivateKey, private)...)
+
+	data.ID = types.StringValue(userID + "!" + tokenName)
+	data.Value = types.StringValue(value)
+	data.ExpirationDate = types.StringValue(expirationDate.UTC().Format(time.RFC3339))
+	data.PrivSeparation = types.BoolValue(privSeparate)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, data)...)
+}
+
+func (r *userTokenEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	private, diags := req.Private.GetKey(ctx, ephemeralTokenPrivateKey)
+
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() || private == nil {
+		return
+	}
+
+	var data userTokenPrivateData
+
+	if err := json.Unmarshal(private, &data); err != nil {
+		resp.Diagnostics.AddError("Error decoding user token private data", err.Error())
+		return
+	}
+
+	err := r.client.Access().DeleteUserToken(ctx, data.UserID, data.TokenName)
+	if errors.Is(err, api.ErrResourceDoesNotExist) {
+		tflog.Debug(ctx, "The user token has already been deleted", map[string]interface{}{
+			"user_id":    data.UserID,
+			"token_name": data.TokenName,
+		})
+
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting user token", err.Error())
+	}
+}
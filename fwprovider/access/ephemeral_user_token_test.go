@@ -0,0 +1,134 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package access
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/access"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/fake"
+)
+
+// ephemeralTestProvider serves the user token ephemeral resource with a preconfigured client.
+type ephemeralTestProvider struct {
+	client proxmox.Client
+}
+
+var _ provider.ProviderWithEphemeralResources = &ephemeralTestProvider{}
+
+func (p *ephemeralTestProvider) Metadata(
+	_ context.Context,
+	_ provider.MetadataRequest,
+	resp *provider.MetadataResponse,
+) {
+	resp.TypeName = "proxmox_virtual_environment"
+}
+
+func (p *ephemeralTestProvider) Schema(context.Context, provider.SchemaRequest, *provider.SchemaResponse) {
+}
+
+func (p *ephemeralTestProvider) Configure(
+	_ context.Context,
+	_ provider.ConfigureRequest,
+	resp *provider.ConfigureResponse,
+) {
+	resp.EphemeralResourceData = config.EphemeralResource{Client: p.client}
+}
+
+func (p *ephemeralTestProvider) Resources(context.Context) []func() resource.Resource {
+	return nil
+}
+
+func (p *ephemeralTestProvider) DataSources(context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+func (p *ephemeralTestProvider) EphemeralResources(context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{NewUserTokenEphemeralResource}
+}
+
+func TestUserTokenEphemeralResourceClose(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	srv := fake.NewServer()
+	t.Cleanup(srv.Close)
+
+	apiClient, err := srv.NewClient()
+	require.NoError(t, err)
+
+	server := providerserver.NewProtocol6(&ephemeralTestProvider{client: proxmox.NewClient(apiClient, nil, "")})()
+
+	emptyConfig, err := tfprotov6.NewDynamicValue(tftypes.Object{}, tftypes.NewValue(tftypes.Object{}, nil))
+	require.NoError(t, err)
+
+	configureResp, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{Config: &emptyConfig})
+	require.NoError(t, err)
+	require.Empty(t, configureResp.Diagnostics)
+
+	var schemaResp ephemeral.SchemaResponse
+
+	NewUserTokenEphemeralResource().Schema(ctx, ephemeral.SchemaRequest{}, &schemaResp)
+
+	objType, ok := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	require.True(t, ok)
+
+	values := map[string]tftypes.Value{}
+	for name, typ := range objType.AttributeTypes {
+		values[name] = tftypes.NewValue(typ, nil)
+	}
+
+	values["user_id"] = tftypes.NewValue(tftypes.String, fake.DefaultUsername)
+	values["token_name"] = tftypes.NewValue(tftypes.String, "tmp")
+
+	tokenConfig, err := tfprotov6.NewDynamicValue(objType, tftypes.NewValue(objType, values))
+	require.NoError(t, err)
+
+	openResp, err := server.OpenEphemeralResource(ctx, &tfprotov6.OpenEphemeralResourceRequest{
+		TypeName: "proxmox_virtual_environment_user_token",
+		Config:   &tokenConfig,
+	})
+	require.NoError(t, err)
+	require.Empty(t, openResp.Diagnostics)
+	require.NotEmpty(t, openResp.Private)
+
+	client := &access.Client{Client: apiClient}
+
+	_, err = client.GetUserToken(ctx, fake.DefaultUsername, "tmp")
+	require.NoError(t, err)
+
+	closeReq := &tfprotov6.CloseEphemeralResourceRequest{
+		TypeName: "proxmox_virtual_environment_user_token",
+		Private:  openResp.Private,
+	}
+
+	closeResp, err := server.CloseEphemeralResource(ctx, closeReq)
+	require.NoError(t, err)
+	assert.Empty(t, closeResp.Diagnostics)
+
+	_, err = client.GetUserToken(ctx, fake.DefaultUsername, "tmp")
+	require.Error(t, err, "the token must be deleted on close")
+
+	// a token that has already been deleted, e.g. by an administrator, is not an error
+	closeResp, err = server.CloseEphemeralResource(ctx, closeReq)
+	require.NoError(t, err)
+	assert.Empty(t, closeResp.Diagnostics)
+}
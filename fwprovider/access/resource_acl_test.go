@@ -14,13 +14,13 @@ import (
 	"regexp"
 	"testing"
 
-	"github.com/brianvoe/gofakeit/v7"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/stretchr/testify/require"
 
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/test"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/access"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
 )
 
 func TestAccAcl_User(t *testing.T) {
@@ -28,7 +28,7 @@ func TestAccAcl_User(t *testing.T) {
 
 	te := test.InitEnvironment(t)
 
-	userID := fmt.Sprintf("%s@pve", gofakeit.Username())
+	userID := fmt.Sprintf("%s@pve", te.Faker.Username())
 	te.AddTemplateVars(map[string]any{
 		"UserID": userID,
 	})
@@ -39,7 +39,7 @@ func TestAccAcl_User(t *testing.T) {
 		PreCheck: func() {
 			err := te.AccessClient().CreateUser(context.Background(), &access.UserCreateRequestBody{
 				ID:       userID,
-				Password: gofakeit.Password(true, true, true, true, false, 8),
+				Password: ptr.Ptr(te.Faker.Password(true, true, true, true, false, 8)),
 			})
 			require.NoError(t, err)
 
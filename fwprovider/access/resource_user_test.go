@@ -13,12 +13,12 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/brianvoe/gofakeit/v7"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/stretchr/testify/require"
 
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/test"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/access"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
 )
 
 func TestAccResourceUser(t *testing.T) {
@@ -26,7 +26,7 @@ func TestAccResourceUser(t *testing.T) {
 
 	te := test.InitEnvironment(t)
 
-	userID := fmt.Sprintf("%s@pve", gofakeit.Username())
+	userID := fmt.Sprintf("%s@pve", te.Faker.Username())
 	te.AddTemplateVars(map[string]any{
 		"UserID": userID,
 	})
@@ -92,8 +92,8 @@ func TestAccResourceUserToken(t *testing.T) {
 	t.Parallel()
 
 	te := test.InitEnvironment(t)
-	userID := fmt.Sprintf("%s@pve", gofakeit.Username())
-	tokenName := gofakeit.Word()
+	userID := fmt.Sprintf("%s@pve", te.Faker.Username())
+	tokenName := te.Faker.Word()
 
 	te.AddTemplateVars(map[string]any{
 		"UserID":    userID,
@@ -110,7 +110,7 @@ func TestAccResourceUserToken(t *testing.T) {
 			func() {
 				err := te.AccessClient().CreateUser(context.Background(), &access.UserCreateRequestBody{
 					ID:       userID,
-					Password: gofakeit.Password(true, true, true, true, false, 8),
+					Password: ptr.Ptr(te.Faker.Password(true, true, true, true, false, 8)),
 				})
 				require.NoError(t, err)
 
@@ -8,6 +8,7 @@ package acme
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
@@ -17,12 +18,15 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/acme/account"
 )
 
@@ -53,6 +57,10 @@ type acmeAccountModel struct {
 	Directory types.String `tfsdk:"directory"`
 	// HMAC key for External Account Binding.
 	EABHMACKey types.String `tfsdk:"eab_hmac_key"`
+	// Write-only HMAC key for External Account Binding, not stored in the state.
+	EABHMACKeyWO types.String `tfsdk:"eab_hmac_key_wo"`
+	// Version of the write-only HMAC key, the account is re-created when it changes.
+	EABHMACKeyWOVersion types.Int64 `tfsdk:"eab_hmac_key_wo_version"`
 	// Key Identifier for External Account Binding.
 	EABKID types.String `tfsdk:"eab_kid"`
 	// Location of the ACME account.
@@ -104,6 +112,26 @@ func (r *acmeAccountResource) Schema(
 			"eab_hmac_key": schema.StringAttribute{
 				Description: "The HMAC key for External Account Binding.",
 				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("eab_hmac_key_wo")),
+					stringvalidator.PreferWriteOnlyAttribute(path.MatchRoot("eab_hmac_key_wo")),
+				},
+			},
+			"eab_hmac_key_wo": schema.StringAttribute{
+				Description: "The HMAC key for External Account Binding, which is not stored in the state.",
+				MarkdownDescription: "The HMAC key for External Account Binding, which is not stored in the state " +
+					"(requires Terraform 1.11 or later). The key is only used when the account is registered, " +
+					"set `eab_hmac_key_wo_version` to re-register the account with a new key.",
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+			},
+			"eab_hmac_key_wo_version": schema.Int64Attribute{
+				Description: "The version of the write-only HMAC key, the account is re-created when it changes.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
 			},
 			"eab_kid": schema.StringAttribute{
 				Description: "The Key Identifier for External Account Binding.",
@@ -156,6 +184,11 @@ func (r *acmeAccountResource) Create(ctx context.Context, req resource.CreateReq
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 
+	// write-only values are only available in the configuration
+	var eabHMACKeyWO types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("eab_hmac_key_wo"), &eabHMACKeyWO)...)
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -165,12 +198,17 @@ func (r *acmeAccountResource) Create(ctx context.Context, req resource.CreateReq
 	createRequest.Directory = plan.Directory.ValueString()
 	createRequest.EABHMACKey = plan.EABHMACKey.ValueString()
 	createRequest.EABKID = plan.EABKID.ValueString()
+
+	if !eabHMACKeyWO.IsNull() {
+		createRequest.EABHMACKey = eabHMACKeyWO.ValueString()
+	}
+
 	createRequest.Name = plan.Name.ValueString()
 	createRequest.TOS = plan.TOS.ValueString()
 
 	err := r.client.Create(ctx, createRequest)
 	if err != nil {
-		if !strings.Contains(err.Error(), "already exists") {
+		if !errors.Is(err, api.ErrAlreadyExists) {
 			resp.Diagnostics.AddError(
 				fmt.Sprintf("Unable to create ACME account '%s'", plan.Name),
 				err.Error(),
@@ -0,0 +1,144 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package acme_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/acme"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+// acmeServer serves the ACME account endpoints, and records the HMAC keys the accounts are registered with.
+type acmeServer struct {
+	mu      sync.Mutex
+	hmacKey []string
+}
+
+func (s *acmeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	switch {
+	case r.Method == http.MethodPost && strings.TrimSuffix(r.URL.Path, "/") == "/api2/json/cluster/acme/account":
+		if err := r.ParseForm(); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		s.mu.Lock()
+		s.hmacKey = append(s.hmacKey, r.PostForm.Get("eab-hmac-key"))
+		s.mu.Unlock()
+
+		_, _ = w.Write([]byte(`{"data":"UPID:pve:00000001:00000001:00000001:acmeregister:test:root@pam:"}`))
+	case strings.HasSuffix(r.URL.Path, "/status"):
+		_, _ = w.Write([]byte(`{"data":{"status":"stopped","exitstatus":"OK"}}`))
+	case strings.HasSuffix(r.URL.Path, "/log"):
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	case r.Method == http.MethodGet && r.URL.Path == "/api2/json/cluster/acme/account/test":
+		_, _ = w.Write([]byte(`{"data":{"account":{"contact":["mailto:admin@example.com"],` +
+			`"createdAt":"2026-01-01T00:00:00Z","status":"valid"},"directory":"https://acme.example.com/directory",` +
+			`"location":"https://acme.example.com/account/1","tos":"https://acme.example.com/tos"}}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestACMEAccountResourceCreateWithWriteOnlyHMACKey(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	s := &acmeServer{}
+
+	srv := httptest.NewTLSServer(s)
+	t.Cleanup(srv.Close)
+
+	creds, err := api.NewCredentials("", "", "", "root@pam!test=00000000-0000-0000-0000-000000000000", "", "")
+	require.NoError(t, err)
+
+	conn, err := api.NewConnection(srv.URL, true, "")
+	require.NoError(t, err)
+
+	apiClient, err := api.NewClient(creds, conn)
+	require.NoError(t, err)
+
+	r, ok := acme.NewACMEAccountResource().(resource.ResourceWithConfigure)
+	require.True(t, ok)
+
+	var configureResp resource.ConfigureResponse
+
+	r.Configure(ctx, resource.ConfigureRequest{
+		ProviderData: config.Resource{Client: proxmox.NewClient(apiClient, nil, "")},
+	}, &configureResp)
+	require.False(t, configureResp.Diagnostics.HasError(), "%v", configureResp.Diagnostics)
+
+	var schemaResp resource.SchemaResponse
+
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError(), "%v", schemaResp.Diagnostics)
+
+	sch := schemaResp.Schema
+
+	objType, ok := sch.Type().TerraformType(ctx).(tftypes.Object)
+	require.True(t, ok)
+
+	values := map[string]tftypes.Value{}
+	for name, typ := range objType.AttributeTypes {
+		values[name] = tftypes.NewValue(typ, nil)
+	}
+
+	values["name"] = tftypes.NewValue(tftypes.String, "test")
+	values["contact"] = tftypes.NewValue(tftypes.String, "admin@example.com")
+	values["directory"] = tftypes.NewValue(tftypes.String, "https://acme.example.com/directory")
+	values["tos"] = tftypes.NewValue(tftypes.String, "https://acme.example.com/tos")
+	values["eab_kid"] = tftypes.NewValue(tftypes.String, "kid")
+	values["eab_hmac_key_wo_version"] = tftypes.NewValue(tftypes.Number, 1)
+
+	// the write-only key is only in the configuration, Terraform plans it as null
+	planValues := map[string]tftypes.Value{}
+	for name, v := range values {
+		planValues[name] = v
+	}
+
+	planValues["created_at"] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+	planValues["location"] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+
+	values["eab_hmac_key_wo"] = tftypes.NewValue(tftypes.String, "hmac-secret")
+
+	createResp := resource.CreateResponse{State: tfsdk.State{Schema: sch, Raw: tftypes.NewValue(objType, nil)}}
+
+	r.Create(ctx, resource.CreateRequest{
+		Config: tfsdk.Config{Schema: sch, Raw: tftypes.NewValue(objType, values)},
+		Plan:   tfsdk.Plan{Schema: sch, Raw: tftypes.NewValue(objType, planValues)},
+	}, &createResp)
+	require.False(t, createResp.Diagnostics.HasError(), "%v", createResp.Diagnostics)
+
+	s.mu.Lock()
+	assert.Equal(t, []string{"hmac-secret"}, s.hmacKey, "the account must be registered with the write-only key")
+	s.mu.Unlock()
+
+	var key types.String
+
+	require.False(t, createResp.State.GetAttribute(ctx, path.Root("eab_hmac_key_wo"), &key).HasError())
+	assert.True(t, key.IsNull(), "the write-only key must not be stored in the state")
+
+	assert.NotContains(t, createResp.State.Raw.String(), "hmac-secret")
+}
@@ -8,6 +8,7 @@ package acme
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -20,6 +21,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/acme/plugins"
 )
 
@@ -145,7 +147,7 @@ func (r *acmePluginResource) Create(ctx context.Context, req resource.CreateRequ
 
 	err := r.client.Create(ctx, createRequest)
 	if err != nil {
-		if !strings.Contains(err.Error(), "already exists") {
+		if !errors.Is(err, api.ErrAlreadyExists) {
 			resp.Diagnostics.AddError(
 				fmt.Sprintf("Unable to create ACME plugin '%s'", createRequest.Plugin),
 				err.Error(),
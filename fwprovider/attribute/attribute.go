@@ -39,3 +39,11 @@ func ShouldBeRemoved(plan attr.Value, state attr.Value, isClone bool) bool {
 func IsDefined(v attr.Value) bool {
 	return !v.IsNull() && !v.IsUnknown()
 }
+
+// CheckDelete adds the API name of an attribute to the fields to delete in an update request, if the attribute
+// has a value in the state but is removed from the plan, so the field is reset to its PVE default.
+func CheckDelete(planField, stateField attr.Value, toDelete *[]string, apiName string) {
+	if planField.IsNull() && !stateField.IsNull() {
+		*toDelete = append(*toDelete, apiName)
+	}
+}
@@ -0,0 +1,136 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package backup
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/backup"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &notBackedUpDatasource{}
+	_ datasource.DataSourceWithConfigure = &notBackedUpDatasource{}
+)
+
+type notBackedUpModel struct {
+	Guests []notBackedUpGuestModel `tfsdk:"guests"`
+}
+
+type notBackedUpGuestModel struct {
+	VMID types.Int64  `tfsdk:"vm_id"`
+	Name types.String `tfsdk:"name"`
+	Type types.String `tfsdk:"type"`
+}
+
+// NewNotBackedUpDataSource creates a new data source for the guests that are not included in any backup job.
+func NewNotBackedUpDataSource() datasource.DataSource {
+	return &notBackedUpDatasource{}
+}
+
+type notBackedUpDatasource struct {
+	client *backup.Client
+}
+
+// Metadata returns the data source type name.
+func (d *notBackedUpDatasource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_backup_not_backed_up"
+}
+
+// Schema returns the schema for the data source.
+func (d *notBackedUpDatasource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves the guests that are not included in any enabled backup job.",
+		Attributes: map[string]schema.Attribute{
+			"guests": schema.ListNestedAttribute{
+				Description: "Guests that are not backed up, ordered by their ID.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"vm_id": schema.Int64Attribute{
+							Description: "ID of the guest.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Name of the guest.",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "Type of the guest, `qemu` or `lxc`.",
+							Computed:    true,
+						},
+					},
+				},
+				Computed: true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider-configured client to the data source.
+func (d *notBackedUpDatasource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.DataSource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected config.DataSource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = cfg.Client.Cluster().Backup()
+}
+
+// Read fetches the guests that are not backed up from the Proxmox cluster.
+func (d *notBackedUpDatasource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	list, err := d.client.ListNotBackedUpGuests(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Guests Not Backed Up",
+			"An unexpected error occurred while reading the guests that are not backed up.\n\n"+
+				"Error: "+err.Error(),
+		)
+
+		return
+	}
+
+	// an empty list rather than null, so that the guests can be counted
+	state := notBackedUpModel{Guests: []notBackedUpGuestModel{}}
+
+	for _, g := range list {
+		state.Guests = append(state.Guests, notBackedUpGuestModel{
+			VMID: types.Int64Value(int64(g.VMID)),
+			Name: types.StringPointerValue(g.Name),
+			Type: types.StringValue(g.Type),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
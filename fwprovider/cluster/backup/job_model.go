@@ -0,0 +1,184 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package backup
+
+import (
+	"context"
+	"slices"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/attribute"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/types/prunebackups"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/backup"
+	proxmoxtypes "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+type jobModel struct {
+	ID       types.String `tfsdk:"id"`
+	Name     types.String `tfsdk:"name"`
+	Schedule types.String `tfsdk:"schedule"`
+	Enabled  types.Bool   `tfsdk:"enabled"`
+	Node     types.String `tfsdk:"node"`
+	Comment  types.String `tfsdk:"comment"`
+
+	// guest selection
+	VMIDs   types.Set    `tfsdk:"vm_ids"`
+	Pool    types.String `tfsdk:"pool"`
+	All     types.Bool   `tfsdk:"all"`
+	Exclude types.Set    `tfsdk:"exclude"`
+
+	// backup options
+	Storage       types.String `tfsdk:"storage"`
+	Mode          types.String `tfsdk:"mode"`
+	Compress      types.String `tfsdk:"compress"`
+	PruneBackups  types.Object `tfsdk:"prune_backups"`
+	NotesTemplate types.String `tfsdk:"notes_template"`
+	RepeatMissed  types.Bool   `tfsdk:"repeat_missed"`
+
+	// notification options
+	NotificationMode types.String `tfsdk:"notification_mode"`
+	MailTo           types.Set    `tfsdk:"mail_to"`
+	MailNotification types.String `tfsdk:"mail_notification"`
+}
+
+// importFromAPI takes data from backup job PVE API response and set fields based on it.
+func (m *jobModel) importFromAPI(ctx context.Context, data *backup.JobData, diags *diag.Diagnostics) {
+	m.ID = types.StringValue(data.ID)
+	m.Name = types.StringValue(data.ID)
+	m.Schedule = types.StringPointerValue(data.Schedule)
+	m.Enabled = types.BoolValue(data.Enabled == nil || bool(*data.Enabled))
+	m.Node = types.StringPointerValue(data.Node)
+	m.Comment = types.StringPointerValue(data.Comment)
+
+	m.VMIDs = vmIDSet(ctx, data.VMID, diags)
+	m.Pool = types.StringPointerValue(data.Pool)
+	m.All = types.BoolValue(data.All != nil && bool(*data.All))
+	m.Exclude = vmIDSet(ctx, data.Exclude, diags)
+
+	m.Storage = types.StringPointerValue(data.Storage)
+	m.Mode = types.StringPointerValue(data.Mode)
+	m.Compress = types.StringPointerValue(data.Compress)
+	m.PruneBackups = prunebackups.FromAPI(ctx, data.PruneBackups, diags)
+	m.NotesTemplate = types.StringPointerValue(data.NotesTemplate)
+	m.RepeatMissed = types.BoolPointerValue(data.RepeatMissed.PointerBool())
+
+	m.NotificationMode = types.StringPointerValue(data.NotificationMode)
+	m.MailNotification = types.StringPointerValue(data.MailNotification)
+
+	if len(data.MailTo) > 0 {
+		mailTo, d := types.SetValueFrom(ctx, types.StringType, []string(data.MailTo))
+		diags.Append(d...)
+
+		m.MailTo = mailTo
+	} else {
+		m.MailTo = types.SetNull(types.StringType)
+	}
+}
+
+// toAPIFields creates the fields of backup job create and update requests.
+func (m *jobModel) toAPIFields(ctx context.Context, diags *diag.Diagnostics) backup.JobFields {
+	fields := backup.JobFields{
+		Comment:          m.Comment.ValueStringPointer(),
+		Compress:         m.Compress.ValueStringPointer(),
+		Enabled:          proxmoxtypes.CustomBool(m.Enabled.ValueBool()).Pointer(),
+		MailNotification: m.MailNotification.ValueStringPointer(),
+		Mode:             m.Mode.ValueStringPointer(),
+		Node:             m.Node.ValueStringPointer(),
+		NotesTemplate:    m.NotesTemplate.ValueStringPointer(),
+		NotificationMode: m.NotificationMode.ValueStringPointer(),
+		PruneBackups:     prunebackups.ToAPI(ctx, m.PruneBackups, diags),
+		Schedule:         m.Schedule.ValueStringPointer(),
+		Storage:          m.Storage.ValueStringPointer(),
+
+		All:     proxmoxtypes.CustomBool(m.All.ValueBool()).Pointer(),
+		Exclude: vmIDList(ctx, m.Exclude, diags),
+		Pool:    m.Pool.ValueStringPointer(),
+		VMID:    vmIDList(ctx, m.VMIDs, diags),
+	}
+
+	if !m.RepeatMissed.IsNull() {
+		fields.RepeatMissed = proxmoxtypes.CustomBool(m.RepeatMissed.ValueBool()).Pointer()
+	}
+
+	if !m.MailTo.IsNull() {
+		var mailTo []string
+
+		diags.Append(m.MailTo.ElementsAs(ctx, &mailTo, false)...)
+		slices.Sort(mailTo)
+
+		fields.MailTo = mailTo
+	}
+
+	return fields
+}
+
+// toDelete returns the API names of the optional fields that have been removed from the configuration.
+func (m *jobModel) toDelete(state *jobModel) []string {
+	var toDelete []string
+
+	attribute.CheckDelete(m.Node, state.Node, &toDelete, "node")
+	attribute.CheckDelete(m.Comment, state.Comment, &toDelete, "comment")
+	attribute.CheckDelete(m.VMIDs, state.VMIDs, &toDelete, "vmid")
+	attribute.CheckDelete(m.Pool, state.Pool, &toDelete, "pool")
+	attribute.CheckDelete(m.Exclude, state.Exclude, &toDelete, "exclude")
+	attribute.CheckDelete(m.Storage, state.Storage, &toDelete, "storage")
+	attribute.CheckDelete(m.Compress, state.Compress, &toDelete, "compress")
+	attribute.CheckDelete(m.PruneBackups, state.PruneBackups, &toDelete, "prune-backups")
+	attribute.CheckDelete(m.NotesTemplate, state.NotesTemplate, &toDelete, "notes-template")
+	attribute.CheckDelete(m.RepeatMissed, state.RepeatMissed, &toDelete, "repeat-missed")
+	attribute.CheckDelete(m.NotificationMode, state.NotificationMode, &toDelete, "notification-mode")
+	attribute.CheckDelete(m.MailTo, state.MailTo, &toDelete, "mailto")
+	attribute.CheckDelete(m.MailNotification, state.MailNotification, &toDelete, "mailnotification")
+
+	return toDelete
+}
+
+// vmIDSet converts a list of VM IDs of the API into a set of numbers, an empty list is converted to null.
+func vmIDSet(ctx context.Context, ids []string, diags *diag.Diagnostics) types.Set {
+	if len(ids) == 0 {
+		return types.SetNull(types.Int64Type)
+	}
+
+	vmIDs := make([]int64, 0, len(ids))
+
+	for _, id := range ids {
+		vmID, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			diags.AddError("Invalid VM ID", "The API returned an invalid VM ID: "+err.Error())
+			continue
+		}
+
+		vmIDs = append(vmIDs, vmID)
+	}
+
+	set, d := types.SetValueFrom(ctx, types.Int64Type, vmIDs)
+	diags.Append(d...)
+
+	return set
+}
+
+// vmIDList converts a set of VM IDs into a sorted list of the API.
+func vmIDList(ctx context.Context, set types.Set, diags *diag.Diagnostics) []string {
+	if set.IsNull() || set.IsUnknown() {
+		return nil
+	}
+
+	var vmIDs []int64
+
+	diags.Append(set.ElementsAs(ctx, &vmIDs, false)...)
+	slices.Sort(vmIDs)
+
+	ids := make([]string, 0, len(vmIDs))
+	for _, vmID := range vmIDs {
+		ids = append(ids, strconv.FormatInt(vmID, 10))
+	}
+
+	return ids
+}
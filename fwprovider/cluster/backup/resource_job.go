@@ -0,0 +1,419 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/attribute"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/types/prunebackups"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/backup"
+)
+
+var (
+	_ resource.Resource                   = &jobResource{}
+	_ resource.ResourceWithConfigure      = &jobResource{}
+	_ resource.ResourceWithImportState    = &jobResource{}
+	_ resource.ResourceWithValidateConfig = &jobResource{}
+)
+
+//nolint:gochecknoglobals
+var jobIDRegexp = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]+$`)
+
+type jobResource struct {
+	client *backup.Client
+}
+
+// NewBackupJobResource creates a new backup job resource.
+func NewBackupJobResource() resource.Resource {
+	return &jobResource{}
+}
+
+func (r *jobResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_backup_job"
+}
+
+func (r *jobResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.Resource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected config.Resource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = cfg.Client.Cluster().Backup()
+}
+
+func (r *jobResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	vmIDs := func(description string) schema.SetAttribute {
+		return schema.SetAttribute{
+			Description: description,
+			ElementType: types.Int64Type,
+			Optional:    true,
+			Validators:  []validator.Set{setvalidator.SizeAtLeast(1)},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Manages a scheduled backup job of the cluster.",
+		MarkdownDescription: "Manages a scheduled backup job (`vzdump`) of the cluster. The guests are selected " +
+			"either by their IDs (`vm_ids`), by a pool (`pool`), or all guests except the excluded ones " +
+			"(`all` and `exclude`).",
+		Attributes: map[string]schema.Attribute{
+			"id": attribute.ID(),
+			"name": schema.StringAttribute{
+				Description: "Unique name of the backup job.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(jobIDRegexp, "must start with a letter, followed by letters, "+
+						"digits, `-` or `_`"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"schedule": schema.StringAttribute{
+				Description: "Schedule of the backup job, in the systemd calendar event format, " +
+					"e.g. `daily`, `sat 02:00` or `mon..fri 21:30`.",
+				Required:   true,
+				Validators: []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Whether the backup job is enabled.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"node": schema.StringAttribute{
+				Description: "Only back up the guests running on this node.",
+				Optional:    true,
+				Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"comment": schema.StringAttribute{
+				Description: "Description of the backup job.",
+				Optional:    true,
+			},
+			"vm_ids": vmIDs("IDs of the guests to back up. Conflicts with `pool` and `all`."),
+			"pool": schema.StringAttribute{
+				Description: "Back up all guests of this pool. Conflicts with `vm_ids` and `all`.",
+				Optional:    true,
+				Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"all": schema.BoolAttribute{
+				Description: "Back up all guests, except the ones listed in `exclude`. " +
+					"Conflicts with `vm_ids` and `pool`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"exclude": vmIDs("IDs of the guests to exclude from the backup, requires `all`."),
+			"storage": schema.StringAttribute{
+				Description: "Datastore the backups are stored on.",
+				Optional:    true,
+				Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"mode": schema.StringAttribute{
+				Description: "Backup mode, one of `snapshot`, `suspend` or `stop`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("snapshot"),
+				Validators:  []validator.String{stringvalidator.OneOf("snapshot", "suspend", "stop")},
+			},
+			"compress": schema.StringAttribute{
+				Description: "Compression of the backups, one of `0` (none), `1` (`lzo`), `gzip`, `lzo` or `zstd`.",
+				Optional:    true,
+				Validators:  []validator.String{stringvalidator.OneOf("0", "1", "gzip", "lzo", "zstd")},
+			},
+			"prune_backups": prunebackups.ResourceAttribute(
+				"Retention of the backups, the retention of the datastore is used when not set.",
+			),
+			"notes_template": schema.StringAttribute{
+				Description: "Template of the notes of the backups, e.g. `{{guestname}}`.",
+				Optional:    true,
+			},
+			"repeat_missed": schema.BoolAttribute{
+				Description: "Whether to run the backup job as soon as possible if a scheduled run was missed.",
+				Optional:    true,
+			},
+			"notification_mode": schema.StringAttribute{
+				Description: "How to send the notifications, one of `auto`, `legacy-sendmail` or " +
+					"`notification-system`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("auto", "legacy-sendmail", "notification-system"),
+				},
+			},
+			"mail_to": schema.SetAttribute{
+				Description: "Email addresses to send the notifications to.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators:  []validator.Set{setvalidator.SizeAtLeast(1)},
+			},
+			"mail_notification": schema.StringAttribute{
+				Description: "When to send an email notification, one of `always` or `failure`.",
+				Optional:    true,
+				Validators:  []validator.String{stringvalidator.OneOf("always", "failure")},
+			},
+		},
+	}
+}
+
+func (r *jobResource) ValidateConfig(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	var data jobModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() ||
+		data.VMIDs.IsUnknown() || data.Pool.IsUnknown() || data.All.IsUnknown() || data.Exclude.IsUnknown() {
+		return
+	}
+
+	selections := 0
+
+	for _, selected := range []bool{!data.VMIDs.IsNull(), !data.Pool.IsNull(), data.All.ValueBool()} {
+		if selected {
+			selections++
+		}
+	}
+
+	if selections != 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("vm_ids"),
+			"Invalid Guest Selection",
+			"Exactly one of the attributes \"vm_ids\", \"pool\" or \"all = true\" must be set.",
+		)
+	}
+
+	if !data.Exclude.IsNull() && !data.All.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("exclude"),
+			"Invalid Attribute Combination",
+			"The attribute \"exclude\" requires \"all = true\".",
+		)
+	}
+}
+
+func (r *jobResource) read(ctx context.Context, model *jobModel, diags *diag.Diagnostics) bool {
+	data, err := r.client.GetJob(ctx, model.ID.ValueString())
+	if err == nil && data == nil {
+		err = api.ErrNoDataObjectInResponse
+	}
+
+	if err != nil {
+		if errors.Is(err, api.ErrResourceDoesNotExist) {
+			return false
+		}
+
+		diags.AddError(
+			"Unable to Read Backup Job",
+			"An unexpected error occurred while reading the backup job.\n\n"+
+				"Error: "+err.Error(),
+		)
+
+		return false
+	}
+
+	model.importFromAPI(ctx, data, diags)
+
+	return true
+}
+
+func (r *jobResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state jobModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found := r.read(ctx, &state, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *jobResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan jobModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reqData := &backup.JobCreateRequestBody{
+		JobFields: plan.toAPIFields(ctx, &resp.Diagnostics),
+		ID:        plan.Name.ValueString(),
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.CreateJob(ctx, reqData)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create Backup Job",
+			"An unexpected error occurred while creating the backup job.\n\n"+
+				"Error: "+err.Error(),
+		)
+
+		return
+	}
+
+	plan.ID = plan.Name
+
+	r.readAfterChange(ctx, &plan, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *jobResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var plan, state jobModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reqData := &backup.JobUpdateRequestBody{
+		JobFields: plan.toAPIFields(ctx, &resp.Diagnostics),
+		Delete:    plan.toDelete(&state),
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.UpdateJob(ctx, state.ID.ValueString(), reqData)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Update Backup Job",
+			"An unexpected error occurred while updating the backup job.\n\n"+
+				"Error: "+err.Error(),
+		)
+
+		return
+	}
+
+	r.readAfterChange(ctx, &plan, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// readAfterChange reads the backup job after it has been created or updated.
+func (r *jobResource) readAfterChange(ctx context.Context, model *jobModel, diags *diag.Diagnostics) {
+	if !r.read(ctx, model, diags) && !diags.HasError() {
+		diags.AddError(
+			"Backup Job Not Found",
+			fmt.Sprintf("The backup job %q could not be found after it has been changed.", model.ID.ValueString()),
+		)
+	}
+}
+
+func (r *jobResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var state jobModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteJob(ctx, state.ID.ValueString())
+	if err != nil && !errors.Is(err, api.ErrResourceDoesNotExist) {
+		resp.Diagnostics.AddError(
+			"Unable to Delete Backup Job",
+			"An unexpected error occurred while deleting the backup job.\n\n"+
+				"Error: "+err.Error(),
+		)
+	}
+}
+
+func (r *jobResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,151 @@
+//go:build acceptance || all
+
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package backup_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/test"
+)
+
+func TestAccResourceBackupJob(t *testing.T) {
+	t.Parallel()
+
+	te := test.InitFakeEnvironment(t)
+
+	type createVM struct {
+		VMID int    `url:"vmid"`
+		Name string `url:"name"`
+	}
+
+	for _, vmid := range []int{100, 101} {
+		require.NoError(t, te.Client().DoRequest(context.Background(), http.MethodPost,
+			fmt.Sprintf("nodes/%s/qemu", te.NodeName), &createVM{VMID: vmid, Name: fmt.Sprintf("vm-%d", vmid)}, nil))
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_backup_job" "test" {
+					name     = "daily"
+					schedule = "*-*-* 02:00"
+					storage  = "local"
+					all      = true
+					exclude  = [101]
+					compress = "zstd"
+					mail_to  = ["admin@example.com"]
+
+					prune_backups = {
+						keep_last  = 3
+						keep_daily = 7
+					}
+				}
+
+				data "proxmox_virtual_environment_backup_not_backed_up" "test" {
+					depends_on = [proxmox_virtual_environment_backup_job.test]
+				}`),
+				Check: resource.ComposeTestCheckFunc(
+					test.ResourceAttributes("proxmox_virtual_environment_backup_job.test", map[string]string{
+						"id":                       "daily",
+						"enabled":                  "true",
+						"mode":                     "snapshot",
+						"exclude.#":                "1",
+						"mail_to.#":                "1",
+						"prune_backups.keep_last":  "3",
+						"prune_backups.keep_daily": "7",
+					}),
+					test.NoResourceAttributesSet("proxmox_virtual_environment_backup_job.test", []string{
+						"vm_ids.#",
+						"pool",
+					}),
+					test.ResourceAttributes("data.proxmox_virtual_environment_backup_not_backed_up.test", map[string]string{
+						"guests.#":       "1",
+						"guests.0.vm_id": "101",
+						"guests.0.name":  "vm-101",
+						"guests.0.type":  "qemu",
+					}),
+				),
+			},
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_backup_job" "test" {
+					name     = "daily"
+					schedule = "sat 03:00"
+					vm_ids   = [100, 101]
+					mode     = "stop"
+					enabled  = false
+					comment  = "weekly full backup"
+				}`),
+				Check: resource.ComposeTestCheckFunc(
+					test.ResourceAttributes("proxmox_virtual_environment_backup_job.test", map[string]string{
+						"schedule": "sat 03:00",
+						"vm_ids.#": "2",
+						"all":      "false",
+						"mode":     "stop",
+						"enabled":  "false",
+						"comment":  "weekly full backup",
+					}),
+					test.NoResourceAttributesSet("proxmox_virtual_environment_backup_job.test", []string{
+						"exclude.#",
+						"storage",
+						"compress",
+						"mail_to.#",
+						"prune_backups",
+					}),
+				),
+			},
+			{
+				ResourceName:      "proxmox_virtual_environment_backup_job.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceBackupJobValidation(t *testing.T) {
+	t.Parallel()
+
+	te := test.InitFakeEnvironment(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_backup_job" "test" {
+					name     = "daily"
+					schedule = "daily"
+					vm_ids   = [100]
+					pool     = "production"
+				}`),
+				ExpectError: regexp.MustCompile(`Exactly one of the attributes`),
+			},
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_backup_job" "test" {
+					name     = "daily"
+					schedule = "daily"
+					pool     = "production"
+					exclude  = [100]
+				}`),
+				ExpectError: regexp.MustCompile(`requires "all = true"`),
+			},
+		},
+	})
+}
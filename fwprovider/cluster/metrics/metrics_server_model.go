@@ -12,23 +12,25 @@ import (
 )
 
 type metricsServerModel struct {
-	ID                  types.String `tfsdk:"id"`
-	Name                types.String `tfsdk:"name"`
-	Disable             types.Bool   `tfsdk:"disable"`
-	MTU                 types.Int64  `tfsdk:"mtu"`
-	Port                types.Int64  `tfsdk:"port"`
-	Server              types.String `tfsdk:"server"`
-	Timeout             types.Int64  `tfsdk:"timeout"`
-	Type                types.String `tfsdk:"type"`
-	InfluxAPIPathPrefix types.String `tfsdk:"influx_api_path_prefix"`
-	InfluxBucket        types.String `tfsdk:"influx_bucket"`
-	InfluxDBProto       types.String `tfsdk:"influx_db_proto"`
-	InfluxMaxBodySize   types.Int64  `tfsdk:"influx_max_body_size"`
-	InfluxOrganization  types.String `tfsdk:"influx_organization"`
-	InfluxToken         types.String `tfsdk:"influx_token"`
-	InfluxVerify        types.Bool   `tfsdk:"influx_verify"`
-	GraphitePath        types.String `tfsdk:"graphite_path"`
-	GraphiteProto       types.String `tfsdk:"graphite_proto"`
+	ID                   types.String `tfsdk:"id"`
+	Name                 types.String `tfsdk:"name"`
+	Disable              types.Bool   `tfsdk:"disable"`
+	MTU                  types.Int64  `tfsdk:"mtu"`
+	Port                 types.Int64  `tfsdk:"port"`
+	Server               types.String `tfsdk:"server"`
+	Timeout              types.Int64  `tfsdk:"timeout"`
+	Type                 types.String `tfsdk:"type"`
+	InfluxAPIPathPrefix  types.String `tfsdk:"influx_api_path_prefix"`
+	InfluxBucket         types.String `tfsdk:"influx_bucket"`
+	InfluxDBProto        types.String `tfsdk:"influx_db_proto"`
+	InfluxMaxBodySize    types.Int64  `tfsdk:"influx_max_body_size"`
+	InfluxOrganization   types.String `tfsdk:"influx_organization"`
+	InfluxToken          types.String `tfsdk:"influx_token"`
+	InfluxTokenWO        types.String `tfsdk:"influx_token_wo"`
+	InfluxTokenWOVersion types.Int64  `tfsdk:"influx_token_wo_version"`
+	InfluxVerify         types.Bool   `tfsdk:"influx_verify"`
+	GraphitePath         types.String `tfsdk:"graphite_path"`
+	GraphiteProto        types.String `tfsdk:"graphite_proto"`
 }
 
 func boolToInt64Ptr(boolPtr *bool) *int64 {
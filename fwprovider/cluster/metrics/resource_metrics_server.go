@@ -17,12 +17,13 @@ import (
 	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/metrics"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
-	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var (
@@ -157,6 +158,29 @@ func (r *metricsServerResource) Schema(
 				Optional:  true,
 				Default:   nil,
 				Sensitive: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("influx_token_wo")),
+					stringvalidator.PreferWriteOnlyAttribute(path.MatchRoot("influx_token_wo")),
+				},
+			},
+			"influx_token_wo": schema.StringAttribute{
+				Description: "The InfluxDB access token, which is not stored in the state " +
+					"(requires Terraform 1.11 or later). The token is sent when the server is created, " +
+					"and when `influx_token_wo_version` changes.",
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("influx_token_wo_version")),
+				},
+			},
+			"influx_token_wo_version": schema.Int64Attribute{
+				Description: "The version of the write-only InfluxDB access token, the token is updated " +
+					"when the version changes.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("influx_token_wo")),
+				},
 			},
 			"influx_verify": schema.BoolAttribute{
 				Description: "Set to `false` to disable certificate verification for https " +
@@ -214,6 +238,12 @@ func (r *metricsServerResource) Read(
 	readModel := &metricsServerModel{}
 	readModel.importFromAPI(state.ID.ValueString(), data)
 
+	// the write-only token must not be stored in the state
+	readModel.InfluxTokenWOVersion = state.InfluxTokenWOVersion
+	if !state.InfluxTokenWOVersion.IsNull() {
+		readModel.InfluxToken = types.StringNull()
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, readModel)...)
 }
 
@@ -232,6 +262,19 @@ func (r *metricsServerResource) Create(
 
 	reqData := plan.toAPIRequestBody()
 
+	// write-only values are only available in the configuration
+	var tokenWO types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("influx_token_wo"), &tokenWO)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !tokenWO.IsNull() {
+		reqData.Token = tokenWO.ValueStringPointer()
+	}
+
 	err := r.client.CreateServer(ctx, reqData)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -248,14 +291,6 @@ func (r *metricsServerResource) Create(
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
-func checkDelete(planField, stateField attr.Value, toDelete *[]string, apiName string) {
-	// we need to remove field via api field if there is value in state
-	// but someone decided to use PVE default and removed value from resource
-	if planField.IsNull() && !stateField.IsNull() {
-		*toDelete = append(*toDelete, apiName)
-	}
-}
-
 func (r *metricsServerResource) Update(
 	ctx context.Context,
 	req resource.UpdateRequest,
@@ -274,22 +309,45 @@ func (r *metricsServerResource) Update(
 
 	var toDelete []string
 
-	checkDelete(plan.Disable, state.Disable, &toDelete, "disable")
-	checkDelete(plan.MTU, state.MTU, &toDelete, "mtu")
-	checkDelete(plan.Timeout, state.Timeout, &toDelete, "timeout")
-	checkDelete(plan.InfluxAPIPathPrefix, state.InfluxAPIPathPrefix, &toDelete, "api-path-prefix")
-	checkDelete(plan.InfluxBucket, state.InfluxBucket, &toDelete, "bucket")
-	checkDelete(plan.InfluxDBProto, state.InfluxDBProto, &toDelete, "influxdbproto")
-	checkDelete(plan.InfluxMaxBodySize, state.InfluxMaxBodySize, &toDelete, "max-body-size")
-	checkDelete(plan.InfluxOrganization, state.InfluxOrganization, &toDelete, "organization")
-	checkDelete(plan.InfluxToken, state.InfluxToken, &toDelete, "token")
-	checkDelete(plan.InfluxVerify, state.InfluxVerify, &toDelete, "verify-certificate")
-	checkDelete(plan.GraphitePath, state.GraphitePath, &toDelete, "path")
-	checkDelete(plan.GraphiteProto, state.GraphiteProto, &toDelete, "proto")
+	attribute.CheckDelete(plan.Disable, state.Disable, &toDelete, "disable")
+	attribute.CheckDelete(plan.MTU, state.MTU, &toDelete, "mtu")
+	attribute.CheckDelete(plan.Timeout, state.Timeout, &toDelete, "timeout")
+	attribute.CheckDelete(plan.InfluxAPIPathPrefix, state.InfluxAPIPathPrefix, &toDelete, "api-path-prefix")
+	attribute.CheckDelete(plan.InfluxBucket, state.InfluxBucket, &toDelete, "bucket")
+	attribute.CheckDelete(plan.InfluxDBProto, state.InfluxDBProto, &toDelete, "influxdbproto")
+	attribute.CheckDelete(plan.InfluxMaxBodySize, state.InfluxMaxBodySize, &toDelete, "max-body-size")
+	attribute.CheckDelete(plan.InfluxOrganization, state.InfluxOrganization, &toDelete, "organization")
+
+	tokenWOChanged := !plan.InfluxTokenWOVersion.Equal(state.InfluxTokenWOVersion)
+	if !tokenWOChanged {
+		attribute.CheckDelete(plan.InfluxToken, state.InfluxToken, &toDelete, "token")
+	}
+
+	attribute.CheckDelete(plan.InfluxVerify, state.InfluxVerify, &toDelete, "verify-certificate")
+	attribute.CheckDelete(plan.GraphitePath, state.GraphitePath, &toDelete, "path")
+	attribute.CheckDelete(plan.GraphiteProto, state.GraphiteProto, &toDelete, "proto")
 
 	reqData := plan.toAPIRequestBody()
 	reqData.Delete = &toDelete
 
+	// the write-only token is only sent when its version changes
+	if tokenWOChanged {
+		var tokenWO types.String
+
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("influx_token_wo"), &tokenWO)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		switch {
+		case !tokenWO.IsNull():
+			reqData.Token = tokenWO.ValueStringPointer()
+		case plan.InfluxToken.IsNull():
+			toDelete = append(toDelete, "token")
+		}
+	}
+
 	err := r.client.UpdateServer(ctx, reqData)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -124,6 +124,50 @@ func TestAccResourceMetricsServer(t *testing.T) {
 				),
 			},
 		}},
+		{"create influxdb http server with a write-only token & rotate it", []resource.TestStep{
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_metrics_server" "acc_influxdb_wo_server" {
+					name   			 		= "acc_example_influxdb_wo_server"
+					server 			 		= "192.168.3.2"
+					port   			 		= 18090
+					type   			 		= "influxdb"
+					influx_db_proto  		= "http"
+					influx_token_wo  		= "token-1"
+					influx_token_wo_version = 1
+				  }`),
+				Check: resource.ComposeTestCheckFunc(
+					test.ResourceAttributes("proxmox_virtual_environment_metrics_server.acc_influxdb_wo_server", map[string]string{
+						"influx_token_wo_version": "1",
+					}),
+					test.NoResourceAttributesSet("proxmox_virtual_environment_metrics_server.acc_influxdb_wo_server", []string{
+						"influx_token",
+						"influx_token_wo",
+					}),
+				),
+			},
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_metrics_server" "acc_influxdb_wo_server" {
+					name   			 		= "acc_example_influxdb_wo_server"
+					server 			 		= "192.168.3.2"
+					port   			 		= 18090
+					type   			 		= "influxdb"
+					influx_db_proto  		= "http"
+					influx_token_wo  		= "token-2"
+					influx_token_wo_version = 2
+				  }`),
+				Check: resource.ComposeTestCheckFunc(
+					test.ResourceAttributes("proxmox_virtual_environment_metrics_server.acc_influxdb_wo_server", map[string]string{
+						"influx_token_wo_version": "2",
+					}),
+					test.NoResourceAttributesSet("proxmox_virtual_environment_metrics_server.acc_influxdb_wo_server", []string{
+						"influx_token",
+						"influx_token_wo",
+					}),
+				),
+			},
+		}},
 		{"create graphite udp metrics server & import it", []resource.TestStep{
 			{
 				ResourceName: "proxmox_virtual_environment_metrics_server.acc_graphite_server",
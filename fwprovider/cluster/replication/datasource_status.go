@@ -0,0 +1,208 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package replication
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/replication"
+	proxmoxtypes "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &statusDatasource{}
+	_ datasource.DataSourceWithConfigure = &statusDatasource{}
+)
+
+type statusModel struct {
+	ID        types.String  `tfsdk:"id"`
+	NodeName  types.String  `tfsdk:"node_name"`
+	VMID      types.Int64   `tfsdk:"vm_id"`
+	Target    types.String  `tfsdk:"target"`
+	LastSync  types.String  `tfsdk:"last_sync"`
+	LastTry   types.String  `tfsdk:"last_try"`
+	NextSync  types.String  `tfsdk:"next_sync"`
+	Duration  types.Float64 `tfsdk:"duration"`
+	FailCount types.Int64   `tfsdk:"fail_count"`
+	Error     types.String  `tfsdk:"error"`
+}
+
+// NewStatusDataSource creates a new data source for the state of a storage replication job.
+func NewStatusDataSource() datasource.DataSource {
+	return &statusDatasource{}
+}
+
+type statusDatasource struct {
+	client *replication.Client
+}
+
+// Metadata returns the data source type name.
+func (d *statusDatasource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_replication_status"
+}
+
+// Schema returns the schema for the data source.
+func (d *statusDatasource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	timestamp := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{
+			Description: description + ", in RFC3339 format.",
+			Computed:    true,
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Retrieves the state of a storage replication job.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "ID of the replication job, `<vm_id>-<job_number>`.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(jobIDRegexp, "must be `<vm_id>-<job_number>`"),
+				},
+			},
+			"node_name": schema.StringAttribute{
+				Description: "Name of the node to read the state from, the source node of the job by default. " +
+					"Only the source node knows the state of the job.",
+				Optional: true,
+				Computed: true,
+			},
+			"vm_id": schema.Int64Attribute{
+				Description: "ID of the replicated guest.",
+				Computed:    true,
+			},
+			"target": schema.StringAttribute{
+				Description: "Name of the node the guest is replicated to.",
+				Computed:    true,
+			},
+			"last_sync": timestamp("Time of the last successful replication"),
+			"last_try":  timestamp("Time of the last replication attempt"),
+			"next_sync": timestamp("Time of the next scheduled replication"),
+			"duration": schema.Float64Attribute{
+				Description: "Duration of the last replication in seconds.",
+				Computed:    true,
+			},
+			"fail_count": schema.Int64Attribute{
+				Description: "Number of consecutive failed replication attempts.",
+				Computed:    true,
+			},
+			"error": schema.StringAttribute{
+				Description: "Error of the last failed replication attempt.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider-configured client to the data source.
+func (d *statusDatasource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.DataSource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected config.DataSource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = cfg.Client.Cluster().Replication()
+}
+
+// Read fetches the state of the replication job from its source node.
+func (d *statusDatasource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state statusModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := state.ID.ValueString()
+
+	if state.NodeName.IsNull() {
+		job, err := d.client.GetJob(ctx, id)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read Replication Job",
+				"An unexpected error occurred while reading the replication job.\n\n"+
+					"Error: "+err.Error(),
+			)
+
+			return
+		}
+
+		if job.Source == nil {
+			resp.Diagnostics.AddError(
+				"Unknown Source Node",
+				fmt.Sprintf("The source node of the replication job %q is unknown, please set \"node_name\".", id),
+			)
+
+			return
+		}
+
+		state.NodeName = types.StringValue(*job.Source)
+	}
+
+	status, err := d.client.GetJobStatus(ctx, state.NodeName.ValueString(), id)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Replication Status",
+			"An unexpected error occurred while reading the state of the replication job.\n\n"+
+				"Error: "+err.Error(),
+		)
+
+		return
+	}
+
+	state.VMID = types.Int64Value(int64(status.Guest))
+	state.Target = types.StringValue(status.Target)
+	state.LastSync = timestampValue(status.LastSync)
+	state.LastTry = timestampValue(status.LastTry)
+	state.NextSync = timestampValue(status.NextSync)
+	state.Duration = types.Float64PointerValue(status.Duration)
+	state.FailCount = types.Int64PointerValue(status.FailCount.PointerInt64())
+	state.Error = types.StringPointerValue(status.Error)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// timestampValue converts a UNIX timestamp of the API into an RFC3339 string, a missing or zero timestamp
+// is converted to null.
+func timestampValue(ts *proxmoxtypes.CustomInt64) types.String {
+	if ts == nil || *ts == 0 {
+		return types.StringNull()
+	}
+
+	return types.StringValue(time.Unix(int64(*ts), 0).UTC().Format(time.RFC3339))
+}
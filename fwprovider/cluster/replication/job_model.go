@@ -0,0 +1,75 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package replication
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/attribute"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/replication"
+	proxmoxtypes "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+// defaultSchedule is the schedule Proxmox VE uses when a replication job has none.
+const defaultSchedule = "*/15"
+
+type jobModel struct {
+	ID        types.String  `tfsdk:"id"`
+	VMID      types.Int64   `tfsdk:"vm_id"`
+	JobNumber types.Int64   `tfsdk:"job_number"`
+	Target    types.String  `tfsdk:"target"`
+	Schedule  types.String  `tfsdk:"schedule"`
+	Rate      types.Float64 `tfsdk:"rate"`
+	Comment   types.String  `tfsdk:"comment"`
+	Disable   types.Bool    `tfsdk:"disable"`
+	Source    types.String  `tfsdk:"source"`
+}
+
+// jobID returns the ID of the replication job, `<vmid>-<job number>`.
+func (m *jobModel) jobID() string {
+	return fmt.Sprintf("%d-%d", m.VMID.ValueInt64(), m.JobNumber.ValueInt64())
+}
+
+// importFromAPI takes data from replication job PVE API response and set fields based on it.
+func (m *jobModel) importFromAPI(data *replication.JobData) {
+	m.ID = types.StringValue(data.ID)
+	m.VMID = types.Int64Value(int64(data.Guest))
+	m.JobNumber = types.Int64Value(int64(data.JobNum))
+	m.Target = types.StringValue(data.Target)
+	m.Rate = types.Float64PointerValue(data.Rate)
+	m.Comment = types.StringPointerValue(data.Comment)
+	m.Disable = types.BoolValue(data.Disable != nil && bool(*data.Disable))
+	m.Source = types.StringPointerValue(data.Source)
+
+	if data.Schedule != nil {
+		m.Schedule = types.StringValue(*data.Schedule)
+	} else {
+		m.Schedule = types.StringValue(defaultSchedule)
+	}
+}
+
+// toAPIFields creates the fields of replication job create and update requests.
+func (m *jobModel) toAPIFields() replication.JobFields {
+	return replication.JobFields{
+		Comment:  m.Comment.ValueStringPointer(),
+		Disable:  proxmoxtypes.CustomBool(m.Disable.ValueBool()).Pointer(),
+		Rate:     m.Rate.ValueFloat64Pointer(),
+		Schedule: m.Schedule.ValueStringPointer(),
+	}
+}
+
+// toDelete returns the API names of the optional fields that have been removed from the configuration.
+func (m *jobModel) toDelete(state *jobModel) []string {
+	var toDelete []string
+
+	attribute.CheckDelete(m.Rate, state.Rate, &toDelete, "rate")
+	attribute.CheckDelete(m.Comment, state.Comment, &toDelete, "comment")
+
+	return toDelete
+}
@@ -0,0 +1,346 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package replication
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/attribute"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/replication"
+)
+
+var (
+	_ resource.Resource                = &jobResource{}
+	_ resource.ResourceWithConfigure   = &jobResource{}
+	_ resource.ResourceWithImportState = &jobResource{}
+)
+
+//nolint:gochecknoglobals
+var jobIDRegexp = regexp.MustCompile(`^[1-9][0-9]{2,8}-[0-9]{1,9}$`)
+
+type jobResource struct {
+	client *replication.Client
+}
+
+// NewReplicationJobResource creates a new storage replication job resource.
+func NewReplicationJobResource() resource.Resource {
+	return &jobResource{}
+}
+
+func (r *jobResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_replication_job"
+}
+
+func (r *jobResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.Resource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected config.Resource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = cfg.Client.Cluster().Replication()
+}
+
+func (r *jobResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a storage replication job of a guest.",
+		MarkdownDescription: "Manages a storage replication job of a guest. The job replicates the local ZFS " +
+			"volumes of the guest from the node the guest runs on to the target node.",
+		Attributes: map[string]schema.Attribute{
+			"id": attribute.ID("The ID of the replication job, `<vm_id>-<job_number>`."),
+			"vm_id": schema.Int64Attribute{
+				Description: "ID of the guest to replicate.",
+				Required:    true,
+				Validators:  []validator.Int64{int64validator.AtLeast(100)},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"job_number": schema.Int64Attribute{
+				Description: "Number of the replication job of the guest, to distinguish the jobs of a guest " +
+					"replicating to several nodes.",
+				Optional:   true,
+				Computed:   true,
+				Default:    int64default.StaticInt64(0),
+				Validators: []validator.Int64{int64validator.AtLeast(0)},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"target": schema.StringAttribute{
+				Description: "Name of the node to replicate to.",
+				Required:    true,
+				Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"schedule": schema.StringAttribute{
+				Description: "Schedule of the replication, in the systemd calendar event format, " +
+					"e.g. `*/15` or `mon..fri 22:00`.",
+				Optional:   true,
+				Computed:   true,
+				Default:    stringdefault.StaticString(defaultSchedule),
+				Validators: []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"rate": schema.Float64Attribute{
+				Description: "Rate limit of the replication in MB/s, unlimited when not set.",
+				Optional:    true,
+				Validators:  []validator.Float64{float64validator.AtLeast(1)},
+			},
+			"comment": schema.StringAttribute{
+				Description: "Description of the replication job.",
+				Optional:    true,
+			},
+			"disable": schema.BoolAttribute{
+				Description: "Whether the replication job is disabled.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"source": schema.StringAttribute{
+				Description: "Name of the node the guest is replicated from.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *jobResource) read(ctx context.Context, model *jobModel, diags *diag.Diagnostics) bool {
+	data, err := r.client.GetJob(ctx, model.ID.ValueString())
+	if err == nil && data == nil {
+		err = api.ErrNoDataObjectInResponse
+	}
+
+	if err != nil {
+		if errors.Is(err, api.ErrResourceDoesNotExist) {
+			return false
+		}
+
+		diags.AddError(
+			"Unable to Read Replication Job",
+			"An unexpected error occurred while reading the replication job.\n\n"+
+				"Error: "+err.Error(),
+		)
+
+		return false
+	}
+
+	// a job marked for removal is gone as far as the configuration is concerned
+	if data.RemoveJob != nil {
+		return false
+	}
+
+	model.importFromAPI(data)
+
+	return true
+}
+
+func (r *jobResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state jobModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found := r.read(ctx, &state, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *jobResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan jobModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := plan.jobID()
+
+	// a replaced job with the same ID is only marked for removal, and is removed by the next replication run
+	err := r.client.WaitForJobRemoval(ctx, id)
+	if err == nil {
+		err = r.client.CreateJob(ctx, &replication.JobCreateRequestBody{
+			JobFields: plan.toAPIFields(),
+			ID:        id,
+			Target:    plan.Target.ValueString(),
+			Type:      replication.JobTypeLocal,
+		})
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create Replication Job",
+			"An unexpected error occurred while creating the replication job.\n\n"+
+				"Error: "+err.Error(),
+		)
+
+		return
+	}
+
+	plan.ID = types.StringValue(id)
+
+	r.readAfterChange(ctx, &plan, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *jobResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var plan, state jobModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.UpdateJob(ctx, state.ID.ValueString(), &replication.JobUpdateRequestBody{
+		JobFields: plan.toAPIFields(),
+		Delete:    plan.toDelete(&state),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Update Replication Job",
+			"An unexpected error occurred while updating the replication job.\n\n"+
+				"Error: "+err.Error(),
+		)
+
+		return
+	}
+
+	r.readAfterChange(ctx, &plan, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// readAfterChange reads the replication job after it has been created or updated.
+func (r *jobResource) readAfterChange(ctx context.Context, model *jobModel, diags *diag.Diagnostics) {
+	if !r.read(ctx, model, diags) && !diags.HasError() {
+		diags.AddError(
+			"Replication Job Not Found",
+			fmt.Sprintf("The replication job %q could not be found after it has been changed.", model.ID.ValueString()),
+		)
+	}
+}
+
+func (r *jobResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var state jobModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteJob(ctx, state.ID.ValueString())
+	if err != nil && !errors.Is(err, api.ErrResourceDoesNotExist) {
+		resp.Diagnostics.AddError(
+			"Unable to Delete Replication Job",
+			"An unexpected error occurred while deleting the replication job.\n\n"+
+				"Error: "+err.Error(),
+		)
+	}
+}
+
+func (r *jobResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	if !jobIDRegexp.MatchString(req.ID) {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected the ID of a replication job, `<vm_id>-<job_number>`, got: %q", req.ID),
+		)
+
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
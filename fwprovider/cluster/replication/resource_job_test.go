@@ -0,0 +1,114 @@
+//go:build acceptance || all
+
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package replication_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/test"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/fake"
+)
+
+func TestAccResourceReplicationJob(t *testing.T) {
+	t.Parallel()
+
+	te := test.InitFakeEnvironment(t, fake.WithNodes("pve2", "pve3"))
+
+	type createVM struct {
+		VMID int `url:"vmid"`
+	}
+
+	require.NoError(t, te.Client().DoRequest(context.Background(), http.MethodPost,
+		fmt.Sprintf("nodes/%s/qemu", te.NodeName), &createVM{VMID: 100}, nil))
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_replication_job" "test" {
+					vm_id   = 100
+					target  = "pve2"
+					rate    = 50
+					comment = "failover"
+				}
+
+				data "proxmox_virtual_environment_replication_status" "test" {
+					id = proxmox_virtual_environment_replication_job.test.id
+				}`),
+				Check: resource.ComposeTestCheckFunc(
+					test.ResourceAttributes("proxmox_virtual_environment_replication_job.test", map[string]string{
+						"id":         "100-0",
+						"job_number": "0",
+						"schedule":   `^\*/15$`,
+						"rate":       "50",
+						"disable":    "false",
+						"source":     te.NodeName,
+					}),
+					test.ResourceAttributes("data.proxmox_virtual_environment_replication_status.test", map[string]string{
+						"node_name":  te.NodeName,
+						"vm_id":      "100",
+						"target":     "pve2",
+						"fail_count": "0",
+						"duration":   `\d`,
+						"last_sync":  `^\d{4}-\d{2}-\d{2}T`,
+					}),
+					test.NoResourceAttributesSet("data.proxmox_virtual_environment_replication_status.test", []string{
+						"error",
+					}),
+				),
+			},
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_replication_job" "test" {
+					vm_id    = 100
+					target   = "pve2"
+					schedule = "mon..fri 22:00"
+					disable  = true
+				}
+
+				resource "proxmox_virtual_environment_replication_job" "second" {
+					vm_id      = 100
+					job_number = 1
+					target     = "pve3"
+				}`),
+				Check: resource.ComposeTestCheckFunc(
+					test.ResourceAttributes("proxmox_virtual_environment_replication_job.test", map[string]string{
+						"schedule": "mon..fri 22:00",
+						"disable":  "true",
+					}),
+					test.NoResourceAttributesSet("proxmox_virtual_environment_replication_job.test", []string{
+						"rate",
+						"comment",
+					}),
+					resource.TestCheckResourceAttr("proxmox_virtual_environment_replication_job.second", "id", "100-1"),
+				),
+			},
+			{
+				ResourceName:      "proxmox_virtual_environment_replication_job.second",
+				ImportState:       true,
+				ImportStateId:     "100-1",
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:  "proxmox_virtual_environment_replication_job.second",
+				ImportState:   true,
+				ImportStateId: "100",
+				ExpectError:   regexp.MustCompile(`Invalid Import ID`),
+			},
+		},
+	})
+}
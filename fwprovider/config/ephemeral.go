@@ -0,0 +1,14 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package config
+
+import "github.com/bpg/terraform-provider-proxmox/proxmox"
+
+// EphemeralResource is the global configuration for all ephemeral resources.
+type EphemeralResource struct {
+	Client proxmox.Client
+}
@@ -87,7 +87,7 @@ func testAccResourceHardwareMappingInit(t *testing.T) (*accTestHardwareMappingFa
 
 	var data accTestHardwareMappingFakeData
 
-	if err := gofakeit.Struct(&data); err != nil {
+	if err := te.Faker.Struct(&data); err != nil {
 		t.Fatalf("could not create fake data for hardware mapping: %s", err)
 	}
 
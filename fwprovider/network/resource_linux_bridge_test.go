@@ -12,8 +12,6 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/brianvoe/gofakeit/v7"
-
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/test"
@@ -22,9 +20,9 @@ import (
 func TestAccResourceLinuxBridge(t *testing.T) {
 	te := test.InitEnvironment(t)
 
-	iface := fmt.Sprintf("vmbr%d", gofakeit.Number(10, 9999))
-	ipV4cidr1 := fmt.Sprintf("%s/24", gofakeit.IPv4Address())
-	ipV4cidr2 := fmt.Sprintf("%s/24", gofakeit.IPv4Address())
+	iface := fmt.Sprintf("vmbr%d", te.Faker.Number(10, 9999))
+	ipV4cidr1 := fmt.Sprintf("%s/24", te.Faker.IPv4Address())
+	ipV4cidr2 := fmt.Sprintf("%s/24", te.Faker.IPv4Address())
 	ipV6cidr := "FE80:0000:0000:0000:0202:B3FF:FE1E:8329/64"
 
 	resource.Test(t, resource.TestCase{
@@ -14,7 +14,6 @@ import (
 	"strconv"
 	"testing"
 
-	"github.com/brianvoe/gofakeit/v7"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/test"
@@ -32,10 +31,10 @@ func TestAccResourceLinuxVLAN(t *testing.T) {
 		iface = "ens18"
 	}
 
-	vlan1 := gofakeit.Number(10, 4094)
-	customName := fmt.Sprintf("iface_%s", gofakeit.Word())
-	vlan2 := gofakeit.Number(10, 4094)
-	ipV4cidr := fmt.Sprintf("%s/24", gofakeit.IPv4Address())
+	vlan1 := te.Faker.Number(10, 4094)
+	customName := fmt.Sprintf("iface_%s", te.Faker.Word())
+	vlan2 := te.Faker.Number(10, 4094)
+	ipV4cidr := fmt.Sprintf("%s/24", te.Faker.IPv4Address())
 
 	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: te.AccProviders,
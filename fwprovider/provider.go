@@ -8,14 +8,16 @@ package fwprovider
 
 import (
 	"context"
-	"fmt"
-	"net"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -26,29 +28,43 @@ import (
 
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/access"
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/acme"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/cluster/backup"
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/cluster/metrics"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/cluster/replication"
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/ha"
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/hardwaremapping"
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/network"
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/nodes/apt"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/sdn"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/storage"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/validators"
 	"github.com/bpg/terraform-provider-proxmox/fwprovider/vm"
 	"github.com/bpg/terraform-provider-proxmox/proxmox"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster"
-	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/ssh"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/ssh/resolver"
 	"github.com/bpg/terraform-provider-proxmox/utils"
+	"github.com/bpg/terraform-provider-proxmox/utils/profile"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
-var _ provider.Provider = &proxmoxProvider{}
+var (
+	_ provider.Provider                       = &proxmoxProvider{}
+	_ provider.ProviderWithEphemeralResources = &proxmoxProvider{}
+)
 
 // New is a helper function to simplify provider server and testing implementation.
-func New(version string) func() provider.Provider {
+// The shared state is shared with the SDK provider served by the same provider instance, it may be nil.
+// The connection options are applied to the API connection after the provider configuration,
+// e.g. to record and replay the API interactions in tests.
+func New(version string, shared *proxmox.SharedState, opts ...api.ConnectionOption) func() provider.Provider {
 	return func() provider.Provider {
 		return &proxmoxProvider{
-			version: version,
+			version:  version,
+			shared:   shared,
+			connOpts: opts,
 		}
 	}
 }
@@ -58,36 +74,87 @@ type proxmoxProvider struct {
 	// provider is built and ran locally, and "test" when running acceptance
 	// testing.
 	version string
+
+	// shared is the state shared with the SDK provider.
+	shared *proxmox.SharedState
+
+	// connOpts are additional options for the API connection.
+	connOpts []api.ConnectionOption
 }
 
 // proxmoxProviderModel maps provider schema data.
 type proxmoxProviderModel struct {
 	Endpoint            types.String `tfsdk:"endpoint"`
+	Endpoints           types.List   `tfsdk:"endpoints"`
 	Insecure            types.Bool   `tfsdk:"insecure"`
 	MinTLS              types.String `tfsdk:"min_tls"`
+	CACertificate       types.String `tfsdk:"ca_certificate"`
+	TLSFingerprint      types.String `tfsdk:"tls_fingerprint"`
+	CancelTasks         types.Bool   `tfsdk:"cancel_tasks_on_interrupt"`
 	AuthTicket          types.String `tfsdk:"auth_ticket"`
 	CSRFPreventionToken types.String `tfsdk:"csrf_prevention_token"`
+	CredentialsCommand  types.List   `tfsdk:"credentials_command"`
 	APIToken            types.String `tfsdk:"api_token"`
 	OTP                 types.String `tfsdk:"otp"`
 	Username            types.String `tfsdk:"username"`
 	Password            types.String `tfsdk:"password"`
 
+	APILimits []struct {
+		MaxConcurrentRequests     types.Int64   `tfsdk:"max_concurrent_requests"`
+		MaxConcurrentTasksPerNode types.Int64   `tfsdk:"max_concurrent_tasks_per_node"`
+		RequestsPerSecond         types.Float64 `tfsdk:"requests_per_second"`
+	} `tfsdk:"api_limits"`
+
+	APIRetry []struct {
+		Attempts             types.Int64  `tfsdk:"attempts"`
+		InitialDelay         types.String `tfsdk:"initial_delay"`
+		MaxDelay             types.String `tfsdk:"max_delay"`
+		Jitter               types.Bool   `tfsdk:"jitter"`
+		RetryableStatusCodes types.List   `tfsdk:"retryable_status_codes"`
+		RetryNonIdempotent   types.Bool   `tfsdk:"retry_non_idempotent"`
+	} `tfsdk:"api_retry"`
+
 	SSH []struct {
 		Agent          types.Bool   `tfsdk:"agent"`
 		AgentSocket    types.String `tfsdk:"agent_socket"`
 		PrivateKey     types.String `tfsdk:"private_key"`
+		PrivateKeyPass types.String `tfsdk:"private_key_passphrase"`
+		Certificate    types.String `tfsdk:"certificate"`
 		Password       types.String `tfsdk:"password"`
 		Username       types.String `tfsdk:"username"`
 		Socks5Server   types.String `tfsdk:"socks5_server"`
 		Socks5Username types.String `tfsdk:"socks5_username"`
 		Socks5Password types.String `tfsdk:"socks5_password"`
 
+		HostKeyVerification types.String `tfsdk:"host_key_verification"`
+		KnownHostsFile      types.String `tfsdk:"known_hosts_file"`
+		KnownHosts          types.String `tfsdk:"known_hosts"`
+
+		NodeAddressSource types.String `tfsdk:"node_address_source"`
+		NodeAddressCIDRs  types.List   `tfsdk:"node_address_cidrs"`
+
+		MaxSessions       types.Int64  `tfsdk:"max_sessions"`
+		KeepAliveInterval types.String `tfsdk:"keepalive_interval"`
+
+		Bastions []struct {
+			Address             types.String `tfsdk:"address"`
+			Port                types.Int64  `tfsdk:"port"`
+			Username            types.String `tfsdk:"username"`
+			Password            types.String `tfsdk:"password"`
+			PrivateKey          types.String `tfsdk:"private_key"`
+			Agent               types.Bool   `tfsdk:"agent"`
+			HostKeyVerification types.String `tfsdk:"host_key_verification"`
+			KnownHostsFile      types.String `tfsdk:"known_hosts_file"`
+			KnownHosts          types.String `tfsdk:"known_hosts"`
+		} `tfsdk:"bastion"`
+
 		Nodes []struct {
 			Name    types.String `tfsdk:"name"`
 			Address types.String `tfsdk:"address"`
 			Port    types.Int64  `tfsdk:"port"`
 		} `tfsdk:"node"`
 	} `tfsdk:"ssh"`
+	Profile        types.String `tfsdk:"profile"`
 	TmpDir         types.String `tfsdk:"tmp_dir"`
 	RandomVMIDs    types.Bool   `tfsdk:"random_vm_ids"`
 	RandomVMIDStat types.Int64  `tfsdk:"random_vm_id_start"`
@@ -113,6 +180,36 @@ func (p *proxmoxProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"cancel_tasks_on_interrupt": schema.BoolAttribute{
+				Description: "Whether to stop running Proxmox VE tasks, e.g. a VM clone, when Terraform is interrupted " +
+					"or an operation times out, so that they do not keep the guest configuration locked. " +
+					"Defaults to `false`.",
+				Optional: true,
+			},
+			"ca_certificate": schema.StringAttribute{
+				Description: "The CA certificate (PEM content or a path to a PEM file) to trust in addition to the " +
+					"system trust store, e.g. the Proxmox VE cluster CA from `/etc/pve/pve-root-ca.pem`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"credentials_command": schema.ListAttribute{
+				Description: "The command printing the credentials as a JSON object, run instead of storing the " +
+					"credentials in the configuration, e.g. a wrapper of a password manager CLI. The first item is the " +
+					"executable, the other items are its arguments. The object has either the `api_token`, or the " +
+					"`username` and `password` fields, and optionally the `ssh_username`, `ssh_password`, " +
+					"`ssh_private_key` and `ssh_private_key_passphrase` fields. The credentials printed by the command " +
+					"take precedence over the other sources, and the command is run again when the API rejects them. " +
+					"The `PROXMOX_VE_CREDENTIALS_COMMAND` environment variable and the profile setting are split on " +
+					"whitespace without quoting, so their executable and arguments cannot contain spaces.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
 			"csrf_prevention_token": schema.StringAttribute{
 				Description: "The pre-authenticated CSRF Prevention Token for the Proxmox VE API.",
 				Optional:    true,
@@ -125,6 +222,16 @@ func (p *proxmoxProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 					stringvalidator.LengthAtLeast(1),
 				},
 			},
+			"endpoints": schema.ListAttribute{
+				Description: "The endpoints for the Proxmox VE API on other cluster nodes, used for failover " +
+					"when the current endpoint is unavailable. If `endpoint` is not set, the first " +
+					"endpoint in the list is the primary one.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
 			"insecure": schema.BoolAttribute{
 				Description: "Whether to skip the TLS verification step.",
 				Optional:    true,
@@ -145,6 +252,16 @@ func (p *proxmoxProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"profile": schema.StringAttribute{
+				Description: "The name of the profile of the configuration file to use, i.e. `PROXMOX_VE_CONFIG` " +
+					"or `~/.config/proxmox/config`. The profile provides the values of the settings " +
+					"that are set neither in the provider configuration, nor with the environment variables. " +
+					"Defaults to the `default` profile, if present.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
 			"random_vm_ids": schema.BoolAttribute{
 				Description: "Whether to generate random VM / Container IDs.",
 				Optional:    true,
@@ -159,6 +276,15 @@ func (p *proxmoxProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 				Optional:    true,
 				Validators:  []validator.Int64{int64validator.Between(100, 999999999)},
 			},
+			"tls_fingerprint": schema.StringAttribute{
+				Description: "The SHA-256 fingerprint of the Proxmox VE API certificate, as shown in the certificate " +
+					"details of the web UI. When set, a certificate matching the fingerprint is trusted without " +
+					"further verification, and any other certificate is rejected.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
 			"tmp_dir": schema.StringAttribute{
 				Description: "The alternative temporary directory.",
 				Optional:    true,
@@ -169,6 +295,77 @@ func (p *proxmoxProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 			},
 		},
 		Blocks: map[string]schema.Block{
+			"api_limits": schema.ListNestedBlock{
+				Description: "Client-side limits for the load put on the Proxmox VE API.",
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"max_concurrent_requests": schema.Int64Attribute{
+							Description: "The maximum number of concurrent API requests. " +
+								"Defaults to `0` (unlimited).",
+							Optional:   true,
+							Validators: []validator.Int64{int64validator.AtLeast(0)},
+						},
+						"max_concurrent_tasks_per_node": schema.Int64Attribute{
+							Description: "The maximum number of concurrent long-running tasks, e.g. VM clones, started by the " +
+								"provider on a single node. Defaults to `0` (unlimited).",
+							Optional:   true,
+							Validators: []validator.Int64{int64validator.AtLeast(0)},
+						},
+						"requests_per_second": schema.Float64Attribute{
+							Description: "The maximum number of API requests per second, e.g. `2.5`. " +
+								"Defaults to `0` (unlimited).",
+							Optional:   true,
+							Validators: []validator.Float64{float64validator.AtLeast(0)},
+						},
+					},
+				},
+			},
+			"api_retry": schema.ListNestedBlock{
+				Description: "The retry policy for failed Proxmox VE API requests.",
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"attempts": schema.Int64Attribute{
+							Description: "The maximum number of attempts for a request, including the initial one. " +
+								"Defaults to `3`.",
+							Optional:   true,
+							Validators: []validator.Int64{int64validator.AtLeast(1)},
+						},
+						"initial_delay": schema.StringAttribute{
+							Description: "The delay before the first retry, e.g. `500ms`. The delay doubles " +
+								"with each subsequent retry. Defaults to `500ms`.",
+							Optional:   true,
+							Validators: []validator.String{validators.DurationValidator()},
+						},
+						"jitter": schema.BoolAttribute{
+							Description: "Whether to randomize the delay between retries. Defaults to `true`.",
+							Optional:    true,
+						},
+						"max_delay": schema.StringAttribute{
+							Description: "The maximum delay between retries, e.g. `5s`. Defaults to `5s`.",
+							Optional:    true,
+							Validators:  []validator.String{validators.DurationValidator()},
+						},
+						"retry_non_idempotent": schema.BoolAttribute{
+							Description: "Whether to retry requests that may modify resources (`POST`, `PUT`, " +
+								"`DELETE`). Requests that failed to acquire a configuration lock are always " +
+								"retried. Defaults to `false`.",
+							Optional: true,
+						},
+						"retryable_status_codes": schema.ListAttribute{
+							Description: "The HTTP status codes that cause a request to be retried. " +
+								"Defaults to `[502, 503, 504, 595]`.",
+							Optional:    true,
+							ElementType: types.Int64Type,
+						},
+					},
+				},
+			},
 			// have to define it as a list due to backwards compatibility
 			"ssh": schema.ListNestedBlock{
 				Description: "The SSH configuration for the Proxmox nodes.",
@@ -189,6 +386,68 @@ func (p *proxmoxProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 								"environment variable.",
 							Optional: true,
 						},
+						"certificate": schema.StringAttribute{
+							Description: "The OpenSSH user certificate, e.g. the content of an `id_ed25519-cert.pub` file, used with the " +
+								"`private_key`, or with the matching key of the SSH agent. " +
+								"Defaults to the value of the `PROXMOX_VE_SSH_CERTIFICATE` environment variable.",
+							Optional: true,
+						},
+						"host_key_verification": schema.StringAttribute{
+							Description: "The host key verification of the SSH connection: `tofu` trusts the host key of an unknown " +
+								"node on first use and adds it to the known hosts file, `known_hosts_file` only accepts the " +
+								"host keys listed in the `known_hosts_file`, and `strict` only accepts the host keys of the " +
+								"cluster listed in `known_hosts`. Defaults to the value of the " +
+								"`PROXMOX_VE_SSH_HOST_KEY_VERIFICATION` environment variable, or `tofu` if not set.",
+							Optional: true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(ssh.HostKeyVerificationModes...),
+							},
+						},
+						"keepalive_interval": schema.StringAttribute{
+							Description: "The interval of the keepalives sent over the SSH connections to the nodes, e.g. `30s`. " +
+								"`0s` disables the keepalives. Defaults to `30s`.",
+							Optional:   true,
+							Validators: []validator.String{validators.DurationValidator()},
+						},
+						"known_hosts": schema.StringAttribute{
+							Description: "The known hosts of the cluster, e.g. the content of `/etc/pve/priv/known_hosts` of a " +
+								"cluster node, used by the `strict` host key verification. The nodes are matched by their " +
+								"address and their name. Defaults to the value of the `PROXMOX_VE_SSH_KNOWN_HOSTS` " +
+								"environment variable.",
+							Optional: true,
+						},
+						"known_hosts_file": schema.StringAttribute{
+							Description: "The path to the known hosts file used by the `known_hosts_file` host key verification. " +
+								"With the `tofu` host key verification, the file replaces `~/.ssh/known_hosts`. Defaults to " +
+								"the value of the `PROXMOX_VE_SSH_KNOWN_HOSTS_FILE` environment variable.",
+							Optional: true,
+						},
+						"max_sessions": schema.Int64Attribute{
+							Description: "The maximum number of concurrent SSH sessions, e.g. command executions and file uploads, " +
+								"on the connection to a single node. Must not exceed the `MaxSessions` setting of the SSH server. " +
+								"Defaults to `10`.",
+							Optional:   true,
+							Validators: []validator.Int64{int64validator.AtLeast(1)},
+						},
+						"node_address_cidrs": schema.ListAttribute{
+							Description: "The CIDRs of the preferred node addresses, e.g. of the management network. Addresses outside " +
+								"of the CIDRs are only used if no preferred address is found.",
+							Optional:    true,
+							ElementType: types.StringType,
+							Validators: []validator.List{
+								listvalidator.ValueStringsAre(validators.CIDRValidator()),
+							},
+						},
+						"node_address_source": schema.StringAttribute{
+							Description: "The source of the node addresses used for the SSH connections: `cluster` uses the node IPs and " +
+								"corosync link addresses of the cluster, `interface` the addresses of the node network interfaces, and " +
+								"`dns` the addresses the node name resolves to. The next sources are tried if no address is found. " +
+								"Defaults to the value of the `PROXMOX_VE_SSH_NODE_ADDRESS_SOURCE` environment variable, or `cluster` if not set.",
+							Optional: true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(resolver.AddressSources...),
+							},
+						},
 						"password": schema.StringAttribute{
 							Description: "The password used for the SSH connection. " +
 								"Defaults to the value of the `password` field of the " +
@@ -197,11 +456,18 @@ func (p *proxmoxProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 							Sensitive: true,
 						},
 						"private_key": schema.StringAttribute{
-							Description: "The unencrypted private key (in PEM format) used for the SSH connection. " +
+							Description: "The private key (in PEM format) used for the SSH connection. An encrypted key " +
+								"requires the `private_key_passphrase`. " +
 								"Defaults to the value of the `PROXMOX_VE_SSH_PRIVATE_KEY` environment variable.",
 							Optional:  true,
 							Sensitive: true,
 						},
+						"private_key_passphrase": schema.StringAttribute{
+							Description: "The passphrase of the encrypted `private_key`. " +
+								"Defaults to the value of the `PROXMOX_VE_SSH_PRIVATE_KEY_PASSPHRASE` environment variable.",
+							Optional:  true,
+							Sensitive: true,
+						},
 						"socks5_password": schema.StringAttribute{
 							Description: "The password for the SOCKS5 proxy server. " +
 								"Defaults to the value of the `PROXMOX_VE_SSH_SOCKS5_PASSWORD` environment variable.",
@@ -226,6 +492,59 @@ func (p *proxmoxProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 						},
 					},
 					Blocks: map[string]schema.Block{
+						"bastion": schema.ListNestedBlock{
+							Description: "A jump host the SSH connections to the nodes are tunneled through. Can be specified multiple " +
+								"times to chain the connections through several jump hosts, in the given order.",
+							NestedObject: schema.NestedBlockObject{
+								Attributes: map[string]schema.Attribute{
+									"address": schema.StringAttribute{
+										Description: "The address of the jump host.",
+										Required:    true,
+									},
+									"agent": schema.BoolAttribute{
+										Description: "Whether to use the SSH agent for the authentication to the jump host. Defaults to `false`.",
+										Optional:    true,
+									},
+									"host_key_verification": schema.StringAttribute{
+										Description: "The verification of the jump host key: `tofu`, `known_hosts_file` or `strict`. " +
+											"Defaults to `tofu`.",
+										Optional: true,
+										Validators: []validator.String{
+											stringvalidator.OneOf(ssh.HostKeyVerificationModes...),
+										},
+									},
+									"known_hosts": schema.StringAttribute{
+										Description: "The known hosts content used by the `strict` host key verification of the jump host.",
+										Optional:    true,
+									},
+									"known_hosts_file": schema.StringAttribute{
+										Description: "The path to the known hosts file used by the `known_hosts_file` host key verification " +
+											"of the jump host.",
+										Optional: true,
+									},
+									"password": schema.StringAttribute{
+										Description: "The password used for the SSH connection to the jump host.",
+										Optional:    true,
+										Sensitive:   true,
+									},
+									"port": schema.Int64Attribute{
+										Description: "The SSH port of the jump host. Defaults to `22`.",
+										Optional:    true,
+										Validators:  []validator.Int64{int64validator.Between(1, 65535)},
+									},
+									"private_key": schema.StringAttribute{
+										Description: "The unencrypted private key (in PEM format) used for the SSH connection to the jump host.",
+										Optional:    true,
+										Sensitive:   true,
+									},
+									"username": schema.StringAttribute{
+										Description: "The username used for the SSH connection to the jump host. Defaults to the " +
+											"username of the SSH connection to the nodes.",
+										Optional: true,
+									},
+								},
+							},
+						},
 						"node": schema.ListNestedBlock{
 							Description: "Overrides for SSH connection configuration for a Proxmox VE node.",
 							NestedObject: schema.NestedBlockObject{
@@ -286,27 +605,56 @@ func (p *proxmoxProvider) Configure(
 		return
 	}
 
-	// Default values to environment variables, but override
-	// with Terraform configuration value if set.
+	profileName := utils.GetAnyStringEnv("PROXMOX_VE_PROFILE")
+
+	if !cfg.Profile.IsNull() {
+		profileName = cfg.Profile.ValueString()
+	}
+
+	prof, err := profile.Load(profileName)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("profile"),
+			"Unable to load Proxmox VE configuration profile",
+			err.Error(),
+		)
+
+		return
+	}
+
+	if prof != nil {
+		tflog.Info(ctx, "Using configuration profile", map[string]interface{}{
+			"profile":  prof.Name,
+			"settings": prof.Keys(),
+		})
+	}
+
+	// Default values to environment variables, then to the configuration profile,
+	// but override with Terraform configuration value if set.
 
 	// Check environment variables
-	endpoint := utils.GetAnyStringEnv("PROXMOX_VE_ENDPOINT")
-	insecure := utils.GetAnyBoolEnv("PROXMOX_VE_INSECURE")
-	minTLS := utils.GetAnyStringEnv("PROXMOX_VE_MIN_TLS")
+	endpoint := prof.String(profile.KeyEndpoint, "PROXMOX_VE_ENDPOINT")
+	endpoints := prof.Strings(profile.KeyEndpoints, "PROXMOX_VE_ENDPOINTS")
+	insecure := prof.Bool(profile.KeyInsecure, "PROXMOX_VE_INSECURE")
+	minTLS := prof.String(profile.KeyMinTLS, "PROXMOX_VE_MIN_TLS")
+	caCertificate := prof.String(profile.KeyCACertificate, "PROXMOX_VE_CA_CERTIFICATE")
+	tlsFingerprint := prof.String(profile.KeyTLSFingerprint, "PROXMOX_VE_TLS_FINGERPRINT")
+	cancelTasks := utils.GetAnyBoolEnv("PROXMOX_VE_CANCEL_TASKS_ON_INTERRUPT")
 	authTicket := utils.GetAnyStringEnv("PROXMOX_VE_AUTH_TICKET")
 	csrfPreventionToken := utils.GetAnyStringEnv("PROXMOX_VE_CSRF_PREVENTION_TOKEN")
 	apiToken := utils.GetAnyStringEnv("PROXMOX_VE_API_TOKEN")
 	username := utils.GetAnyStringEnv("PROXMOX_VE_USERNAME")
 	password := utils.GetAnyStringEnv("PROXMOX_VE_PASSWORD")
-
-	if !cfg.APIToken.IsNull() {
-		apiToken = cfg.APIToken.ValueString()
-	}
+	credentialsCommand := strings.Fields(utils.GetAnyStringEnv("PROXMOX_VE_CREDENTIALS_COMMAND"))
 
 	if !cfg.Endpoint.IsNull() {
 		endpoint = cfg.Endpoint.ValueString()
 	}
 
+	if !cfg.Endpoints.IsNull() {
+		resp.Diagnostics.Append(cfg.Endpoints.ElementsAs(ctx, &endpoints, false)...)
+	}
+
 	if !cfg.Insecure.IsNull() {
 		insecure = cfg.Insecure.ValueBool()
 	}
@@ -315,6 +663,18 @@ func (p *proxmoxProvider) Configure(
 		minTLS = cfg.MinTLS.ValueString()
 	}
 
+	if !cfg.CACertificate.IsNull() {
+		caCertificate = cfg.CACertificate.ValueString()
+	}
+
+	if !cfg.TLSFingerprint.IsNull() {
+		tlsFingerprint = cfg.TLSFingerprint.ValueString()
+	}
+
+	if !cfg.CancelTasks.IsNull() {
+		cancelTasks = cfg.CancelTasks.ValueBool()
+	}
+
 	if !cfg.AuthTicket.IsNull() {
 		authTicket = cfg.AuthTicket.ValueString()
 	}
@@ -335,12 +695,48 @@ func (p *proxmoxProvider) Configure(
 		password = cfg.Password.ValueString()
 	}
 
+	if !cfg.CredentialsCommand.IsNull() {
+		resp.Diagnostics.Append(cfg.CredentialsCommand.ElementsAs(ctx, &credentialsCommand, false)...)
+	}
+
+	// The credentials of the profile are only used if no others are set, they are never mixed with them.
+	credentials := prof.CredentialsOverride(profile.Credentials{
+		APIToken:            apiToken,
+		Username:            username,
+		Password:            password,
+		AuthTicket:          authTicket,
+		CSRFPreventionToken: csrfPreventionToken,
+		CredentialsCommand:  credentialsCommand,
+	})
+	apiToken, username, password = credentials.APIToken, credentials.Username, credentials.Password
+	credentialsCommand = credentials.CredentialsCommand
+
+	var commandCreds api.CredentialsCommandOutput
+
+	if len(credentialsCommand) > 0 {
+		commandCreds, err = p.shared.CredentialsCommands().Run(ctx, credentialsCommand)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("credentials_command"),
+				"Unable to get Proxmox VE credentials",
+				err.Error(),
+			)
+
+			return
+		}
+	}
+
+	if endpoint == "" && len(endpoints) > 0 {
+		endpoint, endpoints = endpoints[0], endpoints[1:]
+	}
+
 	if endpoint == "" {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("endpoint"),
 			"Missing Proxmox VE API Endpoint",
 			"The provider cannot create the Proxmox VE API client as there is a missing or empty value for the API endpoint. "+
-				"Set the host value in the configuration or use the PROXMOX_VE_ENDPOINT environment variable. "+
+				"Set the host value in the configuration, use the PROXMOX_VE_ENDPOINT environment variable, "+
+				"or set the endpoint in the configuration profile. "+
 				"If either is already set, ensure the value is not empty.",
 		)
 	}
@@ -351,7 +747,14 @@ func (p *proxmoxProvider) Configure(
 
 	// Create the Proxmox VE API client
 
-	creds, err := api.NewCredentials(username, password, "", apiToken, authTicket, csrfPreventionToken)
+	var creds api.Credentials
+
+	if commandCreds.HasAPICredentials() {
+		creds, err = p.shared.CredentialsCommands().NewCredentials(credentialsCommand, commandCreds)
+	} else {
+		creds, err = api.NewCredentials(username, password, "", apiToken, authTicket, csrfPreventionToken)
+	}
+
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to create Proxmox VE API credentials",
@@ -359,10 +762,33 @@ func (p *proxmoxProvider) Configure(
 		)
 	}
 
+	retryPolicy := api.DefaultRetryPolicy()
+
+	if len(cfg.APIRetry) > 0 {
+		resp.Diagnostics.Append(applyAPIRetryConfig(ctx, cfg, &retryPolicy)...)
+	}
+
+	var limits api.Limits
+
+	if len(cfg.APILimits) > 0 {
+		limitsCfg := cfg.APILimits[0]
+		limits.MaxConcurrentRequests = int(limitsCfg.MaxConcurrentRequests.ValueInt64())
+		limits.MaxConcurrentTasksPerNode = int(limitsCfg.MaxConcurrentTasksPerNode.ValueInt64())
+		limits.RequestsPerSecond = limitsCfg.RequestsPerSecond.ValueFloat64()
+	}
+
 	conn, err := api.NewConnection(
 		endpoint,
 		insecure,
 		minTLS,
+		append([]api.ConnectionOption{
+			api.WithRetryPolicy(retryPolicy),
+			api.WithFailoverEndpoints(endpoints...),
+			api.WithCACertificate(caCertificate),
+			api.WithTLSFingerprint(tlsFingerprint),
+			api.WithCancelTasksOnInterrupt(cancelTasks),
+			api.WithSharedLimits(p.shared.Limiters(), limits),
+		}, p.connOpts...)...,
 	)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -383,15 +809,31 @@ func (p *proxmoxProvider) Configure(
 		)
 	}
 
-	sshUsername := utils.GetAnyStringEnv("PROXMOX_VE_SSH_USERNAME")
-	sshPassword := utils.GetAnyStringEnv("PROXMOX_VE_SSH_PASSWORD")
-	sshAgent := utils.GetAnyBoolEnv("PROXMOX_VE_SSH_AGENT")
-	sshPrivateKey := utils.GetAnyStringEnv("PROXMOX_VE_SSH_PRIVATE_KEY")
-	sshAgentSocket := utils.GetAnyStringEnv("SSH_AUTH_SOCK", "PROXMOX_VE_SSH_AUTH_SOCK")
-	sshSocks5Server := utils.GetAnyStringEnv("PROXMOX_VE_SSH_SOCKS5_SERVER")
-	sshSocks5Username := utils.GetAnyStringEnv("PROXMOX_VE_SSH_SOCKS5_USERNAME")
-	sshSocks5Password := utils.GetAnyStringEnv("PROXMOX_VE_SSH_SOCKS5_PASSWORD")
-	nodeOverrides := map[string]ssh.ProxmoxNode{}
+	sshUsername := prof.String(profile.KeySSHUsername, "PROXMOX_VE_SSH_USERNAME")
+	sshPassword := prof.String(profile.KeySSHPassword, "PROXMOX_VE_SSH_PASSWORD")
+	sshAgent := prof.Bool(profile.KeySSHAgent, "PROXMOX_VE_SSH_AGENT")
+	sshPrivateKey := prof.String(profile.KeySSHPrivateKey, "PROXMOX_VE_SSH_PRIVATE_KEY")
+	sshPrivateKeyPassphrase := prof.String(profile.KeySSHPrivateKeyPassphrase, "PROXMOX_VE_SSH_PRIVATE_KEY_PASSPHRASE")
+	sshCertificate := prof.String(profile.KeySSHCertificate, "PROXMOX_VE_SSH_CERTIFICATE")
+	sshAgentSocket := prof.String(profile.KeySSHAgentSocket, "SSH_AUTH_SOCK", "PROXMOX_VE_SSH_AUTH_SOCK")
+	sshSocks5Server := prof.String(profile.KeySSHSocks5Server, "PROXMOX_VE_SSH_SOCKS5_SERVER")
+	sshSocks5Username := prof.String(profile.KeySSHSocks5Username, "PROXMOX_VE_SSH_SOCKS5_USERNAME")
+	sshSocks5Password := prof.String(profile.KeySSHSocks5Password, "PROXMOX_VE_SSH_SOCKS5_PASSWORD")
+	sshHostKeyVerification := prof.String(profile.KeySSHHostKeyVerification, "PROXMOX_VE_SSH_HOST_KEY_VERIFICATION")
+	sshKnownHostsFile := prof.String(profile.KeySSHKnownHostsFile, "PROXMOX_VE_SSH_KNOWN_HOSTS_FILE")
+	sshKnownHosts := prof.String(profile.KeySSHKnownHosts, "PROXMOX_VE_SSH_KNOWN_HOSTS")
+	sshNodeAddressSource := prof.String(profile.KeySSHNodeAddressSource, "PROXMOX_VE_SSH_NODE_ADDRESS_SOURCE")
+	nodeOverrides := prof.Nodes()
+
+	var (
+		bastions        []ssh.Bastion
+		configuredCIDRs []string
+	)
+
+	pool := ssh.PoolConfig{
+		MaxSessionsPerNode: ssh.DefaultMaxSessionsPerNode,
+		KeepAliveInterval:  ssh.DefaultKeepAliveInterval,
+	}
 
 	//nolint: nestif
 	if len(cfg.SSH) > 0 {
@@ -415,6 +857,14 @@ func (p *proxmoxProvider) Configure(
 			sshPrivateKey = cfg.SSH[0].PrivateKey.ValueString()
 		}
 
+		if !cfg.SSH[0].PrivateKeyPass.IsNull() {
+			sshPrivateKeyPassphrase = cfg.SSH[0].PrivateKeyPass.ValueString()
+		}
+
+		if !cfg.SSH[0].Certificate.IsNull() {
+			sshCertificate = cfg.SSH[0].Certificate.ValueString()
+		}
+
 		if !cfg.SSH[0].Socks5Server.IsNull() {
 			sshSocks5Server = cfg.SSH[0].Socks5Server.ValueString()
 		}
@@ -427,6 +877,56 @@ func (p *proxmoxProvider) Configure(
 			sshSocks5Password = cfg.SSH[0].Socks5Password.ValueString()
 		}
 
+		if !cfg.SSH[0].HostKeyVerification.IsNull() {
+			sshHostKeyVerification = cfg.SSH[0].HostKeyVerification.ValueString()
+		}
+
+		if !cfg.SSH[0].KnownHostsFile.IsNull() {
+			sshKnownHostsFile = cfg.SSH[0].KnownHostsFile.ValueString()
+		}
+
+		if !cfg.SSH[0].KnownHosts.IsNull() {
+			sshKnownHosts = cfg.SSH[0].KnownHosts.ValueString()
+		}
+
+		if !cfg.SSH[0].NodeAddressSource.IsNull() {
+			sshNodeAddressSource = cfg.SSH[0].NodeAddressSource.ValueString()
+		}
+
+		if !cfg.SSH[0].NodeAddressCIDRs.IsNull() {
+			resp.Diagnostics.Append(cfg.SSH[0].NodeAddressCIDRs.ElementsAs(ctx, &configuredCIDRs, false)...)
+		}
+
+		if !cfg.SSH[0].MaxSessions.IsNull() {
+			pool.MaxSessionsPerNode = int(cfg.SSH[0].MaxSessions.ValueInt64())
+		}
+
+		if !cfg.SSH[0].KeepAliveInterval.IsNull() {
+			d, err := time.ParseDuration(cfg.SSH[0].KeepAliveInterval.ValueString())
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root("ssh").AtListIndex(0).AtName("keepalive_interval"),
+					"Invalid SSH keepalive interval", err.Error())
+
+				return
+			}
+
+			pool.KeepAliveInterval = d
+		}
+
+		for _, b := range cfg.SSH[0].Bastions {
+			bastions = append(bastions, ssh.Bastion{
+				Address:             b.Address.ValueString(),
+				Port:                int32(b.Port.ValueInt64()),
+				Username:            b.Username.ValueString(),
+				Password:            b.Password.ValueString(),
+				PrivateKey:          b.PrivateKey.ValueString(),
+				Agent:               b.Agent.ValueBool(),
+				HostKeyVerification: ssh.HostKeyVerification(b.HostKeyVerification.ValueString()),
+				KnownHostsFile:      b.KnownHostsFile.ValueString(),
+				KnownHosts:          b.KnownHosts.ValueString(),
+			})
+		}
+
 		for _, n := range cfg.SSH[0].Nodes {
 			nodePort := int32(n.Port.ValueInt64())
 			if nodePort == 0 {
@@ -440,6 +940,22 @@ func (p *proxmoxProvider) Configure(
 		}
 	}
 
+	if commandCreds.SSHUsername != "" {
+		sshUsername = commandCreds.SSHUsername
+	}
+
+	if commandCreds.SSHPassword != "" {
+		sshPassword = commandCreds.SSHPassword
+	}
+
+	if commandCreds.SSHPrivateKey != "" {
+		sshPrivateKey = commandCreds.SSHPrivateKey
+	}
+
+	if commandCreds.SSHPrivateKeyPassphrase != "" {
+		sshPrivateKeyPassphrase = commandCreds.SSHPrivateKeyPassphrase
+	}
+
 	if sshUsername == "" && creds.UserCredentials != nil {
 		sshUsername = strings.Split(creds.UserCredentials.Username, "@")[0]
 	}
@@ -448,13 +964,34 @@ func (p *proxmoxProvider) Configure(
 		sshPassword = creds.UserCredentials.Password
 	}
 
+	sshNodeAddressCIDRs := prof.StringsOverride(profile.KeySSHNodeAddressCIDRs, configuredCIDRs)
+
+	nodeResolver, err := resolver.New(apiClient, resolver.AddressSource(sshNodeAddressSource), sshNodeAddressCIDRs)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to create Proxmox VE node address resolver",
+			err.Error(),
+		)
+
+		return
+	}
+
 	sshClient, err := ssh.NewClient(
 		sshUsername, sshPassword, sshAgent, sshAgentSocket, sshPrivateKey,
 		sshSocks5Server, sshSocks5Username, sshSocks5Password,
 		&apiResolverWithOverrides{
-			ar:        apiResolver{c: apiClient},
+			ar:        nodeResolver,
 			overrides: nodeOverrides,
 		},
+		ssh.WithHostKeyVerification(
+			ssh.HostKeyVerification(sshHostKeyVerification),
+			sshKnownHostsFile,
+			sshKnownHosts,
+		),
+		ssh.WithPrivateKeyPassphrase(sshPrivateKeyPassphrase),
+		ssh.WithCertificate(sshCertificate),
+		ssh.WithBastions(bastions...),
+		ssh.WithSharedConnectionPool(p.shared.SSHPool(), pool),
 	)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -468,7 +1005,7 @@ func (p *proxmoxProvider) Configure(
 	}
 
 	// Intentionally use 'PROXMOX_VE_TMPDIR' with 'TMP' instead of 'TEMP', to match os.TempDir's use of $TMPDIR
-	tmpDirOverride := utils.GetAnyStringEnv("PROXMOX_VE_TMPDIR", "PM_VE_TMPDIR")
+	tmpDirOverride := prof.String(profile.KeyTmpDir, "PROXMOX_VE_TMPDIR", "PM_VE_TMPDIR")
 
 	if !cfg.TmpDir.IsNull() {
 		tmpDirOverride = cfg.TmpDir.ValueString()
@@ -491,6 +1028,62 @@ func (p *proxmoxProvider) Configure(
 	resp.DataSourceData = config.DataSource{
 		Client: client,
 	}
+
+	resp.EphemeralResourceData = config.EphemeralResource{
+		Client: client,
+	}
+}
+
+// applyAPIRetryConfig overrides the fields of the retry policy set in the `api_retry` block.
+func applyAPIRetryConfig(ctx context.Context, cfg proxmoxProviderModel, policy *api.RetryPolicy) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	retryCfg := cfg.APIRetry[0]
+
+	if !retryCfg.Attempts.IsNull() {
+		policy.Attempts = uint(retryCfg.Attempts.ValueInt64())
+	}
+
+	if !retryCfg.InitialDelay.IsNull() {
+		d, err := time.ParseDuration(retryCfg.InitialDelay.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("api_retry").AtListIndex(0).AtName("initial_delay"),
+				"Invalid initial delay", err.Error())
+		}
+
+		policy.InitialDelay = d
+	}
+
+	if !retryCfg.MaxDelay.IsNull() {
+		d, err := time.ParseDuration(retryCfg.MaxDelay.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("api_retry").AtListIndex(0).AtName("max_delay"),
+				"Invalid max delay", err.Error())
+		}
+
+		policy.MaxDelay = d
+	}
+
+	if !retryCfg.Jitter.IsNull() {
+		policy.Jitter = retryCfg.Jitter.ValueBool()
+	}
+
+	if !retryCfg.RetryableStatusCodes.IsNull() {
+		var codes []int64
+
+		diags.Append(retryCfg.RetryableStatusCodes.ElementsAs(ctx, &codes, false)...)
+
+		policy.RetryableStatusCodes = make([]int, len(codes))
+		for i, c := range codes {
+			policy.RetryableStatusCodes[i] = int(c)
+		}
+	}
+
+	if !retryCfg.RetryNonIdempotent.IsNull() {
+		policy.RetryNonIdempotent = retryCfg.RetryNonIdempotent.ValueBool()
+	}
+
+	return diags
 }
 
 func (p *proxmoxProvider) Resources(_ context.Context) []func() resource.Resource {
@@ -503,6 +1096,7 @@ func (p *proxmoxProvider) Resources(_ context.Context) []func() resource.Resourc
 		apt.NewStandardRepositoryResource,
 		access.NewACLResource,
 		access.NewUserTokenResource,
+		backup.NewBackupJobResource,
 		ha.NewHAGroupResource,
 		ha.NewHAResourceResource,
 		hardwaremapping.NewPCIResource,
@@ -511,6 +1105,22 @@ func (p *proxmoxProvider) Resources(_ context.Context) []func() resource.Resourc
 		network.NewLinuxVLANResource,
 		vm.NewResource,
 		metrics.NewMetricsServerResource,
+		replication.NewReplicationJobResource,
+		sdn.NewApplierResource,
+		sdn.NewControllerResource,
+		sdn.NewDNSResource,
+		sdn.NewIPAMResource,
+		sdn.NewSubnetResource,
+		sdn.NewVNetResource,
+		sdn.NewZoneResource,
+		storage.NewStorageResource,
+	}
+}
+
+func (p *proxmoxProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		access.NewTicketEphemeralResource,
+		access.NewUserTokenEphemeralResource,
 	}
 }
 
@@ -523,6 +1133,7 @@ func (p *proxmoxProvider) DataSources(_ context.Context) []func() datasource.Dat
 		acme.NewACMEPluginDataSource,
 		apt.NewRepositoryDataSource,
 		apt.NewStandardRepositoryDataSource,
+		backup.NewNotBackedUpDataSource,
 		ha.NewHAGroupDataSource,
 		ha.NewHAGroupsDataSource,
 		ha.NewHAResourceDataSource,
@@ -532,86 +1143,15 @@ func (p *proxmoxProvider) DataSources(_ context.Context) []func() datasource.Dat
 		hardwaremapping.NewUSBDataSource,
 		vm.NewDataSource,
 		metrics.NewMetricsServerDatasource,
+		replication.NewStatusDataSource,
+		sdn.NewSubnetDataSource,
+		sdn.NewVNetDataSource,
+		sdn.NewZoneDataSource,
 	}
 }
 
-type apiResolver struct {
-	c api.Client
-}
-
-func (r *apiResolver) Resolve(ctx context.Context, nodeName string) (ssh.ProxmoxNode, error) {
-	nc := &nodes.Client{Client: r.c, NodeName: nodeName}
-
-	networkDevices, err := nc.ListNetworkInterfaces(ctx)
-	if err != nil {
-		return ssh.ProxmoxNode{}, fmt.Errorf("failed to list network devices of node %q: %w", nc.NodeName, err)
-	}
-
-	nodeAddress := ""
-
-	// try IPv4 address on the interface with IPv4 gateway
-	tflog.Debug(ctx, "Attempting to find interfaces with both a static IPV4 address and gateway.")
-
-	for _, d := range networkDevices {
-		if d.Gateway != nil && d.Address != nil {
-			nodeAddress = *d.Address
-			break
-		}
-	}
-
-	if nodeAddress == "" {
-		// fallback 1: try IPv6 address on the interface with IPv6 gateway
-		tflog.Debug(ctx, "Attempting to find interfaces with both a static IPV6 address and gateway.")
-
-		for _, d := range networkDevices {
-			if d.Gateway6 != nil && d.Address6 != nil {
-				nodeAddress = *d.Address6
-				break
-			}
-		}
-	}
-
-	if nodeAddress == "" {
-		// fallback 2: use first interface with any IPv4 address
-		tflog.Debug(ctx, "Attempting to find interfaces with at least a static IPV4 address.")
-
-		for _, d := range networkDevices {
-			if d.Address != nil {
-				nodeAddress = *d.Address
-				break
-			}
-		}
-	}
-
-	if nodeAddress == "" {
-		// fallback 3: do a good old DNS lookup
-		tflog.Debug(ctx, fmt.Sprintf("Attempting a DNS lookup of node %q.", nc.NodeName))
-
-		ips, err := net.LookupIP(nodeName)
-		if err == nil {
-			for _, ip := range ips {
-				if ipv4 := ip.To4(); ipv4 != nil {
-					nodeAddress = ipv4.String()
-					break
-				}
-			}
-		} else {
-			tflog.Debug(ctx, fmt.Sprintf("Failed to do a DNS lookup of the node: %s", err.Error()))
-		}
-	}
-
-	if nodeAddress == "" {
-		return ssh.ProxmoxNode{}, fmt.Errorf("failed to determine the IP address of node \"%s\"", nc.NodeName)
-	}
-
-	nodeAddressParts := strings.Split(nodeAddress, "/")
-	node := ssh.ProxmoxNode{Address: nodeAddressParts[0], Port: 22}
-
-	return node, nil
-}
-
 type apiResolverWithOverrides struct {
-	ar        apiResolver
+	ar        ssh.NodeResolver
 	overrides map[string]ssh.ProxmoxNode
 }
 
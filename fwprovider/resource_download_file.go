@@ -192,10 +192,12 @@ func (r *downloadFileResource) Schema(
 		Attributes: map[string]schema.Attribute{
 			"id": attribute.ID(),
 			"content_type": schema.StringAttribute{
-				Description: "The file content type. Must be `iso` for VM images or `vztmpl` for LXC images.",
-				Required:    true,
+				Description: "The file content type. Must be `iso` for VM images, `vztmpl` for LXC images, " +
+					"or `import` for VM disk images to import (Proxmox VE 8.4+).",
+				Required: true,
 				Validators: []validator.String{stringvalidator.OneOf([]string{
 					"iso",
+					"import",
 					"vztmpl",
 				}...)},
 				PlanModifiers: []planmodifier.String{
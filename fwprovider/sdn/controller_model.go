@@ -0,0 +1,92 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/attribute"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn"
+	proxmoxtypes "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+type controllerModel struct {
+	ID      types.String `tfsdk:"id"`
+	Name    types.String `tfsdk:"name"`
+	Type    types.String `tfsdk:"type"`
+	Pending types.Bool   `tfsdk:"pending"`
+
+	// bgp and evpn controller options
+	ASN   types.Int64 `tfsdk:"asn"`
+	Peers types.Set   `tfsdk:"peers"`
+
+	// bgp and isis controller options
+	Node types.String `tfsdk:"node"`
+
+	// bgp controller only options
+	BGPMultipathASPathRelax types.Bool   `tfsdk:"bgp_multipath_as_path_relax"`
+	EBGP                    types.Bool   `tfsdk:"ebgp"`
+	EBGPMultihop            types.Int64  `tfsdk:"ebgp_multihop"`
+	Loopback                types.String `tfsdk:"loopback"`
+
+	// isis controller only options
+	ISISDomain     types.String `tfsdk:"isis_domain"`
+	ISISInterfaces types.Set    `tfsdk:"isis_interfaces"`
+	ISISNet        types.String `tfsdk:"isis_net"`
+}
+
+// importFromAPI takes data from SDN controller PVE API response and set fields based on it.
+func (m *controllerModel) importFromAPI(ctx context.Context, data *sdn.ControllerData, diags *diag.Diagnostics) {
+	m.ID = types.StringValue(data.ID)
+	m.Name = types.StringValue(data.ID)
+	m.Type = types.StringValue(data.Type)
+	m.Pending = types.BoolValue(data.State != nil)
+
+	m.ASN = types.Int64PointerValue(data.ASN.PointerInt64())
+	m.Peers = commaSeparatedSet(ctx, data.Peers, diags)
+	m.Node = types.StringPointerValue(data.Node)
+	m.BGPMultipathASPathRelax = types.BoolPointerValue(data.BGPMultipathASPathRelax.PointerBool())
+	m.EBGP = types.BoolPointerValue(data.EBGP.PointerBool())
+	m.EBGPMultihop = types.Int64PointerValue(data.EBGPMultihop.PointerInt64())
+	m.Loopback = types.StringPointerValue(data.Loopback)
+	m.ISISDomain = types.StringPointerValue(data.ISISDomain)
+	m.ISISInterfaces = commaSeparatedSet(ctx, data.ISISInterfaces, diags)
+	m.ISISNet = types.StringPointerValue(data.ISISNet)
+}
+
+// toAPIFields creates the fields of SDN controller create and update requests.
+func (m *controllerModel) toAPIFields(ctx context.Context, diags *diag.Diagnostics) sdn.ControllerFields {
+	return sdn.ControllerFields{
+		ASN:                     customInt64Pointer(m.ASN),
+		Peers:                   commaSeparatedString(ctx, m.Peers, diags),
+		Node:                    m.Node.ValueStringPointer(),
+		BGPMultipathASPathRelax: proxmoxtypes.CustomBoolPtr(m.BGPMultipathASPathRelax.ValueBoolPointer()),
+		EBGP:                    proxmoxtypes.CustomBoolPtr(m.EBGP.ValueBoolPointer()),
+		EBGPMultihop:            customInt64Pointer(m.EBGPMultihop),
+		Loopback:                m.Loopback.ValueStringPointer(),
+		ISISDomain:              m.ISISDomain.ValueStringPointer(),
+		ISISInterfaces:          commaSeparatedString(ctx, m.ISISInterfaces, diags),
+		ISISNet:                 m.ISISNet.ValueStringPointer(),
+	}
+}
+
+// toDelete returns the API names of the optional fields that have been removed from the configuration.
+func (m *controllerModel) toDelete(state *controllerModel) []string {
+	var toDelete []string
+
+	attribute.CheckDelete(
+		m.BGPMultipathASPathRelax, state.BGPMultipathASPathRelax, &toDelete, "bgp-multipath-as-path-relax",
+	)
+	attribute.CheckDelete(m.EBGP, state.EBGP, &toDelete, "ebgp")
+	attribute.CheckDelete(m.EBGPMultihop, state.EBGPMultihop, &toDelete, "ebgp-multihop")
+	attribute.CheckDelete(m.Loopback, state.Loopback, &toDelete, "loopback")
+
+	return toDelete
+}
@@ -0,0 +1,172 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	customtypes "github.com/bpg/terraform-provider-proxmox/fwprovider/types"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn"
+)
+
+var (
+	_ datasource.DataSource              = &subnetDataSource{}
+	_ datasource.DataSourceWithConfigure = &subnetDataSource{}
+)
+
+type subnetDataSource struct {
+	client *sdn.Client
+}
+
+// NewSubnetDataSource creates a new SDN subnet data source.
+func NewSubnetDataSource() datasource.DataSource {
+	return &subnetDataSource{}
+}
+
+func (d *subnetDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_sdn_subnet"
+}
+
+func (d *subnetDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.DataSource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected config.DataSource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = cfg.Client.Cluster().SDN()
+}
+
+func (d *subnetDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves information about a subnet of an SDN VNet, including the changes that have " +
+			"not been applied yet.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The identifier of the subnet, with format `<zone>-<network>-<mask>`.",
+				Computed:    true,
+			},
+			"vnet": schema.StringAttribute{
+				Description: "Name of the VNet of the subnet.",
+				Required:    true,
+			},
+			"cidr": schema.StringAttribute{
+				Description: "Network of the subnet in CIDR notation, e.g. `10.0.0.0/24`.",
+				Required:    true,
+				CustomType:  customtypes.IPCIDRType{},
+			},
+			"zone": schema.StringAttribute{
+				Description: "Name of the zone of the subnet.",
+				Computed:    true,
+			},
+			"gateway": schema.StringAttribute{
+				Description: "Address of the gateway of the subnet.",
+				Computed:    true,
+				CustomType:  customtypes.IPAddrType{},
+			},
+			"snat": schema.BoolAttribute{
+				Description: "Whether the traffic leaving the subnet is masqueraded behind the address of the node.",
+				Computed:    true,
+			},
+			"dns_zone_prefix": schema.StringAttribute{
+				Description: "Prefix added to the DNS domain of the zone for the hosts of the subnet.",
+				Computed:    true,
+			},
+			"dhcp_dns_server": schema.StringAttribute{
+				Description: "Address of the DNS server announced by the DHCP server of the subnet.",
+				Computed:    true,
+				CustomType:  customtypes.IPAddrType{},
+			},
+			"dhcp_ranges": schema.ListNestedAttribute{
+				Description: "Ranges of addresses assigned by the DHCP server of the zone.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"start_address": schema.StringAttribute{
+							Description: "First address of the range.",
+							Computed:    true,
+							CustomType:  customtypes.IPAddrType{},
+						},
+						"end_address": schema.StringAttribute{
+							Description: "Last address of the range.",
+							Computed:    true,
+							CustomType:  customtypes.IPAddrType{},
+						},
+					},
+				},
+			},
+			"pending": schema.BoolAttribute{
+				Description: "Whether the subnet has changes that have not been applied yet.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *subnetDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var state subnetModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	subnets, err := d.client.ListSubnets(ctx, state.VNet.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read SDN Subnet",
+			"An unexpected error occurred while reading the subnets of the SDN VNet.\n\n"+
+				"Error: "+err.Error(),
+		)
+
+		return
+	}
+
+	for _, subnet := range subnets {
+		if subnet.CIDR != nil && *subnet.CIDR == state.CIDR.ValueString() {
+			state.importFromAPI(ctx, &subnet, &resp.Diagnostics)
+			resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+
+			return
+		}
+	}
+
+	resp.Diagnostics.AddError(
+		"SDN Subnet Not Found",
+		fmt.Sprintf("The SDN VNet %q has no subnet %q.", state.VNet.ValueString(), state.CIDR.ValueString()),
+	)
+}
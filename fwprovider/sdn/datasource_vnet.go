@@ -0,0 +1,135 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn"
+)
+
+var (
+	_ datasource.DataSource              = &vnetDataSource{}
+	_ datasource.DataSourceWithConfigure = &vnetDataSource{}
+)
+
+type vnetDataSource struct {
+	client *sdn.Client
+}
+
+// NewVNetDataSource creates a new SDN VNet data source.
+func NewVNetDataSource() datasource.DataSource {
+	return &vnetDataSource{}
+}
+
+func (d *vnetDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_sdn_vnet"
+}
+
+func (d *vnetDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.DataSource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected config.DataSource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = cfg.Client.Cluster().SDN()
+}
+
+func (d *vnetDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves information about an SDN VNet, including the changes that have not been applied yet.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The unique identifier of the VNet.",
+				Computed:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the VNet.",
+				Required:    true,
+			},
+			"zone": schema.StringAttribute{
+				Description: "Name of the zone of the VNet.",
+				Computed:    true,
+			},
+			"alias": schema.StringAttribute{
+				Description: "Alias of the VNet.",
+				Computed:    true,
+			},
+			"isolate_ports": schema.BoolAttribute{
+				Description: "Whether the ports of the guests are isolated from each other.",
+				Computed:    true,
+			},
+			"tag": schema.Int64Attribute{
+				Description: "VLAN tag, or VXLAN ID, of the VNet.",
+				Computed:    true,
+			},
+			"vlan_aware": schema.BoolAttribute{
+				Description: "Whether the guests can use VLAN tags inside the VNet.",
+				Computed:    true,
+			},
+			"pending": schema.BoolAttribute{
+				Description: "Whether the VNet has changes that have not been applied yet.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *vnetDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var state vnetModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data, err := d.client.GetVNet(ctx, state.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read SDN VNet",
+			"An unexpected error occurred while reading the SDN VNet.\n\n"+
+				"Error: "+err.Error(),
+		)
+
+		return
+	}
+
+	state.importFromAPI(data)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
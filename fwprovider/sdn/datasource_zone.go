@@ -0,0 +1,207 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn"
+)
+
+var (
+	_ datasource.DataSource              = &zoneDataSource{}
+	_ datasource.DataSourceWithConfigure = &zoneDataSource{}
+)
+
+type zoneDataSource struct {
+	client *sdn.Client
+}
+
+// NewZoneDataSource creates a new SDN zone data source.
+func NewZoneDataSource() datasource.DataSource {
+	return &zoneDataSource{}
+}
+
+func (d *zoneDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_sdn_zone"
+}
+
+func (d *zoneDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.DataSource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected DataSource Configure Type",
+			fmt.Sprintf("Expected config.DataSource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = cfg.Client.Cluster().SDN()
+}
+
+func (d *zoneDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves information about an SDN zone, including the changes that have not been applied yet.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The unique identifier of the zone.",
+				Computed:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the zone.",
+				Required:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "Type of the zone, one of `simple`, `vlan`, `qinq`, `vxlan` or `evpn`.",
+				Computed:    true,
+			},
+			"dns": schema.StringAttribute{
+				Description: "DNS plugin used to register the addresses of the guests.",
+				Computed:    true,
+			},
+			"dns_zone": schema.StringAttribute{
+				Description: "DNS domain of the zone.",
+				Computed:    true,
+			},
+			"ipam": schema.StringAttribute{
+				Description: "IPAM plugin used to manage the addresses of the guests.",
+				Computed:    true,
+			},
+			"mtu": schema.Int64Attribute{
+				Description: "MTU of the VNets of the zone.",
+				Computed:    true,
+			},
+			"nodes": schema.SetAttribute{
+				Description: "Nodes the zone is deployed on, all nodes if not set.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"reverse_dns": schema.StringAttribute{
+				Description: "DNS plugin used to register the reverse DNS records of the guests.",
+				Computed:    true,
+			},
+			"pending": schema.BoolAttribute{
+				Description: "Whether the zone has changes that have not been applied yet.",
+				Computed:    true,
+			},
+			"dhcp": schema.StringAttribute{
+				Description: "DHCP server of the VNets of the zone (`simple` zones only).",
+				Computed:    true,
+			},
+			"bridge": schema.StringAttribute{
+				Description: "Bridge the VNets of the zone are attached to (`vlan` and `qinq` zones only).",
+				Computed:    true,
+			},
+			"service_vlan": schema.Int64Attribute{
+				Description: "Service VLAN tag of the zone (`qinq` zones only).",
+				Computed:    true,
+			},
+			"service_vlan_protocol": schema.StringAttribute{
+				Description: "Protocol of the service VLAN (`qinq` zones only).",
+				Computed:    true,
+			},
+			"peers": schema.SetAttribute{
+				Description: "Addresses of the peers of the VXLAN overlay (`vxlan` zones only).",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"vxlan_port": schema.Int64Attribute{
+				Description: "UDP port of the VXLAN overlay (`vxlan` zones only).",
+				Computed:    true,
+			},
+			"advertise_subnets": schema.BoolAttribute{
+				Description: "Whether the full subnets are announced in the EVPN network (`evpn` zones only).",
+				Computed:    true,
+			},
+			"controller": schema.StringAttribute{
+				Description: "EVPN controller of the zone (`evpn` zones only).",
+				Computed:    true,
+			},
+			"disable_arp_nd_suppression": schema.BoolAttribute{
+				Description: "Whether the ARP and ND suppression is disabled (`evpn` zones only).",
+				Computed:    true,
+			},
+			"exit_nodes": schema.SetAttribute{
+				Description: "Nodes routing the traffic of the zone to the outside network (`evpn` zones only).",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"exit_nodes_local_routing": schema.BoolAttribute{
+				Description: "Whether the exit nodes can reach the guests of the zone (`evpn` zones only).",
+				Computed:    true,
+			},
+			"exit_nodes_primary": schema.StringAttribute{
+				Description: "Exit node preferred for the traffic to the outside network (`evpn` zones only).",
+				Computed:    true,
+			},
+			"mac": schema.StringAttribute{
+				Description: "Anycast MAC address of the gateways of the VNets (`evpn` zones only).",
+				Computed:    true,
+			},
+			"rt_import": schema.StringAttribute{
+				Description: "Comma-separated route targets to import (`evpn` zones only).",
+				Computed:    true,
+			},
+			"vrf_vxlan": schema.Int64Attribute{
+				Description: "VXLAN ID of the VRF of the zone (`evpn` zones only).",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *zoneDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var state zoneModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data, err := d.client.GetZone(ctx, state.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read SDN Zone",
+			"An unexpected error occurred while reading the SDN zone.\n\n"+
+				"Error: "+err.Error(),
+		)
+
+		return
+	}
+
+	state.importFromAPI(ctx, data, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
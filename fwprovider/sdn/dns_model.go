@@ -0,0 +1,62 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/attribute"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn"
+)
+
+type dnsModel struct {
+	ID            types.String `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	Type          types.String `tfsdk:"type"`
+	Fingerprint   types.String `tfsdk:"fingerprint"`
+	Key           types.String `tfsdk:"key"`
+	KeyWO         types.String `tfsdk:"key_wo"`
+	KeyWOVersion  types.Int64  `tfsdk:"key_wo_version"`
+	ReverseMaskV6 types.Int64  `tfsdk:"reverse_mask_v6"`
+	TTL           types.Int64  `tfsdk:"ttl"`
+	URL           types.String `tfsdk:"url"`
+}
+
+// importFromAPI takes data from SDN DNS plugin PVE API response and set fields based on it.
+// The key is not read back, the API may not return it.
+func (m *dnsModel) importFromAPI(data *sdn.DNSData) {
+	m.ID = types.StringValue(data.ID)
+	m.Name = types.StringValue(data.ID)
+	m.Type = types.StringValue(data.Type)
+
+	m.Fingerprint = types.StringPointerValue(data.Fingerprint)
+	m.ReverseMaskV6 = types.Int64PointerValue(data.ReverseMaskV6.PointerInt64())
+	m.TTL = types.Int64PointerValue(data.TTL.PointerInt64())
+	m.URL = types.StringPointerValue(data.URL)
+}
+
+// toAPIFields creates the fields of SDN DNS plugin create and update requests.
+func (m *dnsModel) toAPIFields() sdn.DNSFields {
+	return sdn.DNSFields{
+		Fingerprint:   m.Fingerprint.ValueStringPointer(),
+		Key:           m.Key.ValueStringPointer(),
+		ReverseMaskV6: customInt64Pointer(m.ReverseMaskV6),
+		TTL:           customInt64Pointer(m.TTL),
+		URL:           m.URL.ValueStringPointer(),
+	}
+}
+
+// toDelete returns the API names of the optional fields that have been removed from the configuration.
+func (m *dnsModel) toDelete(state *dnsModel) []string {
+	var toDelete []string
+
+	attribute.CheckDelete(m.Fingerprint, state.Fingerprint, &toDelete, "fingerprint")
+	attribute.CheckDelete(m.ReverseMaskV6, state.ReverseMaskV6, &toDelete, "reversemaskv6")
+	attribute.CheckDelete(m.TTL, state.TTL, &toDelete, "ttl")
+
+	return toDelete
+}
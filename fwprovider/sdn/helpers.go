@@ -0,0 +1,119 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	proxmoxtypes "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+var (
+	// idRegexp matches the identifiers of SDN zones and VNets.
+	idRegexp = regexp.MustCompile(`^[a-z][a-z0-9]{0,7}$`)
+
+	// pluginIDRegexp matches the identifiers of SDN controllers, IPAM and DNS plugins.
+	pluginIDRegexp = regexp.MustCompile(`^[a-z][a-z0-9_-]*[a-z0-9]$`)
+)
+
+// commaSeparatedSet converts a comma-separated list of the API into a set of strings.
+func commaSeparatedSet(ctx context.Context, s *string, diags *diag.Diagnostics) types.Set {
+	if s == nil || *s == "" {
+		return types.SetNull(types.StringType)
+	}
+
+	var items []string
+
+	for _, item := range strings.Split(*s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	set, d := types.SetValueFrom(ctx, types.StringType, items)
+	diags.Append(d...)
+
+	return set
+}
+
+// commaSeparatedString converts a set of strings into a comma-separated list of the API.
+func commaSeparatedString(ctx context.Context, set types.Set, diags *diag.Diagnostics) *string {
+	if set.IsNull() || set.IsUnknown() {
+		return nil
+	}
+
+	var items []string
+
+	diags.Append(set.ElementsAs(ctx, &items, false)...)
+
+	slices.Sort(items)
+
+	s := strings.Join(items, ",")
+
+	return &s
+}
+
+func customInt64Pointer(v types.Int64) *proxmoxtypes.CustomInt64 {
+	if v.IsNull() || v.IsUnknown() {
+		return nil
+	}
+
+	i := proxmoxtypes.CustomInt64(v.ValueInt64())
+
+	return &i
+}
+
+// validateTypeAttributes checks that the attributes only supported by some types of an SDN object are not set
+// for other types, and that the attributes required by the type of the object are set.
+func validateTypeAttributes(
+	kind string,
+	objectType string,
+	values map[string]attr.Value,
+	typeAttributes map[string][]string,
+	requiredAttributes map[string][]string,
+	diags *diag.Diagnostics,
+) {
+	for name, allowed := range typeAttributes {
+		if !values[name].IsNull() && !slices.Contains(allowed, objectType) {
+			diags.AddAttributeError(
+				path.Root(name),
+				"Invalid Attribute Combination",
+				fmt.Sprintf("The attribute %q is only supported by %s of type %s, not %q.",
+					name, kind, strings.Join(allowed, ", "), objectType),
+			)
+		}
+	}
+
+	for _, name := range requiredAttributes[objectType] {
+		if values[name].IsNull() {
+			diags.AddAttributeError(
+				path.Root(name),
+				"Missing Required Attribute",
+				fmt.Sprintf("The attribute %q is required by %s of type %q.", name, kind, objectType),
+			)
+		}
+	}
+}
+
+// writeOnlyValue returns the value of a write-only attribute, which is only available in the configuration.
+func writeOnlyValue(ctx context.Context, config tfsdk.Config, name string, diags *diag.Diagnostics) *string {
+	var value types.String
+
+	diags.Append(config.GetAttribute(ctx, path.Root(name), &value)...)
+
+	return value.ValueStringPointer()
+}
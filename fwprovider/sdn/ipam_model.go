@@ -0,0 +1,61 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/attribute"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn"
+)
+
+type ipamModel struct {
+	ID   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+	Type types.String `tfsdk:"type"`
+
+	// netbox and phpipam plugin options
+	Fingerprint    types.String `tfsdk:"fingerprint"`
+	Token          types.String `tfsdk:"token"`
+	TokenWO        types.String `tfsdk:"token_wo"`
+	TokenWOVersion types.Int64  `tfsdk:"token_wo_version"`
+	URL            types.String `tfsdk:"url"`
+
+	// phpipam plugin only options
+	Section types.Int64 `tfsdk:"section"`
+}
+
+// importFromAPI takes data from SDN IPAM PVE API response and set fields based on it.
+// The token is not read back, the API may not return it.
+func (m *ipamModel) importFromAPI(data *sdn.IPAMData) {
+	m.ID = types.StringValue(data.ID)
+	m.Name = types.StringValue(data.ID)
+	m.Type = types.StringValue(data.Type)
+
+	m.Fingerprint = types.StringPointerValue(data.Fingerprint)
+	m.URL = types.StringPointerValue(data.URL)
+	m.Section = types.Int64PointerValue(data.Section.PointerInt64())
+}
+
+// toAPIFields creates the fields of SDN IPAM create and update requests.
+func (m *ipamModel) toAPIFields() sdn.IPAMFields {
+	return sdn.IPAMFields{
+		Fingerprint: m.Fingerprint.ValueStringPointer(),
+		Token:       m.Token.ValueStringPointer(),
+		URL:         m.URL.ValueStringPointer(),
+		Section:     customInt64Pointer(m.Section),
+	}
+}
+
+// toDelete returns the API names of the optional fields that have been removed from the configuration.
+func (m *ipamModel) toDelete(state *ipamModel) []string {
+	var toDelete []string
+
+	attribute.CheckDelete(m.Fingerprint, state.Fingerprint, &toDelete, "fingerprint")
+
+	return toDelete
+}
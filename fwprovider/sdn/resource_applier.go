@@ -0,0 +1,210 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/attribute"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn"
+)
+
+var (
+	_ resource.Resource              = &applierResource{}
+	_ resource.ResourceWithConfigure = &applierResource{}
+)
+
+type applierResource struct {
+	client *sdn.Client
+}
+
+type applierModel struct {
+	ID        types.String `tfsdk:"id"`
+	OnCreate  types.Bool   `tfsdk:"on_create"`
+	OnDestroy types.Bool   `tfsdk:"on_destroy"`
+	Triggers  types.Map    `tfsdk:"triggers"`
+}
+
+// NewApplierResource creates a new SDN applier resource.
+func NewApplierResource() resource.Resource {
+	return &applierResource{}
+}
+
+func (r *applierResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_sdn_applier"
+}
+
+func (r *applierResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.Resource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected config.Resource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = cfg.Client.Cluster().SDN()
+}
+
+func (r *applierResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Applies the pending SDN configuration to all nodes of the cluster.",
+		MarkdownDescription: "Applies the pending SDN configuration to all nodes of the cluster. " +
+			"Changes of SDN zones, VNets and subnets are pending until they are applied, so they can be " +
+			"applied once, after all of them have been made. Use `depends_on` to apply the configuration " +
+			"after the SDN resources have been changed, and `triggers` to apply it again when they change.",
+		Attributes: map[string]schema.Attribute{
+			"id": attribute.ID(),
+			"on_create": schema.BoolAttribute{
+				Description: "Whether to apply the SDN configuration when the applier is created, " +
+					"or when its triggers change. Defaults to `true`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"on_destroy": schema.BoolAttribute{
+				Description: "Whether to apply the SDN configuration when the applier is destroyed. " +
+					"Defaults to `true`.",
+				MarkdownDescription: "Whether to apply the SDN configuration when the applier is destroyed. " +
+					"Defaults to `true`. Resources depending on the applier are destroyed before it, " +
+					"so an applier the SDN resources depend on applies their removal.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values that apply the SDN configuration again when they change, " +
+					"e.g. the attributes of the SDN resources.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (r *applierResource) apply(ctx context.Context, diags *diag.Diagnostics) {
+	tflog.Debug(ctx, "Applying the pending SDN configuration")
+
+	if err := r.client.ApplyConfig(ctx); err != nil {
+		diags.AddError(
+			"Unable to Apply SDN Configuration",
+			"An unexpected error occurred while applying the pending SDN configuration.\n\n"+
+				"Error: "+err.Error(),
+		)
+	}
+}
+
+func (r *applierResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan applierModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.OnCreate.ValueBool() {
+		r.apply(ctx, &resp.Diagnostics)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	plan.ID = types.StringValue("sdn")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *applierResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state applierModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *applierResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var plan applierModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.OnCreate.ValueBool() {
+		r.apply(ctx, &resp.Diagnostics)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *applierResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var state applierModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.OnDestroy.ValueBool() {
+		r.apply(ctx, &resp.Diagnostics)
+	}
+}
@@ -0,0 +1,409 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/attribute"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn"
+)
+
+var (
+	_ resource.Resource                   = &controllerResource{}
+	_ resource.ResourceWithConfigure      = &controllerResource{}
+	_ resource.ResourceWithImportState    = &controllerResource{}
+	_ resource.ResourceWithValidateConfig = &controllerResource{}
+)
+
+//nolint:gochecknoglobals
+var (
+	// controllerTypes are the supported types of SDN controllers.
+	controllerTypes = []string{sdn.ControllerTypeBGP, sdn.ControllerTypeEVPN, sdn.ControllerTypeISIS}
+
+	// controllerTypeAttributes are the attributes that only apply to some types of SDN controllers.
+	controllerTypeAttributes = map[string][]string{
+		"asn":                         {sdn.ControllerTypeBGP, sdn.ControllerTypeEVPN},
+		"peers":                       {sdn.ControllerTypeBGP, sdn.ControllerTypeEVPN},
+		"node":                        {sdn.ControllerTypeBGP, sdn.ControllerTypeISIS},
+		"bgp_multipath_as_path_relax": {sdn.ControllerTypeBGP},
+		"ebgp":                        {sdn.ControllerTypeBGP},
+		"ebgp_multihop":               {sdn.ControllerTypeBGP},
+		"loopback":                    {sdn.ControllerTypeBGP},
+		"isis_domain":                 {sdn.ControllerTypeISIS},
+		"isis_interfaces":             {sdn.ControllerTypeISIS},
+		"isis_net":                    {sdn.ControllerTypeISIS},
+	}
+
+	// controllerTypeRequiredAttributes are the attributes required by some types of SDN controllers.
+	controllerTypeRequiredAttributes = map[string][]string{
+		sdn.ControllerTypeBGP:  {"asn", "peers", "node"},
+		sdn.ControllerTypeEVPN: {"asn", "peers"},
+		sdn.ControllerTypeISIS: {"node", "isis_domain", "isis_interfaces", "isis_net"},
+	}
+)
+
+type controllerResource struct {
+	client *sdn.Client
+}
+
+// NewControllerResource creates a new SDN controller resource.
+func NewControllerResource() resource.Resource {
+	return &controllerResource{}
+}
+
+func (r *controllerResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_sdn_controller"
+}
+
+func (r *controllerResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.Resource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected config.Resource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = cfg.Client.Cluster().SDN()
+}
+
+func (r *controllerResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Manages an SDN controller.",
+		MarkdownDescription: "Manages an SDN controller, which routes the traffic of `evpn` zones. " +
+			"Changes are pending until the SDN configuration is applied with the " +
+			"`proxmox_virtual_environment_sdn_applier` resource.",
+		Attributes: map[string]schema.Attribute{
+			"id": attribute.ID(),
+			"name": schema.StringAttribute{
+				Description: "Unique name of the controller.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(pluginIDRegexp, "must start with a lowercase letter, "+
+						"followed by lowercase letters, digits, `-` or `_`, and end with a letter or a digit"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Description: "Type of the controller, one of `bgp`, `evpn` or `isis`.",
+				Required:    true,
+				Validators:  []validator.String{stringvalidator.OneOf(controllerTypes...)},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"pending": schema.BoolAttribute{
+				Description: "Whether the controller has changes that have not been applied yet.",
+				Computed:    true,
+			},
+			"asn": schema.Int64Attribute{
+				Description: "Autonomous system number of the controller (`bgp` and `evpn` controllers only, required).",
+				Optional:    true,
+				Validators:  []validator.Int64{int64validator.Between(1, 4294967295)},
+			},
+			"peers": schema.SetAttribute{
+				Description: "Addresses of the BGP peers, usually the addresses of all nodes for `evpn` controllers " +
+					"(`bgp` and `evpn` controllers only, required).",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators:  []validator.Set{setvalidator.SizeAtLeast(1)},
+			},
+			"node": schema.StringAttribute{
+				Description: "Node the controller is configured on (`bgp` and `isis` controllers only, required).",
+				Optional:    true,
+			},
+			"bgp_multipath_as_path_relax": schema.BoolAttribute{
+				Description: "Whether to balance the traffic between the routes with AS paths of the same length " +
+					"but from different autonomous systems (`bgp` controllers only).",
+				Optional: true,
+			},
+			"ebgp": schema.BoolAttribute{
+				Description: "Whether the peers are external BGP peers (`bgp` controllers only).",
+				Optional:    true,
+			},
+			"ebgp_multihop": schema.Int64Attribute{
+				Description: "Maximum number of hops to the external BGP peers (`bgp` controllers only).",
+				Optional:    true,
+				Validators:  []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"loopback": schema.StringAttribute{
+				Description: "Loopback interface used as the source address of the BGP sessions " +
+					"(`bgp` controllers only).",
+				Optional: true,
+			},
+			"isis_domain": schema.StringAttribute{
+				Description: "Name of the IS-IS domain (`isis` controllers only, required).",
+				Optional:    true,
+			},
+			"isis_interfaces": schema.SetAttribute{
+				Description: "Interfaces IS-IS is enabled on (`isis` controllers only, required).",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators:  []validator.Set{setvalidator.SizeAtLeast(1)},
+			},
+			"isis_net": schema.StringAttribute{
+				Description: "Network entity title of the node, e.g. `49.0001.1921.6800.2001.00` " +
+					"(`isis` controllers only, required).",
+				Optional: true,
+			},
+		},
+	}
+}
+
+func (r *controllerResource) ValidateConfig(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	var data controllerModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || data.Type.IsNull() || data.Type.IsUnknown() {
+		return
+	}
+
+	validateTypeAttributes(
+		"controllers",
+		data.Type.ValueString(),
+		data.typeSpecificValues(),
+		controllerTypeAttributes,
+		controllerTypeRequiredAttributes,
+		&resp.Diagnostics,
+	)
+}
+
+// typeSpecificValues returns the values of the attributes that only apply to some types of SDN controllers.
+func (m *controllerModel) typeSpecificValues() map[string]attr.Value {
+	return map[string]attr.Value{
+		"asn":                         m.ASN,
+		"peers":                       m.Peers,
+		"node":                        m.Node,
+		"bgp_multipath_as_path_relax": m.BGPMultipathASPathRelax,
+		"ebgp":                        m.EBGP,
+		"ebgp_multihop":               m.EBGPMultihop,
+		"loopback":                    m.Loopback,
+		"isis_domain":                 m.ISISDomain,
+		"isis_interfaces":             m.ISISInterfaces,
+		"isis_net":                    m.ISISNet,
+	}
+}
+
+func (r *controllerResource) read(ctx context.Context, model *controllerModel, diags *diag.Diagnostics) bool {
+	data, err := r.client.GetController(ctx, model.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, api.ErrResourceDoesNotExist) {
+			return false
+		}
+
+		diags.AddError(
+			"Unable to Read SDN Controller",
+			"An unexpected error occurred while reading the SDN controller.\n\n"+
+				"Error: "+err.Error(),
+		)
+
+		return false
+	}
+
+	model.importFromAPI(ctx, data, diags)
+
+	return true
+}
+
+func (r *controllerResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state controllerModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found := r.read(ctx, &state, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *controllerResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan controllerModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reqData := &sdn.ControllerCreateRequestBody{
+		ControllerFields: plan.toAPIFields(ctx, &resp.Diagnostics),
+		ID:               plan.Name.ValueString(),
+		Type:             plan.Type.ValueString(),
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.CreateController(ctx, reqData)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create SDN Controller",
+			"An unexpected error occurred while creating the SDN controller.\n\n"+
+				"Error: "+err.Error(),
+		)
+
+		return
+	}
+
+	plan.ID = plan.Name
+
+	r.readAfterChange(ctx, &plan, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *controllerResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var plan, state controllerModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reqData := &sdn.ControllerUpdateRequestBody{
+		ControllerFields: plan.toAPIFields(ctx, &resp.Diagnostics),
+		Delete:           plan.toDelete(&state),
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.UpdateController(ctx, state.ID.ValueString(), reqData)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Update SDN Controller",
+			"An unexpected error occurred while updating the SDN controller.\n\n"+
+				"Error: "+err.Error(),
+		)
+
+		return
+	}
+
+	r.readAfterChange(ctx, &plan, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// readAfterChange reads the controller after it has been created or updated.
+func (r *controllerResource) readAfterChange(ctx context.Context, model *controllerModel, diags *diag.Diagnostics) {
+	if !r.read(ctx, model, diags) && !diags.HasError() {
+		diags.AddError(
+			"SDN Controller Not Found",
+			fmt.Sprintf("The SDN controller %q could not be found after it has been changed.", model.ID.ValueString()),
+		)
+	}
+}
+
+func (r *controllerResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var state controllerModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteController(ctx, state.ID.ValueString())
+	if err != nil && !errors.Is(err, api.ErrResourceDoesNotExist) {
+		resp.Diagnostics.AddError(
+			"Unable to Delete SDN Controller",
+			"An unexpected error occurred while deleting the SDN controller.\n\n"+
+				"Error: "+err.Error(),
+		)
+	}
+}
+
+func (r *controllerResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
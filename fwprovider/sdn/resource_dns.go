@@ -0,0 +1,340 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/attribute"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn"
+)
+
+var (
+	_ resource.Resource                = &dnsResource{}
+	_ resource.ResourceWithConfigure   = &dnsResource{}
+	_ resource.ResourceWithImportState = &dnsResource{}
+)
+
+type dnsResource struct {
+	client *sdn.Client
+}
+
+// NewDNSResource creates a new SDN DNS plugin resource.
+func NewDNSResource() resource.Resource {
+	return &dnsResource{}
+}
+
+func (r *dnsResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_sdn_dns"
+}
+
+func (r *dnsResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.Resource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected config.Resource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = cfg.Client.Cluster().SDN()
+}
+
+func (r *dnsResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Manages an SDN DNS plugin.",
+		MarkdownDescription: "Manages an SDN DNS plugin, which registers the DNS records of the guests of the zones " +
+			"using it. Unlike the zones, the changes of DNS plugins take effect immediately.",
+		Attributes: map[string]schema.Attribute{
+			"id": attribute.ID(),
+			"name": schema.StringAttribute{
+				Description: "Unique name of the DNS plugin.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(pluginIDRegexp, "must start with a lowercase letter, "+
+						"followed by lowercase letters, digits, `-` or `_`, and end with a letter or a digit"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Description: "Type of the DNS plugin, only `powerdns` is supported.",
+				Required:    true,
+				Validators:  []validator.String{stringvalidator.OneOf(sdn.DNSTypePowerDNS)},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"fingerprint": schema.StringAttribute{
+				Description: "SHA-256 fingerprint of the certificate of the API, when it is not trusted.",
+				Optional:    true,
+			},
+			"key": schema.StringAttribute{
+				Description: "API key (required unless `key_wo` is set).",
+				Optional:    true,
+				Sensitive:   true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("key_wo")),
+					stringvalidator.PreferWriteOnlyAttribute(path.MatchRoot("key_wo")),
+				},
+			},
+			"key_wo": schema.StringAttribute{
+				Description: "API key, which is not stored in the state (requires Terraform 1.11 or later). " +
+					"The key is sent when the plugin is created, and when `key_wo_version` changes.",
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("key_wo_version")),
+				},
+			},
+			"key_wo_version": schema.Int64Attribute{
+				Description: "The version of the write-only API key, the key is updated when the version changes.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("key_wo")),
+				},
+			},
+			"reverse_mask_v6": schema.Int64Attribute{
+				Description: "Prefix length of the reverse DNS zones of the IPv6 subnets.",
+				Optional:    true,
+				Validators:  []validator.Int64{int64validator.Between(0, 128)},
+			},
+			"ttl": schema.Int64Attribute{
+				Description: "TTL of the DNS records, in seconds.",
+				Optional:    true,
+				Validators:  []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"url": schema.StringAttribute{
+				Description: "URL of the API, e.g. `https://powerdns.example.com:8081/api/v1/servers/localhost`.",
+				Required:    true,
+			},
+		},
+	}
+}
+
+func (r *dnsResource) read(ctx context.Context, model *dnsModel, diags *diag.Diagnostics) bool {
+	data, err := r.client.GetDNS(ctx, model.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, api.ErrResourceDoesNotExist) {
+			return false
+		}
+
+		diags.AddError(
+			"Unable to Read SDN DNS Plugin",
+			"An unexpected error occurred while reading the SDN DNS plugin.\n\n"+
+				"Error: "+err.Error(),
+		)
+
+		return false
+	}
+
+	model.importFromAPI(data)
+
+	return true
+}
+
+func (r *dnsResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state dnsModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found := r.read(ctx, &state, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *dnsResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan dnsModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reqData := &sdn.DNSCreateRequestBody{
+		DNSFields: plan.toAPIFields(),
+		ID:        plan.Name.ValueString(),
+		Type:      plan.Type.ValueString(),
+	}
+
+	// write-only values are only available in the configuration
+	if keyWO := writeOnlyValue(ctx, req.Config, "key_wo", &resp.Diagnostics); keyWO != nil {
+		reqData.Key = keyWO
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.CreateDNS(ctx, reqData)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create SDN DNS Plugin",
+			"An unexpected error occurred while creating the SDN DNS plugin.\n\n"+
+				"Error: "+err.Error(),
+		)
+
+		return
+	}
+
+	plan.ID = plan.Name
+
+	r.readAfterChange(ctx, &plan, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *dnsResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var plan, state dnsModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reqData := &sdn.DNSUpdateRequestBody{
+		DNSFields: plan.toAPIFields(),
+		Delete:    plan.toDelete(&state),
+	}
+
+	// the write-only key is only sent when its version changes
+	if !plan.KeyWOVersion.Equal(state.KeyWOVersion) {
+		if keyWO := writeOnlyValue(ctx, req.Config, "key_wo", &resp.Diagnostics); keyWO != nil {
+			reqData.Key = keyWO
+		}
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.UpdateDNS(ctx, state.ID.ValueString(), reqData)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Update SDN DNS Plugin",
+			"An unexpected error occurred while updating the SDN DNS plugin.\n\n"+
+				"Error: "+err.Error(),
+		)
+
+		return
+	}
+
+	r.readAfterChange(ctx, &plan, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// readAfterChange reads the DNS plugin after it has been created or updated.
+func (r *dnsResource) readAfterChange(ctx context.Context, model *dnsModel, diags *diag.Diagnostics) {
+	if !r.read(ctx, model, diags) && !diags.HasError() {
+		diags.AddError(
+			"SDN DNS Plugin Not Found",
+			fmt.Sprintf("The SDN DNS plugin %q could not be found after it has been changed.", model.ID.ValueString()),
+		)
+	}
+}
+
+func (r *dnsResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var state dnsModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteDNS(ctx, state.ID.ValueString())
+	if err != nil && !errors.Is(err, api.ErrResourceDoesNotExist) {
+		resp.Diagnostics.AddError(
+			"Unable to Delete SDN DNS Plugin",
+			"An unexpected error occurred while deleting the SDN DNS plugin.\n\n"+
+				"Error: "+err.Error(),
+		)
+	}
+}
+
+func (r *dnsResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
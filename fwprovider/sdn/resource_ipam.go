@@ -0,0 +1,414 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/attribute"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn"
+)
+
+var (
+	_ resource.Resource                   = &ipamResource{}
+	_ resource.ResourceWithConfigure      = &ipamResource{}
+	_ resource.ResourceWithImportState    = &ipamResource{}
+	_ resource.ResourceWithValidateConfig = &ipamResource{}
+)
+
+//nolint:gochecknoglobals
+var (
+	// ipamTypes are the supported types of SDN IPAM plugins.
+	ipamTypes = []string{sdn.IPAMTypePVE, sdn.IPAMTypeNetBox, sdn.IPAMTypePhpIPAM}
+
+	// ipamTypeAttributes are the attributes that only apply to some types of SDN IPAM plugins.
+	ipamTypeAttributes = map[string][]string{
+		"fingerprint":      {sdn.IPAMTypeNetBox, sdn.IPAMTypePhpIPAM},
+		"token":            {sdn.IPAMTypeNetBox, sdn.IPAMTypePhpIPAM},
+		"token_wo":         {sdn.IPAMTypeNetBox, sdn.IPAMTypePhpIPAM},
+		"token_wo_version": {sdn.IPAMTypeNetBox, sdn.IPAMTypePhpIPAM},
+		"url":              {sdn.IPAMTypeNetBox, sdn.IPAMTypePhpIPAM},
+		"section":          {sdn.IPAMTypePhpIPAM},
+	}
+
+	// ipamTypeRequiredAttributes are the attributes required by some types of SDN IPAM plugins.
+	ipamTypeRequiredAttributes = map[string][]string{
+		sdn.IPAMTypeNetBox:  {"url"},
+		sdn.IPAMTypePhpIPAM: {"url", "section"},
+	}
+)
+
+type ipamResource struct {
+	client *sdn.Client
+}
+
+// NewIPAMResource creates a new SDN IPAM plugin resource.
+func NewIPAMResource() resource.Resource {
+	return &ipamResource{}
+}
+
+func (r *ipamResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_sdn_ipam"
+}
+
+func (r *ipamResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.Resource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected config.Resource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = cfg.Client.Cluster().SDN()
+}
+
+func (r *ipamResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Manages an SDN IPAM plugin.",
+		MarkdownDescription: "Manages an SDN IPAM plugin, which manages the addresses of the guests of the zones " +
+			"using it. Unlike the zones, the changes of IPAM plugins take effect immediately.",
+		Attributes: map[string]schema.Attribute{
+			"id": attribute.ID(),
+			"name": schema.StringAttribute{
+				Description: "Unique name of the IPAM plugin.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(pluginIDRegexp, "must start with a lowercase letter, "+
+						"followed by lowercase letters, digits, `-` or `_`, and end with a letter or a digit"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Description: "Type of the IPAM plugin, one of `pve`, `netbox` or `phpipam`.",
+				Required:    true,
+				Validators:  []validator.String{stringvalidator.OneOf(ipamTypes...)},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"fingerprint": schema.StringAttribute{
+				Description: "SHA-256 fingerprint of the certificate of the API, when it is not trusted " +
+					"(`netbox` and `phpipam` plugins only).",
+				Optional: true,
+			},
+			"token": schema.StringAttribute{
+				Description: "API token (`netbox` and `phpipam` plugins only, required unless `token_wo` is set).",
+				Optional:    true,
+				Sensitive:   true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("token_wo")),
+					stringvalidator.PreferWriteOnlyAttribute(path.MatchRoot("token_wo")),
+				},
+			},
+			"token_wo": schema.StringAttribute{
+				Description: "API token, which is not stored in the state (requires Terraform 1.11 or later). " +
+					"The token is sent when the plugin is created, and when `token_wo_version` changes.",
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("token_wo_version")),
+				},
+			},
+			"token_wo_version": schema.Int64Attribute{
+				Description: "The version of the write-only API token, the token is updated when the version changes.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("token_wo")),
+				},
+			},
+			"url": schema.StringAttribute{
+				Description: "URL of the API, e.g. `https://netbox.example.com/api` " +
+					"(`netbox` and `phpipam` plugins only, required).",
+				Optional: true,
+			},
+			"section": schema.Int64Attribute{
+				Description: "ID of the section the subnets are managed in (`phpipam` plugins only, required).",
+				Optional:    true,
+				Validators:  []validator.Int64{int64validator.AtLeast(0)},
+			},
+		},
+	}
+}
+
+func (r *ipamResource) ValidateConfig(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	var data ipamModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || data.Type.IsNull() || data.Type.IsUnknown() {
+		return
+	}
+
+	ipamType := data.Type.ValueString()
+
+	validateTypeAttributes(
+		"IPAM plugins",
+		ipamType,
+		data.typeSpecificValues(),
+		ipamTypeAttributes,
+		ipamTypeRequiredAttributes,
+		&resp.Diagnostics,
+	)
+
+	if ipamType != sdn.IPAMTypePVE && data.Token.IsNull() && data.TokenWO.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("token"),
+			"Missing Required Attribute",
+			fmt.Sprintf("One of the attributes \"token\" or \"token_wo\" is required by IPAM plugins of type %q.",
+				ipamType),
+		)
+	}
+}
+
+// typeSpecificValues returns the values of the attributes that only apply to some types of SDN IPAM plugins.
+func (m *ipamModel) typeSpecificValues() map[string]attr.Value {
+	return map[string]attr.Value{
+		"fingerprint":      m.Fingerprint,
+		"token":            m.Token,
+		"token_wo":         m.TokenWO,
+		"token_wo_version": m.TokenWOVersion,
+		"url":              m.URL,
+		"section":          m.Section,
+	}
+}
+
+func (r *ipamResource) read(ctx context.Context, model *ipamModel, diags *diag.Diagnostics) bool {
+	data, err := r.client.GetIPAM(ctx, model.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, api.ErrResourceDoesNotExist) {
+			return false
+		}
+
+		diags.AddError(
+			"Unable to Read SDN IPAM",
+			"An unexpected error occurred while reading the SDN IPAM plugin.\n\n"+
+				"Error: "+err.Error(),
+		)
+
+		return false
+	}
+
+	model.importFromAPI(data)
+
+	return true
+}
+
+func (r *ipamResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state ipamModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found := r.read(ctx, &state, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *ipamResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan ipamModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reqData := &sdn.IPAMCreateRequestBody{
+		IPAMFields: plan.toAPIFields(),
+		ID:         plan.Name.ValueString(),
+		Type:       plan.Type.ValueString(),
+	}
+
+	// write-only values are only available in the configuration
+	if tokenWO := writeOnlyValue(ctx, req.Config, "token_wo", &resp.Diagnostics); tokenWO != nil {
+		reqData.Token = tokenWO
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.CreateIPAM(ctx, reqData)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create SDN IPAM",
+			"An unexpected error occurred while creating the SDN IPAM plugin.\n\n"+
+				"Error: "+err.Error(),
+		)
+
+		return
+	}
+
+	plan.ID = plan.Name
+
+	r.readAfterChange(ctx, &plan, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *ipamResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var plan, state ipamModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reqData := &sdn.IPAMUpdateRequestBody{
+		IPAMFields: plan.toAPIFields(),
+		Delete:     plan.toDelete(&state),
+	}
+
+	// the write-only token is only sent when its version changes
+	if !plan.TokenWOVersion.Equal(state.TokenWOVersion) {
+		tokenWO := writeOnlyValue(ctx, req.Config, "token_wo", &resp.Diagnostics)
+
+		switch {
+		case tokenWO != nil:
+			reqData.Token = tokenWO
+		case plan.Token.IsNull():
+			reqData.Delete = append(reqData.Delete, "token")
+		}
+	} else {
+		attribute.CheckDelete(plan.Token, state.Token, &reqData.Delete, "token")
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.UpdateIPAM(ctx, state.ID.ValueString(), reqData)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Update SDN IPAM",
+			"An unexpected error occurred while updating the SDN IPAM plugin.\n\n"+
+				"Error: "+err.Error(),
+		)
+
+		return
+	}
+
+	r.readAfterChange(ctx, &plan, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// readAfterChange reads the IPAM plugin after it has been created or updated.
+func (r *ipamResource) readAfterChange(ctx context.Context, model *ipamModel, diags *diag.Diagnostics) {
+	if !r.read(ctx, model, diags) && !diags.HasError() {
+		diags.AddError(
+			"SDN IPAM Not Found",
+			fmt.Sprintf("The SDN IPAM plugin %q could not be found after it has been changed.", model.ID.ValueString()),
+		)
+	}
+}
+
+func (r *ipamResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var state ipamModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteIPAM(ctx, state.ID.ValueString())
+	if err != nil && !errors.Is(err, api.ErrResourceDoesNotExist) {
+		resp.Diagnostics.AddError(
+			"Unable to Delete SDN IPAM",
+			"An unexpected error occurred while deleting the SDN IPAM plugin.\n\n"+
+				"Error: "+err.Error(),
+		)
+	}
+}
+
+func (r *ipamResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
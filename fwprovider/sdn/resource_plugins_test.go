@@ -0,0 +1,183 @@
+//go:build acceptance || all
+
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/test"
+)
+
+func TestAccResourceSDNPlugins(t *testing.T) {
+	t.Parallel()
+
+	te := test.InitFakeEnvironment(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_sdn_controller" "test" {
+					name  = "evpn1"
+					type  = "evpn"
+					asn   = 65000
+					peers = ["10.0.0.1", "10.0.0.2"]
+				}
+
+				resource "proxmox_virtual_environment_sdn_ipam" "test" {
+					name  = "netbox"
+					type  = "netbox"
+					url   = "https://netbox.example.com/api"
+					token = "secret"
+				}
+
+				resource "proxmox_virtual_environment_sdn_dns" "test" {
+					name = "powerdns"
+					type = "powerdns"
+					url  = "https://powerdns.example.com:8081/api/v1/servers/localhost"
+					key  = "secret"
+					ttl  = 3600
+				}
+
+				resource "proxmox_virtual_environment_sdn_zone" "test" {
+					name       = "evpn1"
+					type       = "evpn"
+					controller = proxmox_virtual_environment_sdn_controller.test.name
+					vrf_vxlan  = 10000
+					ipam       = proxmox_virtual_environment_sdn_ipam.test.name
+					dns        = proxmox_virtual_environment_sdn_dns.test.name
+					dns_zone   = "example.com"
+				}`),
+				Check: resource.ComposeTestCheckFunc(
+					test.ResourceAttributes("proxmox_virtual_environment_sdn_controller.test", map[string]string{
+						"id":      "evpn1",
+						"asn":     "65000",
+						"peers.#": "2",
+						"pending": "true",
+					}),
+					test.ResourceAttributes("proxmox_virtual_environment_sdn_ipam.test", map[string]string{
+						"id":    "netbox",
+						"url":   "https://netbox.example.com/api",
+						"token": "secret",
+					}),
+					test.ResourceAttributes("proxmox_virtual_environment_sdn_dns.test", map[string]string{
+						"id":  "powerdns",
+						"ttl": "3600",
+						"key": "secret",
+					}),
+					resource.TestCheckResourceAttr("proxmox_virtual_environment_sdn_zone.test", "controller", "evpn1"),
+				),
+			},
+			{
+				ResourceName:            "proxmox_virtual_environment_sdn_ipam.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"token"},
+			},
+			{
+				ResourceName:            "proxmox_virtual_environment_sdn_dns.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"key"},
+			},
+		},
+	})
+}
+
+func TestAccResourceSDNPluginsWriteOnly(t *testing.T) {
+	t.Parallel()
+
+	te := test.InitFakeEnvironment(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_sdn_ipam" "test" {
+					name             = "phpipam"
+					type             = "phpipam"
+					url              = "https://phpipam.example.com/api/app"
+					section          = 1
+					token_wo         = "secret-1"
+					token_wo_version = 1
+				}
+
+				resource "proxmox_virtual_environment_sdn_dns" "test" {
+					name           = "powerdns"
+					type           = "powerdns"
+					url            = "https://powerdns.example.com:8081/api/v1/servers/localhost"
+					key_wo         = "secret-1"
+					key_wo_version = 1
+				}`),
+				Check: resource.ComposeTestCheckFunc(
+					test.NoResourceAttributesSet("proxmox_virtual_environment_sdn_ipam.test", []string{"token", "token_wo"}),
+					test.NoResourceAttributesSet("proxmox_virtual_environment_sdn_dns.test", []string{"key", "key_wo"}),
+				),
+			},
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_sdn_ipam" "test" {
+					name             = "phpipam"
+					type             = "phpipam"
+					url              = "https://phpipam.example.com/api/app"
+					section          = 1
+					token_wo         = "secret-2"
+					token_wo_version = 2
+				}
+
+				resource "proxmox_virtual_environment_sdn_dns" "test" {
+					name           = "powerdns"
+					type           = "powerdns"
+					url            = "https://powerdns.example.com:8081/api/v1/servers/localhost"
+					key_wo         = "secret-2"
+					key_wo_version = 2
+				}`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("proxmox_virtual_environment_sdn_ipam.test", "token_wo_version", "2"),
+					resource.TestCheckResourceAttr("proxmox_virtual_environment_sdn_dns.test", "key_wo_version", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceSDNPluginsValidation(t *testing.T) {
+	t.Parallel()
+
+	te := test.InitFakeEnvironment(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_sdn_controller" "test" {
+					name = "isis1"
+					type = "isis"
+					asn  = 65000
+				}`),
+				ExpectError: regexp.MustCompile(`only supported by controllers of type`),
+			},
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_sdn_ipam" "test" {
+					name = "netbox"
+					type = "netbox"
+					url  = "https://netbox.example.com/api"
+				}`),
+				ExpectError: regexp.MustCompile(`"token" or "token_wo" is required`),
+			},
+		},
+	})
+}
@@ -0,0 +1,159 @@
+//go:build acceptance || all
+
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/test"
+)
+
+const sdnConfig = `
+resource "proxmox_virtual_environment_sdn_zone" "test" {
+	name   = "acctest"
+	type   = "vlan"
+	bridge = "vmbr0"
+	mtu    = {{.MTU}}
+}
+
+resource "proxmox_virtual_environment_sdn_vnet" "test" {
+	name  = "accvnet"
+	zone  = proxmox_virtual_environment_sdn_zone.test.name
+	tag   = 100
+	alias = "test vnet"
+}
+
+resource "proxmox_virtual_environment_sdn_subnet" "test" {
+	vnet    = proxmox_virtual_environment_sdn_vnet.test.name
+	cidr    = "10.10.0.0/24"
+	gateway = "10.10.0.1"
+	snat    = true
+
+	dhcp_ranges = [{
+		start_address = "10.10.0.100"
+		end_address   = "10.10.0.200"
+	}]
+}
+
+resource "proxmox_virtual_environment_sdn_applier" "test" {
+	triggers = {
+		mtu = proxmox_virtual_environment_sdn_zone.test.mtu
+	}
+
+	depends_on = [
+		proxmox_virtual_environment_sdn_zone.test,
+		proxmox_virtual_environment_sdn_vnet.test,
+		proxmox_virtual_environment_sdn_subnet.test,
+	]
+}
+`
+
+func TestAccResourceSDN(t *testing.T) {
+	t.Parallel()
+
+	te := test.InitFakeEnvironment(t)
+
+	te.AddTemplateVars(map[string]any{"MTU": 1450})
+	createConfig := te.RenderConfig(sdnConfig)
+
+	te.AddTemplateVars(map[string]any{"MTU": 1400})
+	updateConfig := te.RenderConfig(sdnConfig + `
+	data "proxmox_virtual_environment_sdn_zone" "test" {
+		name = proxmox_virtual_environment_sdn_zone.test.name
+	}
+
+	data "proxmox_virtual_environment_sdn_vnet" "test" {
+		name = proxmox_virtual_environment_sdn_vnet.test.name
+	}
+
+	data "proxmox_virtual_environment_sdn_subnet" "test" {
+		vnet = proxmox_virtual_environment_sdn_subnet.test.vnet
+		cidr = proxmox_virtual_environment_sdn_subnet.test.cidr
+	}`)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: createConfig,
+				Check: resource.ComposeTestCheckFunc(
+					test.ResourceAttributes("proxmox_virtual_environment_sdn_zone.test", map[string]string{
+						"id":      "acctest",
+						"type":    "vlan",
+						"bridge":  "vmbr0",
+						"mtu":     "1450",
+						"pending": "true",
+					}),
+					test.ResourceAttributes("proxmox_virtual_environment_sdn_vnet.test", map[string]string{
+						"id":    "accvnet",
+						"zone":  "acctest",
+						"tag":   "100",
+						"alias": "test vnet",
+					}),
+					test.ResourceAttributes("proxmox_virtual_environment_sdn_subnet.test", map[string]string{
+						"id":                          "acctest-10.10.0.0-24",
+						"zone":                        "acctest",
+						"gateway":                     "10.10.0.1",
+						"snat":                        "true",
+						"dhcp_ranges.#":               "1",
+						"dhcp_ranges.0.start_address": "10.10.0.100",
+						"dhcp_ranges.0.end_address":   "10.10.0.200",
+					}),
+					resource.TestCheckResourceAttr("proxmox_virtual_environment_sdn_applier.test", "id", "sdn"),
+				),
+			},
+			{
+				RefreshState: true,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("proxmox_virtual_environment_sdn_zone.test", "pending", "false"),
+					resource.TestCheckResourceAttr("proxmox_virtual_environment_sdn_vnet.test", "pending", "false"),
+					resource.TestCheckResourceAttr("proxmox_virtual_environment_sdn_subnet.test", "pending", "false"),
+				),
+			},
+			{
+				Config: updateConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("proxmox_virtual_environment_sdn_zone.test", "mtu", "1400"),
+					resource.TestCheckResourceAttr("data.proxmox_virtual_environment_sdn_zone.test", "mtu", "1400"),
+					resource.TestCheckResourceAttr("data.proxmox_virtual_environment_sdn_vnet.test", "tag", "100"),
+					resource.TestCheckResourceAttr("data.proxmox_virtual_environment_sdn_subnet.test", "gateway", "10.10.0.1"),
+				),
+			},
+			{
+				ResourceName:      "proxmox_virtual_environment_sdn_subnet.test",
+				ImportState:       true,
+				ImportStateId:     "accvnet/acctest-10.10.0.0-24",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceSDNZoneValidation(t *testing.T) {
+	t.Parallel()
+
+	te := test.InitFakeEnvironment(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_sdn_zone" "test" {
+					name = "acctest"
+					type = "vlan"
+				}`),
+				ExpectError: regexp.MustCompile(`bridge`),
+			},
+		},
+	})
+}
@@ -0,0 +1,373 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/attribute"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	customtypes "github.com/bpg/terraform-provider-proxmox/fwprovider/types"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn"
+)
+
+var (
+	_ resource.Resource                = &subnetResource{}
+	_ resource.ResourceWithConfigure   = &subnetResource{}
+	_ resource.ResourceWithImportState = &subnetResource{}
+)
+
+type subnetResource struct {
+	client *sdn.Client
+}
+
+// NewSubnetResource creates a new SDN subnet resource.
+func NewSubnetResource() resource.Resource {
+	return &subnetResource{}
+}
+
+func (r *subnetResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_sdn_subnet"
+}
+
+func (r *subnetResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.Resource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected config.Resource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = cfg.Client.Cluster().SDN()
+}
+
+func (r *subnetResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a subnet of an SDN VNet.",
+		MarkdownDescription: "Manages a subnet of an SDN VNet. Changes are pending until the SDN configuration " +
+			"is applied with the `proxmox_virtual_environment_sdn_applier` resource.",
+		Attributes: map[string]schema.Attribute{
+			"id": attribute.ID("The identifier of the subnet, with format `<zone>-<network>-<mask>`."),
+			"vnet": schema.StringAttribute{
+				Description: "Name of the VNet of the subnet.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"cidr": schema.StringAttribute{
+				Description: "Network of the subnet in CIDR notation, e.g. `10.0.0.0/24`.",
+				Required:    true,
+				CustomType:  customtypes.IPCIDRType{},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"zone": schema.StringAttribute{
+				Description: "Name of the zone of the subnet.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"gateway": schema.StringAttribute{
+				Description: "Address of the gateway of the subnet.",
+				Optional:    true,
+				CustomType:  customtypes.IPAddrType{},
+			},
+			"snat": schema.BoolAttribute{
+				Description: "Whether to masquerade the traffic leaving the subnet behind the address of the node.",
+				Optional:    true,
+			},
+			"dns_zone_prefix": schema.StringAttribute{
+				Description: "Prefix added to the DNS domain of the zone for the hosts of the subnet.",
+				Optional:    true,
+			},
+			"dhcp_dns_server": schema.StringAttribute{
+				Description: "Address of the DNS server announced by the DHCP server of the subnet.",
+				Optional:    true,
+				CustomType:  customtypes.IPAddrType{},
+			},
+			"dhcp_ranges": schema.ListNestedAttribute{
+				Description: "Ranges of addresses assigned by the DHCP server of the zone. " +
+					"Requires a zone with a DHCP server.",
+				Optional: true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"start_address": schema.StringAttribute{
+							Description: "First address of the range.",
+							Required:    true,
+							CustomType:  customtypes.IPAddrType{},
+						},
+						"end_address": schema.StringAttribute{
+							Description: "Last address of the range.",
+							Required:    true,
+							CustomType:  customtypes.IPAddrType{},
+						},
+					},
+				},
+			},
+			"pending": schema.BoolAttribute{
+				Description: "Whether the subnet has changes that have not been applied yet.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *subnetResource) read(ctx context.Context, model *subnetModel, diags *diag.Diagnostics) bool {
+	data, err := r.client.GetSubnet(ctx, model.VNet.ValueString(), model.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, api.ErrResourceDoesNotExist) {
+			return false
+		}
+
+		diags.AddError(
+			"Unable to Read SDN Subnet",
+			"An unexpected error occurred while reading the SDN subnet.\n\n"+
+				"Error: "+err.Error(),
+		)
+
+		return false
+	}
+
+	model.importFromAPI(ctx, data, diags)
+
+	return true
+}
+
+func (r *subnetResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state subnetModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found := r.read(ctx, &state, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *subnetResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan subnetModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	fields := plan.toAPIFields(ctx, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	vnet, err := r.client.GetVNet(ctx, plan.VNet.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create SDN Subnet",
+			fmt.Sprintf("Could not read the SDN VNet %q of the subnet.\n\nError: %s", plan.VNet.ValueString(), err),
+		)
+
+		return
+	}
+
+	err = r.client.CreateSubnet(ctx, plan.VNet.ValueString(), &sdn.SubnetCreateRequestBody{
+		SubnetFields: fields,
+		CIDR:         plan.CIDR.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create SDN Subnet",
+			"An unexpected error occurred while creating the SDN subnet.\n\n"+
+				"Error: "+err.Error(),
+		)
+
+		return
+	}
+
+	var zone string
+	if vnet.Zone != nil {
+		zone = *vnet.Zone
+	}
+
+	plan.ID = types.StringValue(sdn.SubnetID(zone, plan.CIDR.ValueString()))
+
+	r.readAfterChange(ctx, &plan, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *subnetResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var plan, state subnetModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reqData := &sdn.SubnetUpdateRequestBody{
+		SubnetFields: plan.toAPIFields(ctx, &resp.Diagnostics),
+		Delete:       plan.toDelete(&state),
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.UpdateSubnet(ctx, state.VNet.ValueString(), state.ID.ValueString(), reqData)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Update SDN Subnet",
+			"An unexpected error occurred while updating the SDN subnet.\n\n"+
+				"Error: "+err.Error(),
+		)
+
+		return
+	}
+
+	r.readAfterChange(ctx, &plan, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// readAfterChange reads the subnet after it has been created or updated.
+func (r *subnetResource) readAfterChange(ctx context.Context, model *subnetModel, diags *diag.Diagnostics) {
+	if !r.read(ctx, model, diags) && !diags.HasError() {
+		diags.AddError(
+			"SDN Subnet Not Found",
+			fmt.Sprintf("The SDN subnet %q could not be found after it has been changed.", model.ID.ValueString()),
+		)
+	}
+}
+
+func (r *subnetResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var state subnetModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteSubnet(ctx, state.VNet.ValueString(), state.ID.ValueString())
+	if err != nil && !errors.Is(err, api.ErrResourceDoesNotExist) {
+		resp.Diagnostics.AddError(
+			"Unable to Delete SDN Subnet",
+			"An unexpected error occurred while deleting the SDN subnet.\n\n"+
+				"Error: "+err.Error(),
+		)
+	}
+}
+
+func (r *subnetResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	vnet, id, found := strings.Cut(req.ID, "/")
+	if !found || vnet == "" || id == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: `vnet/subnet_id`. Got: %q", req.ID),
+		)
+
+		return
+	}
+
+	state := subnetModel{
+		ID:         types.StringValue(id),
+		VNet:       types.StringValue(vnet),
+		DHCPRanges: types.ListNull(dhcpRangeType),
+	}
+
+	if !r.read(ctx, &state, &resp.Diagnostics) {
+		if !resp.Diagnostics.HasError() {
+			resp.Diagnostics.AddError(
+				"SDN Subnet Not Found",
+				fmt.Sprintf("The SDN subnet %q of the VNet %q does not exist.", id, vnet),
+			)
+		}
+
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
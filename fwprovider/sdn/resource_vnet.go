@@ -0,0 +1,291 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/attribute"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn"
+)
+
+var (
+	_ resource.Resource                = &vnetResource{}
+	_ resource.ResourceWithConfigure   = &vnetResource{}
+	_ resource.ResourceWithImportState = &vnetResource{}
+)
+
+type vnetResource struct {
+	client *sdn.Client
+}
+
+// NewVNetResource creates a new SDN VNet resource.
+func NewVNetResource() resource.Resource {
+	return &vnetResource{}
+}
+
+func (r *vnetResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_sdn_vnet"
+}
+
+func (r *vnetResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.Resource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected config.Resource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = cfg.Client.Cluster().SDN()
+}
+
+func (r *vnetResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Manages an SDN VNet.",
+		MarkdownDescription: "Manages an SDN VNet. Changes are pending until the SDN configuration is applied " +
+			"with the `proxmox_virtual_environment_sdn_applier` resource.",
+		Attributes: map[string]schema.Attribute{
+			"id": attribute.ID(),
+			"name": schema.StringAttribute{
+				Description: "Unique name of the VNet, up to 8 lowercase letters and digits.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(idRegexp, "must start with a lowercase letter, "+
+						"followed by up to 7 lowercase letters or digits"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"zone": schema.StringAttribute{
+				Description: "Name of the zone of the VNet.",
+				Required:    true,
+			},
+			"alias": schema.StringAttribute{
+				Description: "Alias of the VNet, displayed instead of its name.",
+				Optional:    true,
+				Validators:  []validator.String{stringvalidator.LengthBetween(1, 256)},
+			},
+			"isolate_ports": schema.BoolAttribute{
+				Description: "Whether to isolate the ports of the guests from each other, " +
+					"so they can only reach the uplink.",
+				Optional: true,
+			},
+			"tag": schema.Int64Attribute{
+				Description: "VLAN tag, or VXLAN ID, of the VNet. Required by `vlan`, `qinq`, `vxlan` and " +
+					"`evpn` zones.",
+				Optional:   true,
+				Validators: []validator.Int64{int64validator.Between(1, 16777215)},
+			},
+			"vlan_aware": schema.BoolAttribute{
+				Description: "Whether the guests can use VLAN tags inside the VNet.",
+				Optional:    true,
+			},
+			"pending": schema.BoolAttribute{
+				Description: "Whether the VNet has changes that have not been applied yet.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *vnetResource) read(ctx context.Context, model *vnetModel, diags *diag.Diagnostics) bool {
+	data, err := r.client.GetVNet(ctx, model.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, api.ErrResourceDoesNotExist) {
+			return false
+		}
+
+		diags.AddError(
+			"Unable to Read SDN VNet",
+			"An unexpected error occurred while reading the SDN VNet.\n\n"+
+				"Error: "+err.Error(),
+		)
+
+		return false
+	}
+
+	model.importFromAPI(data)
+
+	return true
+}
+
+func (r *vnetResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state vnetModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found := r.read(ctx, &state, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *vnetResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan vnetModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.CreateVNet(ctx, &sdn.VNetCreateRequestBody{
+		VNetFields: plan.toAPIFields(),
+		ID:         plan.Name.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create SDN VNet",
+			"An unexpected error occurred while creating the SDN VNet.\n\n"+
+				"Error: "+err.Error(),
+		)
+
+		return
+	}
+
+	plan.ID = plan.Name
+
+	r.readAfterChange(ctx, &plan, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *vnetResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var plan, state vnetModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.UpdateVNet(ctx, state.ID.ValueString(), &sdn.VNetUpdateRequestBody{
+		VNetFields: plan.toAPIFields(),
+		Delete:     plan.toDelete(&state),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Update SDN VNet",
+			"An unexpected error occurred while updating the SDN VNet.\n\n"+
+				"Error: "+err.Error(),
+		)
+
+		return
+	}
+
+	r.readAfterChange(ctx, &plan, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// readAfterChange reads the VNet after it has been created or updated.
+func (r *vnetResource) readAfterChange(ctx context.Context, model *vnetModel, diags *diag.Diagnostics) {
+	if !r.read(ctx, model, diags) && !diags.HasError() {
+		diags.AddError(
+			"SDN VNet Not Found",
+			fmt.Sprintf("The SDN VNet %q could not be found after it has been changed.", model.ID.ValueString()),
+		)
+	}
+}
+
+func (r *vnetResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var state vnetModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteVNet(ctx, state.ID.ValueString())
+	if err != nil && !errors.Is(err, api.ErrResourceDoesNotExist) {
+		resp.Diagnostics.AddError(
+			"Unable to Delete SDN VNet",
+			"An unexpected error occurred while deleting the SDN VNet.\n\n"+
+				"Error: "+err.Error(),
+		)
+	}
+}
+
+func (r *vnetResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
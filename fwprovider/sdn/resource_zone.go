@@ -0,0 +1,466 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/attribute"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn"
+)
+
+var (
+	_ resource.Resource                   = &zoneResource{}
+	_ resource.ResourceWithConfigure      = &zoneResource{}
+	_ resource.ResourceWithImportState    = &zoneResource{}
+	_ resource.ResourceWithValidateConfig = &zoneResource{}
+)
+
+//nolint:gochecknoglobals
+var (
+	// zoneTypes are the supported types of SDN zones.
+	zoneTypes = []string{sdn.ZoneTypeSimple, sdn.ZoneTypeVLAN, sdn.ZoneTypeQinQ, sdn.ZoneTypeVXLAN, sdn.ZoneTypeEVPN}
+
+	// zoneTypeAttributes are the attributes that only apply to some types of SDN zones.
+	zoneTypeAttributes = map[string][]string{
+		"dhcp":                       {sdn.ZoneTypeSimple},
+		"bridge":                     {sdn.ZoneTypeVLAN, sdn.ZoneTypeQinQ},
+		"service_vlan":               {sdn.ZoneTypeQinQ},
+		"service_vlan_protocol":      {sdn.ZoneTypeQinQ},
+		"peers":                      {sdn.ZoneTypeVXLAN},
+		"vxlan_port":                 {sdn.ZoneTypeVXLAN},
+		"advertise_subnets":          {sdn.ZoneTypeEVPN},
+		"controller":                 {sdn.ZoneTypeEVPN},
+		"disable_arp_nd_suppression": {sdn.ZoneTypeEVPN},
+		"exit_nodes":                 {sdn.ZoneTypeEVPN},
+		"exit_nodes_local_routing":   {sdn.ZoneTypeEVPN},
+		"exit_nodes_primary":         {sdn.ZoneTypeEVPN},
+		"mac":                        {sdn.ZoneTypeEVPN},
+		"rt_import":                  {sdn.ZoneTypeEVPN},
+		"vrf_vxlan":                  {sdn.ZoneTypeEVPN},
+	}
+
+	// zoneTypeRequiredAttributes are the attributes required by some types of SDN zones.
+	zoneTypeRequiredAttributes = map[string][]string{
+		sdn.ZoneTypeVLAN:  {"bridge"},
+		sdn.ZoneTypeQinQ:  {"bridge", "service_vlan"},
+		sdn.ZoneTypeVXLAN: {"peers"},
+		sdn.ZoneTypeEVPN:  {"controller", "vrf_vxlan"},
+	}
+)
+
+type zoneResource struct {
+	client *sdn.Client
+}
+
+// NewZoneResource creates a new SDN zone resource.
+func NewZoneResource() resource.Resource {
+	return &zoneResource{}
+}
+
+func (r *zoneResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_sdn_zone"
+}
+
+func (r *zoneResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.Resource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected config.Resource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = cfg.Client.Cluster().SDN()
+}
+
+func (r *zoneResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Manages an SDN zone.",
+		MarkdownDescription: "Manages an SDN zone. Changes are pending until the SDN configuration is applied " +
+			"with the `proxmox_virtual_environment_sdn_applier` resource.",
+		Attributes: map[string]schema.Attribute{
+			"id": attribute.ID(),
+			"name": schema.StringAttribute{
+				Description: "Unique name of the zone, up to 8 lowercase letters and digits.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(idRegexp, "must start with a lowercase letter, "+
+						"followed by up to 7 lowercase letters or digits"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Description: "Type of the zone, one of `simple`, `vlan`, `qinq`, `vxlan` or `evpn`.",
+				Required:    true,
+				Validators:  []validator.String{stringvalidator.OneOf(zoneTypes...)},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"dns": schema.StringAttribute{
+				Description: "DNS plugin used to register the addresses of the guests.",
+				Optional:    true,
+			},
+			"dns_zone": schema.StringAttribute{
+				Description: "DNS domain of the zone, e.g. `example.com`.",
+				Optional:    true,
+			},
+			"ipam": schema.StringAttribute{
+				Description: "IPAM plugin used to manage the addresses of the guests, e.g. `pve`.",
+				Optional:    true,
+			},
+			"mtu": schema.Int64Attribute{
+				Description: "MTU of the VNets of the zone.",
+				Optional:    true,
+				Validators:  []validator.Int64{int64validator.Between(512, 65536)},
+			},
+			"nodes": schema.SetAttribute{
+				Description: "Nodes the zone is deployed on. The zone is deployed on all nodes if not set.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators:  []validator.Set{setvalidator.SizeAtLeast(1)},
+			},
+			"reverse_dns": schema.StringAttribute{
+				Description: "DNS plugin used to register the reverse DNS records of the guests.",
+				Optional:    true,
+			},
+			"pending": schema.BoolAttribute{
+				Description: "Whether the zone has changes that have not been applied yet.",
+				Computed:    true,
+			},
+			"dhcp": schema.StringAttribute{
+				Description: "DHCP server of the VNets of the zone, only `dnsmasq` is supported (`simple` zones only).",
+				Optional:    true,
+				Validators:  []validator.String{stringvalidator.OneOf("dnsmasq")},
+			},
+			"bridge": schema.StringAttribute{
+				Description: "Bridge the VNets of the zone are attached to (`vlan` and `qinq` zones only, required).",
+				Optional:    true,
+			},
+			"service_vlan": schema.Int64Attribute{
+				Description: "Service VLAN tag of the zone (`qinq` zones only, required).",
+				Optional:    true,
+				Validators:  []validator.Int64{int64validator.Between(1, 4094)},
+			},
+			"service_vlan_protocol": schema.StringAttribute{
+				Description: "Protocol of the service VLAN, either `802.1q` or `802.1ad` (`qinq` zones only).",
+				Optional:    true,
+				Validators:  []validator.String{stringvalidator.OneOf("802.1q", "802.1ad")},
+			},
+			"peers": schema.SetAttribute{
+				Description: "Addresses of the peers of the VXLAN overlay, usually the addresses of all nodes " +
+					"(`vxlan` zones only, required).",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators:  []validator.Set{setvalidator.SizeAtLeast(1)},
+			},
+			"vxlan_port": schema.Int64Attribute{
+				Description: "UDP port of the VXLAN overlay, defaults to `4789` (`vxlan` zones only).",
+				Optional:    true,
+				Validators:  []validator.Int64{int64validator.Between(1, 65535)},
+			},
+			"advertise_subnets": schema.BoolAttribute{
+				Description: "Whether to announce the full subnets in the EVPN network (`evpn` zones only).",
+				Optional:    true,
+			},
+			"controller": schema.StringAttribute{
+				Description: "EVPN controller of the zone (`evpn` zones only, required).",
+				Optional:    true,
+			},
+			"disable_arp_nd_suppression": schema.BoolAttribute{
+				Description: "Whether to disable the ARP and ND suppression (`evpn` zones only).",
+				Optional:    true,
+			},
+			"exit_nodes": schema.SetAttribute{
+				Description: "Nodes routing the traffic of the zone to the outside network (`evpn` zones only).",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators:  []validator.Set{setvalidator.SizeAtLeast(1)},
+			},
+			"exit_nodes_local_routing": schema.BoolAttribute{
+				Description: "Whether the exit nodes can reach the guests of the zone (`evpn` zones only).",
+				Optional:    true,
+			},
+			"exit_nodes_primary": schema.StringAttribute{
+				Description: "Exit node preferred for the traffic to the outside network (`evpn` zones only).",
+				Optional:    true,
+			},
+			"mac": schema.StringAttribute{
+				Description: "Anycast MAC address of the gateways of the VNets (`evpn` zones only).",
+				Optional:    true,
+			},
+			"rt_import": schema.StringAttribute{
+				Description: "Comma-separated route targets to import, e.g. `65000:1000` (`evpn` zones only).",
+				Optional:    true,
+			},
+			"vrf_vxlan": schema.Int64Attribute{
+				Description: "VXLAN ID of the VRF of the zone (`evpn` zones only, required).",
+				Optional:    true,
+				Validators:  []validator.Int64{int64validator.Between(1, 16777215)},
+			},
+		},
+	}
+}
+
+func (r *zoneResource) ValidateConfig(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	var data zoneModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || data.Type.IsNull() || data.Type.IsUnknown() {
+		return
+	}
+
+	validateTypeAttributes(
+		"zones",
+		data.Type.ValueString(),
+		data.typeSpecificValues(),
+		zoneTypeAttributes,
+		zoneTypeRequiredAttributes,
+		&resp.Diagnostics,
+	)
+}
+
+// typeSpecificValues returns the values of the attributes that only apply to some types of SDN zones.
+func (m *zoneModel) typeSpecificValues() map[string]attr.Value {
+	return map[string]attr.Value{
+		"dhcp":                       m.DHCP,
+		"bridge":                     m.Bridge,
+		"service_vlan":               m.ServiceVLAN,
+		"service_vlan_protocol":      m.ServiceVLANProtocol,
+		"peers":                      m.Peers,
+		"vxlan_port":                 m.VXLANPort,
+		"advertise_subnets":          m.AdvertiseSubnets,
+		"controller":                 m.Controller,
+		"disable_arp_nd_suppression": m.DisableARPNDSuppression,
+		"exit_nodes":                 m.ExitNodes,
+		"exit_nodes_local_routing":   m.ExitNodesLocalRouting,
+		"exit_nodes_primary":         m.ExitNodesPrimary,
+		"mac":                        m.MAC,
+		"rt_import":                  m.RouteTargetImport,
+		"vrf_vxlan":                  m.VRFVXLAN,
+	}
+}
+
+func (r *zoneResource) read(ctx context.Context, model *zoneModel, diags *diag.Diagnostics) bool {
+	data, err := r.client.GetZone(ctx, model.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, api.ErrResourceDoesNotExist) {
+			return false
+		}
+
+		diags.AddError(
+			"Unable to Read SDN Zone",
+			"An unexpected error occurred while reading the SDN zone.\n\n"+
+				"Error: "+err.Error(),
+		)
+
+		return false
+	}
+
+	model.importFromAPI(ctx, data, diags)
+
+	return true
+}
+
+func (r *zoneResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state zoneModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found := r.read(ctx, &state, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *zoneResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan zoneModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reqData := &sdn.ZoneCreateRequestBody{
+		ZoneFields: plan.toAPIFields(ctx, &resp.Diagnostics),
+		ID:         plan.Name.ValueString(),
+		Type:       plan.Type.ValueString(),
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.CreateZone(ctx, reqData)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create SDN Zone",
+			"An unexpected error occurred while creating the SDN zone.\n\n"+
+				"Error: "+err.Error(),
+		)
+
+		return
+	}
+
+	plan.ID = plan.Name
+
+	r.readAfterChange(ctx, &plan, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *zoneResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var plan, state zoneModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reqData := &sdn.ZoneUpdateRequestBody{
+		ZoneFields: plan.toAPIFields(ctx, &resp.Diagnostics),
+		Delete:     plan.toDelete(&state),
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.UpdateZone(ctx, state.ID.ValueString(), reqData)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Update SDN Zone",
+			"An unexpected error occurred while updating the SDN zone.\n\n"+
+				"Error: "+err.Error(),
+		)
+
+		return
+	}
+
+	r.readAfterChange(ctx, &plan, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// readAfterChange reads the zone after it has been created or updated.
+func (r *zoneResource) readAfterChange(ctx context.Context, model *zoneModel, diags *diag.Diagnostics) {
+	if !r.read(ctx, model, diags) && !diags.HasError() {
+		diags.AddError(
+			"SDN Zone Not Found",
+			fmt.Sprintf("The SDN zone %q could not be found after it has been changed.", model.ID.ValueString()),
+		)
+	}
+}
+
+func (r *zoneResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var state zoneModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteZone(ctx, state.ID.ValueString())
+	if err != nil && !errors.Is(err, api.ErrResourceDoesNotExist) {
+		resp.Diagnostics.AddError(
+			"Unable to Delete SDN Zone",
+			"An unexpected error occurred while deleting the SDN zone.\n\n"+
+				"Error: "+err.Error(),
+		)
+	}
+}
+
+func (r *zoneResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,122 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/attribute"
+	customtypes "github.com/bpg/terraform-provider-proxmox/fwprovider/types"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn"
+	proxmoxtypes "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+type subnetModel struct {
+	ID            types.String            `tfsdk:"id"`
+	VNet          types.String            `tfsdk:"vnet"`
+	CIDR          customtypes.IPCIDRValue `tfsdk:"cidr"`
+	Zone          types.String            `tfsdk:"zone"`
+	Gateway       customtypes.IPAddrValue `tfsdk:"gateway"`
+	SNAT          types.Bool              `tfsdk:"snat"`
+	DNSZonePrefix types.String            `tfsdk:"dns_zone_prefix"`
+	DHCPDNSServer customtypes.IPAddrValue `tfsdk:"dhcp_dns_server"`
+	DHCPRanges    types.List              `tfsdk:"dhcp_ranges"`
+	Pending       types.Bool              `tfsdk:"pending"`
+}
+
+type dhcpRangeModel struct {
+	StartAddress customtypes.IPAddrValue `tfsdk:"start_address"`
+	EndAddress   customtypes.IPAddrValue `tfsdk:"end_address"`
+}
+
+//nolint:gochecknoglobals
+var dhcpRangeType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"start_address": customtypes.IPAddrType{},
+		"end_address":   customtypes.IPAddrType{},
+	},
+}
+
+// importFromAPI takes data from SDN subnet PVE API response and set fields based on it.
+// Note: the API response of a pending subnet does not always contain the VNet, so it is kept from the model.
+func (m *subnetModel) importFromAPI(ctx context.Context, data *sdn.SubnetData, diags *diag.Diagnostics) {
+	m.ID = types.StringValue(data.ID)
+	m.CIDR = customtypes.NewIPCIDRPointerValue(data.CIDR)
+	m.Zone = types.StringPointerValue(data.Zone)
+	m.Gateway = customtypes.NewIPAddrPointerValue(data.Gateway)
+	m.SNAT = types.BoolPointerValue(data.SNAT.PointerBool())
+	m.DNSZonePrefix = types.StringPointerValue(data.DNSZonePrefix)
+	m.DHCPDNSServer = customtypes.NewIPAddrPointerValue(data.DHCPDNSServer)
+	m.Pending = types.BoolValue(data.State != nil)
+
+	if data.VNet != nil {
+		m.VNet = types.StringPointerValue(data.VNet)
+	}
+
+	if len(data.DHCPRanges) == 0 {
+		m.DHCPRanges = types.ListNull(dhcpRangeType)
+		return
+	}
+
+	ranges := make([]dhcpRangeModel, 0, len(data.DHCPRanges))
+
+	for _, dr := range data.DHCPRanges {
+		ranges = append(ranges, dhcpRangeModel{
+			StartAddress: customtypes.NewIPAddrPointerValue(&dr.StartAddress),
+			EndAddress:   customtypes.NewIPAddrPointerValue(&dr.EndAddress),
+		})
+	}
+
+	list, d := types.ListValueFrom(ctx, dhcpRangeType, ranges)
+	diags.Append(d...)
+
+	m.DHCPRanges = list
+}
+
+// toAPIFields creates the fields of SDN subnet create and update requests.
+func (m *subnetModel) toAPIFields(ctx context.Context, diags *diag.Diagnostics) sdn.SubnetFields {
+	fields := sdn.SubnetFields{
+		DHCPDNSServer: m.DHCPDNSServer.ValueStringPointer(),
+		DNSZonePrefix: m.DNSZonePrefix.ValueStringPointer(),
+		Gateway:       m.Gateway.ValueStringPointer(),
+		SNAT:          proxmoxtypes.CustomBoolPtr(m.SNAT.ValueBoolPointer()),
+	}
+
+	if m.DHCPRanges.IsNull() || m.DHCPRanges.IsUnknown() {
+		return fields
+	}
+
+	var ranges []dhcpRangeModel
+
+	diags.Append(m.DHCPRanges.ElementsAs(ctx, &ranges, false)...)
+
+	for _, dr := range ranges {
+		fields.DHCPRanges = append(fields.DHCPRanges, sdn.DHCPRange{
+			StartAddress: dr.StartAddress.ValueString(),
+			EndAddress:   dr.EndAddress.ValueString(),
+		})
+	}
+
+	return fields
+}
+
+// toDelete returns the API names of the optional fields that have been removed from the configuration.
+func (m *subnetModel) toDelete(state *subnetModel) []string {
+	var toDelete []string
+
+	attribute.CheckDelete(m.Gateway, state.Gateway, &toDelete, "gateway")
+	attribute.CheckDelete(m.SNAT, state.SNAT, &toDelete, "snat")
+	attribute.CheckDelete(m.DNSZonePrefix, state.DNSZonePrefix, &toDelete, "dnszoneprefix")
+	attribute.CheckDelete(m.DHCPDNSServer, state.DHCPDNSServer, &toDelete, "dhcp-dns-server")
+	attribute.CheckDelete(m.DHCPRanges, state.DHCPRanges, &toDelete, "dhcp-range")
+
+	return toDelete
+}
@@ -0,0 +1,61 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/attribute"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn"
+	proxmoxtypes "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+type vnetModel struct {
+	ID           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	Zone         types.String `tfsdk:"zone"`
+	Alias        types.String `tfsdk:"alias"`
+	IsolatePorts types.Bool   `tfsdk:"isolate_ports"`
+	Tag          types.Int64  `tfsdk:"tag"`
+	VLANAware    types.Bool   `tfsdk:"vlan_aware"`
+	Pending      types.Bool   `tfsdk:"pending"`
+}
+
+// importFromAPI takes data from SDN VNet PVE API response and set fields based on it.
+func (m *vnetModel) importFromAPI(data *sdn.VNetData) {
+	m.ID = types.StringValue(data.ID)
+	m.Name = types.StringValue(data.ID)
+	m.Zone = types.StringPointerValue(data.Zone)
+	m.Alias = types.StringPointerValue(data.Alias)
+	m.IsolatePorts = types.BoolPointerValue(data.IsolatePorts.PointerBool())
+	m.Tag = types.Int64PointerValue(data.Tag.PointerInt64())
+	m.VLANAware = types.BoolPointerValue(data.VLANAware.PointerBool())
+	m.Pending = types.BoolValue(data.State != nil)
+}
+
+// toAPIFields creates the fields of SDN VNet create and update requests.
+func (m *vnetModel) toAPIFields() sdn.VNetFields {
+	return sdn.VNetFields{
+		Alias:        m.Alias.ValueStringPointer(),
+		IsolatePorts: proxmoxtypes.CustomBoolPtr(m.IsolatePorts.ValueBoolPointer()),
+		Tag:          customInt64Pointer(m.Tag),
+		VLANAware:    proxmoxtypes.CustomBoolPtr(m.VLANAware.ValueBoolPointer()),
+		Zone:         m.Zone.ValueStringPointer(),
+	}
+}
+
+// toDelete returns the API names of the optional fields that have been removed from the configuration.
+func (m *vnetModel) toDelete(state *vnetModel) []string {
+	var toDelete []string
+
+	attribute.CheckDelete(m.Alias, state.Alias, &toDelete, "alias")
+	attribute.CheckDelete(m.IsolatePorts, state.IsolatePorts, &toDelete, "isolate-ports")
+	attribute.CheckDelete(m.Tag, state.Tag, &toDelete, "tag")
+	attribute.CheckDelete(m.VLANAware, state.VLANAware, &toDelete, "vlanaware")
+
+	return toDelete
+}
@@ -0,0 +1,139 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/attribute"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn"
+	proxmoxtypes "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+type zoneModel struct {
+	ID         types.String `tfsdk:"id"`
+	Name       types.String `tfsdk:"name"`
+	Type       types.String `tfsdk:"type"`
+	DNS        types.String `tfsdk:"dns"`
+	DNSZone    types.String `tfsdk:"dns_zone"`
+	IPAM       types.String `tfsdk:"ipam"`
+	MTU        types.Int64  `tfsdk:"mtu"`
+	Nodes      types.Set    `tfsdk:"nodes"`
+	ReverseDNS types.String `tfsdk:"reverse_dns"`
+	Pending    types.Bool   `tfsdk:"pending"`
+
+	// simple zone only options
+	DHCP types.String `tfsdk:"dhcp"`
+
+	// vlan and qinq zone only options
+	Bridge types.String `tfsdk:"bridge"`
+
+	// qinq zone only options
+	ServiceVLAN         types.Int64  `tfsdk:"service_vlan"`
+	ServiceVLANProtocol types.String `tfsdk:"service_vlan_protocol"`
+
+	// vxlan zone only options
+	Peers     types.Set   `tfsdk:"peers"`
+	VXLANPort types.Int64 `tfsdk:"vxlan_port"`
+
+	// evpn zone only options
+	AdvertiseSubnets        types.Bool   `tfsdk:"advertise_subnets"`
+	Controller              types.String `tfsdk:"controller"`
+	DisableARPNDSuppression types.Bool   `tfsdk:"disable_arp_nd_suppression"`
+	ExitNodes               types.Set    `tfsdk:"exit_nodes"`
+	ExitNodesLocalRouting   types.Bool   `tfsdk:"exit_nodes_local_routing"`
+	ExitNodesPrimary        types.String `tfsdk:"exit_nodes_primary"`
+	MAC                     types.String `tfsdk:"mac"`
+	RouteTargetImport       types.String `tfsdk:"rt_import"`
+	VRFVXLAN                types.Int64  `tfsdk:"vrf_vxlan"`
+}
+
+// importFromAPI takes data from SDN zone PVE API response and set fields based on it.
+func (m *zoneModel) importFromAPI(ctx context.Context, data *sdn.ZoneData, diags *diag.Diagnostics) {
+	m.ID = types.StringValue(data.ID)
+	m.Name = types.StringValue(data.ID)
+	m.Type = types.StringValue(data.Type)
+	m.Pending = types.BoolValue(data.State != nil)
+
+	m.DNS = types.StringPointerValue(data.DNS)
+	m.DNSZone = types.StringPointerValue(data.DNSZone)
+	m.IPAM = types.StringPointerValue(data.IPAM)
+	m.MTU = types.Int64PointerValue(data.MTU.PointerInt64())
+	m.Nodes = commaSeparatedSet(ctx, data.Nodes, diags)
+	m.ReverseDNS = types.StringPointerValue(data.ReverseDNS)
+	m.DHCP = types.StringPointerValue(data.DHCP)
+	m.Bridge = types.StringPointerValue(data.Bridge)
+	m.ServiceVLAN = types.Int64PointerValue(data.ServiceVLAN.PointerInt64())
+	m.ServiceVLANProtocol = types.StringPointerValue(data.ServiceVLANProtocol)
+	m.Peers = commaSeparatedSet(ctx, data.Peers, diags)
+	m.VXLANPort = types.Int64PointerValue(data.VXLANPort.PointerInt64())
+	m.AdvertiseSubnets = types.BoolPointerValue(data.AdvertiseSubnets.PointerBool())
+	m.Controller = types.StringPointerValue(data.Controller)
+	m.DisableARPNDSuppression = types.BoolPointerValue(data.DisableARPNDSuppression.PointerBool())
+	m.ExitNodes = commaSeparatedSet(ctx, data.ExitNodes, diags)
+	m.ExitNodesLocalRouting = types.BoolPointerValue(data.ExitNodesLocalRouting.PointerBool())
+	m.ExitNodesPrimary = types.StringPointerValue(data.ExitNodesPrimary)
+	m.MAC = types.StringPointerValue(data.MAC)
+	m.RouteTargetImport = types.StringPointerValue(data.RouteTargetImport)
+	m.VRFVXLAN = types.Int64PointerValue(data.VRFVXLAN.PointerInt64())
+}
+
+// toAPIFields creates the fields of SDN zone create and update requests.
+func (m *zoneModel) toAPIFields(ctx context.Context, diags *diag.Diagnostics) sdn.ZoneFields {
+	return sdn.ZoneFields{
+		DNS:                     m.DNS.ValueStringPointer(),
+		DNSZone:                 m.DNSZone.ValueStringPointer(),
+		IPAM:                    m.IPAM.ValueStringPointer(),
+		MTU:                     customInt64Pointer(m.MTU),
+		Nodes:                   commaSeparatedString(ctx, m.Nodes, diags),
+		ReverseDNS:              m.ReverseDNS.ValueStringPointer(),
+		DHCP:                    m.DHCP.ValueStringPointer(),
+		Bridge:                  m.Bridge.ValueStringPointer(),
+		ServiceVLAN:             customInt64Pointer(m.ServiceVLAN),
+		ServiceVLANProtocol:     m.ServiceVLANProtocol.ValueStringPointer(),
+		Peers:                   commaSeparatedString(ctx, m.Peers, diags),
+		VXLANPort:               customInt64Pointer(m.VXLANPort),
+		AdvertiseSubnets:        proxmoxtypes.CustomBoolPtr(m.AdvertiseSubnets.ValueBoolPointer()),
+		Controller:              m.Controller.ValueStringPointer(),
+		DisableARPNDSuppression: proxmoxtypes.CustomBoolPtr(m.DisableARPNDSuppression.ValueBoolPointer()),
+		ExitNodes:               commaSeparatedString(ctx, m.ExitNodes, diags),
+		ExitNodesLocalRouting:   proxmoxtypes.CustomBoolPtr(m.ExitNodesLocalRouting.ValueBoolPointer()),
+		ExitNodesPrimary:        m.ExitNodesPrimary.ValueStringPointer(),
+		MAC:                     m.MAC.ValueStringPointer(),
+		RouteTargetImport:       m.RouteTargetImport.ValueStringPointer(),
+		VRFVXLAN:                customInt64Pointer(m.VRFVXLAN),
+	}
+}
+
+// toDelete returns the API names of the optional fields that have been removed from the configuration.
+func (m *zoneModel) toDelete(state *zoneModel) []string {
+	var toDelete []string
+
+	attribute.CheckDelete(m.DNS, state.DNS, &toDelete, "dns")
+	attribute.CheckDelete(m.DNSZone, state.DNSZone, &toDelete, "dnszone")
+	attribute.CheckDelete(m.IPAM, state.IPAM, &toDelete, "ipam")
+	attribute.CheckDelete(m.MTU, state.MTU, &toDelete, "mtu")
+	attribute.CheckDelete(m.Nodes, state.Nodes, &toDelete, "nodes")
+	attribute.CheckDelete(m.ReverseDNS, state.ReverseDNS, &toDelete, "reversedns")
+	attribute.CheckDelete(m.DHCP, state.DHCP, &toDelete, "dhcp")
+	attribute.CheckDelete(m.ServiceVLANProtocol, state.ServiceVLANProtocol, &toDelete, "vlan-protocol")
+	attribute.CheckDelete(m.VXLANPort, state.VXLANPort, &toDelete, "vxlan-port")
+	attribute.CheckDelete(m.AdvertiseSubnets, state.AdvertiseSubnets, &toDelete, "advertise-subnets")
+	attribute.CheckDelete(
+		m.DisableARPNDSuppression, state.DisableARPNDSuppression, &toDelete, "disable-arp-nd-suppression",
+	)
+	attribute.CheckDelete(m.ExitNodes, state.ExitNodes, &toDelete, "exitnodes")
+	attribute.CheckDelete(m.ExitNodesLocalRouting, state.ExitNodesLocalRouting, &toDelete, "exitnodes-local-routing")
+	attribute.CheckDelete(m.ExitNodesPrimary, state.ExitNodesPrimary, &toDelete, "exitnodes-primary")
+	attribute.CheckDelete(m.MAC, state.MAC, &toDelete, "mac")
+	attribute.CheckDelete(m.RouteTargetImport, state.RouteTargetImport, &toDelete, "rt-import")
+
+	return toDelete
+}
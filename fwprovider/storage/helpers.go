@@ -0,0 +1,111 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	proxmoxtypes "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+// idRegexp matches the identifiers of datastores.
+var idRegexp = regexp.MustCompile(`^[a-z][a-z0-9\-_.]*[a-z0-9]$`)
+
+// stringSet converts a list of the API into a set of strings, an empty list is converted to null.
+func stringSet(ctx context.Context, items []string, diags *diag.Diagnostics) types.Set {
+	if len(items) == 0 {
+		return types.SetNull(types.StringType)
+	}
+
+	set, d := types.SetValueFrom(ctx, types.StringType, items)
+	diags.Append(d...)
+
+	return set
+}
+
+// setStrings converts a set of strings into a sorted list of the API.
+func setStrings(ctx context.Context, set types.Set, diags *diag.Diagnostics) []string {
+	if set.IsNull() || set.IsUnknown() {
+		return nil
+	}
+
+	var items []string
+
+	diags.Append(set.ElementsAs(ctx, &items, false)...)
+
+	slices.Sort(items)
+
+	return items
+}
+
+// validateTypeAttributes checks that the attributes only supported by some types of datastores are not set
+// for other types, and that the attributes required by the type of the datastore are set.
+func validateTypeAttributes(
+	storageType string,
+	values map[string]attr.Value,
+	typeAttributes map[string][]string,
+	requiredAttributes map[string][]string,
+	diags *diag.Diagnostics,
+) {
+	for name, allowed := range typeAttributes {
+		if !values[name].IsNull() && !slices.Contains(allowed, storageType) {
+			diags.AddAttributeError(
+				path.Root(name),
+				"Invalid Attribute Combination",
+				fmt.Sprintf("The attribute %q is only supported by datastores of type %s, not %q.",
+					name, strings.Join(allowed, ", "), storageType),
+			)
+		}
+	}
+
+	for _, name := range requiredAttributes[storageType] {
+		if values[name].IsNull() {
+			diags.AddAttributeError(
+				path.Root(name),
+				"Missing Required Attribute",
+				fmt.Sprintf("The attribute %q is required by datastores of type %q.", name, storageType),
+			)
+		}
+	}
+}
+
+// writeOnlyValue returns the value of a write-only attribute, which is only available in the configuration.
+func writeOnlyValue(ctx context.Context, config tfsdk.Config, name string, diags *diag.Diagnostics) *string {
+	var value types.String
+
+	diags.Append(config.GetAttribute(ctx, path.Root(name), &value)...)
+
+	return value.ValueStringPointer()
+}
+
+func customBoolPointer(v types.Bool) *proxmoxtypes.CustomBool {
+	if v.IsNull() || v.IsUnknown() {
+		return nil
+	}
+
+	return proxmoxtypes.CustomBool(v.ValueBool()).Pointer()
+}
+
+func customInt64Pointer(v types.Int64) *proxmoxtypes.CustomInt64 {
+	if v.IsNull() || v.IsUnknown() {
+		return nil
+	}
+
+	i := proxmoxtypes.CustomInt64(v.ValueInt64())
+
+	return &i
+}
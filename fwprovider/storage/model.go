@@ -0,0 +1,240 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package storage
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/attribute"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/types/prunebackups"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/storage"
+)
+
+type storageModel struct {
+	ID           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	Type         types.String `tfsdk:"type"`
+	Content      types.Set    `tfsdk:"content"`
+	Nodes        types.Set    `tfsdk:"nodes"`
+	Disable      types.Bool   `tfsdk:"disable"`
+	Shared       types.Bool   `tfsdk:"shared"`
+	PruneBackups types.Object `tfsdk:"prune_backups"`
+
+	// dir, nfs, cifs and cephfs options
+	Preallocation types.String `tfsdk:"preallocation"`
+	Path          types.String `tfsdk:"path"`
+	Options       types.String `tfsdk:"options"`
+
+	// nfs, cifs and pbs options
+	Server types.String `tfsdk:"server"`
+	Export types.String `tfsdk:"export"`
+
+	// cifs options
+	Share      types.String `tfsdk:"share"`
+	Domain     types.String `tfsdk:"domain"`
+	SMBVersion types.String `tfsdk:"smb_version"`
+	Subdir     types.String `tfsdk:"subdir"`
+
+	// cifs, pbs, rbd and cephfs credentials
+	Username          types.String `tfsdk:"username"`
+	Password          types.String `tfsdk:"password"`
+	PasswordWO        types.String `tfsdk:"password_wo"`
+	PasswordWOVersion types.Int64  `tfsdk:"password_wo_version"`
+
+	// lvm and lvmthin options
+	VGName     types.String `tfsdk:"vg_name"`
+	ThinPool   types.String `tfsdk:"thin_pool"`
+	SafeRemove types.Bool   `tfsdk:"safe_remove"`
+
+	// zfspool and rbd options
+	Pool      types.String `tfsdk:"pool"`
+	BlockSize types.String `tfsdk:"block_size"`
+	Sparse    types.Bool   `tfsdk:"sparse"`
+
+	// rbd and cephfs options
+	MonHosts         types.Set    `tfsdk:"mon_hosts"`
+	Keyring          types.String `tfsdk:"keyring"`
+	KeyringWO        types.String `tfsdk:"keyring_wo"`
+	KeyringWOVersion types.Int64  `tfsdk:"keyring_wo_version"`
+	KRBD             types.Bool   `tfsdk:"krbd"`
+	Namespace        types.String `tfsdk:"namespace"`
+	FSName           types.String `tfsdk:"fs_name"`
+
+	// pbs options
+	Datastore              types.String `tfsdk:"datastore"`
+	Fingerprint            types.String `tfsdk:"fingerprint"`
+	Port                   types.Int64  `tfsdk:"port"`
+	EncryptionKey          types.String `tfsdk:"encryption_key"`
+	EncryptionKeyWO        types.String `tfsdk:"encryption_key_wo"`
+	EncryptionKeyWOVersion types.Int64  `tfsdk:"encryption_key_wo_version"`
+}
+
+// importFromAPI takes data from the storage configuration PVE API response and set fields based on it.
+// The credentials are not read back, the API never returns them.
+func (m *storageModel) importFromAPI(ctx context.Context, data *storage.DatastoreGetResponseData, diags *diag.Diagnostics) {
+	m.ID = types.StringPointerValue(data.Storage)
+	m.Name = types.StringPointerValue(data.Storage)
+	m.Type = types.StringPointerValue(data.Type)
+	m.Content = stringSet(ctx, data.Content, diags)
+	m.Nodes = stringSet(ctx, data.Nodes, diags)
+	m.Disable = types.BoolValue(data.Disable != nil && bool(*data.Disable))
+
+	// PVE reports all network datastores as shared, the attribute can only be set on the others
+	if slices.Contains(storageTypeAttributes["shared"], m.Type.ValueString()) {
+		m.Shared = types.BoolPointerValue(data.Shared.PointerBool())
+	} else {
+		m.Shared = types.BoolNull()
+	}
+
+	m.PruneBackups = prunebackups.FromAPI(ctx, data.PruneBackups, diags)
+
+	m.Preallocation = types.StringPointerValue(data.Preallocation)
+	m.Path = types.StringPointerValue(data.Path)
+	m.Options = types.StringPointerValue(data.Options)
+
+	m.Server = types.StringPointerValue(data.Server)
+	m.Export = types.StringPointerValue(data.Export)
+
+	m.Share = types.StringPointerValue(data.Share)
+	m.Domain = types.StringPointerValue(data.Domain)
+	m.SMBVersion = types.StringPointerValue(data.SMBVersion)
+	m.Subdir = types.StringPointerValue(data.Subdir)
+	m.Username = types.StringPointerValue(data.Username)
+
+	m.VGName = types.StringPointerValue(data.VGName)
+	m.ThinPool = types.StringPointerValue(data.ThinPool)
+	m.SafeRemove = types.BoolPointerValue(data.SafeRemove.PointerBool())
+
+	m.Pool = types.StringPointerValue(data.Pool)
+	m.BlockSize = types.StringPointerValue(data.BlockSize)
+	m.Sparse = types.BoolPointerValue(data.Sparse.PointerBool())
+
+	m.MonHosts = stringSet(ctx, monHostsFromAPI(data.MonHost), diags)
+	m.KRBD = types.BoolPointerValue(data.KRBD.PointerBool())
+	m.Namespace = types.StringPointerValue(data.Namespace)
+	m.FSName = types.StringPointerValue(data.FSName)
+
+	m.Datastore = types.StringPointerValue(data.Datastore)
+	m.Fingerprint = types.StringPointerValue(data.Fingerprint)
+	m.Port = types.Int64PointerValue(data.Port.PointerInt64())
+}
+
+// toAPIFields creates the fields of datastore create and update requests.
+func (m *storageModel) toAPIFields(ctx context.Context, diags *diag.Diagnostics) storage.DatastoreFields {
+	fields := storage.DatastoreFields{
+		Content:      setStrings(ctx, m.Content, diags),
+		Disable:      customBoolPointer(m.Disable),
+		Nodes:        setStrings(ctx, m.Nodes, diags),
+		PruneBackups: prunebackups.ToAPI(ctx, m.PruneBackups, diags),
+		Shared:       customBoolPointer(m.Shared),
+
+		Preallocation: m.Preallocation.ValueStringPointer(),
+		Path:          m.Path.ValueStringPointer(),
+		Options:       m.Options.ValueStringPointer(),
+
+		Server: m.Server.ValueStringPointer(),
+		Export: m.Export.ValueStringPointer(),
+
+		Domain:     m.Domain.ValueStringPointer(),
+		Share:      m.Share.ValueStringPointer(),
+		SMBVersion: m.SMBVersion.ValueStringPointer(),
+		Subdir:     m.Subdir.ValueStringPointer(),
+		Username:   m.Username.ValueStringPointer(),
+		Password:   m.Password.ValueStringPointer(),
+
+		VGName:     m.VGName.ValueStringPointer(),
+		SafeRemove: customBoolPointer(m.SafeRemove),
+		ThinPool:   m.ThinPool.ValueStringPointer(),
+
+		Pool:      m.Pool.ValueStringPointer(),
+		BlockSize: m.BlockSize.ValueStringPointer(),
+		Sparse:    customBoolPointer(m.Sparse),
+
+		Keyring:   m.Keyring.ValueStringPointer(),
+		KRBD:      customBoolPointer(m.KRBD),
+		Namespace: m.Namespace.ValueStringPointer(),
+		FSName:    m.FSName.ValueStringPointer(),
+
+		Datastore:     m.Datastore.ValueStringPointer(),
+		Fingerprint:   m.Fingerprint.ValueStringPointer(),
+		Port:          customInt64Pointer(m.Port),
+		EncryptionKey: m.EncryptionKey.ValueStringPointer(),
+	}
+
+	if monHosts := setStrings(ctx, m.MonHosts, diags); len(monHosts) > 0 {
+		s := strings.Join(monHosts, " ")
+		fields.MonHost = &s
+	}
+
+	// the path is computed for the network datastores, it is only known when set in the configuration
+	if m.Path.IsUnknown() {
+		fields.Path = nil
+	}
+
+	return fields
+}
+
+// toDelete returns the API names of the optional fields that have been removed from the configuration.
+// The credentials are handled separately, as they may be set by write-only attributes.
+func (m *storageModel) toDelete(state *storageModel) []string {
+	var toDelete []string
+
+	attribute.CheckDelete(m.Nodes, state.Nodes, &toDelete, "nodes")
+	attribute.CheckDelete(m.Shared, state.Shared, &toDelete, "shared")
+	attribute.CheckDelete(m.PruneBackups, state.PruneBackups, &toDelete, "prune-backups")
+	attribute.CheckDelete(m.Preallocation, state.Preallocation, &toDelete, "preallocation")
+	attribute.CheckDelete(m.Options, state.Options, &toDelete, "options")
+	attribute.CheckDelete(m.Domain, state.Domain, &toDelete, "domain")
+	attribute.CheckDelete(m.SMBVersion, state.SMBVersion, &toDelete, "smbversion")
+	attribute.CheckDelete(m.Subdir, state.Subdir, &toDelete, "subdir")
+	attribute.CheckDelete(m.Username, state.Username, &toDelete, "username")
+	attribute.CheckDelete(m.SafeRemove, state.SafeRemove, &toDelete, "saferemove")
+	attribute.CheckDelete(m.Pool, state.Pool, &toDelete, "pool")
+	attribute.CheckDelete(m.BlockSize, state.BlockSize, &toDelete, "blocksize")
+	attribute.CheckDelete(m.Sparse, state.Sparse, &toDelete, "sparse")
+	attribute.CheckDelete(m.MonHosts, state.MonHosts, &toDelete, "monhost")
+	attribute.CheckDelete(m.KRBD, state.KRBD, &toDelete, "krbd")
+	attribute.CheckDelete(m.Namespace, state.Namespace, &toDelete, "namespace")
+	attribute.CheckDelete(m.FSName, state.FSName, &toDelete, "fs-name")
+	attribute.CheckDelete(m.Fingerprint, state.Fingerprint, &toDelete, "fingerprint")
+	attribute.CheckDelete(m.Port, state.Port, &toDelete, "port")
+
+	return toDelete
+}
+
+// clearFixed removes the fields that can't be changed once the datastore has been created from an update request.
+// A change of these fields requires the replacement of the datastore.
+func (m *storageModel) clearFixed(fields *storage.DatastoreFields) {
+	fields.Path = nil
+	fields.Server = nil
+	fields.Export = nil
+	fields.Share = nil
+	fields.VGName = nil
+	fields.ThinPool = nil
+	fields.Datastore = nil
+
+	if m.Type.ValueString() == storage.TypeZFSPool {
+		fields.Pool = nil
+	}
+}
+
+// monHostsFromAPI splits the list of monitor hosts of the API, which may be separated by spaces, commas
+// or semicolons.
+func monHostsFromAPI(s *string) []string {
+	if s == nil {
+		return nil
+	}
+
+	return strings.FieldsFunc(*s, func(r rune) bool {
+		return r == ' ' || r == ',' || r == ';'
+	})
+}
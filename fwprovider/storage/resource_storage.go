@@ -0,0 +1,750 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/attribute"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/types/prunebackups"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/storage"
+)
+
+var (
+	_ resource.Resource                   = &storageResource{}
+	_ resource.ResourceWithConfigure      = &storageResource{}
+	_ resource.ResourceWithImportState    = &storageResource{}
+	_ resource.ResourceWithValidateConfig = &storageResource{}
+)
+
+//nolint:gochecknoglobals
+var (
+	// storageTypes are the supported types of datastores.
+	storageTypes = []string{
+		storage.TypeDir,
+		storage.TypeNFS,
+		storage.TypeCIFS,
+		storage.TypeLVM,
+		storage.TypeLVMThin,
+		storage.TypeZFSPool,
+		storage.TypeRBD,
+		storage.TypeCephFS,
+		storage.TypePBS,
+	}
+
+	// storageContentTypes are the types of content a datastore may hold.
+	storageContentTypes = []string{"backup", "images", "import", "iso", "rootdir", "snippets", "vztmpl"}
+
+	// storageTypeAttributes are the attributes that only apply to some types of datastores.
+	storageTypeAttributes = map[string][]string{
+		"shared":        {storage.TypeDir, storage.TypeLVM},
+		"prune_backups": {storage.TypeDir, storage.TypeNFS, storage.TypeCIFS, storage.TypeCephFS, storage.TypePBS},
+		"preallocation": {storage.TypeDir, storage.TypeNFS, storage.TypeCIFS},
+		"path":          {storage.TypeDir, storage.TypeNFS, storage.TypeCIFS, storage.TypeCephFS},
+		"options":       {storage.TypeNFS, storage.TypeCIFS, storage.TypeCephFS},
+		"server":        {storage.TypeNFS, storage.TypeCIFS, storage.TypePBS},
+		"export":        {storage.TypeNFS},
+		"share":         {storage.TypeCIFS},
+		"domain":        {storage.TypeCIFS},
+		"smb_version":   {storage.TypeCIFS},
+		"subdir":        {storage.TypeCIFS, storage.TypeCephFS},
+		"username":      {storage.TypeCIFS, storage.TypeRBD, storage.TypeCephFS, storage.TypePBS},
+
+		"password":            {storage.TypeCIFS, storage.TypePBS},
+		"password_wo":         {storage.TypeCIFS, storage.TypePBS},
+		"password_wo_version": {storage.TypeCIFS, storage.TypePBS},
+
+		"vg_name":     {storage.TypeLVM, storage.TypeLVMThin},
+		"thin_pool":   {storage.TypeLVMThin},
+		"safe_remove": {storage.TypeLVM},
+		"pool":        {storage.TypeZFSPool, storage.TypeRBD},
+		"block_size":  {storage.TypeZFSPool},
+		"sparse":      {storage.TypeZFSPool},
+		"mon_hosts":   {storage.TypeRBD, storage.TypeCephFS},
+
+		"keyring":            {storage.TypeRBD, storage.TypeCephFS},
+		"keyring_wo":         {storage.TypeRBD, storage.TypeCephFS},
+		"keyring_wo_version": {storage.TypeRBD, storage.TypeCephFS},
+
+		"krbd":        {storage.TypeRBD},
+		"namespace":   {storage.TypeRBD, storage.TypePBS},
+		"fs_name":     {storage.TypeCephFS},
+		"datastore":   {storage.TypePBS},
+		"fingerprint": {storage.TypePBS},
+		"port":        {storage.TypePBS},
+
+		"encryption_key":            {storage.TypePBS},
+		"encryption_key_wo":         {storage.TypePBS},
+		"encryption_key_wo_version": {storage.TypePBS},
+	}
+
+	// storageTypeRequiredAttributes are the attributes required by some types of datastores.
+	storageTypeRequiredAttributes = map[string][]string{
+		storage.TypeDir:     {"path"},
+		storage.TypeNFS:     {"server", "export"},
+		storage.TypeCIFS:    {"server", "share"},
+		storage.TypeLVM:     {"vg_name"},
+		storage.TypeLVMThin: {"vg_name", "thin_pool"},
+		storage.TypeZFSPool: {"pool"},
+		storage.TypePBS:     {"server", "datastore", "username"},
+	}
+)
+
+type storageResource struct {
+	client *storage.Client
+}
+
+// NewStorageResource creates a new resource for a datastore of the cluster storage configuration.
+func NewStorageResource() resource.Resource {
+	return &storageResource{}
+}
+
+func (r *storageResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_storage"
+}
+
+func (r *storageResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	cfg, ok := req.ProviderData.(config.Resource)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected config.Resource, got: %T", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = cfg.Client.Storage()
+}
+
+func (r *storageResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a datastore of the cluster storage configuration.",
+		MarkdownDescription: "Manages a datastore of the cluster storage configuration (`/etc/pve/storage.cfg`). " +
+			"Only the storage definition is managed, the content of the datastore is not deleted when it is " +
+			"removed.",
+		Attributes: map[string]schema.Attribute{
+			"id": attribute.ID(),
+			"name": schema.StringAttribute{
+				Description: "Unique name of the datastore.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(idRegexp, "must start with a lowercase letter, "+
+						"followed by lowercase letters, digits, `-`, `_` or `.`, and end with a letter or a digit"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Description: "Type of the datastore, one of `dir`, `nfs`, `cifs`, `lvm`, `lvmthin`, `zfspool`, " +
+					"`rbd`, `cephfs` or `pbs`.",
+				Required:   true,
+				Validators: []validator.String{stringvalidator.OneOf(storageTypes...)},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"content": schema.SetAttribute{
+				Description: "Types of content the datastore holds, e.g. `images`, `rootdir`, `iso`, `vztmpl`, " +
+					"`backup`, `snippets` or `import`. The default depends on the type of the datastore.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(storageContentTypes...)),
+				},
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"nodes": schema.SetAttribute{
+				Description: "Nodes the datastore is available on, all nodes when not set.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators:  []validator.Set{setvalidator.SizeAtLeast(1)},
+			},
+			"disable": schema.BoolAttribute{
+				Description: "Whether the datastore is disabled.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"shared": schema.BoolAttribute{
+				Description: "Whether the datastore is shared by all nodes, e.g. a directory on a cluster file " +
+					"system (`dir` and `lvm` datastores only). The other network datastores are always shared.",
+				Optional: true,
+			},
+			"prune_backups": prunebackups.ResourceAttribute("Retention options of the backups stored on the datastore " +
+				"(`dir`, `nfs`, `cifs`, `cephfs` and `pbs` datastores only)."),
+			"preallocation": schema.StringAttribute{
+				Description: "Preallocation mode of raw and qcow2 images, one of `off`, `metadata`, `falloc` or " +
+					"`full` (`dir`, `nfs` and `cifs` datastores only).",
+				Optional:   true,
+				Validators: []validator.String{stringvalidator.OneOf("off", "metadata", "falloc", "full")},
+			},
+			"path": schema.StringAttribute{
+				Description: "Path of the directory (`dir` datastores only, required), or mount point of the " +
+					"network file system (`nfs`, `cifs` and `cephfs` datastores, defaults to `/mnt/pve/<name>`).",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"options": schema.StringAttribute{
+				Description: "Mount options of the network file system (`nfs`, `cifs` and `cephfs` datastores only).",
+				Optional:    true,
+			},
+			"server": schema.StringAttribute{
+				Description: "Name or IP address of the server (`nfs`, `cifs` and `pbs` datastores only, required).",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"export": schema.StringAttribute{
+				Description: "Path of the NFS export (`nfs` datastores only, required).",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"share": schema.StringAttribute{
+				Description: "Name of the CIFS share (`cifs` datastores only, required).",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"domain": schema.StringAttribute{
+				Description: "Domain of the CIFS user (`cifs` datastores only).",
+				Optional:    true,
+			},
+			"smb_version": schema.StringAttribute{
+				Description: "SMB protocol version, one of `default`, `2.0`, `2.1`, `3`, `3.0` or `3.11` " +
+					"(`cifs` datastores only).",
+				Optional:   true,
+				Validators: []validator.String{stringvalidator.OneOf("default", "2.0", "2.1", "3", "3.0", "3.11")},
+			},
+			"subdir": schema.StringAttribute{
+				Description: "Subdirectory of the share or file system to mount (`cifs` and `cephfs` datastores only).",
+				Optional:    true,
+			},
+			"username": schema.StringAttribute{
+				Description: "User name used to connect to the server (`cifs`, `rbd`, `cephfs` and `pbs` datastores " +
+					"only, required for `pbs`), e.g. `backup@pbs` for a Proxmox Backup Server.",
+				Optional: true,
+			},
+			"password": schema.StringAttribute{
+				Description: "Password of the user (`cifs` and `pbs` datastores only, required for `pbs` unless " +
+					"`password_wo` is set).",
+				Optional:  true,
+				Sensitive: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("password_wo")),
+					stringvalidator.PreferWriteOnlyAttribute(path.MatchRoot("password_wo")),
+				},
+			},
+			"password_wo": schema.StringAttribute{
+				Description: "Password of the user, which is not stored in the state (requires Terraform 1.11 or " +
+					"later). The password is sent when the datastore is created, and when `password_wo_version` changes.",
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("password_wo_version")),
+				},
+			},
+			"password_wo_version": schema.Int64Attribute{
+				Description: "The version of the write-only password, the password is updated when the version changes.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("password_wo")),
+				},
+			},
+			"vg_name": schema.StringAttribute{
+				Description: "Name of the LVM volume group (`lvm` and `lvmthin` datastores only, required).",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"thin_pool": schema.StringAttribute{
+				Description: "Name of the LVM thin pool (`lvmthin` datastores only, required).",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"safe_remove": schema.BoolAttribute{
+				Description: "Whether to zero-out the data of removed volumes (`lvm` datastores only).",
+				Optional:    true,
+			},
+			"pool": schema.StringAttribute{
+				Description: "Name of the ZFS pool (`zfspool` datastores only, required), or of the Ceph pool " +
+					"(`rbd` datastores only).",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+							var storageType types.String
+
+							resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("type"), &storageType)...)
+
+							resp.RequiresReplace = storageType.ValueString() == storage.TypeZFSPool
+						},
+						"The ZFS pool of a datastore can't be changed.",
+						"The ZFS pool of a datastore can't be changed.",
+					),
+				},
+			},
+			"block_size": schema.StringAttribute{
+				Description: "Block size of the ZFS volumes, e.g. `16k` (`zfspool` datastores only).",
+				Optional:    true,
+			},
+			"sparse": schema.BoolAttribute{
+				Description: "Whether to use sparse ZFS volumes (`zfspool` datastores only).",
+				Optional:    true,
+			},
+			"mon_hosts": schema.SetAttribute{
+				Description: "Monitors of an external Ceph cluster (`rbd` and `cephfs` datastores only). " +
+					"The monitors of the local Ceph cluster are used when not set.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators:  []validator.Set{setvalidator.SizeAtLeast(1)},
+			},
+			"keyring": schema.StringAttribute{
+				Description: "Keyring (`rbd` datastores) or secret (`cephfs` datastores) used to authenticate to an " +
+					"external Ceph cluster.",
+				Optional:  true,
+				Sensitive: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("keyring_wo")),
+					stringvalidator.PreferWriteOnlyAttribute(path.MatchRoot("keyring_wo")),
+				},
+			},
+			"keyring_wo": schema.StringAttribute{
+				Description: "Keyring or secret, which is not stored in the state (requires Terraform 1.11 or later). " +
+					"The keyring is sent when the datastore is created, and when `keyring_wo_version` changes.",
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("keyring_wo_version")),
+				},
+			},
+			"keyring_wo_version": schema.Int64Attribute{
+				Description: "The version of the write-only keyring, the keyring is updated when the version changes.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("keyring_wo")),
+				},
+			},
+			"krbd": schema.BoolAttribute{
+				Description: "Whether to access the images with the kernel RBD module (`rbd` datastores only).",
+				Optional:    true,
+			},
+			"namespace": schema.StringAttribute{
+				Description: "Namespace of the Ceph pool or of the Proxmox Backup Server datastore " +
+					"(`rbd` and `pbs` datastores only).",
+				Optional: true,
+			},
+			"fs_name": schema.StringAttribute{
+				Description: "Name of the Ceph file system (`cephfs` datastores only).",
+				Optional:    true,
+			},
+			"datastore": schema.StringAttribute{
+				Description: "Name of the Proxmox Backup Server datastore (`pbs` datastores only, required).",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"fingerprint": schema.StringAttribute{
+				Description: "SHA-256 fingerprint of the certificate of the Proxmox Backup Server, when it is not " +
+					"trusted (`pbs` datastores only).",
+				Optional:  true,
+				Sensitive: true,
+			},
+			"port": schema.Int64Attribute{
+				Description: "Port of the Proxmox Backup Server API (`pbs` datastores only).",
+				Optional:    true,
+				Validators:  []validator.Int64{int64validator.Between(1, 65535)},
+			},
+			"encryption_key": schema.StringAttribute{
+				Description: "Client-side encryption key of the backups, or `autogen` to generate one " +
+					"(`pbs` datastores only).",
+				Optional:  true,
+				Sensitive: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("encryption_key_wo")),
+					stringvalidator.PreferWriteOnlyAttribute(path.MatchRoot("encryption_key_wo")),
+				},
+			},
+			"encryption_key_wo": schema.StringAttribute{
+				Description: "Client-side encryption key, which is not stored in the state (requires Terraform 1.11 " +
+					"or later). The key is sent when the datastore is created, and when `encryption_key_wo_version` " +
+					"changes.",
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("encryption_key_wo_version")),
+				},
+			},
+			"encryption_key_wo_version": schema.Int64Attribute{
+				Description: "The version of the write-only encryption key, the key is updated when the version " +
+					"changes.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("encryption_key_wo")),
+				},
+			},
+		},
+	}
+}
+
+func (r *storageResource) ValidateConfig(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	var data storageModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || data.Type.IsNull() || data.Type.IsUnknown() {
+		return
+	}
+
+	storageType := data.Type.ValueString()
+
+	validateTypeAttributes(
+		storageType,
+		data.typeSpecificValues(),
+		storageTypeAttributes,
+		storageTypeRequiredAttributes,
+		&resp.Diagnostics,
+	)
+
+	if storageType == storage.TypePBS && data.Password.IsNull() && data.PasswordWO.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("password"),
+			"Missing Required Attribute",
+			fmt.Sprintf("One of the attributes \"password\" or \"password_wo\" is required by datastores of type %q.",
+				storageType),
+		)
+	}
+}
+
+// typeSpecificValues returns the values of the attributes that only apply to some types of datastores.
+func (m *storageModel) typeSpecificValues() map[string]attr.Value {
+	return map[string]attr.Value{
+		"shared":                    m.Shared,
+		"prune_backups":             m.PruneBackups,
+		"preallocation":             m.Preallocation,
+		"path":                      m.Path,
+		"options":                   m.Options,
+		"server":                    m.Server,
+		"export":                    m.Export,
+		"share":                     m.Share,
+		"domain":                    m.Domain,
+		"smb_version":               m.SMBVersion,
+		"subdir":                    m.Subdir,
+		"username":                  m.Username,
+		"password":                  m.Password,
+		"password_wo":               m.PasswordWO,
+		"password_wo_version":       m.PasswordWOVersion,
+		"vg_name":                   m.VGName,
+		"thin_pool":                 m.ThinPool,
+		"safe_remove":               m.SafeRemove,
+		"pool":                      m.Pool,
+		"block_size":                m.BlockSize,
+		"sparse":                    m.Sparse,
+		"mon_hosts":                 m.MonHosts,
+		"keyring":                   m.Keyring,
+		"keyring_wo":                m.KeyringWO,
+		"keyring_wo_version":        m.KeyringWOVersion,
+		"krbd":                      m.KRBD,
+		"namespace":                 m.Namespace,
+		"fs_name":                   m.FSName,
+		"datastore":                 m.Datastore,
+		"fingerprint":               m.Fingerprint,
+		"port":                      m.Port,
+		"encryption_key":            m.EncryptionKey,
+		"encryption_key_wo":         m.EncryptionKeyWO,
+		"encryption_key_wo_version": m.EncryptionKeyWOVersion,
+	}
+}
+
+// secretAttribute is a credential of a datastore, which may be set by a write-only attribute.
+type secretAttribute struct {
+	value     types.String
+	version   types.Int64
+	writeOnly string
+	apiName   string
+	field     **string
+}
+
+// secretAttributes returns the credentials of the datastore, bound to the fields of an API request.
+func (m *storageModel) secretAttributes(fields *storage.DatastoreFields) []secretAttribute {
+	return []secretAttribute{
+		{m.Password, m.PasswordWOVersion, "password_wo", "password", &fields.Password},
+		{m.Keyring, m.KeyringWOVersion, "keyring_wo", "keyring", &fields.Keyring},
+		{m.EncryptionKey, m.EncryptionKeyWOVersion, "encryption_key_wo", "encryption-key", &fields.EncryptionKey},
+	}
+}
+
+func (r *storageResource) read(ctx context.Context, model *storageModel, diags *diag.Diagnostics) bool {
+	data, err := r.client.GetDatastore(ctx, model.ID.ValueString())
+	if err == nil && data == nil {
+		err = api.ErrNoDataObjectInResponse
+	}
+
+	if err != nil {
+		if errors.Is(err, api.ErrResourceDoesNotExist) {
+			return false
+		}
+
+		diags.AddError(
+			"Unable to Read Storage",
+			"An unexpected error occurred while reading the datastore.\n\n"+
+				"Error: "+err.Error(),
+		)
+
+		return false
+	}
+
+	model.importFromAPI(ctx, data, diags)
+
+	return true
+}
+
+func (r *storageResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state storageModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found := r.read(ctx, &state, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *storageResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan storageModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reqData := &storage.DatastoreCreateRequestBody{
+		DatastoreFields: plan.toAPIFields(ctx, &resp.Diagnostics),
+		ID:              plan.Name.ValueString(),
+		Type:            plan.Type.ValueString(),
+	}
+
+	// write-only values are only available in the configuration
+	for _, s := range plan.secretAttributes(&reqData.DatastoreFields) {
+		if value := writeOnlyValue(ctx, req.Config, s.writeOnly, &resp.Diagnostics); value != nil {
+			*s.field = value
+		}
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.CreateDatastore(ctx, reqData)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create Storage",
+			"An unexpected error occurred while creating the datastore.\n\n"+
+				"Error: "+err.Error(),
+		)
+
+		return
+	}
+
+	plan.ID = plan.Name
+
+	r.readAfterChange(ctx, &plan, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+func (r *storageResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var plan, state storageModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reqData := &storage.DatastoreUpdateRequestBody{
+		DatastoreFields: plan.toAPIFields(ctx, &resp.Diagnostics),
+		Delete:          plan.toDelete(&state),
+	}
+
+	plan.clearFixed(&reqData.DatastoreFields)
+
+	stateSecrets := state.secretAttributes(&storage.DatastoreFields{})
+
+	for i, s := range plan.secretAttributes(&reqData.DatastoreFields) {
+		switch {
+		case !s.version.Equal(stateSecrets[i].version):
+			// the write-only value is only sent when its version changes
+			value := writeOnlyValue(ctx, req.Config, s.writeOnly, &resp.Diagnostics)
+
+			switch {
+			case value != nil:
+				*s.field = value
+			case s.value.IsNull():
+				reqData.Delete = append(reqData.Delete, s.apiName)
+			}
+		case s.value.Equal(stateSecrets[i].value):
+			// an unchanged value is not sent again, e.g. to not generate a new `autogen` encryption key
+			*s.field = nil
+		default:
+			attribute.CheckDelete(s.value, stateSecrets[i].value, &reqData.Delete, s.apiName)
+		}
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.UpdateDatastore(ctx, state.ID.ValueString(), reqData)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Update Storage",
+			"An unexpected error occurred while updating the datastore.\n\n"+
+				"Error: "+err.Error(),
+		)
+
+		return
+	}
+
+	r.readAfterChange(ctx, &plan, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// readAfterChange reads the datastore after it has been created or updated.
+func (r *storageResource) readAfterChange(ctx context.Context, model *storageModel, diags *diag.Diagnostics) {
+	if !r.read(ctx, model, diags) && !diags.HasError() {
+		diags.AddError(
+			"Storage Not Found",
+			fmt.Sprintf("The datastore %q could not be found after it has been changed.", model.ID.ValueString()),
+		)
+	}
+}
+
+func (r *storageResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var state storageModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteDatastore(ctx, state.ID.ValueString())
+	if err != nil && !errors.Is(err, api.ErrResourceDoesNotExist) {
+		resp.Diagnostics.AddError(
+			"Unable to Delete Storage",
+			"An unexpected error occurred while deleting the datastore.\n\n"+
+				"Error: "+err.Error(),
+		)
+	}
+}
+
+func (r *storageResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
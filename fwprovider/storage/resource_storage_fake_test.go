@@ -0,0 +1,112 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package storage_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/storage"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/test"
+)
+
+// TestResourceStorageShared checks that the `shared` flag PVE reports for the network datastores is not read
+// back into the state, where the attribute cannot be set.
+func TestResourceStorageShared(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	te := test.InitFakeEnvironment(t)
+
+	r, ok := storage.NewStorageResource().(resource.ResourceWithConfigure)
+	require.True(t, ok)
+
+	var configureResp resource.ConfigureResponse
+
+	r.Configure(ctx, resource.ConfigureRequest{ProviderData: te.ResourceConfig()}, &configureResp)
+	require.False(t, configureResp.Diagnostics.HasError(), "%v", configureResp.Diagnostics)
+
+	var schemaResp resource.SchemaResponse
+
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError(), "%v", schemaResp.Diagnostics)
+
+	s := schemaResp.Schema
+
+	objType, ok := s.Type().TerraformType(ctx).(tftypes.Object)
+	require.True(t, ok)
+
+	tests := []struct {
+		name     string
+		values   map[string]tftypes.Value
+		expected types.Bool
+	}{
+		{"nfs", map[string]tftypes.Value{
+			"type":   tftypes.NewValue(tftypes.String, "nfs"),
+			"server": tftypes.NewValue(tftypes.String, "10.0.0.10"),
+			"export": tftypes.NewValue(tftypes.String, "/export/backups"),
+		}, types.BoolNull()},
+		{"dir", map[string]tftypes.Value{
+			"type":   tftypes.NewValue(tftypes.String, "dir"),
+			"path":   tftypes.NewValue(tftypes.String, "/mnt/data"),
+			"shared": tftypes.NewValue(tftypes.Bool, true),
+		}, types.BoolValue(true)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			values := map[string]tftypes.Value{}
+
+			for name, typ := range objType.AttributeTypes {
+				values[name] = tftypes.NewValue(typ, nil)
+
+				if attr, ok := s.Attributes[name]; ok && attr.IsComputed() {
+					values[name] = tftypes.NewValue(typ, tftypes.UnknownValue)
+				}
+			}
+
+			values["name"] = tftypes.NewValue(tftypes.String, tt.name)
+
+			for name, v := range tt.values {
+				values[name] = v
+			}
+
+			raw := tftypes.NewValue(objType, values)
+
+			createResp := resource.CreateResponse{State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(objType, nil)}}
+
+			r.Create(ctx, resource.CreateRequest{
+				Config: tfsdk.Config{Schema: s, Raw: raw},
+				Plan:   tfsdk.Plan{Schema: s, Raw: raw},
+			}, &createResp)
+			require.False(t, createResp.Diagnostics.HasError(), "%v", createResp.Diagnostics)
+
+			var shared types.Bool
+
+			require.False(t, createResp.State.GetAttribute(ctx, path.Root("shared"), &shared).HasError())
+			assert.Equal(t, tt.expected, shared)
+
+			readResp := resource.ReadResponse{State: createResp.State}
+
+			r.Read(ctx, resource.ReadRequest{State: createResp.State}, &readResp)
+			require.False(t, readResp.Diagnostics.HasError(), "%v", readResp.Diagnostics)
+
+			require.False(t, readResp.State.GetAttribute(ctx, path.Root("shared"), &shared).HasError())
+			assert.Equal(t, tt.expected, shared)
+		})
+	}
+}
@@ -0,0 +1,231 @@
+//go:build acceptance || all
+
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package storage_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/test"
+)
+
+func TestAccResourceStorage(t *testing.T) {
+	t.Parallel()
+
+	te := test.InitFakeEnvironment(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_storage" "nfs" {
+					name    = "nfs-backups"
+					type    = "nfs"
+					server  = "10.0.0.10"
+					export  = "/export/backups"
+					content = ["backup", "iso"]
+					nodes   = ["{{.NodeName}}"]
+
+					prune_backups = {
+						keep_last  = 3
+						keep_daily = 7
+					}
+				}
+
+				resource "proxmox_virtual_environment_storage" "dir" {
+					name   = "data"
+					type   = "dir"
+					path   = "/mnt/data"
+					shared = true
+				}`),
+				Check: resource.ComposeTestCheckFunc(
+					test.ResourceAttributes("proxmox_virtual_environment_storage.nfs", map[string]string{
+						"id":                       "nfs-backups",
+						"path":                     "/mnt/pve/nfs-backups",
+						"content.#":                "2",
+						"nodes.#":                  "1",
+						"disable":                  "false",
+						"prune_backups.keep_last":  "3",
+						"prune_backups.keep_daily": "7",
+					}),
+					test.NoResourceAttributesSet("proxmox_virtual_environment_storage.nfs", []string{
+						"prune_backups.keep_all",
+					}),
+					test.ResourceAttributes("proxmox_virtual_environment_storage.dir", map[string]string{
+						"id":        "data",
+						"content.#": "2",
+						"shared":    "true",
+					}),
+				),
+			},
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_storage" "nfs" {
+					name    = "nfs-backups"
+					type    = "nfs"
+					server  = "10.0.0.10"
+					export  = "/export/backups"
+					content = ["backup"]
+					options = "vers=4.2"
+					disable = true
+				}
+
+				resource "proxmox_virtual_environment_storage" "dir" {
+					name = "data"
+					type = "dir"
+					path = "/mnt/data"
+				}`),
+				Check: resource.ComposeTestCheckFunc(
+					test.ResourceAttributes("proxmox_virtual_environment_storage.nfs", map[string]string{
+						"content.#": "1",
+						"options":   "vers=4.2",
+						"disable":   "true",
+					}),
+					test.NoResourceAttributesSet("proxmox_virtual_environment_storage.nfs", []string{
+						"nodes.#",
+						"prune_backups",
+					}),
+					test.NoResourceAttributesSet("proxmox_virtual_environment_storage.dir", []string{"shared"}),
+				),
+			},
+			{
+				ResourceName:      "proxmox_virtual_environment_storage.nfs",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceStorageCredentials(t *testing.T) {
+	t.Parallel()
+
+	te := test.InitFakeEnvironment(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_storage" "pbs" {
+					name           = "pbs"
+					type           = "pbs"
+					server         = "pbs.example.com"
+					datastore      = "store1"
+					username       = "backup@pbs"
+					password       = "secret"
+					fingerprint    = "ab:cd:ef"
+					encryption_key = "autogen"
+				}
+
+				resource "proxmox_virtual_environment_storage" "cifs" {
+					name                = "cifs"
+					type                = "cifs"
+					server              = "fileserver.example.com"
+					share               = "images"
+					username            = "proxmox"
+					password_wo         = "secret-1"
+					password_wo_version = 1
+				}`),
+				Check: resource.ComposeTestCheckFunc(
+					test.ResourceAttributes("proxmox_virtual_environment_storage.pbs", map[string]string{
+						"content.#":      "1",
+						"password":       "secret",
+						"fingerprint":    "ab:cd:ef",
+						"encryption_key": "autogen",
+					}),
+					test.NoResourceAttributesSet("proxmox_virtual_environment_storage.cifs", []string{
+						"password",
+						"password_wo",
+					}),
+				),
+			},
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_storage" "pbs" {
+					name           = "pbs"
+					type           = "pbs"
+					server         = "pbs.example.com"
+					datastore      = "store1"
+					username       = "backup@pbs"
+					password       = "secret"
+					encryption_key = "autogen"
+					port           = 8008
+				}
+
+				resource "proxmox_virtual_environment_storage" "cifs" {
+					name                = "cifs"
+					type                = "cifs"
+					server              = "fileserver.example.com"
+					share               = "images"
+					username            = "proxmox"
+					password_wo         = "secret-2"
+					password_wo_version = 2
+				}`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("proxmox_virtual_environment_storage.pbs", "port", "8008"),
+					test.NoResourceAttributesSet("proxmox_virtual_environment_storage.pbs", []string{"fingerprint"}),
+					resource.TestCheckResourceAttr("proxmox_virtual_environment_storage.cifs", "password_wo_version", "2"),
+				),
+			},
+			{
+				ResourceName:            "proxmox_virtual_environment_storage.pbs",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password", "encryption_key"},
+			},
+		},
+	})
+}
+
+func TestAccResourceStorageValidation(t *testing.T) {
+	t.Parallel()
+
+	te := test.InitFakeEnvironment(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_storage" "test" {
+					name   = "nfs"
+					type   = "nfs"
+					server = "10.0.0.10"
+					export = "/export"
+					shared = true
+				}`),
+				ExpectError: regexp.MustCompile(`only supported by datastores of type`),
+			},
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_storage" "test" {
+					name    = "thin"
+					type    = "lvmthin"
+					vg_name = "pve"
+				}`),
+				ExpectError: regexp.MustCompile(`"thin_pool" is required by datastores of type "lvmthin"`),
+			},
+			{
+				Config: te.RenderConfig(`
+				resource "proxmox_virtual_environment_storage" "test" {
+					name      = "pbs"
+					type      = "pbs"
+					server    = "pbs.example.com"
+					datastore = "store1"
+					username  = "backup@pbs"
+				}`),
+				ExpectError: regexp.MustCompile(`"password" or "password_wo" is required`),
+			},
+		},
+	})
+}
@@ -0,0 +1,70 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmoxtf/resource"
+)
+
+// TestCassetteReplayUser runs the CRUD functions of the user resource against the recorded API interactions
+// in testdata/cassettes/TestCassetteReplayUser.json, without access to a Proxmox VE cluster.
+//
+// The fixture was recorded against the fake server of the proxmox/fake package, not against a real Proxmox VE,
+// so it only exercises the recording and the replay, and its responses, e.g. the error bodies, follow the fake
+// server. Record it again against a real cluster with PROXMOX_VE_ACC_CASSETTE=record.
+func TestCassetteReplayUser(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	te := InitReplayEnvironment(t)
+	meta := te.ProviderConfiguration()
+	r := resource.User()
+
+	// the same user ID is generated for the recording and the replay
+	userID := fmt.Sprintf("%s@pve", te.Faker.Username())
+
+	raw := map[string]any{
+		"user_id":  userID,
+		"comment":  "created",
+		"password": te.Faker.Password(true, true, true, true, false, 12),
+	}
+
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+
+	diags := r.CreateContext(ctx, d, meta)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, userID, d.Id())
+	assert.Equal(t, "created", d.Get("comment"))
+
+	raw["comment"] = "updated"
+
+	diff, err := r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(raw), meta)
+	require.NoError(t, err)
+
+	updated, err := schema.InternalMap(r.Schema).Data(d.State(), diff)
+	require.NoError(t, err)
+
+	diags = r.UpdateContext(ctx, updated, meta)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "updated", updated.Get("comment"))
+
+	diags = r.DeleteContext(ctx, updated, meta)
+	require.False(t, diags.HasError(), "%v", diags)
+
+	_, err = te.AccessClient().GetUser(ctx, userID)
+	require.ErrorIs(t, err, api.ErrResourceDoesNotExist)
+}
@@ -14,6 +14,8 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/fake"
 )
 
 func TestAccDatasourceVersion(t *testing.T) {
@@ -42,3 +44,23 @@ func TestAccDatasourceVersion(t *testing.T) {
 		},
 	})
 }
+
+func TestAccDatasourceVersionFakeServer(t *testing.T) {
+	te := InitFakeEnvironment(t)
+
+	datasourceName := "data.proxmox_virtual_environment_version.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: te.RenderConfig(`data "proxmox_virtual_environment_version" "test" {}`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(datasourceName, "release", "8.3"),
+					resource.TestCheckResourceAttr(datasourceName, "repository_id", "fake"),
+					resource.TestCheckResourceAttr(datasourceName, "version", fake.Version),
+				),
+			},
+		},
+	})
+}
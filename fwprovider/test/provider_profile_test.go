@@ -0,0 +1,108 @@
+//go:build acceptance || all
+
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/fake"
+)
+
+func TestAccProviderProfileFakeServer(t *testing.T) {
+	te := InitFakeEnvironment(t)
+
+	configFile := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(configFile, []byte(fmt.Sprintf(`
+[default]
+endpoint = https://pve.invalid:8006/
+
+[fake]
+endpoint = %s
+insecure = true
+api_token = %s
+ssh_node.%s = 127.0.0.1
+
+[unknown-token]
+endpoint = %s
+insecure = true
+api_token = root@pam!unknown=00000000-0000-0000-0000-000000000000
+`, te.fakeServer.Endpoint(), fake.DefaultAPIToken, fake.DefaultNodeName, te.fakeServer.Endpoint())), 0o600))
+
+	// the environment variables take precedence over the profile
+	t.Setenv("PROXMOX_VE_CONFIG", configFile)
+	t.Setenv("PROXMOX_VE_ENDPOINT", "")
+	t.Setenv("PROXMOX_VE_INSECURE", "")
+	t.Setenv("PROXMOX_VE_API_TOKEN", "")
+
+	datasourceName := "data.proxmox_virtual_environment_version.test"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: te.AccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "proxmox" {
+	profile  = "fake"
+	username = ""
+	password = ""
+}
+
+data "proxmox_virtual_environment_version" "test" {}
+
+# served by the SDK provider
+data "proxmox_virtual_environment_nodes" "test" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(datasourceName, "version", fake.Version),
+					resource.TestCheckResourceAttr("data.proxmox_virtual_environment_nodes.test", "names.0",
+						fake.DefaultNodeName),
+				),
+			},
+			{
+				// the credentials of the profile are not mixed with the configured ones
+				Config: fmt.Sprintf(`
+provider "proxmox" {
+	profile  = "unknown-token"
+	username = "%s"
+	password = "%s"
+}
+
+# served by the framework provider
+resource "proxmox_virtual_environment_storage" "test" {
+	name = "profile"
+	type = "dir"
+	path = "/mnt/profile"
+}
+
+# served by the SDK provider
+data "proxmox_virtual_environment_nodes" "test" {}`, fake.DefaultUsername, fake.DefaultPassword),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("proxmox_virtual_environment_storage.test", "id", "profile"),
+					resource.TestCheckResourceAttr("data.proxmox_virtual_environment_nodes.test", "names.0",
+						fake.DefaultNodeName),
+				),
+			},
+			{
+				Config: `
+provider "proxmox" {
+	profile  = "missing"
+}
+
+data "proxmox_virtual_environment_version" "test" {}`,
+				ExpectError: regexp.MustCompile(`profile "missing" is not found`),
+			},
+		},
+	})
+}
@@ -0,0 +1,32 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/stretchr/testify/require"
+)
+
+// TestProviderSchemaParity ensures the provider schemas of the framework and SDKv2 providers are identical,
+// otherwise the mux server refuses to serve them.
+func TestProviderSchemaParity(t *testing.T) {
+	t.Parallel()
+
+	server, err := muxProviders(t)["proxmox"]()
+	require.NoError(t, err)
+
+	resp, err := server.GetProviderSchema(t.Context(), &tfprotov6.GetProviderSchemaRequest{})
+	require.NoError(t, err)
+
+	for _, d := range resp.Diagnostics {
+		require.NotEqual(t, tfprotov6.DiagnosticSeverityError, d.Severity, "%s: %s", d.Summary, d.Detail)
+	}
+
+	require.NotNil(t, resp.Provider)
+}
@@ -11,11 +11,9 @@ package test
 import (
 	"context"
 	"fmt"
-	"math/rand"
 	"testing"
 	"time"
 
-	"github.com/brianvoe/gofakeit/v7"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/stretchr/testify/require"
@@ -33,9 +31,9 @@ func TestAccResourceContainer(t *testing.T) {
 
 	te := InitEnvironment(t)
 
-	imageFileName := gofakeit.Word() + "-ubuntu-23.04-standard_23.04-1_amd64.tar.zst"
-	accTestContainerID := 100000 + rand.Intn(99999)
-	accTestContainerIDClone := 100000 + rand.Intn(99999)
+	imageFileName := te.Faker.Word() + "-ubuntu-23.04-standard_23.04-1_amd64.tar.zst"
+	accTestContainerID := 100000 + te.Faker.IntN(99999)
+	accTestContainerIDClone := 100000 + te.Faker.IntN(99999)
 
 	te.AddTemplateVars(map[string]interface{}{
 		"ImageFileName":        imageFileName,
@@ -8,12 +8,19 @@ package test
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"hash/fnv"
 	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
 	"text/template"
 
+	"github.com/brianvoe/gofakeit/v7"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
@@ -23,11 +30,17 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/bpg/terraform-provider-proxmox/fwprovider"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/config"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/access"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cassette"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/fake"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/storage"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/ssh"
+	"github.com/bpg/terraform-provider-proxmox/proxmoxtf"
 	sdkV2provider "github.com/bpg/terraform-provider-proxmox/proxmoxtf/provider"
 	"github.com/bpg/terraform-provider-proxmox/utils"
 )
@@ -42,8 +55,26 @@ type Environment struct {
 	AccProviders          map[string]func() (tfprotov6.ProviderServer, error)
 	once                  sync.Once
 	c                     api.Client
+	fakeServer            *fake.Server
+	cassette              *cassette.Cassette
 	CloudImagesServer     string
 	ContainerImagesServer string
+
+	// Faker generates the random names and IDs of the test. It is seeded with the name of the test when
+	// the API interactions are recorded or replayed, so the requests of the replay match the recording.
+	Faker *gofakeit.Faker
+}
+
+// cassetteDir is the directory of the recorded API interactions, relative to the test package.
+const cassetteDir = "testdata/cassettes"
+
+// replayEnv contains the placeholder connection settings used when replaying cassettes,
+// so the tests do not need a Proxmox VE cluster or credentials.
+var replayEnv = map[string]string{
+	"PROXMOX_VE_ENDPOINT":  "https://pve.cassette.invalid:8006/",
+	"PROXMOX_VE_USERNAME":  "root@pam",
+	"PROXMOX_VE_PASSWORD":  "password",
+	"PROXMOX_VE_API_TOKEN": "root@pam!cassette=00000000-0000-0000-0000-000000000000",
 }
 
 // RenderConfigOption is a configuration option for rendering the provider configuration.
@@ -53,6 +84,26 @@ type RenderConfigOption interface {
 
 type renderConfig struct {
 	providerConfig string
+	replaying      bool
+}
+
+// getenv returns the value of the environment variable, or its placeholder when replaying cassettes.
+func (r *renderConfig) getenv(name string) string {
+	if v, ok := replayEnv[name]; ok && r.replaying {
+		return v
+	}
+
+	return utils.GetAnyStringEnv(name)
+}
+
+// returns the endpoint line of the provider config, the endpoint is taken from the environment
+// unless cassettes are replayed.
+func (r *renderConfig) endpoint() string {
+	if !r.replaying {
+		return ""
+	}
+
+	return fmt.Sprintf("\tendpoint = \"%s\"\n", r.getenv("PROXMOX_VE_ENDPOINT"))
 }
 
 // returns the ssh configuration section of the provider config.
@@ -64,7 +115,7 @@ func (r *renderConfig) ssh() string {
 
 	nodeAddress := utils.GetAnyStringEnv("PROXMOX_VE_ACC_NODE_SSH_ADDRESS")
 	if nodeAddress == "" {
-		endpoint := utils.GetAnyStringEnv("PROXMOX_VE_ENDPOINT")
+		endpoint := r.getenv("PROXMOX_VE_ENDPOINT")
 
 		u, err := url.Parse(endpoint)
 		if err != nil {
@@ -98,16 +149,16 @@ func WithRootUser() RenderConfigOption {
 type rootUserConfigOption struct{}
 
 func (o *rootUserConfigOption) apply(rc *renderConfig) error {
-	if utils.GetAnyStringEnv("PROXMOX_VE_USERNAME") == "" || utils.GetAnyStringEnv("PROXMOX_VE_PASSWORD") == "" {
+	if rc.getenv("PROXMOX_VE_USERNAME") == "" || rc.getenv("PROXMOX_VE_PASSWORD") == "" {
 		return fmt.Errorf("PROXMOX_VE_USERNAME and PROXMOX_VE_PASSWORD must be set")
 	}
 
 	rootUser := fmt.Sprintf("\tusername = \"%s\"\n\tpassword = \"%s\"\n\tapi_token = \"\"",
-		utils.GetAnyStringEnv("PROXMOX_VE_USERNAME"),
-		utils.GetAnyStringEnv("PROXMOX_VE_PASSWORD"),
+		rc.getenv("PROXMOX_VE_USERNAME"),
+		rc.getenv("PROXMOX_VE_PASSWORD"),
 	)
 
-	rc.providerConfig = fmt.Sprintf("provider \"proxmox\" {\n%s\n%s\n}", rootUser, rc.ssh())
+	rc.providerConfig = fmt.Sprintf("provider \"proxmox\" {\n%s%s\n%s\n}", rc.endpoint(), rootUser, rc.ssh())
 
 	return nil
 }
@@ -120,22 +171,129 @@ func WithAPIToken() RenderConfigOption {
 type apiTokenConfigOption struct{}
 
 func (o *apiTokenConfigOption) apply(rc *renderConfig) error {
-	if utils.GetAnyStringEnv("PROXMOX_VE_API_TOKEN") == "" {
+	if rc.getenv("PROXMOX_VE_API_TOKEN") == "" {
 		return fmt.Errorf("PROXMOX_VE_API_TOKEN must be set")
 	}
 
 	apiToken := fmt.Sprintf("\tapi_token = \"%s\"\n\tusername = \"\"\n\tpassword = \"\"",
-		utils.GetAnyStringEnv("PROXMOX_VE_API_TOKEN"))
+		rc.getenv("PROXMOX_VE_API_TOKEN"))
 
-	rc.providerConfig = fmt.Sprintf("provider \"proxmox\" {\n%s\n%s\n}", apiToken, rc.ssh())
+	rc.providerConfig = fmt.Sprintf("provider \"proxmox\" {\n%s%s\n%s\n}", rc.endpoint(), apiToken, rc.ssh())
+
+	return nil
+}
+
+// WithFakeServer returns a configuration option that points the provider to the given fake Proxmox VE API server.
+func WithFakeServer(srv *fake.Server) RenderConfigOption {
+	return &fakeServerConfigOption{srv: srv}
+}
+
+type fakeServerConfigOption struct {
+	srv *fake.Server
+}
+
+func (o *fakeServerConfigOption) apply(rc *renderConfig) error {
+	if o.srv == nil {
+		return fmt.Errorf("fake server must be started")
+	}
+
+	rc.providerConfig = fmt.Sprintf(`provider "proxmox" {
+	endpoint  = "%s"
+	insecure  = true
+	api_token = "%s"
+	username  = ""
+	password  = ""
+	ssh {
+		node {
+			name    = "%s"
+			address = "127.0.0.1"
+		}
+	}
+}`, o.srv.Endpoint(), fake.DefaultAPIToken, fake.DefaultNodeName)
 
 	return nil
 }
 
 // InitEnvironment initializes a new test environment for acceptance tests.
+//
+// When PROXMOX_VE_ACC_CASSETTE is set to "record", the API interactions of the test are recorded to a
+// sanitized fixture in testdata/cassettes once the test passes. When it is set to "replay", the interactions
+// are served from the fixture without access to a Proxmox VE cluster, and tests without a fixture are skipped.
+// Interactions over SSH are not recorded, so tests that depend on SSH cannot be replayed.
+// The random inputs of the tests must be generated with the Faker of the environment to be replayed.
 func InitEnvironment(t *testing.T) *Environment {
 	t.Helper()
 
+	te := newEnvironment(t, nil)
+
+	mode := cassette.Mode(utils.GetAnyStringEnv("PROXMOX_VE_ACC_CASSETTE"))
+	if mode == "" {
+		te.AccProviders = muxProviders(t)
+
+		return te
+	}
+
+	err := te.useCassette(mode)
+	if errors.Is(err, os.ErrNotExist) {
+		t.Skipf("no recorded API interactions for %s", t.Name())
+	}
+
+	require.NoError(t, err)
+
+	return te
+}
+
+// InitReplayEnvironment initializes a new test environment replaying the API interactions recorded in the
+// fixture of the test, so the test runs offline without a Proxmox VE cluster. The interactions are recorded
+// again when PROXMOX_VE_ACC_CASSETTE is set to "record". Unlike InitEnvironment, a missing fixture fails the test.
+func InitReplayEnvironment(t *testing.T) *Environment {
+	t.Helper()
+
+	te := newEnvironment(t, nil)
+
+	mode := cassette.ModeReplay
+	if utils.GetAnyStringEnv("PROXMOX_VE_ACC_CASSETTE") == string(cassette.ModeRecord) {
+		mode = cassette.ModeRecord
+	}
+
+	require.NoError(t, te.useCassette(mode))
+
+	return te
+}
+
+// useCassette records or replays the API interactions of the test, and seeds the Faker with the test name.
+func (e *Environment) useCassette(mode cassette.Mode) error {
+	t := e.t
+
+	name := strings.NewReplacer("/", "_", " ", "_").Replace(t.Name())
+
+	c, err := cassette.Open(filepath.Join(cassetteDir, name+".json"), mode)
+	if err != nil {
+		return fmt.Errorf("failed to open the cassette of %s: %w", t.Name(), err)
+	}
+
+	if mode == cassette.ModeRecord {
+		t.Cleanup(func() {
+			if !t.Failed() {
+				require.NoError(t, c.Save())
+			}
+		})
+	}
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(t.Name()))
+
+	e.Faker = gofakeit.New(h.Sum64())
+	e.cassette = c
+	e.AccProviders = muxProviders(t, e.connectionOptions()...)
+
+	return nil
+}
+
+// newEnvironment initializes the parts of the test environment that do not depend on the API.
+func newEnvironment(t *testing.T, providers map[string]func() (tfprotov6.ProviderServer, error)) *Environment {
+	t.Helper()
+
 	nodeName := utils.GetAnyStringEnv("PROXMOX_VE_ACC_NODE_NAME")
 	if nodeName == "" {
 		nodeName = "pve"
@@ -166,10 +324,29 @@ func InitEnvironment(t *testing.T) *Environment {
 		CloudImagesServer:     cloudImagesServer,
 		ContainerImagesServer: containerImagesServer,
 
-		AccProviders: muxProviders(t),
+		AccProviders: providers,
+
+		Faker: gofakeit.New(0),
 	}
 }
 
+// InitFakeEnvironment initializes a new test environment backed by an in-process fake Proxmox VE API server.
+// The server is stopped when the test finishes. Configurations rendered by the environment point the provider
+// to the fake server, so the tests do not need a real Proxmox VE cluster.
+func InitFakeEnvironment(t *testing.T, opts ...fake.Option) *Environment {
+	t.Helper()
+
+	srv := fake.NewServer(opts...)
+	t.Cleanup(srv.Close)
+
+	te := newEnvironment(t, muxProviders(t))
+	te.fakeServer = srv
+	te.NodeName = fake.DefaultNodeName
+	te.templateVars["NodeName"] = fake.DefaultNodeName
+
+	return te
+}
+
 // AddTemplateVars adds the given variables to the template variables of the current test environment.
 // Please note that NodeName and ProviderConfig are reserved keys, they are set by the test environment
 // and cannot be overridden.
@@ -182,10 +359,14 @@ func (e *Environment) AddTemplateVars(vars map[string]any) {
 // RenderConfig renders the given configuration with for the current test environment using template engine.
 func (e *Environment) RenderConfig(cfg string, opt ...RenderConfigOption) string {
 	if len(opt) == 0 {
-		opt = append(opt, WithAPIToken())
+		if e.fakeServer != nil {
+			opt = append(opt, WithFakeServer(e.fakeServer))
+		} else {
+			opt = append(opt, WithAPIToken())
+		}
 	}
 
-	rc := &renderConfig{}
+	rc := &renderConfig{replaying: e.replaying()}
 	for _, o := range opt {
 		err := o.apply(rc)
 		require.NoError(e.t, err, "configuration error")
@@ -206,23 +387,42 @@ func (e *Environment) RenderConfig(cfg string, opt ...RenderConfigOption) string
 // 1. API token
 // 2. Ticket
 // 3. User credentials.
+// For environments initialized with InitFakeEnvironment, the client is connected to the fake server.
 func (e *Environment) Client() api.Client {
 	if e.c == nil {
 		e.once.Do(
 			func() {
-				endpoint := utils.GetAnyStringEnv("PROXMOX_VE_ENDPOINT")
-				authTicket := utils.GetAnyStringEnv("PROXMOX_VE_AUTH_TICKET")
-				csrfPreventionToken := utils.GetAnyStringEnv("PROXMOX_VE_CSRF_PREVENTION_TOKEN")
-				apiToken := utils.GetAnyStringEnv("PROXMOX_VE_API_TOKEN")
-				username := utils.GetAnyStringEnv("PROXMOX_VE_USERNAME")
-				password := utils.GetAnyStringEnv("PROXMOX_VE_PASSWORD")
+				if e.fakeServer != nil {
+					var err error
+
+					e.c, err = e.fakeServer.NewClient()
+					if err != nil {
+						panic(err)
+					}
+
+					return
+				}
+
+				rc := &renderConfig{replaying: e.replaying()}
+
+				endpoint := rc.getenv("PROXMOX_VE_ENDPOINT")
+				authTicket := rc.getenv("PROXMOX_VE_AUTH_TICKET")
+				csrfPreventionToken := rc.getenv("PROXMOX_VE_CSRF_PREVENTION_TOKEN")
+				apiToken := rc.getenv("PROXMOX_VE_API_TOKEN")
+				username := rc.getenv("PROXMOX_VE_USERNAME")
+				password := rc.getenv("PROXMOX_VE_PASSWORD")
+
+				if e.replaying() {
+					// the placeholder token is used, even if the user credentials are set
+					authTicket, csrfPreventionToken, username, password = "", "", "", ""
+				}
 
 				creds, err := api.NewCredentials(username, password, "", apiToken, authTicket, csrfPreventionToken)
 				if err != nil {
 					panic(err)
 				}
 
-				conn, err := api.NewConnection(endpoint, true, "")
+				conn, err := api.NewConnection(endpoint, true, "", e.connectionOptions()...)
 				if err != nil {
 					panic(err)
 				}
@@ -237,6 +437,20 @@ func (e *Environment) Client() api.Client {
 	return e.c
 }
 
+// replaying returns true if the API interactions are served from a cassette.
+func (e *Environment) replaying() bool {
+	return e.cassette != nil && e.cassette.Mode() == cassette.ModeReplay
+}
+
+// connectionOptions returns the options for the API connections of the test environment.
+func (e *Environment) connectionOptions() []api.ConnectionOption {
+	if e.cassette == nil {
+		return nil
+	}
+
+	return []api.ConnectionOption{api.WithTransport(e.cassette.Wrap)}
+}
+
 // AccessClient returns a new access client for the test environment.
 func (e *Environment) AccessClient() *access.Client {
 	return &access.Client{Client: e.Client()}
@@ -257,21 +471,66 @@ func (e *Environment) ClusterClient() *cluster.Client {
 	return &cluster.Client{Client: e.Client()}
 }
 
+// offlineNodeResolver resolves the nodes of the fake server and of the cassettes, which cannot be accessed using SSH.
+type offlineNodeResolver struct{}
+
+func (offlineNodeResolver) Resolve(_ context.Context, nodeName string) (ssh.ProxmoxNode, error) {
+	return ssh.ProxmoxNode{}, fmt.Errorf("node %q of the test environment cannot be accessed using SSH", nodeName)
+}
+
+// directClient returns a client for calling the resources of the providers directly against the fake server,
+// or the cassette of the test.
+func (e *Environment) directClient() proxmox.Client {
+	require.True(e.t, e.fakeServer != nil || e.cassette != nil,
+		"the environment must be initialized with InitFakeEnvironment or InitReplayEnvironment")
+
+	sshClient, err := ssh.NewClient("root", "", false, "", "", "", "", "", offlineNodeResolver{})
+	require.NoError(e.t, err)
+
+	return proxmox.NewClient(e.Client(), sshClient, "")
+}
+
+// ProviderConfiguration returns the configuration of the SDK provider, to call its resources directly
+// against the fake server of an environment initialized with InitFakeEnvironment, or the cassette of an
+// environment initialized with InitReplayEnvironment.
+func (e *Environment) ProviderConfiguration() proxmoxtf.ProviderConfiguration {
+	client := e.directClient()
+
+	cfg, err := proxmoxtf.NewProviderConfiguration(client.API(), client.SSH(), "", cluster.IDGeneratorConfig{})
+	require.NoError(e.t, err)
+
+	return cfg
+}
+
+// ResourceConfig returns the configuration of the framework provider's resources, to call them directly
+// against the fake server of an environment initialized with InitFakeEnvironment, or the cassette of an
+// environment initialized with InitReplayEnvironment.
+func (e *Environment) ResourceConfig() config.Resource {
+	client := e.directClient()
+
+	return config.Resource{
+		Client:      client,
+		IDGenerator: cluster.NewIDGenerator(client.Cluster(), cluster.IDGeneratorConfig{}),
+	}
+}
+
 // muxProviders returns a map of mux servers for the acceptance tests.
-func muxProviders(t *testing.T) map[string]func() (tfprotov6.ProviderServer, error) {
+func muxProviders(t *testing.T, opts ...api.ConnectionOption) map[string]func() (tfprotov6.ProviderServer, error) {
 	t.Helper()
 
 	// Init mux servers
 	return map[string]func() (tfprotov6.ProviderServer, error){
 		"proxmox": func() (tfprotov6.ProviderServer, error) {
+			shared := proxmox.NewSharedState()
+
 			return tf6muxserver.NewMuxServer(t.Context(),
-				providerserver.NewProtocol6(fwprovider.New("test")()),
+				providerserver.NewProtocol6(fwprovider.New("test", shared, opts...)()),
 				func() tfprotov6.ProviderServer {
 					sdkV2Provider, err := tf5to6server.UpgradeServer(
 						t.Context(),
 						func() tfprotov5.ProviderServer {
 							return schema.NewGRPCProviderServer(
-								sdkV2provider.ProxmoxVirtualEnvironment(),
+								sdkV2provider.ProxmoxVirtualEnvironment(shared, opts...),
 							)
 						},
 					)
@@ -0,0 +1,119 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+// Package prunebackups provides the schema of the backup retention options, which are shared by the datastores
+// and the backup jobs, and its conversion from and to the API.
+package prunebackups
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/storage"
+	proxmoxtypes "github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+type model struct {
+	KeepAll     types.Bool  `tfsdk:"keep_all"`
+	KeepLast    types.Int64 `tfsdk:"keep_last"`
+	KeepHourly  types.Int64 `tfsdk:"keep_hourly"`
+	KeepDaily   types.Int64 `tfsdk:"keep_daily"`
+	KeepWeekly  types.Int64 `tfsdk:"keep_weekly"`
+	KeepMonthly types.Int64 `tfsdk:"keep_monthly"`
+	KeepYearly  types.Int64 `tfsdk:"keep_yearly"`
+}
+
+//nolint:gochecknoglobals
+var attrTypes = map[string]attr.Type{
+	"keep_all":     types.BoolType,
+	"keep_last":    types.Int64Type,
+	"keep_hourly":  types.Int64Type,
+	"keep_daily":   types.Int64Type,
+	"keep_weekly":  types.Int64Type,
+	"keep_monthly": types.Int64Type,
+	"keep_yearly":  types.Int64Type,
+}
+
+// ResourceAttribute returns a resource schema attribute for the backup retention options.
+func ResourceAttribute(description string) schema.SingleNestedAttribute {
+	keep := func(description string) schema.Int64Attribute {
+		return schema.Int64Attribute{
+			Description: description,
+			Optional:    true,
+			Validators:  []validator.Int64{int64validator.AtLeast(1)},
+		}
+	}
+
+	return schema.SingleNestedAttribute{
+		Description: description,
+		Optional:    true,
+		Attributes: map[string]schema.Attribute{
+			"keep_all": schema.BoolAttribute{
+				Description: "Keep all backups, the other options must not be set.",
+				Optional:    true,
+			},
+			"keep_last":    keep("Number of most recent backups to keep."),
+			"keep_hourly":  keep("Number of hours to keep the last backup of."),
+			"keep_daily":   keep("Number of days to keep the last backup of."),
+			"keep_weekly":  keep("Number of weeks to keep the last backup of."),
+			"keep_monthly": keep("Number of months to keep the last backup of."),
+			"keep_yearly":  keep("Number of years to keep the last backup of."),
+		},
+	}
+}
+
+// FromAPI converts the backup retention options of the API into an object of the ResourceAttribute schema.
+func FromAPI(ctx context.Context, pb *storage.PruneBackups, diags *diag.Diagnostics) types.Object {
+	if pb == nil {
+		return types.ObjectNull(attrTypes)
+	}
+
+	obj, d := types.ObjectValueFrom(ctx, attrTypes, model{
+		KeepAll:     types.BoolPointerValue(pb.KeepAll.PointerBool()),
+		KeepLast:    types.Int64PointerValue(pb.KeepLast),
+		KeepHourly:  types.Int64PointerValue(pb.KeepHourly),
+		KeepDaily:   types.Int64PointerValue(pb.KeepDaily),
+		KeepWeekly:  types.Int64PointerValue(pb.KeepWeekly),
+		KeepMonthly: types.Int64PointerValue(pb.KeepMonthly),
+		KeepYearly:  types.Int64PointerValue(pb.KeepYearly),
+	})
+	diags.Append(d...)
+
+	return obj
+}
+
+// ToAPI converts an object of the ResourceAttribute schema into the backup retention options of the API.
+func ToAPI(ctx context.Context, obj types.Object, diags *diag.Diagnostics) *storage.PruneBackups {
+	if obj.IsNull() || obj.IsUnknown() {
+		return nil
+	}
+
+	var pb model
+
+	diags.Append(obj.As(ctx, &pb, basetypes.ObjectAsOptions{})...)
+
+	var keepAll *proxmoxtypes.CustomBool
+	if !pb.KeepAll.IsNull() {
+		keepAll = proxmoxtypes.CustomBool(pb.KeepAll.ValueBool()).Pointer()
+	}
+
+	return &storage.PruneBackups{
+		KeepAll:     keepAll,
+		KeepLast:    pb.KeepLast.ValueInt64Pointer(),
+		KeepHourly:  pb.KeepHourly.ValueInt64Pointer(),
+		KeepDaily:   pb.KeepDaily.ValueInt64Pointer(),
+		KeepWeekly:  pb.KeepWeekly.ValueInt64Pointer(),
+		KeepMonthly: pb.KeepMonthly.ValueInt64Pointer(),
+		KeepYearly:  pb.KeepYearly.ValueInt64Pointer(),
+	}
+}
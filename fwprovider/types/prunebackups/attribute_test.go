@@ -0,0 +1,41 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package prunebackups
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/storage"
+)
+
+func TestConversion(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	var diags diag.Diagnostics
+
+	assert.True(t, FromAPI(ctx, nil, &diags).IsNull())
+
+	obj := FromAPI(ctx, &storage.PruneBackups{KeepLast: ptr.Ptr(int64(3)), KeepDaily: ptr.Ptr(int64(7))}, &diags)
+	require.False(t, diags.HasError(), diags)
+	assert.Equal(t, "3", obj.Attributes()["keep_last"].String())
+	assert.True(t, obj.Attributes()["keep_all"].IsNull())
+
+	pb := ToAPI(ctx, obj, &diags)
+	require.False(t, diags.HasError(), diags)
+	assert.Equal(t, int64(3), *pb.KeepLast)
+	assert.Equal(t, int64(7), *pb.KeepDaily)
+	assert.Nil(t, pb.KeepAll, "unset options must not be sent")
+	assert.Nil(t, pb.KeepWeekly)
+}
@@ -8,8 +8,10 @@ package validators
 
 import (
 	"fmt"
+	"net"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -29,6 +31,33 @@ func AbsoluteFilePathValidator() validator.String {
 	)
 }
 
+// DurationValidator validates that a string is a duration, e.g. `500ms` or `1m30s`.
+func DurationValidator() validator.String {
+	return NewParseValidator(
+		func(s string) (time.Duration, error) {
+			d, err := time.ParseDuration(s)
+			if err == nil && d < 0 {
+				err = fmt.Errorf("%q is negative", s)
+			}
+
+			return d, err //nolint:wrapcheck
+		},
+		"must be a non-negative duration, e.g. `500ms` or `1m30s`",
+	)
+}
+
+// CIDRValidator validates that a string is a network in CIDR notation, e.g. `10.0.0.0/24`.
+func CIDRValidator() validator.String {
+	return NewParseValidator(
+		func(s string) (*net.IPNet, error) {
+			_, n, err := net.ParseCIDR(s)
+
+			return n, err //nolint:wrapcheck
+		},
+		"must be a network in CIDR notation, e.g. `10.0.0.0/24`",
+	)
+}
+
 // NonEmptyString returns a new validator to ensure a non-empty string.
 func NonEmptyString() validator.String {
 	return stringvalidator.All(
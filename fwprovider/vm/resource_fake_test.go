@@ -0,0 +1,141 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package vm_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/test"
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/vm"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+// TestResourceFakeServer runs the CRUD functions of the VM resource against the fake server.
+func TestResourceFakeServer(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	te := test.InitFakeEnvironment(t)
+
+	r, ok := vm.NewResource().(resource.ResourceWithConfigure)
+	require.True(t, ok)
+
+	var configureResp resource.ConfigureResponse
+
+	r.Configure(ctx, resource.ConfigureRequest{ProviderData: te.ResourceConfig()}, &configureResp)
+	require.False(t, configureResp.Diagnostics.HasError(), "%v", configureResp.Diagnostics)
+
+	var schemaResp resource.SchemaResponse
+
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError(), "%v", schemaResp.Diagnostics)
+
+	s := schemaResp.Schema
+
+	objType, ok := s.Type().TerraformType(ctx).(tftypes.Object)
+	require.True(t, ok)
+
+	// the plan of a new VM, with unknown values for the attributes computed by the provider
+	values := map[string]tftypes.Value{}
+
+	for name, typ := range objType.AttributeTypes {
+		values[name] = tftypes.NewValue(typ, nil)
+
+		if attr, ok := s.Attributes[name]; ok && attr.IsComputed() {
+			values[name] = tftypes.NewValue(typ, tftypes.UnknownValue)
+		}
+	}
+
+	values["node_name"] = tftypes.NewValue(tftypes.String, te.NodeName)
+	values["name"] = tftypes.NewValue(tftypes.String, "test")
+	values["description"] = tftypes.NewValue(tftypes.String, "created")
+	values["stop_on_destroy"] = tftypes.NewValue(tftypes.Bool, false)
+	values["tags"] = tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{
+		tftypes.NewValue(tftypes.String, "a"),
+		tftypes.NewValue(tftypes.String, "b"),
+	})
+
+	plan := tfsdk.Plan{Schema: s, Raw: tftypes.NewValue(objType, values)}
+	emptyState := tfsdk.State{Schema: s, Raw: tftypes.NewValue(objType, nil)}
+
+	createResp := resource.CreateResponse{State: emptyState}
+
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, &createResp)
+	require.False(t, createResp.Diagnostics.HasError(), "%v", createResp.Diagnostics)
+
+	state := createResp.State
+	require.True(t, state.Raw.IsFullyKnown(), "the computed attributes must be read back after creation")
+
+	var id types.Int64
+
+	require.False(t, state.GetAttribute(ctx, path.Root("id"), &id).HasError())
+	require.Positive(t, id.ValueInt64())
+
+	vmClient := te.NodeClient().VM(int(id.ValueInt64()))
+
+	config, err := vmClient.GetVM(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, config.Name)
+	assert.Equal(t, "test", *config.Name)
+
+	readResp := resource.ReadResponse{State: state}
+
+	r.Read(ctx, resource.ReadRequest{State: state}, &readResp)
+	require.False(t, readResp.Diagnostics.HasError(), "%v", readResp.Diagnostics)
+
+	var name, description types.String
+
+	require.False(t, readResp.State.GetAttribute(ctx, path.Root("name"), &name).HasError())
+	require.False(t, readResp.State.GetAttribute(ctx, path.Root("description"), &description).HasError())
+	assert.Equal(t, "test", name.ValueString())
+	assert.Equal(t, "created", description.ValueString())
+
+	state = readResp.State
+
+	updatePlan := tfsdk.Plan{Schema: s, Raw: state.Raw.Copy()}
+	require.False(t, updatePlan.SetAttribute(ctx, path.Root("name"), "renamed").HasError())
+	require.False(t, updatePlan.SetAttribute(ctx, path.Root("description"), "updated").HasError())
+
+	updateResp := resource.UpdateResponse{State: state}
+
+	r.Update(ctx, resource.UpdateRequest{Plan: updatePlan, State: state}, &updateResp)
+	require.False(t, updateResp.Diagnostics.HasError(), "%v", updateResp.Diagnostics)
+
+	config, err = vmClient.GetVM(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, config.Name)
+	require.NotNil(t, config.Description)
+	assert.Equal(t, "renamed", *config.Name)
+	assert.Equal(t, "updated", *config.Description)
+
+	state = updateResp.State
+
+	deleteResp := resource.DeleteResponse{State: state}
+
+	r.Delete(ctx, resource.DeleteRequest{State: state}, &deleteResp)
+	require.False(t, deleteResp.Diagnostics.HasError(), "%v", deleteResp.Diagnostics)
+	assert.True(t, deleteResp.State.Raw.IsNull())
+
+	_, err = vmClient.GetVM(ctx)
+	require.ErrorIs(t, err, api.ErrResourceDoesNotExist)
+
+	// a VM deleted outside of Terraform is removed from the state
+	readResp = resource.ReadResponse{State: state}
+
+	r.Read(ctx, resource.ReadRequest{State: state}, &readResp)
+	require.False(t, readResp.Diagnostics.HasError(), "%v", readResp.Diagnostics)
+	assert.True(t, readResp.State.Raw.IsNull())
+}
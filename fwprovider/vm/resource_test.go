@@ -9,7 +9,6 @@
 package vm_test
 
 import (
-	"math/rand"
 	"regexp"
 	"testing"
 
@@ -23,7 +22,7 @@ func TestAccResourceVM(t *testing.T) {
 
 	te := test.InitEnvironment(t)
 	te.AddTemplateVars(map[string]interface{}{
-		"TestVMID": 100000 + rand.Intn(99999),
+		"TestVMID": 100000 + te.Faker.IntN(99999),
 	})
 
 	tests := []struct {
@@ -20,6 +20,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/bpg/terraform-provider-proxmox/fwprovider"
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
 	"github.com/bpg/terraform-provider-proxmox/proxmoxtf/provider"
 )
 
@@ -39,6 +40,7 @@ import (
 //go:generate cp ./build/docs-gen/data-sources/virtual_environment_acme_plugins.md ./docs/data-sources/
 //go:generate cp ./build/docs-gen/data-sources/virtual_environment_apt_repository.md ./docs/data-sources/
 //go:generate cp ./build/docs-gen/data-sources/virtual_environment_apt_standard_repository.md ./docs/data-sources/
+//go:generate cp ./build/docs-gen/data-sources/virtual_environment_backup_not_backed_up.md ./docs/data-sources/
 //go:generate cp ./build/docs-gen/data-sources/virtual_environment_hagroup.md ./docs/data-sources/
 //go:generate cp ./build/docs-gen/data-sources/virtual_environment_hagroups.md ./docs/data-sources/
 //go:generate cp ./build/docs-gen/data-sources/virtual_environment_hardware_mapping_pci.md ./docs/data-sources/
@@ -49,11 +51,16 @@ import (
 //go:generate cp ./build/docs-gen/data-sources/virtual_environment_version.md ./docs/data-sources/
 //go:generate cp ./build/docs-gen/data-sources/virtual_environment_vm2.md ./docs/data-sources/
 //go:generate cp ./build/docs-gen/data-sources/virtual_environment_metrics_server.md ./docs/data-sources/
+//go:generate cp ./build/docs-gen/data-sources/virtual_environment_replication_status.md ./docs/data-sources/
+//go:generate cp ./build/docs-gen/data-sources/virtual_environment_sdn_subnet.md ./docs/data-sources/
+//go:generate cp ./build/docs-gen/data-sources/virtual_environment_sdn_vnet.md ./docs/data-sources/
+//go:generate cp ./build/docs-gen/data-sources/virtual_environment_sdn_zone.md ./docs/data-sources/
 //go:generate cp ./build/docs-gen/resources/virtual_environment_acl.md ./docs/resources/
 //go:generate cp ./build/docs-gen/resources/virtual_environment_acme_account.md ./docs/resources/
 //go:generate cp ./build/docs-gen/resources/virtual_environment_acme_dns_plugin.md ./docs/resources/
 //go:generate cp ./build/docs-gen/resources/virtual_environment_apt_repository.md ./docs/resources/
 //go:generate cp ./build/docs-gen/resources/virtual_environment_apt_standard_repository.md ./docs/resources/
+//go:generate cp ./build/docs-gen/resources/virtual_environment_backup_job.md ./docs/resources/
 //go:generate cp ./build/docs-gen/resources/virtual_environment_cluster_options.md ./docs/resources/
 //go:generate cp ./build/docs-gen/resources/virtual_environment_download_file.md ./docs/resources/
 //go:generate cp ./build/docs-gen/resources/virtual_environment_hagroup.md ./docs/resources/
@@ -65,6 +72,17 @@ import (
 //go:generate cp ./build/docs-gen/resources/virtual_environment_user_token.md ./docs/resources/
 //go:generate cp ./build/docs-gen/resources/virtual_environment_vm2.md ./docs/resources/
 //go:generate cp ./build/docs-gen/resources/virtual_environment_metrics_server.md ./docs/resources/
+//go:generate cp ./build/docs-gen/resources/virtual_environment_replication_job.md ./docs/resources/
+//go:generate cp ./build/docs-gen/resources/virtual_environment_sdn_applier.md ./docs/resources/
+//go:generate cp ./build/docs-gen/resources/virtual_environment_sdn_controller.md ./docs/resources/
+//go:generate cp ./build/docs-gen/resources/virtual_environment_sdn_dns.md ./docs/resources/
+//go:generate cp ./build/docs-gen/resources/virtual_environment_sdn_ipam.md ./docs/resources/
+//go:generate cp ./build/docs-gen/resources/virtual_environment_sdn_subnet.md ./docs/resources/
+//go:generate cp ./build/docs-gen/resources/virtual_environment_sdn_vnet.md ./docs/resources/
+//go:generate cp ./build/docs-gen/resources/virtual_environment_sdn_zone.md ./docs/resources/
+//go:generate cp ./build/docs-gen/resources/virtual_environment_storage.md ./docs/resources/
+//go:generate cp ./build/docs-gen/ephemeral-resources/virtual_environment_ticket.md ./docs/ephemeral-resources/
+//go:generate cp ./build/docs-gen/ephemeral-resources/virtual_environment_user_token.md ./docs/ephemeral-resources/
 
 // these will be set by the goreleaser configuration
 // to appropriate values for the compiled binary.
@@ -78,11 +96,14 @@ func main() {
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
+	// the providers are served by the same provider instance, and share its state
+	shared := proxmox.NewSharedState()
+
 	upgradedSdkServer, err := tf5to6server.UpgradeServer(
 		ctx,
 		func() tfprotov5.ProviderServer {
 			return schema.NewGRPCProviderServer(
-				provider.ProxmoxVirtualEnvironment(),
+				provider.ProxmoxVirtualEnvironment(shared),
 			)
 		},
 	)
@@ -91,7 +112,7 @@ func main() {
 	}
 
 	providers := []func() tfprotov6.ProviderServer{
-		providerserver.NewProtocol6(fwprovider.New(version)()),
+		providerserver.NewProtocol6(fwprovider.New(version, shared)()),
 		func() tfprotov6.ProviderServer {
 			return upgradedSdkServer
 		},
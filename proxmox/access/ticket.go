@@ -0,0 +1,33 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package access
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+// CreateTicket creates an authentication ticket and CSRF prevention token for a user.
+// The request is sent without the client's own credentials, so a rejected login of the user does not
+// affect the client's authentication.
+func (c *Client) CreateTicket(ctx context.Context, d *TicketCreateRequestBody) (*api.AuthenticationResponseData, error) {
+	resBody := &api.AuthenticationResponseBody{}
+
+	err := c.DoRequest(api.WithoutAuthentication(ctx), http.MethodPost, c.ExpandPath("ticket"), d, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error creating ticket for user %s: %w", d.Username, err)
+	}
+
+	if err = resBody.Validate(); err != nil {
+		return nil, fmt.Errorf("error creating ticket for user %s: %w", d.Username, err)
+	}
+
+	return resBody.Data, nil
+}
@@ -0,0 +1,116 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package access
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/fake"
+)
+
+func TestCreateTicket(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	srv := fake.NewServer()
+	t.Cleanup(srv.Close)
+
+	apiClient, err := srv.NewClient()
+	require.NoError(t, err)
+
+	c := &Client{Client: apiClient}
+
+	data, err := c.CreateTicket(ctx, &TicketCreateRequestBody{
+		Username: fake.DefaultUsername,
+		Password: fake.DefaultPassword,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, data.Ticket)
+	require.NotNil(t, data.CSRFPreventionToken)
+	assert.NotEmpty(t, *data.Ticket)
+	assert.NotEmpty(t, *data.CSRFPreventionToken)
+	assert.Equal(t, fake.DefaultUsername, data.Username)
+
+	_, err = c.CreateTicket(ctx, &TicketCreateRequestBody{
+		Username: fake.DefaultUsername,
+		Password: "wrong",
+	})
+	require.ErrorContains(t, err, "error creating ticket for user root@pam")
+}
+
+func TestCreateTicketKeepsClientCredentials(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu      sync.Mutex
+		logins  int
+		cookies []string
+	)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api2/json/access/ticket", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if cookie, err := r.Cookie("PVEAuthCookie"); err == nil {
+			cookies = append(cookies, cookie.Value)
+		}
+
+		if r.FormValue("password") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		logins++
+
+		_, _ = fmt.Fprintf(w,
+			`{"data":{"username":%q,"ticket":"PVE:%s:%08X","CSRFPreventionToken":"csrf"}}`,
+			r.FormValue("username"), r.FormValue("username"), logins)
+	})
+	mux.HandleFunc("GET /api2/json/version", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"version":"8.3.0"}}`))
+	})
+
+	srv := httptest.NewTLSServer(mux)
+	t.Cleanup(srv.Close)
+
+	conn, err := api.NewConnection(srv.URL, true, "")
+	require.NoError(t, err)
+
+	creds, err := api.NewCredentials("root@pam", "secret", "", "", "", "")
+	require.NoError(t, err)
+
+	apiClient, err := api.NewClient(creds, conn)
+	require.NoError(t, err)
+
+	c := &Client{Client: apiClient}
+
+	require.NoError(t, c.DoRequest(t.Context(), http.MethodGet, "version", nil, nil))
+
+	_, err = c.CreateTicket(t.Context(), &TicketCreateRequestBody{
+		Username: "user@pve",
+		Password: "wrong",
+	})
+	require.ErrorContains(t, err, "error creating ticket for user user@pve")
+
+	// the rejected login is neither sent with the client's ticket, nor makes the client log in again
+	assert.Equal(t, 1, logins)
+	assert.Empty(t, cookies)
+
+	require.NoError(t, c.DoRequest(t.Context(), http.MethodGet, "version", nil, nil))
+	assert.Equal(t, 1, logins)
+}
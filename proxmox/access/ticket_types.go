@@ -0,0 +1,14 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package access
+
+// TicketCreateRequestBody contains the data for a ticket create request.
+type TicketCreateRequestBody struct {
+	Username string  `json:"username"      url:"username"`
+	Password string  `json:"password"      url:"password"`
+	OTP      *string `json:"otp,omitempty" url:"otp,omitempty"`
+}
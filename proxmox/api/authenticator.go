@@ -25,3 +25,29 @@ type Authenticator interface {
 	// AuthenticateRequest adds authentication data to a new request.
 	AuthenticateRequest(ctx context.Context, req *http.Request) error
 }
+
+// RenewableAuthenticator is an Authenticator whose credentials can expire and be re-issued.
+type RenewableAuthenticator interface {
+	Authenticator
+
+	// Invalidate discards the cached credentials after they have been rejected by the server,
+	// so the next request is authenticated with newly issued ones.
+	Invalidate(ctx context.Context)
+}
+
+// unauthenticatedKey is the context key marking requests that are sent without the client's credentials.
+type unauthenticatedKey struct{}
+
+// WithoutAuthentication returns a context for requests that must be sent without the client's credentials,
+// e.g. to log in as another user. A request sent with this context is not re-authenticated when it is
+// rejected as unauthorized, as the rejection does not concern the client's credentials.
+func WithoutAuthentication(ctx context.Context) context.Context {
+	return context.WithValue(ctx, unauthenticatedKey{}, true)
+}
+
+// isUnauthenticated returns true if the request with the context must be sent without the client's credentials.
+func isUnauthenticated(ctx context.Context) bool {
+	unauthenticated, _ := ctx.Value(unauthenticatedKey{}).(bool)
+
+	return unauthenticated
+}
@@ -10,6 +10,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -20,6 +21,7 @@ import (
 	"slices"
 	"sort"
 	"strings"
+	"sync"
 
 	"github.com/avast/retry-go/v4"
 	"github.com/google/go-querystring/query"
@@ -57,27 +59,100 @@ type Client interface {
 
 	// HTTP returns a lower-level HTTP client.
 	HTTP() *http.Client
+
+	// TLSConfig returns the TLS configuration for connections to hosts other than the API endpoints.
+	TLSConfig() *tls.Config
+
+	// CancelTasksOnInterrupt returns true if tasks should be stopped when waiting for them is interrupted.
+	CancelTasksOnInterrupt() bool
+
+	// AcquireTaskSlot waits until a long-running task can be started on the node without exceeding
+	// the configured limits. The returned function must be called when the task has completed.
+	AcquireTaskSlot(ctx context.Context, node string) (func(), error)
 }
 
 // Connection represents a connection to the Proxmox Virtual Environment API.
 type Connection struct {
-	endpoint   string
-	httpClient *http.Client
+	// endpoints contains the primary endpoint followed by the failover endpoints, active is the index
+	// of the endpoint currently used for requests.
+	endpoints []string
+	active    int
+	mu        sync.RWMutex
+
+	httpClient  *http.Client
+	retryPolicy *RetryPolicy
+
+	// transportWrappers wrap the HTTP transport of the connection, in the order they were added
+	transportWrappers []func(http.RoundTripper) http.RoundTripper
+
+	cancelTasksOnInterrupt bool
+
+	// client-side limits, see limits.go
+	limiter *limiter
+
+	// TLS settings, see tls.go
+	insecure    bool
+	minTLS      uint16
+	rootCAs     *x509.CertPool
+	fingerprint []byte
 }
 
-// NewConnection creates and initializes a Connection instance.
-func NewConnection(endpoint string, insecure bool, minTLS string) (*Connection, error) {
-	u, err := url.ParseRequestURI(endpoint)
-	if err != nil {
-		return nil, errors.New(
-			"you must specify a valid endpoint for the Proxmox Virtual Environment API (valid: https://host:port/)",
-		)
+// ConnectionOption is an option for configuring a Connection.
+type ConnectionOption func(*Connection) error
+
+// WithRetryPolicy sets the policy for retrying failed requests made over the connection.
+func WithRetryPolicy(policy RetryPolicy) ConnectionOption {
+	return func(c *Connection) error {
+		c.retryPolicy = &policy
+
+		return nil
 	}
+}
 
-	if u.Scheme != "https" {
-		return nil, errors.New(
-			"you must specify a secure endpoint for the Proxmox Virtual Environment API (valid: https://host:port/)",
-		)
+// WithFailoverEndpoints adds endpoints of other cluster nodes, which are used when the primary endpoint
+// becomes unavailable.
+func WithFailoverEndpoints(endpoints ...string) ConnectionOption {
+	return func(c *Connection) error {
+		for _, e := range endpoints {
+			endpoint, err := parseEndpoint(e)
+			if err != nil {
+				return err
+			}
+
+			if !slices.Contains(c.endpoints, endpoint) {
+				c.endpoints = append(c.endpoints, endpoint)
+			}
+		}
+
+		return nil
+	}
+}
+
+// WithCancelTasksOnInterrupt enables stopping of the tasks started over the connection when waiting for
+// them is interrupted, e.g. by a timeout or the user, so they do not keep locks on the guest configuration.
+func WithCancelTasksOnInterrupt(enabled bool) ConnectionOption {
+	return func(c *Connection) error {
+		c.cancelTasksOnInterrupt = enabled
+
+		return nil
+	}
+}
+
+// WithTransport wraps the HTTP transport used for the requests made over the connection, e.g. to record
+// and replay the API interactions in tests.
+func WithTransport(wrap func(http.RoundTripper) http.RoundTripper) ConnectionOption {
+	return func(c *Connection) error {
+		c.transportWrappers = append(c.transportWrappers, wrap)
+
+		return nil
+	}
+}
+
+// NewConnection creates and initializes a Connection instance.
+func NewConnection(endpoint string, insecure bool, minTLS string, opts ...ConnectionOption) (*Connection, error) {
+	primary, err := parseEndpoint(endpoint)
+	if err != nil {
+		return nil, err
 	}
 
 	version, err := GetMinTLSVersion(minTLS)
@@ -85,28 +160,66 @@ func NewConnection(endpoint string, insecure bool, minTLS string) (*Connection,
 		return nil, err
 	}
 
+	conn := &Connection{
+		endpoints: []string{primary},
+		insecure:  insecure,
+		minTLS:    version,
+	}
+
+	for _, opt := range opts {
+		if err := opt(conn); err != nil {
+			return nil, err
+		}
+	}
+
 	var transport http.RoundTripper = &http.Transport{
-		Proxy: http.ProxyFromEnvironment,
-		TLSClientConfig: &tls.Config{
-			// deepcode ignore InsecureTLSConfig: the min TLS version is configurable
-			MinVersion:         version,
-			InsecureSkipVerify: insecure, //nolint:gosec
-		},
+		Proxy:           http.ProxyFromEnvironment,
+		TLSClientConfig: conn.apiTLSConfig(),
+	}
+
+	for _, wrap := range conn.transportWrappers {
+		transport = wrap(transport)
 	}
 
 	if logging.IsDebugOrHigher() {
 		transport = logging.NewLoggingHTTPTransport(transport)
 	}
 
+	conn.httpClient = &http.Client{
+		Transport: transport,
+	}
+
+	return conn, nil
+}
+
+// parseEndpoint validates the endpoint URL and strips the path from it.
+func parseEndpoint(endpoint string) (string, error) {
+	u, err := url.ParseRequestURI(endpoint)
+	if err != nil {
+		return "", errors.New(
+			"you must specify a valid endpoint for the Proxmox Virtual Environment API (valid: https://host:port/)",
+		)
+	}
+
+	if u.Scheme != "https" {
+		return "", errors.New(
+			"you must specify a secure endpoint for the Proxmox Virtual Environment API (valid: https://host:port/)",
+		)
+	}
+
 	// make sure the path does not contain "/api2/json"
 	u.Path = ""
 
-	return &Connection{
-		endpoint: strings.TrimRight(u.String(), "/"),
-		httpClient: &http.Client{
-			Transport: transport,
-		},
-	}, nil
+	return strings.TrimRight(u.String(), "/"), nil
+}
+
+// RetryPolicy returns the policy for retrying failed requests made over the connection.
+func (c *Connection) RetryPolicy() RetryPolicy {
+	if c.retryPolicy == nil {
+		return DefaultRetryPolicy()
+	}
+
+	return *c.retryPolicy
 }
 
 // VirtualEnvironmentClient implements an API client for the Proxmox Virtual Environment API.
@@ -121,29 +234,31 @@ func NewClient(creds Credentials, conn *Connection) (Client, error) {
 		return nil, errors.New("connection must not be nil")
 	}
 
-	var auth Authenticator
+	auth, err := newAuthenticator(creds, conn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create API client: %w", err)
+	}
 
-	var err error
+	return &client{
+		conn: conn,
+		auth: auth,
+	}, nil
+}
 
+// newAuthenticator creates the authenticator for the credentials.
+func newAuthenticator(creds Credentials, conn *Connection) (Authenticator, error) {
 	switch {
+	case creds.CommandCredentials != nil:
+		return NewCommandAuthenticator(creds, conn)
 	case creds.TokenCredentials != nil:
-		auth, err = NewTokenAuthenticator(*creds.TokenCredentials)
+		return NewTokenAuthenticator(*creds.TokenCredentials)
 	case creds.TicketCredentials != nil:
-		auth, err = NewTicketAuthenticator(*creds.TicketCredentials)
+		return NewTicketAuthenticator(*creds.TicketCredentials)
 	case creds.UserCredentials != nil:
-		auth = NewUserAuthenticator(*creds.UserCredentials, conn)
+		return NewUserAuthenticator(*creds.UserCredentials, conn), nil
 	default:
 		return nil, errors.New("must provide either user credentials, an API token, or a ticket")
 	}
-
-	if err != nil {
-		return nil, fmt.Errorf("failed to create API client: %w", err)
-	}
-
-	return &client{
-		conn: conn,
-		auth: auth,
-	}, nil
 }
 
 // DoRequest performs a HTTP request against a JSON API endpoint.
@@ -161,9 +276,14 @@ func (c *client) DoRequest(
 
 	var reqContentLength *int64
 
+	var encodedBody string
+
 	modifiedPath := path
 	reqBodyType := ""
 
+	// only requests with form-encoded bodies can be re-sent, streamed bodies are consumed by the first attempt
+	replayable := true
+
 	//nolint:nestif
 	if requestBody != nil {
 		multipartData, multipart := requestBody.(*MultiPartData)
@@ -174,8 +294,10 @@ func (c *client) DoRequest(
 			reqBodyReader = multipartData.Reader
 			reqBodyType = fmt.Sprintf("multipart/form-data; boundary=%s", multipartData.Boundary)
 			reqContentLength = multipartData.Size
+			replayable = false
 		case pipedBody:
 			reqBodyReader = pipedBodyReader
+			replayable = false
 		default:
 			v, err := query.Values(requestBody)
 			if err != nil {
@@ -195,81 +317,143 @@ func (c *client) DoRequest(
 						modifiedPath = fmt.Sprintf("%s&%s", modifiedPath, encodedValues)
 					}
 				} else {
-					reqBodyReader = bytes.NewBufferString(encodedValues)
+					encodedBody = encodedValues
 					reqBodyType = "application/x-www-form-urlencoded"
 				}
 			}
 		}
-	} else {
-		reqBodyReader = new(bytes.Buffer)
 	}
 
-	req, err := http.NewRequestWithContext(
-		ctx,
-		method,
-		fmt.Sprintf("%s/%s/%s", c.conn.endpoint, basePathJSONAPI, modifiedPath),
-		reqBodyReader,
-	)
-	if err != nil {
-		return fmt.Errorf(
-			"failed to create HTTP %s request (path: %s) - Reason: %w",
-			method,
-			modifiedPath,
-			err,
-		)
+	policy := c.conn.RetryPolicy()
+	if !replayable {
+		policy.Attempts = 1
 	}
 
-	req.Header.Add("Accept", "application/json")
+	// set when the request was rejected before it was sent, because the client could not authenticate
+	authFailed := false
 
-	if reqContentLength != nil {
-		req.ContentLength = *reqContentLength
-	}
+	// set when the request is sent without the client's credentials, see WithoutAuthentication
+	unauthenticated := isUnauthenticated(ctx)
 
-	if reqBodyType != "" {
-		req.Header.Add("Content-Type", reqBodyType)
-	}
+	// the endpoint the request has been sent to last
+	var endpoint string
 
-	err = c.auth.AuthenticateRequest(ctx, req)
-	if err != nil {
-		return fmt.Errorf("failed to authenticate HTTP %s request (path: %s) - Reason: %w",
+	// attempt sends the request once
+	attempt := func() (*http.Response, error) {
+		endpoint = c.conn.Endpoint()
+
+		body := reqBodyReader
+		if replayable {
+			body = bytes.NewBufferString(encodedBody)
+		}
+
+		req, err := http.NewRequestWithContext(
+			ctx,
 			method,
-			modifiedPath,
-			err,
+			fmt.Sprintf("%s/%s/%s", endpoint, basePathJSONAPI, modifiedPath),
+			body,
 		)
+		if err != nil {
+			return nil, retry.Unrecoverable(fmt.Errorf(
+				"failed to create HTTP %s request (path: %s) - Reason: %w",
+				method,
+				modifiedPath,
+				err,
+			))
+		}
+
+		req.Header.Add("Accept", "application/json")
+
+		if reqContentLength != nil {
+			req.ContentLength = *reqContentLength
+		}
+
+		if reqBodyType != "" {
+			req.Header.Add("Content-Type", reqBodyType)
+		}
+
+		if !unauthenticated {
+			err = c.auth.AuthenticateRequest(ctx, req)
+			authFailed = err != nil
+		}
+
+		if err != nil {
+			c.failoverOnError(ctx, endpoint, err)
+
+			return nil, fmt.Errorf("failed to authenticate HTTP %s request (path: %s) - Reason: %w",
+				method,
+				modifiedPath,
+				err,
+			)
+		}
+
+		release, err := c.conn.acquireRequestSlot(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := c.conn.httpClient.Do(req)
+		if err != nil {
+			release()
+			c.failoverOnError(ctx, endpoint, err)
+
+			return nil, fmt.Errorf("failed to perform HTTP %s request (path: %s) - Reason: %w",
+				method,
+				modifiedPath,
+				err,
+			)
+		}
+
+		res.Body = &releasingBody{ReadCloser: res.Body, release: release}
+
+		if err = validateResponseCode(res); err != nil {
+			utils.CloseOrLogError(ctx)(res.Body)
+			c.failoverOnError(ctx, endpoint, err)
+
+			return nil, err
+		}
+
+		return res, nil
+	}
+
+	// send sends the request, retrying it according to the retry policy
+	send := func() (*http.Response, error) {
+		return retry.DoWithData(attempt, policy.retryOptions(ctx, method)...)
 	}
 
 	//nolint:bodyclose
-	res, err := retry.DoWithData(
-		func() (*http.Response, error) {
-			return c.conn.httpClient.Do(req)
-		},
-		retry.Context(ctx),
-		retry.RetryIf(func(err error) bool {
-			var urlErr *url.Error
-			if errors.As(err, &urlErr) {
-				return strings.ToUpper(urlErr.Op) == http.MethodGet
-			}
+	res, err := send()
 
-			return false
-		}),
-		retry.LastErrorOnly(true),
-		retry.Attempts(3),
-	)
-	if err != nil {
-		return fmt.Errorf("failed to perform HTTP %s request (path: %s) - Reason: %w",
-			method,
-			modifiedPath,
-			err,
-		)
+	// the request has exhausted its retries, but it can still be re-sent to a failover endpoint
+	for range c.conn.EndpointCount() - 1 {
+		if !replayable || !isFailoverError(err) || !policy.canFailover(method, err) ||
+			endpoint == c.conn.Endpoint() {
+			break
+		}
+
+		//nolint:bodyclose
+		res, err = send()
 	}
 
-	defer utils.CloseOrLogError(ctx)(res.Body)
+	var httpErr *HTTPError
+
+	//nolint:bodyclose
+	if ra, ok := c.auth.(RenewableAuthenticator); ok && replayable && !authFailed && !unauthenticated &&
+		errors.As(err, &httpErr) && httpErr.Code == http.StatusUnauthorized {
+		// the ticket may have expired or been revoked server-side, re-authenticate once
+		tflog.Debug(ctx, "the request was rejected as unauthorized, re-authenticating")
+
+		ra.Invalidate(ctx)
+
+		res, err = send()
+	}
 
-	err = validateResponseCode(res)
 	if err != nil {
-		return err
+		return err //nolint:wrapcheck // the errors are wrapped by the retried function
 	}
 
+	defer utils.CloseOrLogError(ctx)(res.Body)
+
 	//nolint:nestif
 	if responseBody != nil {
 		err = json.NewDecoder(res.Body).Decode(responseBody)
@@ -312,6 +496,14 @@ func (c *client) DoRequest(
 	return nil
 }
 
+// failoverOnError switches the connection to another endpoint if the error indicates that the endpoint
+// used for the request is unavailable.
+func (c *client) failoverOnError(ctx context.Context, endpoint string, err error) {
+	if isFailoverError(err) {
+		c.conn.failover(ctx, endpoint)
+	}
+}
+
 type dataResponse struct {
 	Data interface{} `json:"data"`
 }
@@ -333,6 +525,18 @@ func (c *client) HTTP() *http.Client {
 	return c.conn.httpClient
 }
 
+func (c *client) TLSConfig() *tls.Config {
+	return c.conn.TLSConfig()
+}
+
+func (c *client) CancelTasksOnInterrupt() bool {
+	return c.conn.cancelTasksOnInterrupt
+}
+
+func (c *client) AcquireTaskSlot(ctx context.Context, node string) (func(), error) {
+	return c.conn.AcquireTaskSlot(ctx, node)
+}
+
 // validateResponseCode ensures that a response is valid.
 func validateResponseCode(res *http.Response) error {
 	if res.StatusCode < 200 || res.StatusCode >= 300 {
@@ -361,6 +565,15 @@ func validateResponseCode(res *http.Response) error {
 			return errors.Join(ErrResourceDoesNotExist, httpError)
 		}
 
+		matched := MatchError(msg)
+		if matched == nil && res.StatusCode == http.StatusForbidden {
+			matched = &PermissionError{}
+		}
+
+		if matched != nil {
+			return errors.Join(matched, httpError)
+		}
+
 		return httpError
 	}
 
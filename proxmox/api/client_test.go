@@ -9,12 +9,16 @@ package api
 import (
 	"context"
 	"errors"
+	"io"
 	"net/http"
 	"reflect"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -70,7 +74,7 @@ func TestClientDoRequest(t *testing.T) {
 
 			c := client{
 				conn: &Connection{
-					endpoint: "http://localhost",
+					endpoints: []string{"http://localhost"},
 					httpClient: newTestClient(func(_ *http.Request) *http.Response {
 						sc, err := strconv.Atoi(strings.Fields(tt.status)[0])
 						require.NoError(t, err)
@@ -109,3 +113,109 @@ func TestClientDoRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestClientDoRequestRetry(t *testing.T) {
+	t.Parallel()
+
+	policy := RetryPolicy{
+		Attempts:             3,
+		InitialDelay:         time.Millisecond,
+		MaxDelay:             time.Millisecond,
+		RetryableStatusCodes: []int{http.StatusServiceUnavailable, StatusNoRouteToHost},
+	}
+
+	tests := []struct {
+		name               string
+		method             string
+		status             string
+		retryNonIdempotent bool
+		wantAttempts       int
+	}{
+		{name: "GET with retryable status", method: http.MethodGet, status: "503 Service Unavailable", wantAttempts: 3},
+		{name: "GET with no route to host", method: http.MethodGet, status: "595 No route to host", wantAttempts: 3},
+		{name: "GET with non-retryable status", method: http.MethodGet, status: "500 Internal Server Error", wantAttempts: 1},
+		{name: "GET for missing resource", method: http.MethodGet, status: "500 VM 100 does not exist", wantAttempts: 1},
+		{name: "POST with retryable status", method: http.MethodPost, status: "503 Service Unavailable", wantAttempts: 1},
+		{
+			name:               "POST with retryable status and non-idempotent retries",
+			method:             http.MethodPost,
+			status:             "503 Service Unavailable",
+			retryNonIdempotent: true,
+			wantAttempts:       3,
+		},
+		{
+			name:         "PUT with lock timeout",
+			method:       http.MethodPut,
+			status:       "500 can't lock file '/var/lock/qemu-server/lock-100.conf' - got timeout",
+			wantAttempts: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var attempts atomic.Int32
+
+			p := policy
+			p.RetryNonIdempotent = tt.retryNonIdempotent
+
+			c := client{
+				conn: &Connection{
+					endpoints: []string{"http://localhost"},
+					httpClient: newTestClient(func(req *http.Request) *http.Response {
+						attempts.Add(1)
+
+						if req.Body != nil {
+							body, err := io.ReadAll(req.Body)
+							require.NoError(t, err)
+
+							if req.Method != http.MethodGet {
+								assert.Equal(t, "name=test", string(body), "the body must be re-sent on every attempt")
+							}
+						}
+
+						sc, err := strconv.Atoi(strings.Fields(tt.status)[0])
+						require.NoError(t, err)
+
+						return &http.Response{
+							Status:     tt.status,
+							StatusCode: sc,
+							Body:       io.NopCloser(strings.NewReader("")),
+						}
+					}),
+					retryPolicy: &p,
+				},
+				auth: dummyAuthenticator{},
+			}
+
+			body := struct {
+				Name string `url:"name"`
+			}{Name: "test"}
+
+			err := c.DoRequest(t.Context(), tt.method, "any", &body, nil)
+			require.Error(t, err)
+			assert.Equal(t, tt.wantAttempts, int(attempts.Load()))
+		})
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	t.Parallel()
+
+	p := RetryPolicy{InitialDelay: time.Second, MaxDelay: 5 * time.Second}
+
+	assert.Equal(t, time.Second, p.delay(1, nil, nil))
+	assert.Equal(t, 2*time.Second, p.delay(2, nil, nil))
+	assert.Equal(t, 4*time.Second, p.delay(3, nil, nil))
+	assert.Equal(t, 5*time.Second, p.delay(4, nil, nil))
+	assert.Equal(t, 5*time.Second, p.delay(100, nil, nil))
+
+	p.Jitter = true
+
+	for n := uint(1); n < 5; n++ {
+		d := p.delay(n, nil, nil)
+		assert.GreaterOrEqual(t, d, time.Second/2)
+		assert.LessOrEqual(t, d, 5*time.Second)
+	}
+}
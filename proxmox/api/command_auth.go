@@ -0,0 +1,322 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// credentialsCommandTimeout is the time a credentials command is given to print the credentials,
+// e.g. to prompt for the unlock of a password manager.
+const credentialsCommandTimeout = 2 * time.Minute
+
+// CredentialsCommandOutput is the JSON object printed by a credentials command. The API credentials are
+// either the API token, or the username and password. The SSH fields are optional.
+type CredentialsCommandOutput struct {
+	APIToken                string `json:"api_token,omitempty"`
+	Username                string `json:"username,omitempty"`
+	Password                string `json:"password,omitempty"`
+	SSHUsername             string `json:"ssh_username,omitempty"`
+	SSHPassword             string `json:"ssh_password,omitempty"`
+	SSHPrivateKey           string `json:"ssh_private_key,omitempty"`
+	SSHPrivateKeyPassphrase string `json:"ssh_private_key_passphrase,omitempty"`
+}
+
+// HasAPICredentials returns true if the output contains credentials for the API.
+func (o CredentialsCommandOutput) HasAPICredentials() bool {
+	return o.APIToken != "" || o.Username != "" || o.Password != ""
+}
+
+// CommandCredentials contains the credentials command, which is run again to re-issue the API credentials
+// when they are rejected by the server.
+type CommandCredentials struct {
+	Command []string
+
+	// authenticator is the authenticator shared by the clients using the command, nil if it is not shared
+	authenticator *commandAuthenticator
+}
+
+// CredentialsCommands runs the credentials commands, and shares the credentials they print between the API clients
+// using the same command, e.g. the clients of the SDK and framework providers. A shared command is run once, and once
+// again when the credentials are rejected, whatever the number of clients using it.
+// A nil CredentialsCommands runs the command for each client.
+type CredentialsCommands struct {
+	mu             sync.Mutex
+	authenticators map[string]*commandAuthenticator
+}
+
+// NewCredentialsCommands creates a new runner of the credentials commands sharing their credentials.
+func NewCredentialsCommands() *CredentialsCommands {
+	return &CredentialsCommands{
+		authenticators: map[string]*commandAuthenticator{},
+	}
+}
+
+// Run returns the credentials printed by the command. A shared command is only run if it has not been run yet,
+// or if the credentials it printed last have been rejected.
+func (c *CredentialsCommands) Run(ctx context.Context, command []string) (CredentialsCommandOutput, error) {
+	if c == nil {
+		return RunCredentialsCommand(ctx, command)
+	}
+
+	return c.authenticator(command).output(ctx)
+}
+
+// NewCredentials creates the credentials from the output of the command, see NewCommandCredentials.
+// The API clients created with the credentials share the authenticator of the command.
+func (c *CredentialsCommands) NewCredentials(command []string, output CredentialsCommandOutput) (Credentials, error) {
+	creds, err := NewCommandCredentials(command, output)
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	if c != nil {
+		creds.CommandCredentials.authenticator = c.authenticator(command)
+	}
+
+	return creds, nil
+}
+
+func (c *CredentialsCommands) authenticator(command []string) *commandAuthenticator {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// the arguments cannot contain a NUL byte, so the key is unique for each command
+	key := strings.Join(command, "\x00")
+
+	a, ok := c.authenticators[key]
+	if !ok {
+		a = newCommandAuthenticator(command)
+		c.authenticators[key] = a
+	}
+
+	return a
+}
+
+// RunCredentialsCommand runs the credentials command, and decodes the credentials it prints to stdout.
+func RunCredentialsCommand(ctx context.Context, command []string) (CredentialsCommandOutput, error) {
+	if len(command) == 0 || command[0] == "" {
+		return CredentialsCommandOutput{}, errors.New("the credentials command is empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, credentialsCommandTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+
+	//nolint:gosec // the command is configured by the user on purpose
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	tflog.Debug(ctx, "Running the credentials command", map[string]interface{}{
+		"command": command[0],
+	})
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return CredentialsCommandOutput{}, fmt.Errorf("the credentials command failed: %w: %s", err, msg)
+		}
+
+		return CredentialsCommandOutput{}, fmt.Errorf("the credentials command failed: %w", err)
+	}
+
+	var out CredentialsCommandOutput
+
+	// the output is not included in the error, as it holds secrets
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		return CredentialsCommandOutput{}, errors.New("the credentials command did not print a valid JSON object")
+	}
+
+	return out, nil
+}
+
+// NewCommandCredentials creates the credentials from the output of the credentials command.
+// The API client runs the command again when the credentials are rejected by the server.
+func NewCommandCredentials(command []string, output CredentialsCommandOutput) (Credentials, error) {
+	creds, err := output.credentials()
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	creds.CommandCredentials = &CommandCredentials{Command: command}
+
+	return creds, nil
+}
+
+func (o CredentialsCommandOutput) credentials() (Credentials, error) {
+	creds, err := NewCredentials(o.Username, o.Password, "", o.APIToken, "", "")
+	if err != nil {
+		return Credentials{}, fmt.Errorf("invalid credentials printed by the credentials command: %w", err)
+	}
+
+	return creds, nil
+}
+
+type commandAuthenticator struct {
+	command []string
+
+	// run runs the credentials command
+	run func(ctx context.Context, command []string) (CredentialsCommandOutput, error)
+
+	mu sync.Mutex
+	// conn is the connection of the first client using the authenticator
+	conn *Connection
+	// out is the output of the last run of the command, nil once the credentials have been rejected
+	out *CredentialsCommandOutput
+	// auth authenticates with the credentials printed last, nil once they have been rejected
+	auth Authenticator
+}
+
+func newCommandAuthenticator(command []string) *commandAuthenticator {
+	return &commandAuthenticator{
+		command: command,
+		run:     RunCredentialsCommand,
+	}
+}
+
+// NewCommandAuthenticator creates a new authenticator using the credentials printed by a command,
+// which is run again to re-issue the credentials when they are rejected by the server.
+// The authenticator is shared with the other clients if the credentials were created by CredentialsCommands.
+func NewCommandAuthenticator(creds Credentials, conn *Connection) (RenewableAuthenticator, error) {
+	if creds.CommandCredentials == nil {
+		return nil, errors.New("no credentials command provided")
+	}
+
+	a := creds.CommandCredentials.authenticator
+	if a == nil {
+		a = newCommandAuthenticator(creds.CommandCredentials.Command)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.conn == nil {
+		a.conn = conn
+	}
+
+	if a.auth != nil {
+		return a, nil
+	}
+
+	creds.CommandCredentials = nil
+
+	auth, err := newAuthenticator(creds, a.conn)
+	if err != nil {
+		return nil, err
+	}
+
+	a.auth = auth
+
+	return a, nil
+}
+
+// output returns the output of the last run of the command, running it if there is none.
+func (a *commandAuthenticator) output(ctx context.Context) (CredentialsCommandOutput, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.outputLocked(ctx)
+}
+
+func (a *commandAuthenticator) outputLocked(ctx context.Context) (CredentialsCommandOutput, error) {
+	if a.out != nil {
+		return *a.out, nil
+	}
+
+	out, err := a.run(ctx, a.command)
+	if err != nil {
+		return CredentialsCommandOutput{}, err
+	}
+
+	a.out = &out
+
+	return out, nil
+}
+
+// current returns the authenticator of the current credentials, running the command if there are none.
+func (a *commandAuthenticator) current(ctx context.Context) (Authenticator, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.auth != nil {
+		return a.auth, nil
+	}
+
+	out, err := a.outputLocked(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	creds, err := out.credentials()
+	if err != nil {
+		return nil, err
+	}
+
+	auth, err := newAuthenticator(creds, a.conn)
+	if err != nil {
+		return nil, err
+	}
+
+	a.auth = auth
+
+	return auth, nil
+}
+
+// Invalidate discards the current credentials, so the command is run again for the next request.
+func (a *commandAuthenticator) Invalidate(ctx context.Context) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	tflog.Debug(ctx, "The credentials were rejected, the credentials command will be run again")
+
+	a.out = nil
+	a.auth = nil
+}
+
+func (a *commandAuthenticator) IsRoot(ctx context.Context) bool {
+	auth, err := a.current(ctx)
+	if err != nil {
+		tflog.Warn(ctx, "Failed to run the credentials command while checking root status", map[string]interface{}{
+			"error": err.Error(),
+		})
+
+		return false
+	}
+
+	return auth.IsRoot(ctx)
+}
+
+func (a *commandAuthenticator) IsRootTicket(ctx context.Context) bool {
+	auth, err := a.current(ctx)
+	if err != nil {
+		return false
+	}
+
+	return auth.IsRootTicket(ctx)
+}
+
+// AuthenticateRequest adds authentication data to a new request.
+func (a *commandAuthenticator) AuthenticateRequest(ctx context.Context, req *http.Request) error {
+	auth, err := a.current(ctx)
+	if err != nil {
+		return err
+	}
+
+	return auth.AuthenticateRequest(ctx, req) //nolint:wrapcheck
+}
@@ -0,0 +1,234 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testTokenA = "terraform@pve!provider=aaaaaaaa-0000-0000-0000-000000000000"
+	testTokenB = "terraform@pve!provider=bbbbbbbb-0000-0000-0000-000000000000"
+)
+
+// credentialsFile is the output of a credentials command printing the content of a file.
+type credentialsFile struct {
+	path    string
+	command []string
+}
+
+func newCredentialsFile(t *testing.T, content string) *credentialsFile {
+	t.Helper()
+
+	f := &credentialsFile{path: filepath.Join(t.TempDir(), "credentials.json")}
+	f.command = []string{"cat", f.path}
+	f.write(t, content)
+
+	return f
+}
+
+func (f *credentialsFile) write(t *testing.T, content string) {
+	t.Helper()
+
+	require.NoError(t, os.WriteFile(f.path, []byte(content), 0o600))
+}
+
+func TestRunCredentialsCommand(t *testing.T) {
+	t.Parallel()
+
+	f := newCredentialsFile(t, `{"api_token":"`+testTokenA+`","ssh_username":"terraform","ssh_private_key":"key"}`)
+
+	out, err := RunCredentialsCommand(t.Context(), f.command)
+	require.NoError(t, err)
+	assert.Equal(t, CredentialsCommandOutput{
+		APIToken:      testTokenA,
+		SSHUsername:   "terraform",
+		SSHPrivateKey: "key",
+	}, out)
+	assert.True(t, out.HasAPICredentials())
+
+	creds, err := NewCommandCredentials(f.command, out)
+	require.NoError(t, err)
+	require.NotNil(t, creds.TokenCredentials)
+	require.NotNil(t, creds.CommandCredentials)
+	assert.Equal(t, f.command, creds.CommandCredentials.Command)
+
+	f.write(t, `not json`)
+
+	_, err = RunCredentialsCommand(t.Context(), f.command)
+	require.ErrorContains(t, err, "did not print a valid JSON object")
+
+	_, err = RunCredentialsCommand(t.Context(), []string{"cat", filepath.Join(t.TempDir(), "missing")})
+	require.ErrorContains(t, err, "the credentials command failed")
+	require.ErrorContains(t, err, "No such file or directory", "the error output of the command must be reported")
+
+	_, err = RunCredentialsCommand(t.Context(), nil)
+	require.ErrorContains(t, err, "is empty")
+
+	_, err = NewCommandCredentials(f.command, CredentialsCommandOutput{SSHUsername: "terraform"})
+	require.Error(t, err)
+}
+
+func TestCommandAuthenticatorRerunsCommandOnUnauthorized(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu    sync.Mutex
+		token = testTokenA
+	)
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if r.Header.Get("Authorization") != "PVEAPIToken="+token {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"data":{"version":"8.3.0"}}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	f := newCredentialsFile(t, fmt.Sprintf(`{"api_token":%q}`, testTokenA))
+
+	out, err := RunCredentialsCommand(t.Context(), f.command)
+	require.NoError(t, err)
+
+	creds, err := NewCommandCredentials(f.command, out)
+	require.NoError(t, err)
+
+	conn, err := NewConnection(srv.URL, true, "")
+	require.NoError(t, err)
+
+	c, err := NewClient(creds, conn)
+	require.NoError(t, err)
+
+	auth, ok := c.(*client).auth.(*commandAuthenticator)
+	require.True(t, ok)
+
+	runs := 0
+	auth.run = func(ctx context.Context, command []string) (CredentialsCommandOutput, error) {
+		runs++
+		return RunCredentialsCommand(ctx, command)
+	}
+
+	require.NoError(t, c.DoRequest(t.Context(), http.MethodGet, "version", nil, nil))
+	assert.Equal(t, 0, runs, "the credentials of the first run must be reused")
+
+	// the token is rotated, e.g. it was short-lived
+	mu.Lock()
+	token = testTokenB
+	mu.Unlock()
+
+	f.write(t, fmt.Sprintf(`{"api_token":%q}`, testTokenB))
+
+	require.NoError(t, c.DoRequest(t.Context(), http.MethodGet, "version", nil, nil))
+	assert.Equal(t, 1, runs, "the command must be run again once the credentials are rejected")
+
+	require.NoError(t, c.DoRequest(t.Context(), http.MethodGet, "version", nil, nil))
+	assert.Equal(t, 1, runs)
+}
+
+func TestCredentialsCommandsShareTheCommand(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu    sync.Mutex
+		token = testTokenA
+	)
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if r.Header.Get("Authorization") != "PVEAPIToken="+token {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"data":{"version":"8.3.0"}}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	f := newCredentialsFile(t, fmt.Sprintf(`{"api_token":%q}`, testTokenA))
+
+	commands := NewCredentialsCommands()
+
+	var runs atomic.Int32
+
+	commands.authenticator(f.command).run = func(ctx context.Context, command []string) (CredentialsCommandOutput, error) {
+		runs.Add(1)
+		return RunCredentialsCommand(ctx, command)
+	}
+
+	// the clients of the SDK and framework providers
+	clients := make([]Client, 2)
+
+	for i := range clients {
+		out, err := commands.Run(t.Context(), f.command)
+		require.NoError(t, err)
+
+		creds, err := commands.NewCredentials(f.command, out)
+		require.NoError(t, err)
+
+		conn, err := NewConnection(srv.URL, true, "")
+		require.NoError(t, err)
+
+		clients[i], err = NewClient(creds, conn)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, int32(1), runs.Load(), "the command must be run once for all the clients")
+
+	for _, c := range clients {
+		require.NoError(t, c.DoRequest(t.Context(), http.MethodGet, "version", nil, nil))
+	}
+
+	assert.Equal(t, int32(1), runs.Load())
+
+	// the token is rotated
+	mu.Lock()
+	token = testTokenB
+	mu.Unlock()
+
+	f.write(t, fmt.Sprintf(`{"api_token":%q}`, testTokenB))
+
+	for _, c := range clients {
+		require.NoError(t, c.DoRequest(t.Context(), http.MethodGet, "version", nil, nil))
+	}
+
+	assert.Equal(t, int32(2), runs.Load(), "the command must be run again once for all the clients")
+}
+
+func TestNilCredentialsCommandsRunTheCommand(t *testing.T) {
+	t.Parallel()
+
+	f := newCredentialsFile(t, fmt.Sprintf(`{"api_token":%q}`, testTokenA))
+
+	var commands *CredentialsCommands
+
+	out, err := commands.Run(t.Context(), f.command)
+	require.NoError(t, err)
+	assert.Equal(t, testTokenA, out.APIToken)
+
+	creds, err := commands.NewCredentials(f.command, out)
+	require.NoError(t, err)
+	require.NotNil(t, creds.CommandCredentials)
+	assert.Nil(t, creds.CommandCredentials.authenticator)
+}
@@ -28,6 +28,9 @@ type Credentials struct {
 	UserCredentials   *UserCredentials
 	TokenCredentials  *TokenCredentials
 	TicketCredentials *TicketCredentials
+
+	// CommandCredentials is set if the user or token credentials were printed by a credentials command.
+	CommandCredentials *CommandCredentials
 }
 
 // UserCredentials contains the username, password, and OTP for authenticating with the Proxmox VE API.
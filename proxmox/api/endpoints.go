@@ -0,0 +1,154 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/bpg/terraform-provider-proxmox/utils"
+)
+
+// healthCheckTimeout limits the time spent checking whether a failover endpoint is available.
+const healthCheckTimeout = 5 * time.Second
+
+// Endpoint returns the endpoint currently used for requests.
+func (c *Connection) Endpoint() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.endpoints[c.active]
+}
+
+// EndpointCount returns the number of endpoints configured for the connection.
+func (c *Connection) EndpointCount() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return len(c.endpoints)
+}
+
+// failover switches to the next healthy endpoint after a request to the failed endpoint did not succeed.
+// The new endpoint stays active until it fails as well. Returns false if no other endpoint is available.
+// The health checks run without holding the lock, so that concurrent requests are not blocked meanwhile.
+func (c *Connection) failover(ctx context.Context, failed string) bool {
+	c.mu.RLock()
+	active := c.active
+	c.mu.RUnlock()
+
+	if c.endpoints[active] != failed {
+		// a concurrent request has already switched to another endpoint
+		return true
+	}
+
+	for i := 1; i < len(c.endpoints); i++ {
+		next := (active + i) % len(c.endpoints)
+
+		if err := c.checkHealth(ctx, c.endpoints[next]); err != nil {
+			tflog.Debug(ctx, "failover endpoint is not available", map[string]interface{}{
+				"endpoint": c.endpoints[next],
+				"error":    err.Error(),
+			})
+
+			continue
+		}
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		if c.endpoints[c.active] != failed {
+			// a concurrent request has switched to another endpoint during the health checks
+			return true
+		}
+
+		tflog.Warn(ctx, "switching to a failover endpoint", map[string]interface{}{
+			"failed_endpoint": failed,
+			"endpoint":        c.endpoints[next],
+		})
+
+		c.active = next
+
+		return true
+	}
+
+	return false
+}
+
+// checkHealth checks whether the API on the endpoint responds. The request is not authenticated, so
+// any response other than a server error means the endpoint is available.
+func (c *Connection) checkHealth(ctx context.Context, endpoint string) error {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		fmt.Sprintf("%s/%s/version", endpoint, basePathJSONAPI),
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create health check request: %w", err)
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to perform health check request: %w", err)
+	}
+
+	defer utils.CloseOrLogError(ctx)(res.Body)
+
+	if res.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("health check failed with HTTP %d", res.StatusCode)
+	}
+
+	return nil
+}
+
+// isFailoverError returns true if the error indicates that the endpoint itself is unavailable,
+// rather than the request being rejected by the API.
+func isFailoverError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Code == http.StatusBadGateway ||
+			httpErr.Code == http.StatusServiceUnavailable ||
+			httpErr.Code == http.StatusGatewayTimeout
+	}
+
+	var urlErr *url.Error
+
+	return errors.As(err, &urlErr)
+}
+
+// canFailover returns true if a request with the given method that failed with err can be safely re-sent
+// to another endpoint.
+func (p RetryPolicy) canFailover(method string, err error) bool {
+	if isIdempotent(method) || p.RetryNonIdempotent {
+		return true
+	}
+
+	// the request has not reached the API if the connection could not be established,
+	// or if pveproxy refused it
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+
+	var httpErr *HTTPError
+
+	return errors.As(err, &httpErr) && httpErr.Code == http.StatusServiceUnavailable
+}
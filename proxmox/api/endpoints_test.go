@@ -0,0 +1,162 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newNodeServer starts a TLS server that responds to all requests with the given status code.
+func newNodeServer(t *testing.T, status int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"data":null}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, &requests
+}
+
+// newDeadEndpoint returns the URL of a server that is no longer listening.
+func newDeadEndpoint(t *testing.T) string {
+	t.Helper()
+
+	srv := httptest.NewTLSServer(http.NotFoundHandler())
+	srv.Close()
+
+	return srv.URL
+}
+
+func newFailoverClient(t *testing.T, endpoints ...string) *client {
+	t.Helper()
+
+	conn, err := NewConnection(endpoints[0], true, "",
+		WithFailoverEndpoints(endpoints[1:]...),
+		WithRetryPolicy(RetryPolicy{Attempts: 2, InitialDelay: time.Millisecond}),
+	)
+	require.NoError(t, err)
+
+	return &client{conn: conn, auth: dummyAuthenticator{}}
+}
+
+func TestConnectionFailover(t *testing.T) {
+	t.Parallel()
+
+	dead := newDeadEndpoint(t)
+	unhealthy, _ := newNodeServer(t, http.StatusServiceUnavailable)
+	healthy, requests := newNodeServer(t, http.StatusOK)
+
+	c := newFailoverClient(t, dead, unhealthy.URL, healthy.URL)
+
+	require.NoError(t, c.DoRequest(t.Context(), http.MethodGet, "version", nil, nil))
+	assert.Equal(t, healthy.URL, c.conn.Endpoint(), "the healthy endpoint must be selected")
+
+	require.NoError(t, c.DoRequest(t.Context(), http.MethodGet, "version", nil, nil))
+	assert.Equal(t, healthy.URL, c.conn.Endpoint(), "the selected endpoint must be sticky")
+
+	// one health check and two requests
+	assert.Equal(t, int32(3), requests.Load())
+}
+
+func TestConnectionFailoverNonIdempotent(t *testing.T) {
+	t.Parallel()
+
+	t.Run("connection refused", func(t *testing.T) {
+		t.Parallel()
+
+		healthy, _ := newNodeServer(t, http.StatusOK)
+		c := newFailoverClient(t, newDeadEndpoint(t), healthy.URL)
+
+		require.NoError(t, c.DoRequest(t.Context(), http.MethodPost, "nodes/pve/qemu", nil, nil))
+	})
+
+	t.Run("bad gateway", func(t *testing.T) {
+		t.Parallel()
+
+		badGateway, _ := newNodeServer(t, http.StatusBadGateway)
+		healthy, requests := newNodeServer(t, http.StatusOK)
+		c := newFailoverClient(t, badGateway.URL, healthy.URL)
+
+		// the request might have been processed, so it must not be re-sent
+		err := c.DoRequest(t.Context(), http.MethodPost, "nodes/pve/qemu", nil, nil)
+		require.Error(t, err)
+		assert.Equal(t, healthy.URL, c.conn.Endpoint(), "subsequent requests must use the healthy endpoint")
+		assert.Equal(t, int32(1), requests.Load(), "only the health check must reach the healthy endpoint")
+	})
+}
+
+func TestConnectionNoHealthyEndpoint(t *testing.T) {
+	t.Parallel()
+
+	dead := newDeadEndpoint(t)
+	c := newFailoverClient(t, dead, newDeadEndpoint(t))
+
+	require.Error(t, c.DoRequest(t.Context(), http.MethodGet, "version", nil, nil))
+	assert.Equal(t, dead, c.conn.Endpoint())
+}
+
+func TestConnectionFailoverDoesNotBlockRequests(t *testing.T) {
+	t.Parallel()
+
+	checking := make(chan struct{})
+	release := make(chan struct{})
+
+	var once sync.Once
+
+	slow := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		once.Do(func() {
+			// the first request is the health check
+			close(checking)
+			<-release
+		})
+
+		_, _ = w.Write([]byte(`{"data":null}`))
+	}))
+	t.Cleanup(slow.Close)
+
+	dead := newDeadEndpoint(t)
+	c := newFailoverClient(t, dead, slow.URL)
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- c.DoRequest(t.Context(), http.MethodGet, "version", nil, nil)
+	}()
+
+	<-checking
+
+	endpoint := make(chan string, 1)
+
+	go func() {
+		endpoint <- c.conn.Endpoint()
+	}()
+
+	select {
+	case e := <-endpoint:
+		assert.Equal(t, dead, e, "the endpoint must not change before the health check succeeds")
+	case <-time.After(time.Second):
+		t.Error("reading the active endpoint must not wait for the health check")
+	}
+
+	close(release)
+
+	require.NoError(t, <-done)
+	assert.Equal(t, slow.URL, c.conn.Endpoint())
+}
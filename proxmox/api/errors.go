@@ -8,6 +8,8 @@ package api
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 )
 
 // Error is a sentinel error type for API errors.
@@ -23,6 +25,26 @@ const ErrNoDataObjectInResponse Error = "the server did not include a data objec
 // ErrResourceDoesNotExist is returned when the requested resource does not exist.
 const ErrResourceDoesNotExist Error = "the requested resource does not exist"
 
+// ErrAlreadyExists is returned when the resource to be created already exists.
+const ErrAlreadyExists Error = "the resource already exists"
+
+// ErrConfigLocked is returned when the server timed out waiting for a configuration lock held by
+// another operation. The request has not been processed, so it is safe to retry.
+const ErrConfigLocked Error = "the configuration is locked by another operation"
+
+// ErrPermissionDenied is returned when the user is missing a privilege. Use PermissionError to get the
+// details.
+const ErrPermissionDenied Error = "permission denied"
+
+// ErrQuorumLost is returned when the cluster has no quorum, so the configuration cannot be changed.
+const ErrQuorumLost Error = "the cluster has lost quorum"
+
+// ErrStorageFull is returned when there is not enough free space on the storage.
+const ErrStorageFull Error = "there is not enough free space on the storage"
+
+// ErrTaskFailed is returned when a task completes with an exit status other than OK.
+const ErrTaskFailed Error = "the task has failed"
+
 // HTTPError is a generic error type for HTTP errors.
 type HTTPError struct {
 	Code    int
@@ -32,3 +54,72 @@ type HTTPError struct {
 func (err HTTPError) Error() string {
 	return fmt.Sprintf("received an HTTP %d response - Reason: %s", err.Code, err.Message)
 }
+
+// PermissionError is returned when the API rejects a request because the user is missing a privilege.
+type PermissionError struct {
+	// Path is the ACL path of the permission check, e.g. "/vms/100". Empty if not reported.
+	Path string
+	// Privileges are the privileges of which at least one is required, e.g. "VM.Config.Disk".
+	Privileges []string
+}
+
+func (err *PermissionError) Error() string {
+	if err.Path == "" {
+		return string(ErrPermissionDenied)
+	}
+
+	return fmt.Sprintf("%s: missing privilege %s on %s", ErrPermissionDenied,
+		strings.Join(err.Privileges, " or "), err.Path)
+}
+
+// Is makes PermissionError match ErrPermissionDenied.
+func (err *PermissionError) Is(target error) bool {
+	return target == ErrPermissionDenied //nolint:errorlint
+}
+
+// permissionCheckRegex matches messages like "Permission check failed (/vms/100, VM.Config.Disk)".
+var permissionCheckRegex = regexp.MustCompile(`Permission check failed \(([^,)]+), ([^)]+)\)`)
+
+// messageErrors maps lowercase fragments of error messages returned by the API to sentinel errors.
+var messageErrors = []struct {
+	fragments []string
+	err       error
+}{
+	{[]string{"can't lock file", "got timeout"}, ErrConfigLocked},
+	{[]string{"already exists"}, ErrAlreadyExists},
+	{[]string{"no quorum"}, ErrQuorumLost},
+	{[]string{"no space left on device"}, ErrStorageFull},
+	{[]string{"not enough free space"}, ErrStorageFull},
+	{[]string{"insufficient free space"}, ErrStorageFull},
+	{[]string{"out of space"}, ErrStorageFull},
+}
+
+// MatchError returns the error matching an error message returned by the API, e.g. the reason of an
+// HTTP error or the exit status of a task. Returns nil if the message is not recognized.
+func MatchError(message string) error {
+	if m := permissionCheckRegex.FindStringSubmatch(message); m != nil {
+		return &PermissionError{
+			Path:       strings.TrimSpace(m[1]),
+			Privileges: strings.Split(strings.TrimSpace(m[2]), "|"),
+		}
+	}
+
+	message = strings.ToLower(message)
+
+	for _, me := range messageErrors {
+		matches := true
+
+		for _, f := range me.fragments {
+			if !strings.Contains(message, f) {
+				matches = false
+				break
+			}
+		}
+
+		if matches {
+			return me.err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package api
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		message string
+		want    error
+	}{
+		{"can't lock file '/var/lock/qemu-server/lock-100.conf' - got timeout", ErrConfigLocked},
+		{"unable to create VM 100 - VM 100 already exists on node 'pve'", ErrAlreadyExists},
+		{"cluster not ready - no quorum? (500)", ErrQuorumLost},
+		{"zfs error: cannot create 'rpool/data/vm-100-disk-0': out of space", ErrStorageFull},
+		{"write failed: No space left on device", ErrStorageFull},
+		{"Volume group \"pve\" has insufficient free space (100 extents): 2560 required.", ErrStorageFull},
+		{"Permission check failed (/vms/100, VM.Config.Disk)", ErrPermissionDenied},
+		{"got timeout", nil},
+		{"some other error", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.message, func(t *testing.T) {
+			t.Parallel()
+
+			err := MatchError(tt.message)
+			if tt.want == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateResponseCodeClassifiesErrors(t *testing.T) {
+	t.Parallel()
+
+	response := func(status string, body string) *http.Response {
+		code, err := strconv.Atoi(status[:3])
+		require.NoError(t, err)
+
+		return &http.Response{
+			StatusCode: code,
+			Status:     status,
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}
+	}
+
+	t.Run("permission denied", func(t *testing.T) {
+		t.Parallel()
+
+		err := validateResponseCode(response("403 Permission check failed (/vms/100, VM.Config.Disk|VM.Allocate)", "{}"))
+
+		var permErr *PermissionError
+
+		require.ErrorAs(t, err, &permErr)
+		assert.Equal(t, "/vms/100", permErr.Path)
+		assert.Equal(t, []string{"VM.Config.Disk", "VM.Allocate"}, permErr.Privileges)
+		require.ErrorIs(t, err, ErrPermissionDenied)
+
+		var httpErr *HTTPError
+
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusForbidden, httpErr.Code)
+	})
+
+	t.Run("forbidden without details", func(t *testing.T) {
+		t.Parallel()
+
+		err := validateResponseCode(response("403 Forbidden", ""))
+		require.ErrorIs(t, err, ErrPermissionDenied)
+	})
+
+	t.Run("already exists in parameter errors", func(t *testing.T) {
+		t.Parallel()
+
+		err := validateResponseCode(response("400 Parameter verification failed.",
+			`{"errors":{"vmid":"VM 100 already exists"}}`))
+		require.ErrorIs(t, err, ErrAlreadyExists)
+	})
+
+	t.Run("unclassified", func(t *testing.T) {
+		t.Parallel()
+
+		err := validateResponseCode(response("500 Internal Server Error", ""))
+
+		var httpErr *HTTPError
+
+		require.ErrorAs(t, err, &httpErr)
+		assert.False(t, errors.Is(err, ErrAlreadyExists))
+		assert.False(t, errors.Is(err, ErrPermissionDenied))
+	})
+}
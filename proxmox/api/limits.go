@@ -0,0 +1,179 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// Limits defines client-side limits for the load put on the Proxmox VE API. Zero values disable the limits.
+type Limits struct {
+	// MaxConcurrentRequests is the maximum number of API requests in flight at the same time.
+	MaxConcurrentRequests int
+	// MaxConcurrentTasksPerNode is the maximum number of long-running tasks, e.g. VM clones,
+	// started by the client that run on a single node at the same time.
+	MaxConcurrentTasksPerNode int
+	// RequestsPerSecond is the maximum rate of API requests.
+	RequestsPerSecond float64
+}
+
+// WithLimits sets the limits for the requests made over the connection.
+func WithLimits(limits Limits) ConnectionOption {
+	return WithSharedLimits(nil, limits)
+}
+
+// WithSharedLimits sets the limits for the requests made over the connection, which are shared with the other
+// connections to the same endpoint using the same shared limiters and limits. A nil shared limiters is not shared.
+func WithSharedLimits(shared *SharedLimiters, limits Limits) ConnectionOption {
+	return func(c *Connection) error {
+		if limits.MaxConcurrentRequests < 0 || limits.MaxConcurrentTasksPerNode < 0 || limits.RequestsPerSecond < 0 {
+			return fmt.Errorf("API limits must not be negative")
+		}
+
+		c.limiter = shared.limiter(c.endpoints[0], limits)
+
+		return nil
+	}
+}
+
+// SharedLimiters shares the state of the limits between several connections, e.g. the connections of the SDK
+// and framework providers of a provider instance, so that the limits apply to all of them together.
+type SharedLimiters struct {
+	mu       sync.Mutex
+	limiters map[limiterKey]*limiter
+}
+
+type limiterKey struct {
+	endpoint string
+	limits   Limits
+}
+
+// NewSharedLimiters creates new limiters to share between connections.
+func NewSharedLimiters() *SharedLimiters {
+	return &SharedLimiters{limiters: map[limiterKey]*limiter{}}
+}
+
+func (s *SharedLimiters) limiter(endpoint string, limits Limits) *limiter {
+	if s == nil {
+		return newLimiter(limits)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := limiterKey{endpoint: endpoint, limits: limits}
+
+	l, ok := s.limiters[key]
+	if !ok {
+		l = newLimiter(limits)
+		s.limiters[key] = l
+	}
+
+	return l
+}
+
+// limiter holds the state of the limits.
+type limiter struct {
+	requestSlots    chan struct{}
+	rateLimiter     *rate.Limiter
+	maxTasksPerNode int
+	taskSlots       map[string]chan struct{}
+	taskSlotsMu     sync.Mutex
+}
+
+func newLimiter(limits Limits) *limiter {
+	l := &limiter{
+		maxTasksPerNode: limits.MaxConcurrentTasksPerNode,
+		taskSlots:       map[string]chan struct{}{},
+	}
+
+	if limits.MaxConcurrentRequests > 0 {
+		l.requestSlots = make(chan struct{}, limits.MaxConcurrentRequests)
+	}
+
+	if limits.RequestsPerSecond > 0 {
+		burst := max(int(math.Ceil(limits.RequestsPerSecond)), 1)
+		l.rateLimiter = rate.NewLimiter(rate.Limit(limits.RequestsPerSecond), burst)
+	}
+
+	return l
+}
+
+// acquireRequestSlot waits until a request can be sent without exceeding the limits.
+// The returned function must be called when the response has been read.
+func (c *Connection) acquireRequestSlot(ctx context.Context) (func(), error) {
+	if c.limiter == nil {
+		return func() {}, nil
+	}
+
+	if c.limiter.rateLimiter != nil {
+		if err := c.limiter.rateLimiter.Wait(ctx); err != nil {
+			return nil, fmt.Errorf("failed to wait for the API request rate limit: %w", err)
+		}
+	}
+
+	return acquireSlot(ctx, c.limiter.requestSlots)
+}
+
+// AcquireTaskSlot waits until a long-running task can be started on the node without exceeding the limits.
+// The returned function must be called when the task has completed.
+func (c *Connection) AcquireTaskSlot(ctx context.Context, node string) (func(), error) {
+	if c.limiter == nil || c.limiter.maxTasksPerNode == 0 {
+		return func() {}, nil
+	}
+
+	l := c.limiter
+
+	l.taskSlotsMu.Lock()
+
+	slots, ok := l.taskSlots[node]
+	if !ok {
+		slots = make(chan struct{}, l.maxTasksPerNode)
+		l.taskSlots[node] = slots
+	}
+
+	l.taskSlotsMu.Unlock()
+
+	return acquireSlot(ctx, slots)
+}
+
+// acquireSlot takes a slot from the semaphore, which is unlimited if nil.
+func acquireSlot(ctx context.Context, slots chan struct{}) (func(), error) {
+	if slots == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case slots <- struct{}{}:
+		var once sync.Once
+
+		return func() {
+			once.Do(func() { <-slots })
+		}, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("failed to wait for the API concurrency limit: %w", ctx.Err())
+	}
+}
+
+// releasingBody releases the request slot when the response body is closed.
+type releasingBody struct {
+	io.ReadCloser
+
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	defer b.release()
+
+	return b.ReadCloser.Close() //nolint:wrapcheck
+}
@@ -0,0 +1,141 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnectionLimitsConcurrentRequests(t *testing.T) {
+	t.Parallel()
+
+	var inFlight, maxInFlight atomic.Int32
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+
+		time.Sleep(20 * time.Millisecond)
+
+		_, _ = w.Write([]byte(`{"data":null}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	conn, err := NewConnection(srv.URL, true, "", WithLimits(Limits{MaxConcurrentRequests: 2}))
+	require.NoError(t, err)
+
+	c := &client{conn: conn, auth: dummyAuthenticator{}}
+
+	var wg sync.WaitGroup
+
+	for range 8 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			assert.NoError(t, c.DoRequest(t.Context(), http.MethodGet, "version", nil, nil))
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, int32(2), maxInFlight.Load())
+}
+
+func TestConnectionTaskSlots(t *testing.T) {
+	t.Parallel()
+
+	conn, err := NewConnection("https://localhost", true, "", WithLimits(Limits{MaxConcurrentTasksPerNode: 1}))
+	require.NoError(t, err)
+
+	release, err := conn.AcquireTaskSlot(t.Context(), "pve1")
+	require.NoError(t, err)
+
+	// another node is not affected
+	releaseOther, err := conn.AcquireTaskSlot(t.Context(), "pve2")
+	require.NoError(t, err)
+	releaseOther()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = conn.AcquireTaskSlot(ctx, "pve1")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	release()
+	release() // releasing twice must not free another slot
+
+	release, err = conn.AcquireTaskSlot(t.Context(), "pve1")
+	require.NoError(t, err)
+	release()
+
+	unlimited, err := NewConnection("https://localhost", true, "")
+	require.NoError(t, err)
+
+	for range 3 {
+		_, err = unlimited.AcquireTaskSlot(t.Context(), "pve1")
+		require.NoError(t, err)
+	}
+}
+
+func TestSharedLimiters(t *testing.T) {
+	t.Parallel()
+
+	shared := NewSharedLimiters()
+	limits := Limits{MaxConcurrentTasksPerNode: 1}
+
+	newConnection := func(endpoint string, shared *SharedLimiters) *Connection {
+		conn, err := NewConnection(endpoint, true, "", WithSharedLimits(shared, limits))
+		require.NoError(t, err)
+
+		return conn
+	}
+
+	first := newConnection("https://pve.example.com:8006", shared)
+	second := newConnection("https://pve.example.com:8006/", shared)
+	other := newConnection("https://other.example.com:8006", shared)
+	unshared := newConnection("https://pve.example.com:8006", nil)
+
+	release, err := first.AcquireTaskSlot(t.Context(), "pve1")
+	require.NoError(t, err)
+
+	// the connections to the same endpoint share the slots
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = second.AcquireTaskSlot(ctx, "pve1")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	for _, conn := range []*Connection{other, unshared} {
+		releaseOther, err := conn.AcquireTaskSlot(t.Context(), "pve1")
+		require.NoError(t, err)
+		releaseOther()
+	}
+
+	release()
+
+	release, err = second.AcquireTaskSlot(t.Context(), "pve1")
+	require.NoError(t, err)
+	release()
+}
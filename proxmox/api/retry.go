@@ -0,0 +1,138 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package api
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/avast/retry-go/v4"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// StatusNoRouteToHost is the non-standard status code returned by pveproxy when it cannot reach
+// the node that should handle the request.
+const StatusNoRouteToHost = 595
+
+// RetryPolicy defines how failed API requests are retried.
+type RetryPolicy struct {
+	// Attempts is the maximum number of attempts, including the initial request.
+	Attempts uint
+	// InitialDelay is the delay before the first retry. The delay doubles with each subsequent retry.
+	InitialDelay time.Duration
+	// MaxDelay is the upper bound for the delay between retries.
+	MaxDelay time.Duration
+	// Jitter randomizes the delays, so that parallel operations do not retry in lockstep.
+	Jitter bool
+	// RetryableStatusCodes is the list of HTTP status codes that cause a request to be retried.
+	RetryableStatusCodes []int
+	// RetryNonIdempotent enables retries of requests with methods other than GET and HEAD.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns the retry policy used when none is configured.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		Attempts:     3,
+		InitialDelay: 500 * time.Millisecond,
+		MaxDelay:     5 * time.Second,
+		Jitter:       true,
+		RetryableStatusCodes: []int{
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+			StatusNoRouteToHost,
+		},
+		RetryNonIdempotent: false,
+	}
+}
+
+// retryOptions returns the retry-go options implementing the policy for a request with the given method.
+func (p RetryPolicy) retryOptions(ctx context.Context, method string) []retry.Option {
+	attempts := max(p.Attempts, 1)
+
+	return []retry.Option{
+		retry.Context(ctx),
+		retry.Attempts(attempts),
+		retry.DelayType(p.delay),
+		retry.LastErrorOnly(true),
+		retry.RetryIf(func(err error) bool {
+			return p.isRetryable(method, err)
+		}),
+		retry.OnRetry(func(n uint, err error) {
+			if n+1 < attempts {
+				tflog.Warn(ctx, "retrying HTTP request", map[string]interface{}{
+					"attempt":      n + 1,
+					"max_attempts": attempts,
+					"error":        err.Error(),
+				})
+			}
+		}),
+	}
+}
+
+// delay returns the delay before the n-th retry (starting with 1) using exponential backoff.
+func (p RetryPolicy) delay(n uint, _ error, _ *retry.Config) time.Duration {
+	d := p.InitialDelay
+
+	for i := uint(1); i < n && d < math.MaxInt64/2; i++ {
+		if p.MaxDelay > 0 && d >= p.MaxDelay {
+			break
+		}
+
+		d *= 2
+	}
+
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+
+	if p.Jitter && d > 1 {
+		// keep at least half of the delay, randomize the rest
+		half := d / 2
+		d = half + rand.N(d-half) //nolint:gosec
+	}
+
+	return d
+}
+
+// isRetryable returns true if a request with the given method that failed with err should be retried.
+func (p RetryPolicy) isRetryable(method string, err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, ErrResourceDoesNotExist) {
+		return false
+	}
+
+	if errors.Is(err, ErrConfigLocked) {
+		// the server gave up waiting for the config lock before making any changes,
+		// so the request is safe to retry regardless of the method.
+		return true
+	}
+
+	if !p.RetryNonIdempotent && !isIdempotent(method) {
+		return false
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return slices.Contains(p.RetryableStatusCodes, httpErr.Code)
+	}
+
+	var urlErr *url.Error
+
+	return errors.As(err, &urlErr)
+}
+
+func isIdempotent(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead
+}
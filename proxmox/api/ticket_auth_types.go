@@ -7,6 +7,8 @@
 package api
 
 import (
+	"errors"
+
 	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
 )
 
@@ -34,3 +36,26 @@ type AuthenticationResponseData struct {
 	Ticket              *string                             `json:"ticket,omitempty"`
 	Username            string                              `json:"username"`
 }
+
+// Validate checks that the response contains a complete ticket.
+func (b *AuthenticationResponseBody) Validate() error {
+	if b.Data == nil {
+		return errors.New("the server did not include a data object in the authentication response")
+	}
+
+	if b.Data.CSRFPreventionToken == nil {
+		return errors.New(
+			"the server did not include a CSRF prevention token in the authentication response",
+		)
+	}
+
+	if b.Data.Ticket == nil {
+		return errors.New("the server did not include a ticket in the authentication response")
+	}
+
+	if b.Data.Username == "" {
+		return errors.New("the server did not include the username in the authentication response")
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ErrTLSFingerprintMismatch is returned when the certificate presented by the API endpoint does not
+// match the configured fingerprint.
+var ErrTLSFingerprintMismatch = errors.New("the TLS certificate fingerprint of the endpoint does not match")
+
+// WithCACertificate adds the PEM encoded CA certificates to the certificates trusted by the connection,
+// in addition to the system trust store. The value is either the PEM content or a path to a PEM file.
+func WithCACertificate(certificate string) ConnectionOption {
+	return func(c *Connection) error {
+		if certificate == "" {
+			return nil
+		}
+
+		pemData := []byte(certificate)
+
+		if !bytes.Contains(pemData, []byte("-----BEGIN")) {
+			data, err := os.ReadFile(certificate)
+			if err != nil {
+				return fmt.Errorf("failed to read the CA certificate file: %w", err)
+			}
+
+			pemData = data
+		}
+
+		if c.rootCAs == nil {
+			pool, err := x509.SystemCertPool()
+			if err != nil {
+				pool = x509.NewCertPool()
+			}
+
+			c.rootCAs = pool
+		}
+
+		if !c.rootCAs.AppendCertsFromPEM(pemData) {
+			return errors.New("the CA certificate does not contain any valid PEM encoded certificates")
+		}
+
+		return nil
+	}
+}
+
+// WithTLSFingerprint pins the certificate of the API endpoints to the given SHA-256 fingerprint, as shown
+// in the certificate details of the Proxmox VE web UI, e.g. "AB:CD:...". A certificate matching the
+// fingerprint is accepted without verifying its chain and host name; any other certificate is rejected.
+func WithTLSFingerprint(fingerprint string) ConnectionOption {
+	return func(c *Connection) error {
+		if fingerprint == "" {
+			return nil
+		}
+
+		fp, err := parseTLSFingerprint(fingerprint)
+		if err != nil {
+			return err
+		}
+
+		c.fingerprint = fp
+
+		return nil
+	}
+}
+
+// parseTLSFingerprint decodes a hex encoded SHA-256 fingerprint, with or without colon separators.
+func parseTLSFingerprint(fingerprint string) ([]byte, error) {
+	fp, err := hex.DecodeString(strings.ReplaceAll(strings.TrimSpace(fingerprint), ":", ""))
+	if err != nil || len(fp) != sha256.Size {
+		return nil, fmt.Errorf("invalid TLS fingerprint %q: must be a hex encoded SHA-256 hash", fingerprint)
+	}
+
+	return fp, nil
+}
+
+// TLSConfig returns the TLS configuration for connections to hosts other than the API endpoints, e.g.
+// for downloading files. It trusts the configured CA certificates, but not the pinned fingerprint.
+func (c *Connection) TLSConfig() *tls.Config {
+	return &tls.Config{
+		// deepcode ignore InsecureTLSConfig: the min TLS version is configurable
+		MinVersion:         c.minTLS,
+		RootCAs:            c.rootCAs,
+		InsecureSkipVerify: c.insecure, //nolint:gosec
+	}
+}
+
+// apiTLSConfig returns the TLS configuration for connections to the API endpoints.
+func (c *Connection) apiTLSConfig() *tls.Config {
+	config := c.TLSConfig()
+
+	if c.fingerprint == nil {
+		return config
+	}
+
+	// the standard verification is replaced by the fingerprint check
+	config.InsecureSkipVerify = true
+	config.VerifyConnection = func(cs tls.ConnectionState) error {
+		if len(cs.PeerCertificates) == 0 {
+			return ErrTLSFingerprintMismatch
+		}
+
+		fp := sha256.Sum256(cs.PeerCertificates[0].Raw)
+		if subtle.ConstantTimeCompare(fp[:], c.fingerprint) != 1 {
+			return fmt.Errorf("%w: got %s", ErrTLSFingerprintMismatch, formatTLSFingerprint(fp[:]))
+		}
+
+		return nil
+	}
+
+	return config
+}
+
+// formatTLSFingerprint formats the fingerprint the same way as Proxmox VE does.
+func formatTLSFingerprint(fp []byte) string {
+	parts := make([]string, len(fp))
+	for i, b := range fp {
+		parts[i] = fmt.Sprintf("%02X", b)
+	}
+
+	return strings.Join(parts, ":")
+}
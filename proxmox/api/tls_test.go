@@ -0,0 +1,106 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/pem"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnectionCACertificate(t *testing.T) {
+	t.Parallel()
+
+	srv, _ := newNodeServer(t, http.StatusOK)
+	caPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}))
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, []byte(caPEM), 0o600))
+
+	tests := []struct {
+		name    string
+		opts    []ConnectionOption
+		wantErr bool
+	}{
+		{"system trust store", nil, true},
+		{"PEM content", []ConnectionOption{WithCACertificate(caPEM)}, false},
+		{"PEM file", []ConnectionOption{WithCACertificate(caFile)}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			conn, err := NewConnection(srv.URL, false, "", append(tt.opts, WithRetryPolicy(RetryPolicy{Attempts: 1}))...)
+			require.NoError(t, err)
+
+			c := &client{conn: conn, auth: dummyAuthenticator{}}
+
+			err = c.DoRequest(t.Context(), http.MethodGet, "version", nil, nil)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+
+	_, err := NewConnection(srv.URL, false, "", WithCACertificate("-----BEGIN CERTIFICATE-----\ninvalid"))
+	require.Error(t, err)
+}
+
+func TestConnectionTLSFingerprint(t *testing.T) {
+	t.Parallel()
+
+	srv, _ := newNodeServer(t, http.StatusOK)
+	fp := sha256.Sum256(srv.Certificate().Raw)
+
+	tests := []struct {
+		name        string
+		fingerprint string
+		wantErr     bool
+	}{
+		{"matching", formatTLSFingerprint(fp[:]), false},
+		{"matching lowercase without colons", strings.ToLower(strings.ReplaceAll(formatTLSFingerprint(fp[:]), ":", "")), false},
+		{"mismatching", strings.Repeat("00:", 31) + "00", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			conn, err := NewConnection(srv.URL, false, "",
+				WithTLSFingerprint(tt.fingerprint),
+				WithRetryPolicy(RetryPolicy{Attempts: 1}),
+			)
+			require.NoError(t, err)
+
+			c := &client{conn: conn, auth: dummyAuthenticator{}}
+
+			err = c.DoRequest(t.Context(), http.MethodGet, "version", nil, nil)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrTLSFingerprintMismatch)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+
+	_, err := NewConnection(srv.URL, false, "", WithTLSFingerprint("AB:CD"))
+	require.Error(t, err)
+
+	conn, err := NewConnection(srv.URL, false, "", WithTLSFingerprint(formatTLSFingerprint(fp[:])))
+	require.NoError(t, err)
+	assert.Nil(t, conn.TLSConfig().VerifyConnection, "the fingerprint must only be pinned for the API endpoints")
+}
@@ -15,16 +15,36 @@ import (
 	"net/http"
 	"net/url"
 	"sync"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/bpg/terraform-provider-proxmox/utils"
 )
 
+const (
+	// TicketLifetime is the validity period of tickets issued by Proxmox VE.
+	TicketLifetime = 2 * time.Hour
+
+	// ticketRenewalAge is the age after which a ticket is renewed before it is used for a request.
+	ticketRenewalAge = TicketLifetime - 30*time.Minute
+)
+
+// ErrTicketRenewalOTP is returned when a ticket of an account protected by a one-time password
+// has expired and has to be re-issued, which requires a new one-time password.
+var ErrTicketRenewalOTP = errors.New(
+	"the authentication ticket has expired and cannot be re-issued without a new one-time password (OTP), " +
+		"consider using an API token for long-running operations",
+)
+
 type userAuthenticator struct {
 	conn        *Connection
+	username    string
 	authRequest string
+	hasOTP      bool
 	authData    *AuthenticationResponseData
+	issuedAt    time.Time
+	now         func() time.Time
 
 	mu sync.Mutex
 }
@@ -50,23 +70,86 @@ func NewUserAuthenticator(creds UserCredentials, conn *Connection) Authenticator
 
 	return &userAuthenticator{
 		conn:        conn,
+		username:    creds.Username,
 		authRequest: authRequest,
+		hasOTP:      creds.OTP != "",
+		now:         time.Now,
 	}
 }
 
+// authenticate returns the cached ticket, renewing it when it is about to expire,
+// or requests a new one if there is no valid ticket.
 func (t *userAuthenticator) authenticate(ctx context.Context) (*AuthenticationResponseData, error) {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
-	if t.authData != nil {
+	age := t.now().Sub(t.issuedAt)
+
+	if t.authData != nil && age < ticketRenewalAge {
 		return t.authData, nil
 	}
 
+	if t.authData != nil && age < TicketLifetime {
+		tflog.Debug(ctx, "Renewing the authentication ticket", map[string]interface{}{
+			"age": age.String(),
+		})
+
+		// an existing ticket can be exchanged for a new one by passing it as the password
+		renewRequest := fmt.Sprintf(
+			"username=%s&password=%s",
+			url.QueryEscape(t.username),
+			url.QueryEscape(*t.authData.Ticket),
+		)
+
+		data, err := t.requestTicket(ctx, renewRequest)
+		if err == nil {
+			t.authData = data
+			t.issuedAt = t.now()
+
+			return data, nil
+		}
+
+		tflog.Warn(ctx, "Failed to renew the authentication ticket, requesting a new one", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	if t.hasOTP && t.authData != nil {
+		return nil, ErrTicketRenewalOTP
+	}
+
+	data, err := t.requestTicket(ctx, t.authRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	t.authData = data
+	t.issuedAt = t.now()
+
+	return data, nil
+}
+
+// Invalidate discards the cached ticket, e.g. after it has been rejected by the server,
+// so the next request is authenticated with a new ticket.
+func (t *userAuthenticator) Invalidate(_ context.Context) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// keep the expired ticket around for accounts with OTP, so authenticate can report a meaningful error
+	t.issuedAt = time.Time{}
+
+	if !t.hasOTP {
+		t.authData = nil
+	}
+}
+
+// requestTicket requests a new ticket using the given form-encoded credentials.
+func (t *userAuthenticator) requestTicket(ctx context.Context, authRequest string) (*AuthenticationResponseData, error) {
 	req, err := http.NewRequestWithContext(
 		ctx,
 		http.MethodPost,
-		fmt.Sprintf("%s/%s/access/ticket", t.conn.endpoint, basePathJSONAPI),
-		bytes.NewBufferString(t.authRequest),
+		fmt.Sprintf("%s/%s/access/ticket", t.conn.Endpoint(), basePathJSONAPI),
+		bytes.NewBufferString(authRequest),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create authentication request: %w", err)
@@ -98,32 +181,23 @@ func (t *userAuthenticator) authenticate(ctx context.Context) (*AuthenticationRe
 		return nil, fmt.Errorf("failed to decode authentication response, %w", err)
 	}
 
-	if resBody.Data == nil {
-		return nil, errors.New("the server did not include a data object in the authentication response")
-	}
-
-	if resBody.Data.CSRFPreventionToken == nil {
-		return nil, errors.New(
-			"the server did not include a CSRF prevention token in the authentication response",
-		)
-	}
-
-	if resBody.Data.Ticket == nil {
-		return nil, errors.New("the server did not include a ticket in the authentication response")
-	}
-
-	if resBody.Data.Username == "" {
-		return nil, errors.New("the server did not include the username in the authentication response")
+	if err = resBody.Validate(); err != nil {
+		return nil, err
 	}
 
-	t.authData = resBody.Data
-
 	return resBody.Data, nil
 }
 
 func (t *userAuthenticator) IsRoot(ctx context.Context) bool {
-	if t.authData == nil {
-		if _, err := t.authenticate(ctx); err != nil {
+	t.mu.Lock()
+	data := t.authData
+	t.mu.Unlock()
+
+	if data == nil {
+		var err error
+
+		data, err = t.authenticate(ctx)
+		if err != nil {
 			tflog.Warn(ctx, "Failed to authenticate while checking root status", map[string]interface{}{
 				"error": err.Error(),
 			})
@@ -132,7 +206,7 @@ func (t *userAuthenticator) IsRoot(ctx context.Context) bool {
 		}
 	}
 
-	return t.authData != nil && t.authData.Username == rootUsername
+	return data.Username == rootUsername
 }
 
 func (t *userAuthenticator) IsRootTicket(ctx context.Context) bool {
@@ -0,0 +1,182 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ticketServer is a minimal Proxmox VE API issuing tickets for a single user.
+type ticketServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	tickets  map[string]bool
+	issued   int
+	password string
+	renewals []string
+}
+
+func newTicketServer(t *testing.T) *ticketServer {
+	t.Helper()
+
+	ts := &ticketServer{tickets: map[string]bool{}, password: "secret"}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api2/json/access/ticket", func(w http.ResponseWriter, r *http.Request) {
+		ts.mu.Lock()
+		defer ts.mu.Unlock()
+
+		password := r.FormValue("password")
+
+		switch {
+		case password == ts.password:
+		case ts.tickets[password]:
+			ts.renewals = append(ts.renewals, password)
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		ts.issued++
+		ticket := fmt.Sprintf("PVE:root@pam:%08X", ts.issued)
+		ts.tickets[ticket] = true
+
+		_, _ = fmt.Fprintf(w,
+			`{"data":{"username":"root@pam","ticket":%q,"CSRFPreventionToken":"csrf"}}`, ticket)
+	})
+	mux.HandleFunc("GET /api2/json/version", func(w http.ResponseWriter, r *http.Request) {
+		ts.mu.Lock()
+		defer ts.mu.Unlock()
+
+		cookie, err := r.Cookie("PVEAuthCookie")
+		if err != nil || !ts.tickets[cookie.Value] {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"data":{"version":"8.3.0"}}`))
+	})
+
+	ts.Server = httptest.NewTLSServer(mux)
+	t.Cleanup(ts.Close)
+
+	return ts
+}
+
+// expireTickets invalidates all issued tickets, as if they had expired or been revoked.
+func (ts *ticketServer) expireTickets() {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	clear(ts.tickets)
+}
+
+func newTicketServerClient(t *testing.T, ts *ticketServer, otp string) (Client, *userAuthenticator) {
+	t.Helper()
+
+	conn, err := NewConnection(ts.URL, true, "")
+	require.NoError(t, err)
+
+	auth, ok := NewUserAuthenticator(UserCredentials{Username: "root@pam", Password: "secret", OTP: otp}, conn).(*userAuthenticator)
+	require.True(t, ok)
+
+	return &client{conn: conn, auth: auth}, auth
+}
+
+func TestUserAuthenticatorRenewsTicket(t *testing.T) {
+	t.Parallel()
+
+	ts := newTicketServer(t)
+	c, auth := newTicketServerClient(t, ts, "")
+
+	now := time.Now()
+	auth.now = func() time.Time { return now }
+
+	require.NoError(t, c.DoRequest(t.Context(), http.MethodGet, "version", nil, nil))
+	firstTicket := *auth.authData.Ticket
+
+	now = now.Add(time.Hour)
+	require.NoError(t, c.DoRequest(t.Context(), http.MethodGet, "version", nil, nil))
+	assert.Equal(t, firstTicket, *auth.authData.Ticket, "a fresh ticket must be reused")
+
+	now = now.Add(45 * time.Minute)
+	require.NoError(t, c.DoRequest(t.Context(), http.MethodGet, "version", nil, nil))
+	assert.NotEqual(t, firstTicket, *auth.authData.Ticket, "an ageing ticket must be renewed")
+	assert.Equal(t, []string{firstTicket}, ts.renewals, "the ticket must be renewed using the existing ticket")
+}
+
+func TestUserAuthenticatorReauthenticatesOnUnauthorized(t *testing.T) {
+	t.Parallel()
+
+	ts := newTicketServer(t)
+	c, _ := newTicketServerClient(t, ts, "")
+
+	require.NoError(t, c.DoRequest(t.Context(), http.MethodGet, "version", nil, nil))
+
+	ts.expireTickets()
+
+	require.NoError(t, c.DoRequest(t.Context(), http.MethodGet, "version", nil, nil))
+	assert.Equal(t, 2, ts.issued)
+}
+
+func TestUserAuthenticatorOTPTicketExpiry(t *testing.T) {
+	t.Parallel()
+
+	ts := newTicketServer(t)
+	c, auth := newTicketServerClient(t, ts, "123456")
+
+	now := time.Now()
+	auth.now = func() time.Time { return now }
+
+	require.NoError(t, c.DoRequest(t.Context(), http.MethodGet, "version", nil, nil))
+
+	now = now.Add(3 * time.Hour)
+
+	err := c.DoRequest(t.Context(), http.MethodGet, "version", nil, nil)
+	require.ErrorIs(t, err, ErrTicketRenewalOTP)
+}
+
+func TestUserAuthenticatorIsRootConcurrentInvalidate(t *testing.T) {
+	t.Parallel()
+
+	ts := newTicketServer(t)
+	c, auth := newTicketServerClient(t, ts, "")
+
+	var wg sync.WaitGroup
+
+	for range 4 {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+
+			for range 10 {
+				assert.True(t, auth.IsRoot(t.Context()))
+			}
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			for range 10 {
+				auth.Invalidate(t.Context())
+				assert.NoError(t, c.DoRequest(t.Context(), http.MethodGet, "version", nil, nil))
+			}
+		}()
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,163 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+// Package cassette records the interactions with the Proxmox VE API to fixture files, and replays them
+// later without access to the API. It is intended for acceptance tests, which can record their
+// interactions with a real cluster once and then run deterministically offline.
+//
+// The recorded fixtures are sanitized: credentials are redacted, tickets and CSRF prevention tokens are
+// replaced by placeholders, and UPIDs are normalized, so the fixtures do not depend on the time and
+// the process IDs of the recording run.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Mode is the mode of a cassette.
+type Mode string
+
+const (
+	// ModeRecord passes the requests to the API and records the interactions.
+	ModeRecord Mode = "record"
+
+	// ModeReplay serves the requests from the recorded interactions, without access to the API.
+	ModeReplay Mode = "replay"
+)
+
+// ErrNoInteraction is returned in replay mode for requests that have not been recorded.
+var ErrNoInteraction = errors.New("no recorded interaction matches the request")
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a sanitized API request.
+type Request struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Query  string `json:"query,omitempty"`
+	Body   string `json:"body,omitempty"`
+}
+
+// Response is a sanitized API response.
+type Response struct {
+	StatusCode  int    `json:"status_code"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        string `json:"body,omitempty"`
+}
+
+type fixture struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Cassette records or replays the interactions with the API.
+type Cassette struct {
+	path string
+	mode Mode
+
+	mu           sync.Mutex
+	interactions []Interaction
+	// used marks the interactions that have already been replayed
+	used []bool
+	// upids maps the UPIDs seen while recording to their normalized form, seq numbers them
+	upids map[string]string
+	seq   int
+}
+
+// Open opens the cassette stored in the given fixture file. In replay mode the file must exist, in
+// record mode it is (re)written by Save.
+func Open(path string, mode Mode) (*Cassette, error) {
+	c := &Cassette{
+		path:  path,
+		mode:  mode,
+		upids: map[string]string{},
+	}
+
+	switch mode {
+	case ModeRecord:
+	case ModeReplay:
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cassette: %w", err)
+		}
+
+		var f fixture
+		if err := json.Unmarshal(data, &f); err != nil {
+			return nil, fmt.Errorf("failed to parse cassette %q: %w", path, err)
+		}
+
+		c.interactions = f.Interactions
+		c.used = make([]bool, len(f.Interactions))
+	default:
+		return nil, fmt.Errorf("invalid cassette mode %q, must be one of %q or %q", mode, ModeRecord, ModeReplay)
+	}
+
+	return c, nil
+}
+
+// Mode returns the mode of the cassette.
+func (c *Cassette) Mode() Mode {
+	return c.mode
+}
+
+// Interactions returns a copy of the interactions recorded or loaded so far.
+func (c *Cassette) Interactions() []Interaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]Interaction(nil), c.interactions...)
+}
+
+// Wrap returns a transport that records the interactions passed to next, or replays them without
+// using next at all, depending on the mode of the cassette.
+func (c *Cassette) Wrap(next http.RoundTripper) http.RoundTripper {
+	if c.mode == ModeReplay {
+		return &replayTransport{c: c}
+	}
+
+	return &recordTransport{c: c, next: next}
+}
+
+// Save writes the recorded interactions to the fixture file. It does nothing in replay mode.
+func (c *Cassette) Save() error {
+	if c.mode != ModeRecord {
+		return nil
+	}
+
+	var buf bytes.Buffer
+
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+
+	c.mu.Lock()
+	err := enc.Encode(fixture{Interactions: c.interactions})
+	c.mu.Unlock()
+
+	if err != nil {
+		return fmt.Errorf("failed to encode cassette: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o750); err != nil {
+		return fmt.Errorf("failed to create cassette directory: %w", err)
+	}
+
+	if err := os.WriteFile(c.path, buf.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+
+	return nil
+}
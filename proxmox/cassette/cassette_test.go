@@ -0,0 +1,161 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package cassette
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/access"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/fake"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/vms"
+)
+
+// createVM creates a VM using username and password authentication, and returns its name as read back.
+func createVM(t *testing.T, endpoint string, c *Cassette) (string, error) {
+	t.Helper()
+
+	creds, err := api.NewCredentials(fake.DefaultUsername, fake.DefaultPassword, "", "", "", "")
+	require.NoError(t, err)
+
+	conn, err := api.NewConnection(endpoint, true, "",
+		api.WithTransport(c.Wrap),
+		api.WithRetryPolicy(api.RetryPolicy{Attempts: 1}),
+	)
+	require.NoError(t, err)
+
+	client, err := api.NewClient(creds, conn)
+	require.NoError(t, err)
+
+	vm := (&nodes.Client{Client: client, NodeName: fake.DefaultNodeName}).VM(100)
+
+	if err := vm.CreateVM(t.Context(), &vms.CreateRequestBody{VMID: 100, Name: ptr.Ptr("recorded")}); err != nil {
+		return "", err //nolint:wrapcheck
+	}
+
+	cfg, err := vm.GetVM(t.Context())
+	if err != nil {
+		return "", err //nolint:wrapcheck
+	}
+
+	return *cfg.Name, nil
+}
+
+func TestCassetteRecordAndReplay(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "cassettes", "vm.json")
+
+	srv := fake.NewServer()
+	endpoint := srv.Endpoint()
+
+	recorder, err := Open(path, ModeRecord)
+	require.NoError(t, err)
+
+	name, err := createVM(t, endpoint, recorder)
+	require.NoError(t, err)
+	assert.Equal(t, "recorded", name)
+	require.NoError(t, recorder.Save())
+
+	srv.Close()
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	fixture := string(data)
+	assert.NotContains(t, fixture, `password=`+fake.DefaultPassword)
+	assert.Contains(t, fixture, `password=`+Redacted+`&username=root%40pam`)
+	assert.Contains(t, fixture, `"CSRFPreventionToken\":\"`+Redacted+`\"`)
+	assert.Contains(t, fixture, `PVE:root@pam:00000000::`+Redacted)
+	assert.Contains(t, fixture, `UPID:pve:00000001:00000000:00000000:qmcreate:100:root@pam:`)
+
+	// the server is gone, the interactions are served from the fixture
+	player, err := Open(path, ModeReplay)
+	require.NoError(t, err)
+
+	name, err = createVM(t, endpoint, player)
+	require.NoError(t, err)
+	assert.Equal(t, "recorded", name)
+
+	// requests that have not been recorded fail
+	_, err = createVM(t, endpoint, player)
+	require.ErrorIs(t, err, ErrNoInteraction)
+
+	_, err = Open(filepath.Join(t.TempDir(), "missing.json"), ModeReplay)
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestCassetteRedactsTokenSecret(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "token.json")
+
+	srv := fake.NewServer()
+	t.Cleanup(srv.Close)
+
+	recorder, err := Open(path, ModeRecord)
+	require.NoError(t, err)
+
+	creds, err := api.NewCredentials("", "", "", fake.DefaultAPIToken, "", "")
+	require.NoError(t, err)
+
+	conn, err := api.NewConnection(srv.Endpoint(), true, "", api.WithTransport(recorder.Wrap))
+	require.NoError(t, err)
+
+	client, err := api.NewClient(creds, conn)
+	require.NoError(t, err)
+
+	token, err := (&access.Client{Client: client}).CreateUserToken(
+		t.Context(), fake.DefaultUsername, "recorded", &access.UserTokenCreateRequestBody{},
+	)
+	require.NoError(t, err)
+
+	_, secret, found := strings.Cut(token, "=")
+	require.True(t, found)
+	require.NotEmpty(t, secret)
+
+	require.NoError(t, recorder.Save())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	fixture := string(data)
+	assert.NotContains(t, fixture, secret)
+	assert.Contains(t, fixture, `\"value\":\"`+Redacted+`\"`)
+	assert.Contains(t, fixture, `root@pam!recorded`)
+}
+
+func TestSanitizeValues(t *testing.T) {
+	t.Parallel()
+
+	c := &Cassette{upids: map[string]string{}}
+
+	upid := "UPID:pve:000C1A2B:0040C1D2:65A1B2C3:qmclone:100:root@pam:"
+
+	got := sanitizeValues("username=root%40pam&password=secret&cipassword=x&target="+upid, c.normalizeUPIDs)
+	assert.Equal(t,
+		"cipassword=REDACTED&password=REDACTED&target=UPID%3Apve%3A00000001%3A00000000%3A00000000%3Aqmclone%3A100%3Aroot%40pam%3A&username=root%40pam",
+		got,
+	)
+
+	got = sanitizeValues("token=t&key=k&keyring=r&encryption-key=e&eab-hmac-key=h&server=s", c.normalizeUPIDs)
+	assert.Equal(t,
+		"eab-hmac-key=REDACTED&encryption-key=REDACTED&key=REDACTED&keyring=REDACTED&server=s&token=REDACTED",
+		got,
+	)
+
+	// normalization is stable and idempotent
+	assert.Equal(t, c.normalizeUPIDs(upid), c.normalizeUPIDs(c.normalizeUPIDs(upid)))
+}
@@ -0,0 +1,114 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package cassette
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Redacted replaces the values of sensitive fields in the fixtures.
+const Redacted = "REDACTED"
+
+var (
+	// e.g. UPID:pve:000C1A2B:0040C1D2:65A1B2C3:qmclone:100:root@pam:
+	upidPattern = regexp.MustCompile(
+		`UPID:[^:\s"]+:[0-9A-Fa-f]{8}:[0-9A-Fa-f]{8,}:[0-9A-Fa-f]{8}:[^:\s"]*:[^:\s"]*:[^:\s"]*:`,
+	)
+
+	// e.g. PVE:root@pam:65A1B2C3::<signature>, the signature is replaced
+	ticketPattern = regexp.MustCompile(`(PVE[A-Z]*:[^:\s"]+:)[0-9A-Fa-f]{8}::[^"\s&]+`)
+
+	// JSON fields of the responses that carry credentials
+	sensitiveJSONPattern = regexp.MustCompile(`("(?:CSRFPreventionToken|password)"\s*:\s*")(?:[^"\\]|\\.)*"`)
+
+	// the secret of a new API token is returned in the "value" field of the token creation response
+	tokenPathPattern  = regexp.MustCompile(`/access/users/[^/]+/token/[^/]+$`)
+	tokenValuePattern = regexp.MustCompile(`("value"\s*:\s*")(?:[^"\\]|\\.)*"`)
+)
+
+// sensitiveFields are the request parameters that carry credentials, in addition to those containing
+// "password" or "secret", e.g. the tokens of the metric servers and the SDN IPAMs, the keys of the SDN DNS
+// plugins, the Ceph keyrings, the PBS encryption keys and the ACME EAB keys.
+var sensitiveFields = map[string]struct{}{
+	"otp":            {},
+	"tfa-challenge":  {},
+	"token":          {},
+	"key":            {},
+	"keyring":        {},
+	"encryption-key": {},
+	"eab-hmac-key":   {},
+}
+
+// isSensitiveField returns true if the request parameter carries credentials.
+func isSensitiveField(name string) bool {
+	name = strings.ToLower(name)
+
+	if _, ok := sensitiveFields[name]; ok {
+		return true
+	}
+
+	return strings.Contains(name, "password") || strings.Contains(name, "secret")
+}
+
+// sanitizeValues redacts the sensitive parameters of a form-encoded query or body. The result is
+// sorted by the parameter name, so it can be used for matching requests.
+func sanitizeValues(encoded string, normalize func(string) string) string {
+	values, err := url.ParseQuery(encoded)
+	if err != nil {
+		return normalize(encoded)
+	}
+
+	for name, vv := range values {
+		for i := range vv {
+			if isSensitiveField(name) {
+				vv[i] = Redacted
+			} else {
+				vv[i] = normalize(vv[i])
+			}
+		}
+	}
+
+	return values.Encode()
+}
+
+// sanitizeBody replaces the tickets and credentials in the response body of a request.
+func sanitizeBody(req *http.Request, body string) string {
+	body = ticketPattern.ReplaceAllString(body, "${1}00000000::"+Redacted)
+	body = sensitiveJSONPattern.ReplaceAllString(body, `${1}`+Redacted+`"`)
+
+	if req.Method == http.MethodPost && tokenPathPattern.MatchString(req.URL.Path) {
+		body = tokenValuePattern.ReplaceAllString(body, `${1}`+Redacted+`"`)
+	}
+
+	return body
+}
+
+// normalizeUPIDs replaces the UPIDs in s by their normalized form, which only retains the node, the task
+// type, the ID and the user of the task. The process ID is replaced by a sequence number that is unique
+// within the cassette, and the times are zeroed. The caller must hold the cassette lock.
+func (c *Cassette) normalizeUPIDs(s string) string {
+	return upidPattern.ReplaceAllStringFunc(s, func(upid string) string {
+		if normalized, ok := c.upids[upid]; ok {
+			return normalized
+		}
+
+		c.seq++
+
+		parts := strings.Split(upid, ":")
+		normalized := fmt.Sprintf("UPID:%s:%08X:00000000:00000000:%s:%s:%s:",
+			parts[1], c.seq, parts[5], parts[6], parts[7])
+
+		c.upids[upid] = normalized
+		c.upids[normalized] = normalized
+
+		return normalized
+	})
+}
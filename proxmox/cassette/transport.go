@@ -0,0 +1,172 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package cassette
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// recordTransport passes the requests to the API and records the sanitized interactions.
+type recordTransport struct {
+	c    *Cassette
+	next http.RoundTripper
+}
+
+func (t *recordTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readFormBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	resBody, err := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the response to record: %w", err)
+	}
+
+	res.Body = io.NopCloser(bytes.NewReader(resBody))
+
+	t.c.mu.Lock()
+	defer t.c.mu.Unlock()
+
+	t.c.interactions = append(t.c.interactions, Interaction{
+		Request: sanitizeRequest(req, body, t.c.normalizeUPIDs),
+		Response: Response{
+			StatusCode:  res.StatusCode,
+			ContentType: res.Header.Get("Content-Type"),
+			Body:        t.c.normalizeUPIDs(sanitizeBody(req, string(resBody))),
+		},
+	})
+
+	return res, nil
+}
+
+// replayTransport serves the requests from the recorded interactions.
+type replayTransport struct {
+	c *Cassette
+}
+
+func (t *replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readFormBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// drain the bodies that are not recorded, e.g. uploads streamed from a pipe
+	if req.Body != nil {
+		_, _ = io.Copy(io.Discard, req.Body)
+		_ = req.Body.Close()
+	}
+
+	key := sanitizeRequest(req, body, func(s string) string { return s })
+
+	recorded, ok := t.c.next(key)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, key.Method, key.Path)
+	}
+
+	header := http.Header{}
+	if recorded.ContentType != "" {
+		header.Set("Content-Type", recorded.ContentType)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}, nil
+}
+
+// next returns the response of the first interaction matching the request that has not been replayed yet.
+// Once all matching interactions have been replayed, GET requests keep getting the last response, as the
+// number of reads, e.g. while polling, may differ between the runs.
+func (c *Cassette) next(key Request) (Response, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	last := -1
+
+	for i, interaction := range c.interactions {
+		if interaction.Request != key {
+			continue
+		}
+
+		if !c.used[i] {
+			c.used[i] = true
+
+			return interaction.Response, true
+		}
+
+		last = i
+	}
+
+	if last >= 0 && key.Method == http.MethodGet {
+		return c.interactions[last].Response, true
+	}
+
+	return Response{}, false
+}
+
+// readFormBody reads the body of a form-encoded request and restores it, so it can still be sent.
+// Bodies of other types, e.g. file uploads, are neither read nor recorded.
+func readFormBody(req *http.Request) (string, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return "", nil
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if mediaType != "application/x-www-form-urlencoded" {
+		return "", nil
+	}
+
+	data, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+
+	if err != nil {
+		return "", fmt.Errorf("failed to read the request body: %w", err)
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(data))
+	req.ContentLength = int64(len(data))
+
+	return string(data), nil
+}
+
+// sanitizeRequest returns the sanitized form of the request, which is both recorded and used for matching.
+func sanitizeRequest(req *http.Request, body string, normalize func(string) string) Request {
+	r := Request{
+		Method: req.Method,
+		Path:   normalize(req.URL.Path),
+	}
+
+	if req.URL.RawQuery != "" {
+		r.Query = sanitizeValues(req.URL.RawQuery, normalize)
+	}
+
+	if body != "" {
+		r.Body = sanitizeValues(body, normalize)
+	}
+
+	return r
+}
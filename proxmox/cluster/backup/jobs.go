@@ -0,0 +1,103 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+// GetJob retrieves a backup job.
+func (c *Client) GetJob(ctx context.Context, id string) (*JobData, error) {
+	resBody := &JobResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.ExpandPath(url.PathEscape(id)), nil, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error reading backup job: %w", err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data, nil
+}
+
+// ListJobs lists the backup jobs.
+func (c *Client) ListJobs(ctx context.Context) ([]JobData, error) {
+	resBody := &JobListResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.ExpandPath(""), nil, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error listing backup jobs: %w", err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	sort.Slice(resBody.Data, func(i, j int) bool {
+		return resBody.Data[i].ID < resBody.Data[j].ID
+	})
+
+	return resBody.Data, nil
+}
+
+// CreateJob creates a backup job.
+func (c *Client) CreateJob(ctx context.Context, data *JobCreateRequestBody) error {
+	err := c.DoRequest(ctx, http.MethodPost, c.ExpandPath(""), data, nil)
+	if err != nil {
+		return fmt.Errorf("error creating backup job: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateJob updates a backup job.
+func (c *Client) UpdateJob(ctx context.Context, id string, data *JobUpdateRequestBody) error {
+	err := c.DoRequest(ctx, http.MethodPut, c.ExpandPath(url.PathEscape(id)), data, nil)
+	if err != nil {
+		return fmt.Errorf("error updating backup job: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteJob deletes a backup job.
+func (c *Client) DeleteJob(ctx context.Context, id string) error {
+	err := c.DoRequest(ctx, http.MethodDelete, c.ExpandPath(url.PathEscape(id)), nil, nil)
+	if err != nil {
+		return fmt.Errorf("error deleting backup job: %w", err)
+	}
+
+	return nil
+}
+
+// ListNotBackedUpGuests lists the guests that are not included in any backup job.
+func (c *Client) ListNotBackedUpGuests(ctx context.Context) ([]NotBackedUpGuest, error) {
+	resBody := &NotBackedUpResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, "cluster/backup-info/not-backed-up", nil, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error listing guests not backed up: %w", err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	sort.Slice(resBody.Data, func(i, j int) bool {
+		return resBody.Data[i].VMID < resBody.Data[j].VMID
+	})
+
+	return resBody.Data, nil
+}
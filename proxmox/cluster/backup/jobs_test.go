@@ -0,0 +1,106 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/fake"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/storage"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+func TestJobs(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	srv := fake.NewServer()
+	t.Cleanup(srv.Close)
+
+	apiClient, err := srv.NewClient()
+	require.NoError(t, err)
+
+	type createVM struct {
+		VMID int    `url:"vmid"`
+		Name string `url:"name"`
+	}
+
+	for _, vmid := range []int{100, 101, 102} {
+		require.NoError(t, apiClient.DoRequest(ctx, http.MethodPost,
+			fmt.Sprintf("nodes/%s/qemu", fake.DefaultNodeName),
+			&createVM{VMID: vmid, Name: fmt.Sprintf("vm-%d", vmid)}, nil))
+	}
+
+	c := &Client{Client: apiClient}
+
+	guests, err := c.ListNotBackedUpGuests(ctx)
+	require.NoError(t, err)
+	assert.Len(t, guests, 3)
+
+	require.NoError(t, c.CreateJob(ctx, &JobCreateRequestBody{
+		ID: "daily",
+		JobFields: JobFields{
+			Schedule: ptr.Ptr("daily"),
+			Storage:  ptr.Ptr("local"),
+			Mode:     ptr.Ptr("snapshot"),
+			All:      types.CustomBool(true).Pointer(),
+			Exclude:  types.CustomCommaSeparatedList{"101"},
+			PruneBackups: &storage.PruneBackups{
+				KeepLast: ptr.Ptr(int64(7)),
+			},
+		},
+	}))
+
+	job, err := c.GetJob(ctx, "daily")
+	require.NoError(t, err)
+	assert.Equal(t, "daily", *job.Schedule)
+	assert.True(t, bool(*job.Enabled), "jobs must be enabled by default")
+	assert.Equal(t, types.CustomCommaSeparatedList{"101"}, job.Exclude)
+	assert.Equal(t, int64(7), *job.PruneBackups.KeepLast)
+
+	guests, err = c.ListNotBackedUpGuests(ctx)
+	require.NoError(t, err)
+	require.Len(t, guests, 1)
+	assert.Equal(t, 101, guests[0].VMID)
+	assert.Equal(t, "vm-101", *guests[0].Name)
+
+	err = c.UpdateJob(ctx, "daily", &JobUpdateRequestBody{
+		JobFields: JobFields{VMID: types.CustomCommaSeparatedList{"100"}},
+	})
+	require.Error(t, err, "the guest selection must not be ambiguous")
+
+	require.NoError(t, c.UpdateJob(ctx, "daily", &JobUpdateRequestBody{
+		JobFields: JobFields{
+			VMID:    types.CustomCommaSeparatedList{"100", "101"},
+			Enabled: types.CustomBool(false).Pointer(),
+		},
+		Delete: []string{"all", "exclude"},
+	}))
+
+	guests, err = c.ListNotBackedUpGuests(ctx)
+	require.NoError(t, err)
+	assert.Len(t, guests, 3, "disabled jobs must not be taken into account")
+
+	jobs, err := c.ListJobs(ctx)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, types.CustomCommaSeparatedList{"100", "101"}, jobs[0].VMID)
+
+	require.NoError(t, c.DeleteJob(ctx, "daily"))
+
+	_, err = c.GetJob(ctx, "daily")
+	require.ErrorIs(t, err, api.ErrResourceDoesNotExist)
+}
@@ -0,0 +1,79 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package backup
+
+import (
+	"github.com/bpg/terraform-provider-proxmox/proxmox/storage"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+// JobFields contains the fields of a backup job that can be set on create and update.
+type JobFields struct {
+	Comment          *string                        `json:"comment,omitempty"           url:"comment,omitempty"`
+	Compress         *string                        `json:"compress,omitempty"          url:"compress,omitempty"`
+	Enabled          *types.CustomBool              `json:"enabled,omitempty"           url:"enabled,omitempty,int"`
+	MailNotification *string                        `json:"mailnotification,omitempty"  url:"mailnotification,omitempty"`
+	MailTo           types.CustomCommaSeparatedList `json:"mailto,omitempty"            url:"mailto,omitempty,comma"`
+	Mode             *string                        `json:"mode,omitempty"              url:"mode,omitempty"`
+	Node             *string                        `json:"node,omitempty"              url:"node,omitempty"`
+	NotesTemplate    *string                        `json:"notes-template,omitempty"    url:"notes-template,omitempty"`
+	NotificationMode *string                        `json:"notification-mode,omitempty" url:"notification-mode,omitempty"`
+	PruneBackups     *storage.PruneBackups          `json:"prune-backups,omitempty"     url:"prune-backups,omitempty"`
+	RepeatMissed     *types.CustomBool              `json:"repeat-missed,omitempty"     url:"repeat-missed,omitempty,int"`
+	Schedule         *string                        `json:"schedule,omitempty"          url:"schedule,omitempty"`
+	Storage          *string                        `json:"storage,omitempty"           url:"storage,omitempty"`
+
+	// guest selection, either a list of guests, a pool, or all guests except the excluded ones
+	All     *types.CustomBool              `json:"all,omitempty"     url:"all,omitempty,int"`
+	Exclude types.CustomCommaSeparatedList `json:"exclude,omitempty" url:"exclude,omitempty,comma"`
+	Pool    *string                        `json:"pool,omitempty"    url:"pool,omitempty"`
+	VMID    types.CustomCommaSeparatedList `json:"vmid,omitempty"    url:"vmid,omitempty,comma"`
+}
+
+// JobData contains the data from a backup job response.
+type JobData struct {
+	JobFields
+
+	ID   string  `json:"id"`
+	Type *string `json:"type,omitempty"`
+}
+
+// JobResponseBody contains the body from a backup job response.
+type JobResponseBody struct {
+	Data *JobData `json:"data,omitempty"`
+}
+
+// JobListResponseBody contains the body from a backup job list response.
+type JobListResponseBody struct {
+	Data []JobData `json:"data,omitempty"`
+}
+
+// JobCreateRequestBody contains the body for creating a backup job.
+type JobCreateRequestBody struct {
+	JobFields
+
+	ID string `url:"id"`
+}
+
+// JobUpdateRequestBody contains the body for updating a backup job.
+type JobUpdateRequestBody struct {
+	JobFields
+
+	Delete []string `url:"delete,omitempty,comma"`
+}
+
+// NotBackedUpGuest is a guest that is not included in any backup job.
+type NotBackedUpGuest struct {
+	VMID int     `json:"vmid"`
+	Name *string `json:"name,omitempty"`
+	Type string  `json:"type"`
+}
+
+// NotBackedUpResponseBody contains the body from a not backed up guests response.
+type NotBackedUpResponseBody struct {
+	Data []NotBackedUpGuest `json:"data,omitempty"`
+}
@@ -11,10 +11,13 @@ import (
 
 	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/acme"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/backup"
 	clusterfirewall "github.com/bpg/terraform-provider-proxmox/proxmox/cluster/firewall"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/ha"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/mapping"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/metrics"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/replication"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster/sdn"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/firewall"
 )
 
@@ -35,6 +38,11 @@ func (c *Client) Firewall() clusterfirewall.API {
 	}
 }
 
+// Backup returns a client for managing the cluster's backup jobs.
+func (c *Client) Backup() *backup.Client {
+	return &backup.Client{Client: c}
+}
+
 // HA returns a client for managing the cluster's High Availability features.
 func (c *Client) HA() *ha.Client {
 	return &ha.Client{Client: c}
@@ -54,3 +62,13 @@ func (c *Client) ACME() *acme.Client {
 func (c *Client) Metrics() *metrics.Client {
 	return &metrics.Client{Client: c}
 }
+
+// Replication returns a client for managing the cluster's storage replication jobs.
+func (c *Client) Replication() *replication.Client {
+	return &replication.Client{Client: c}
+}
+
+// SDN returns a client for managing the cluster's software-defined network.
+func (c *Client) SDN() *sdn.Client {
+	return &sdn.Client{Client: c}
+}
@@ -15,12 +15,12 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/avast/retry-go/v4"
 	"github.com/rogpeppe/go-internal/lockedfile"
 
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
 )
 
@@ -109,7 +109,7 @@ func (g IDGenerator) NextID(ctx context.Context) (int, error) {
 		return g.client.GetNextID(ctx, newID)
 	},
 		retry.OnRetry(func(_ uint, err error) {
-			if strings.Contains(err.Error(), "already exists") && newID != nil {
+			if errors.Is(err, api.ErrAlreadyExists) && newID != nil {
 				newID, err = g.client.GetNextID(ctx, nil)
 			}
 
@@ -0,0 +1,25 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package replication
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+// Client is an interface for accessing the Proxmox storage replication API.
+type Client struct {
+	api.Client
+}
+
+// ExpandPath expands a relative path to the Proxmox storage replication API path.
+// An empty path expands to the path of the job list, without a trailing slash.
+func (c *Client) ExpandPath(path string) string {
+	return strings.TrimSuffix(fmt.Sprintf("cluster/replication/%s", path), "/")
+}
@@ -0,0 +1,150 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package replication
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/avast/retry-go/v4"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+var errRemovalPending = errors.New("the replication job is still marked for removal")
+
+// GetJob retrieves a replication job.
+func (c *Client) GetJob(ctx context.Context, id string) (*JobData, error) {
+	resBody := &JobResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.ExpandPath(url.PathEscape(id)), nil, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error reading replication job: %w", err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data, nil
+}
+
+// ListJobs lists the replication jobs.
+func (c *Client) ListJobs(ctx context.Context) ([]JobData, error) {
+	resBody := &JobListResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.ExpandPath(""), nil, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error listing replication jobs: %w", err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	sort.Slice(resBody.Data, func(i, j int) bool {
+		if resBody.Data[i].Guest != resBody.Data[j].Guest {
+			return resBody.Data[i].Guest < resBody.Data[j].Guest
+		}
+
+		return resBody.Data[i].JobNum < resBody.Data[j].JobNum
+	})
+
+	return resBody.Data, nil
+}
+
+// CreateJob creates a replication job.
+func (c *Client) CreateJob(ctx context.Context, data *JobCreateRequestBody) error {
+	err := c.DoRequest(ctx, http.MethodPost, c.ExpandPath(""), data, nil)
+	if err != nil {
+		return fmt.Errorf("error creating replication job: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateJob updates a replication job.
+func (c *Client) UpdateJob(ctx context.Context, id string, data *JobUpdateRequestBody) error {
+	err := c.DoRequest(ctx, http.MethodPut, c.ExpandPath(url.PathEscape(id)), data, nil)
+	if err != nil {
+		return fmt.Errorf("error updating replication job: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteJob marks a replication job for removal. The replicated volumes are removed from the target node
+// and the job is removed by the next replication run.
+func (c *Client) DeleteJob(ctx context.Context, id string) error {
+	err := c.DoRequest(ctx, http.MethodDelete, c.ExpandPath(url.PathEscape(id)), nil, nil)
+	if err != nil {
+		return fmt.Errorf("error deleting replication job: %w", err)
+	}
+
+	return nil
+}
+
+// WaitForJobRemoval waits until a replication job that has been marked for removal is removed by the
+// replication runner, so that a job with the same ID can be created again. It returns right away when the job
+// does not exist or is not marked for removal.
+func (c *Client) WaitForJobRemoval(ctx context.Context, id string) error {
+	err := retry.Do(
+		func() error {
+			job, err := c.GetJob(ctx, id)
+			if errors.Is(err, api.ErrResourceDoesNotExist) {
+				return nil
+			}
+
+			if err != nil {
+				return retry.Unrecoverable(err)
+			}
+
+			if job.RemoveJob != nil {
+				return errRemovalPending
+			}
+
+			return nil
+		},
+		retry.Context(ctx),
+		retry.Delay(5*time.Second),
+		retry.DelayType(retry.FixedDelay),
+		retry.Attempts(60),
+		retry.LastErrorOnly(true),
+	)
+	if err != nil {
+		return fmt.Errorf("error waiting for the removal of replication job %q: %w", id, err)
+	}
+
+	return nil
+}
+
+// GetJobStatus retrieves the state of a replication job from its source node.
+func (c *Client) GetJobStatus(ctx context.Context, node string, id string) (*JobStatus, error) {
+	resBody := &JobStatusResponseBody{}
+
+	err := c.DoRequest(
+		ctx,
+		http.MethodGet,
+		fmt.Sprintf("nodes/%s/replication/%s/status", url.PathEscape(node), url.PathEscape(id)),
+		nil,
+		resBody,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error reading replication job status: %w", err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data, nil
+}
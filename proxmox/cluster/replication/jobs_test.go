@@ -0,0 +1,96 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package replication
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/fake"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+func TestJobs(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	srv := fake.NewServer(fake.WithNodes("pve2"))
+	t.Cleanup(srv.Close)
+
+	apiClient, err := srv.NewClient()
+	require.NoError(t, err)
+
+	type createVM struct {
+		VMID int `url:"vmid"`
+	}
+
+	require.NoError(t, apiClient.DoRequest(ctx, http.MethodPost,
+		fmt.Sprintf("nodes/%s/qemu", fake.DefaultNodeName), &createVM{VMID: 100}, nil))
+
+	c := &Client{Client: apiClient}
+
+	err = c.CreateJob(ctx, &JobCreateRequestBody{ID: "100-0", Target: fake.DefaultNodeName, Type: JobTypeLocal})
+	require.Error(t, err, "the target node must not be the source node")
+
+	require.NoError(t, c.CreateJob(ctx, &JobCreateRequestBody{
+		ID:     "100-0",
+		Target: "pve2",
+		Type:   JobTypeLocal,
+		JobFields: JobFields{
+			Schedule: ptr.Ptr("*/30"),
+			Rate:     ptr.Ptr(12.5),
+			Comment:  ptr.Ptr("failover"),
+		},
+	}))
+
+	job, err := c.GetJob(ctx, "100-0")
+	require.NoError(t, err)
+	assert.Equal(t, 100, job.Guest)
+	assert.Equal(t, 0, job.JobNum)
+	assert.Equal(t, "pve2", job.Target)
+	assert.Equal(t, fake.DefaultNodeName, *job.Source)
+	assert.InDelta(t, 12.5, *job.Rate, 0)
+	assert.Nil(t, job.Disable)
+
+	status, err := c.GetJobStatus(ctx, fake.DefaultNodeName, "100-0")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), int64(*status.FailCount))
+	assert.NotNil(t, status.LastSync)
+	assert.NotNil(t, status.Duration)
+
+	_, err = c.GetJobStatus(ctx, "pve2", "100-0")
+	require.ErrorIs(t, err, api.ErrResourceDoesNotExist, "the status is only known by the source node")
+
+	require.NoError(t, c.UpdateJob(ctx, "100-0", &JobUpdateRequestBody{
+		JobFields: JobFields{Disable: types.CustomBool(true).Pointer()},
+		Delete:    []string{"rate", "comment"},
+	}))
+
+	jobs, err := c.ListJobs(ctx)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.True(t, bool(*jobs[0].Disable))
+	assert.Nil(t, jobs[0].Rate)
+	assert.Nil(t, jobs[0].Comment)
+
+	require.NoError(t, c.WaitForJobRemoval(ctx, "100-0"), "a job that is not marked for removal is not waited for")
+
+	require.NoError(t, c.DeleteJob(ctx, "100-0"))
+
+	_, err = c.GetJob(ctx, "100-0")
+	require.ErrorIs(t, err, api.ErrResourceDoesNotExist)
+
+	require.NoError(t, c.WaitForJobRemoval(ctx, "100-0"))
+}
@@ -0,0 +1,81 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package replication
+
+import (
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+// JobTypeLocal is the type of the replication jobs between the nodes of the cluster.
+const JobTypeLocal = "local"
+
+// JobFields contains the fields of a replication job that can be set on create and update.
+type JobFields struct {
+	Comment  *string           `json:"comment,omitempty"  url:"comment,omitempty"`
+	Disable  *types.CustomBool `json:"disable,omitempty"  url:"disable,omitempty,int"`
+	Rate     *float64          `json:"rate,omitempty"     url:"rate,omitempty"`
+	Schedule *string           `json:"schedule,omitempty" url:"schedule,omitempty"`
+}
+
+// JobData contains the data from a replication job response.
+type JobData struct {
+	JobFields
+
+	ID     string  `json:"id"`
+	Guest  int     `json:"guest"`
+	JobNum int     `json:"jobnum"`
+	Target string  `json:"target"`
+	Type   string  `json:"type"`
+	Source *string `json:"source,omitempty"`
+
+	// RemoveJob is set when the job has been marked for removal, the job is removed by the next replication run.
+	RemoveJob *string `json:"remove_job,omitempty"`
+}
+
+// JobResponseBody contains the body from a replication job response.
+type JobResponseBody struct {
+	Data *JobData `json:"data,omitempty"`
+}
+
+// JobListResponseBody contains the body from a replication job list response.
+type JobListResponseBody struct {
+	Data []JobData `json:"data,omitempty"`
+}
+
+// JobCreateRequestBody contains the body for creating a replication job.
+type JobCreateRequestBody struct {
+	JobFields
+
+	ID     string `url:"id"`
+	Target string `url:"target"`
+	Type   string `url:"type"`
+}
+
+// JobUpdateRequestBody contains the body for updating a replication job.
+type JobUpdateRequestBody struct {
+	JobFields
+
+	Delete []string `url:"delete,omitempty,comma"`
+}
+
+// JobStatus contains the state of a replication job on its source node.
+type JobStatus struct {
+	ID        string             `json:"id"`
+	Guest     int                `json:"guest"`
+	Target    string             `json:"target"`
+	Duration  *float64           `json:"duration,omitempty"`
+	Error     *string            `json:"error,omitempty"`
+	FailCount *types.CustomInt64 `json:"fail_count,omitempty"`
+	LastSync  *types.CustomInt64 `json:"last_sync,omitempty"`
+	LastTry   *types.CustomInt64 `json:"last_try,omitempty"`
+	NextSync  *types.CustomInt64 `json:"next_sync,omitempty"`
+}
+
+// JobStatusResponseBody contains the body from a replication job status response.
+type JobStatusResponseBody struct {
+	Data *JobStatus `json:"data,omitempty"`
+}
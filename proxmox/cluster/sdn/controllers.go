@@ -0,0 +1,74 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+)
+
+// GetController retrieves an SDN controller, including the changes that have not been applied yet.
+func (c *Client) GetController(ctx context.Context, id string) (*ControllerData, error) {
+	data := &ControllerData{}
+
+	err := c.getPending(ctx, c.ExpandPath("controllers/"+url.PathEscape(id)), data)
+	if err != nil {
+		return nil, fmt.Errorf("error reading SDN controller: %w", err)
+	}
+
+	return data, nil
+}
+
+// ListControllers lists the SDN controllers, including the changes that have not been applied yet.
+func (c *Client) ListControllers(ctx context.Context) ([]ControllerData, error) {
+	var data []ControllerData
+
+	err := c.getPending(ctx, c.ExpandPath("controllers"), &data)
+	if err != nil {
+		return nil, fmt.Errorf("error listing SDN controllers: %w", err)
+	}
+
+	sort.Slice(data, func(i, j int) bool {
+		return data[i].ID < data[j].ID
+	})
+
+	return data, nil
+}
+
+// CreateController creates an SDN controller. The controller is pending until the SDN configuration is applied.
+func (c *Client) CreateController(ctx context.Context, data *ControllerCreateRequestBody) error {
+	err := c.DoRequest(ctx, http.MethodPost, c.ExpandPath("controllers"), data, nil)
+	if err != nil {
+		return fmt.Errorf("error creating SDN controller: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateController updates an SDN controller. The changes are pending until the SDN configuration is applied.
+func (c *Client) UpdateController(ctx context.Context, id string, data *ControllerUpdateRequestBody) error {
+	err := c.DoRequest(ctx, http.MethodPut, c.ExpandPath("controllers/"+url.PathEscape(id)), data, nil)
+	if err != nil {
+		return fmt.Errorf("error updating SDN controller: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteController deletes an SDN controller. The controller is pending deletion until the SDN configuration
+// is applied.
+func (c *Client) DeleteController(ctx context.Context, id string) error {
+	err := c.DoRequest(ctx, http.MethodDelete, c.ExpandPath("controllers/"+url.PathEscape(id)), nil, nil)
+	if err != nil {
+		return fmt.Errorf("error deleting SDN controller: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+const (
+	// ControllerTypeBGP is the type of a controller peering with external BGP routers from a node.
+	ControllerTypeBGP = "bgp"
+
+	// ControllerTypeEVPN is the type of a controller distributing the routes of EVPN zones between the nodes.
+	ControllerTypeEVPN = "evpn"
+
+	// ControllerTypeISIS is the type of a controller using IS-IS as the underlay of EVPN zones on a node.
+	ControllerTypeISIS = "isis"
+)
+
+// ControllerFields contains the fields of an SDN controller that can be set on create and update.
+type ControllerFields struct {
+	// bgp and evpn controller options
+	ASN   *types.CustomInt64 `json:"asn,omitempty"   url:"asn,omitempty"`
+	Peers *string            `json:"peers,omitempty" url:"peers,omitempty"`
+
+	// bgp and isis controller options
+	Node *string `json:"node,omitempty" url:"node,omitempty"`
+
+	// bgp controller only options
+	BGPMultipathASPathRelax *types.CustomBool  `json:"bgp-multipath-as-path-relax,omitempty" url:"bgp-multipath-as-path-relax,omitempty,int"`
+	EBGP                    *types.CustomBool  `json:"ebgp,omitempty"                        url:"ebgp,omitempty,int"`
+	EBGPMultihop            *types.CustomInt64 `json:"ebgp-multihop,omitempty"               url:"ebgp-multihop,omitempty"`
+	Loopback                *string            `json:"loopback,omitempty"                    url:"loopback,omitempty"`
+
+	// isis controller only options
+	ISISDomain     *string `json:"isis-domain,omitempty" url:"isis-domain,omitempty"`
+	ISISInterfaces *string `json:"isis-ifaces,omitempty" url:"isis-ifaces,omitempty"`
+	ISISNet        *string `json:"isis-net,omitempty"    url:"isis-net,omitempty"`
+}
+
+// ControllerData contains the data from an SDN controller response.
+type ControllerData struct {
+	ControllerFields
+
+	ID   string `json:"controller"`
+	Type string `json:"type"`
+
+	// State is the state of the changes that have not been applied yet, if any.
+	State *string `json:"state,omitempty"`
+}
+
+// ControllerCreateRequestBody contains the body for creating an SDN controller.
+type ControllerCreateRequestBody struct {
+	ControllerFields
+
+	ID   string `url:"controller"`
+	Type string `url:"type"`
+}
+
+// ControllerUpdateRequestBody contains the body for updating an SDN controller.
+type ControllerUpdateRequestBody struct {
+	ControllerFields
+
+	Delete []string `url:"delete,omitempty,comma"`
+}
@@ -0,0 +1,84 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+// GetDNS retrieves an SDN DNS plugin. The DNS plugins are not part of the pending SDN configuration,
+// the changes take effect immediately.
+func (c *Client) GetDNS(ctx context.Context, id string) (*DNSData, error) {
+	resBody := &DNSResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.ExpandPath("dns/"+url.PathEscape(id)), nil, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error reading SDN DNS plugin: %w", err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data, nil
+}
+
+// ListDNS lists the SDN DNS plugins.
+func (c *Client) ListDNS(ctx context.Context) ([]DNSData, error) {
+	resBody := &DNSListResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.ExpandPath("dns"), nil, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error listing SDN DNS plugins: %w", err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	sort.Slice(resBody.Data, func(i, j int) bool {
+		return resBody.Data[i].ID < resBody.Data[j].ID
+	})
+
+	return resBody.Data, nil
+}
+
+// CreateDNS creates an SDN DNS plugin.
+func (c *Client) CreateDNS(ctx context.Context, data *DNSCreateRequestBody) error {
+	err := c.DoRequest(ctx, http.MethodPost, c.ExpandPath("dns"), data, nil)
+	if err != nil {
+		return fmt.Errorf("error creating SDN DNS plugin: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateDNS updates an SDN DNS plugin.
+func (c *Client) UpdateDNS(ctx context.Context, id string, data *DNSUpdateRequestBody) error {
+	err := c.DoRequest(ctx, http.MethodPut, c.ExpandPath("dns/"+url.PathEscape(id)), data, nil)
+	if err != nil {
+		return fmt.Errorf("error updating SDN DNS plugin: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteDNS deletes an SDN DNS plugin.
+func (c *Client) DeleteDNS(ctx context.Context, id string) error {
+	err := c.DoRequest(ctx, http.MethodDelete, c.ExpandPath("dns/"+url.PathEscape(id)), nil, nil)
+	if err != nil {
+		return fmt.Errorf("error deleting SDN DNS plugin: %w", err)
+	}
+
+	return nil
+}
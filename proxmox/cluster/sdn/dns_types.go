@@ -0,0 +1,56 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+// DNSTypePowerDNS is the type of a DNS plugin using PowerDNS.
+const DNSTypePowerDNS = "powerdns"
+
+// DNSFields contains the fields of an SDN DNS plugin that can be set on create and update.
+type DNSFields struct {
+	Fingerprint   *string            `json:"fingerprint,omitempty"   url:"fingerprint,omitempty"`
+	Key           *string            `json:"key,omitempty"           url:"key,omitempty"`
+	ReverseMaskV6 *types.CustomInt64 `json:"reversemaskv6,omitempty" url:"reversemaskv6,omitempty"`
+	TTL           *types.CustomInt64 `json:"ttl,omitempty"           url:"ttl,omitempty"`
+	URL           *string            `json:"url,omitempty"           url:"url,omitempty"`
+}
+
+// DNSData contains the data from an SDN DNS plugin response.
+type DNSData struct {
+	DNSFields
+
+	ID   string `json:"dns"`
+	Type string `json:"type"`
+}
+
+// DNSResponseBody contains the body from an SDN DNS plugin response.
+type DNSResponseBody struct {
+	Data *DNSData `json:"data,omitempty"`
+}
+
+// DNSListResponseBody contains the body from an SDN DNS plugin list response.
+type DNSListResponseBody struct {
+	Data []DNSData `json:"data,omitempty"`
+}
+
+// DNSCreateRequestBody contains the body for creating an SDN DNS plugin.
+type DNSCreateRequestBody struct {
+	DNSFields
+
+	ID   string `url:"dns"`
+	Type string `url:"type"`
+}
+
+// DNSUpdateRequestBody contains the body for updating an SDN DNS plugin.
+type DNSUpdateRequestBody struct {
+	DNSFields
+
+	Delete []string `url:"delete,omitempty,comma"`
+}
@@ -0,0 +1,84 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+// GetIPAM retrieves an SDN IPAM plugin. The IPAM plugins are not part of the pending SDN configuration,
+// the changes take effect immediately.
+func (c *Client) GetIPAM(ctx context.Context, id string) (*IPAMData, error) {
+	resBody := &IPAMResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.ExpandPath("ipams/"+url.PathEscape(id)), nil, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error reading SDN IPAM: %w", err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data, nil
+}
+
+// ListIPAMs lists the SDN IPAM plugins.
+func (c *Client) ListIPAMs(ctx context.Context) ([]IPAMData, error) {
+	resBody := &IPAMListResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.ExpandPath("ipams"), nil, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error listing SDN IPAMs: %w", err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	sort.Slice(resBody.Data, func(i, j int) bool {
+		return resBody.Data[i].ID < resBody.Data[j].ID
+	})
+
+	return resBody.Data, nil
+}
+
+// CreateIPAM creates an SDN IPAM plugin.
+func (c *Client) CreateIPAM(ctx context.Context, data *IPAMCreateRequestBody) error {
+	err := c.DoRequest(ctx, http.MethodPost, c.ExpandPath("ipams"), data, nil)
+	if err != nil {
+		return fmt.Errorf("error creating SDN IPAM: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateIPAM updates an SDN IPAM plugin.
+func (c *Client) UpdateIPAM(ctx context.Context, id string, data *IPAMUpdateRequestBody) error {
+	err := c.DoRequest(ctx, http.MethodPut, c.ExpandPath("ipams/"+url.PathEscape(id)), data, nil)
+	if err != nil {
+		return fmt.Errorf("error updating SDN IPAM: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteIPAM deletes an SDN IPAM plugin.
+func (c *Client) DeleteIPAM(ctx context.Context, id string) error {
+	err := c.DoRequest(ctx, http.MethodDelete, c.ExpandPath("ipams/"+url.PathEscape(id)), nil, nil)
+	if err != nil {
+		return fmt.Errorf("error deleting SDN IPAM: %w", err)
+	}
+
+	return nil
+}
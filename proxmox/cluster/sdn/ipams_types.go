@@ -0,0 +1,66 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+const (
+	// IPAMTypePVE is the type of the built-in IPAM of Proxmox VE.
+	IPAMTypePVE = "pve"
+
+	// IPAMTypeNetBox is the type of an IPAM plugin using NetBox.
+	IPAMTypeNetBox = "netbox"
+
+	// IPAMTypePhpIPAM is the type of an IPAM plugin using phpIPAM.
+	IPAMTypePhpIPAM = "phpipam"
+)
+
+// IPAMFields contains the fields of an SDN IPAM plugin that can be set on create and update.
+type IPAMFields struct {
+	// netbox and phpipam plugin options
+	Fingerprint *string `json:"fingerprint,omitempty" url:"fingerprint,omitempty"`
+	Token       *string `json:"token,omitempty"       url:"token,omitempty"`
+	URL         *string `json:"url,omitempty"         url:"url,omitempty"`
+
+	// phpipam plugin only options
+	Section *types.CustomInt64 `json:"section,omitempty" url:"section,omitempty"`
+}
+
+// IPAMData contains the data from an SDN IPAM plugin response.
+type IPAMData struct {
+	IPAMFields
+
+	ID   string `json:"ipam"`
+	Type string `json:"type"`
+}
+
+// IPAMResponseBody contains the body from an SDN IPAM plugin response.
+type IPAMResponseBody struct {
+	Data *IPAMData `json:"data,omitempty"`
+}
+
+// IPAMListResponseBody contains the body from an SDN IPAM plugin list response.
+type IPAMListResponseBody struct {
+	Data []IPAMData `json:"data,omitempty"`
+}
+
+// IPAMCreateRequestBody contains the body for creating an SDN IPAM plugin.
+type IPAMCreateRequestBody struct {
+	IPAMFields
+
+	ID   string `url:"ipam"`
+	Type string `url:"type"`
+}
+
+// IPAMUpdateRequestBody contains the body for updating an SDN IPAM plugin.
+type IPAMUpdateRequestBody struct {
+	IPAMFields
+
+	Delete []string `url:"delete,omitempty,comma"`
+}
@@ -0,0 +1,123 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+// ApplyConfig applies the pending SDN configuration to all nodes of the cluster.
+func (c *Client) ApplyConfig(ctx context.Context) error {
+	resBody := &ApplyResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodPut, "cluster/sdn", nil, resBody)
+	if err != nil {
+		return fmt.Errorf("error applying SDN configuration: %w", err)
+	}
+
+	if resBody.Data == nil {
+		return api.ErrNoDataObjectInResponse
+	}
+
+	err = c.Tasks().WaitForTask(ctx, *resBody.Data)
+	if err != nil {
+		return fmt.Errorf("error applying SDN configuration: failed waiting for task: %w", err)
+	}
+
+	return nil
+}
+
+// getPending retrieves an SDN object, or a list of SDN objects, including the changes that have not been
+// applied yet. The pending values replace the applied ones, and objects pending deletion are reported as
+// not existing, so the result reflects the configuration that is applied next.
+func (c *Client) getPending(ctx context.Context, path string, out any) error {
+	resBody := &pendingResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, path, &pendingRequestQuery{Pending: true}, resBody)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if len(resBody.Data) == 0 {
+		return api.ErrNoDataObjectInResponse
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(resBody.Data))
+	dec.UseNumber()
+
+	var data any
+
+	if err = dec.Decode(&data); err != nil {
+		return fmt.Errorf("failed to decode the pending configuration: %w", err)
+	}
+
+	switch d := data.(type) {
+	case nil:
+		return api.ErrNoDataObjectInResponse
+	case map[string]any:
+		if d["state"] == PendingStateDeleted {
+			return api.ErrResourceDoesNotExist
+		}
+
+		data = applyPending(d)
+	case []any:
+		list := make([]any, 0, len(d))
+
+		for _, item := range d {
+			obj, ok := item.(map[string]any)
+			if !ok || obj["state"] == PendingStateDeleted {
+				continue
+			}
+
+			list = append(list, applyPending(obj))
+		}
+
+		data = list
+	default:
+		return fmt.Errorf("unexpected pending configuration of type %T", data)
+	}
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode the pending configuration: %w", err)
+	}
+
+	if err = json.Unmarshal(b, out); err != nil {
+		return fmt.Errorf("failed to decode the pending configuration: %w", err)
+	}
+
+	return nil
+}
+
+// applyPending replaces the applied values of an object with its pending values.
+func applyPending(obj map[string]any) map[string]any {
+	pending, _ := obj["pending"].(map[string]any)
+
+	for k, v := range pending {
+		if v == PendingStateDeleted {
+			delete(obj, k)
+		} else {
+			obj[k] = v
+		}
+	}
+
+	delete(obj, "pending")
+
+	return obj
+}
+
+// pendingRequestQuery contains the query of a request for the pending configuration.
+type pendingRequestQuery struct {
+	Pending types.CustomBool `url:"pending,int"`
+}
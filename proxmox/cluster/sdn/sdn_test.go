@@ -0,0 +1,199 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/fake"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+func TestPendingChanges(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	srv := fake.NewServer()
+	t.Cleanup(srv.Close)
+
+	apiClient, err := srv.NewClient()
+	require.NoError(t, err)
+
+	c := &Client{Client: apiClient}
+
+	require.NoError(t, c.CreateZone(ctx, &ZoneCreateRequestBody{
+		ID:   "zone1",
+		Type: ZoneTypeVLAN,
+		ZoneFields: ZoneFields{
+			Bridge: ptr.Ptr("vmbr0"),
+			MTU:    ptr.Ptr(types.CustomInt64(1500)),
+		},
+	}))
+	require.NoError(t, c.CreateVNet(ctx, &VNetCreateRequestBody{
+		ID:         "vnet1",
+		VNetFields: VNetFields{Zone: ptr.Ptr("zone1"), Tag: ptr.Ptr(types.CustomInt64(100))},
+	}))
+	require.NoError(t, c.CreateSubnet(ctx, "vnet1", &SubnetCreateRequestBody{
+		CIDR: "10.0.0.0/24",
+		SubnetFields: SubnetFields{
+			Gateway: ptr.Ptr("10.0.0.1"),
+			SNAT:    types.CustomBool(true).Pointer(),
+			DHCPRanges: DHCPRanges{
+				{StartAddress: "10.0.0.100", EndAddress: "10.0.0.149"},
+				{StartAddress: "10.0.0.200", EndAddress: "10.0.0.249"},
+			},
+		},
+	}))
+
+	zone, err := c.GetZone(ctx, "zone1")
+	require.NoError(t, err)
+	assert.Equal(t, ZoneTypeVLAN, zone.Type)
+	assert.Equal(t, "vmbr0", *zone.Bridge)
+	assert.Equal(t, int64(1500), *zone.MTU.PointerInt64())
+	assert.Equal(t, PendingStateNew, *zone.State, "the zone is pending until the configuration is applied")
+
+	subnetID := SubnetID("zone1", "10.0.0.0/24")
+
+	subnet, err := c.GetSubnet(ctx, "vnet1", subnetID)
+	require.NoError(t, err)
+	assert.Equal(t, "zone1-10.0.0.0-24", subnet.ID)
+	assert.Equal(t, "10.0.0.0/24", *subnet.CIDR)
+	assert.True(t, bool(*subnet.SNAT))
+	assert.Equal(t, DHCPRanges{
+		{StartAddress: "10.0.0.100", EndAddress: "10.0.0.149"},
+		{StartAddress: "10.0.0.200", EndAddress: "10.0.0.249"},
+	}, subnet.DHCPRanges, "the pending ranges must be parsed")
+
+	require.NoError(t, c.ApplyConfig(ctx))
+
+	zone, err = c.GetZone(ctx, "zone1")
+	require.NoError(t, err)
+	assert.Nil(t, zone.State)
+
+	subnet, err = c.GetSubnet(ctx, "vnet1", subnetID)
+	require.NoError(t, err)
+	assert.Nil(t, subnet.State)
+	assert.Len(t, subnet.DHCPRanges, 2)
+
+	require.NoError(t, c.UpdateZone(ctx, "zone1", &ZoneUpdateRequestBody{
+		ZoneFields: ZoneFields{Bridge: ptr.Ptr("vmbr1")},
+		Delete:     []string{"mtu"},
+	}))
+
+	zone, err = c.GetZone(ctx, "zone1")
+	require.NoError(t, err)
+	assert.Equal(t, PendingStateChanged, *zone.State)
+	assert.Equal(t, "vmbr1", *zone.Bridge, "the pending value must replace the applied value")
+	assert.Nil(t, zone.MTU, "the pending deletion must remove the applied value")
+
+	require.NoError(t, c.DeleteSubnet(ctx, "vnet1", subnetID))
+
+	_, err = c.GetSubnet(ctx, "vnet1", subnetID)
+	require.ErrorIs(t, err, api.ErrResourceDoesNotExist, "a subnet pending deletion must not exist")
+
+	subnets, err := c.ListSubnets(ctx, "vnet1")
+	require.NoError(t, err)
+	assert.Empty(t, subnets)
+
+	vnets, err := c.ListVNets(ctx)
+	require.NoError(t, err)
+	require.Len(t, vnets, 1)
+	assert.Equal(t, "vnet1", vnets[0].ID)
+	assert.Equal(t, int64(100), *vnets[0].Tag.PointerInt64())
+}
+
+func TestPlugins(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	srv := fake.NewServer()
+	t.Cleanup(srv.Close)
+
+	apiClient, err := srv.NewClient()
+	require.NoError(t, err)
+
+	c := &Client{Client: apiClient}
+
+	require.NoError(t, c.CreateController(ctx, &ControllerCreateRequestBody{
+		ID:   "evpn1",
+		Type: ControllerTypeEVPN,
+		ControllerFields: ControllerFields{
+			ASN:   ptr.Ptr(types.CustomInt64(65000)),
+			Peers: ptr.Ptr("10.0.0.1,10.0.0.2"),
+		},
+	}))
+	require.NoError(t, c.CreateIPAM(ctx, &IPAMCreateRequestBody{
+		ID:   "netbox",
+		Type: IPAMTypeNetBox,
+		IPAMFields: IPAMFields{
+			URL:   ptr.Ptr("https://netbox.example.com/api"),
+			Token: ptr.Ptr("secret"),
+		},
+	}))
+	require.NoError(t, c.CreateDNS(ctx, &DNSCreateRequestBody{
+		ID:   "powerdns",
+		Type: DNSTypePowerDNS,
+		DNSFields: DNSFields{
+			URL: ptr.Ptr("https://powerdns.example.com/api/v1/servers/localhost"),
+			Key: ptr.Ptr("secret"),
+			TTL: ptr.Ptr(types.CustomInt64(3600)),
+		},
+	}))
+
+	controller, err := c.GetController(ctx, "evpn1")
+	require.NoError(t, err)
+	assert.Equal(t, ControllerTypeEVPN, controller.Type)
+	assert.Equal(t, int64(65000), *controller.ASN.PointerInt64())
+	assert.Equal(t, PendingStateNew, *controller.State, "the controller is pending until the configuration is applied")
+
+	ipam, err := c.GetIPAM(ctx, "netbox")
+	require.NoError(t, err)
+	assert.Equal(t, IPAMTypeNetBox, ipam.Type)
+	assert.Equal(t, "https://netbox.example.com/api", *ipam.URL)
+
+	require.NoError(t, c.UpdateDNS(ctx, "powerdns", &DNSUpdateRequestBody{Delete: []string{"ttl"}}))
+
+	dns, err := c.GetDNS(ctx, "powerdns")
+	require.NoError(t, err)
+	assert.Equal(t, DNSTypePowerDNS, dns.Type)
+	assert.Nil(t, dns.TTL)
+
+	require.NoError(t, c.DeleteIPAM(ctx, "netbox"))
+
+	_, err = c.GetIPAM(ctx, "netbox")
+	require.ErrorIs(t, err, api.ErrResourceDoesNotExist)
+
+	controllers, err := c.ListControllers(ctx)
+	require.NoError(t, err)
+	require.Len(t, controllers, 1)
+	assert.Equal(t, "evpn1", controllers[0].ID)
+}
+
+func TestParseDHCPRanges(t *testing.T) {
+	t.Parallel()
+
+	ranges, err := parseDHCPRanges("start-address=10.0.0.10,end-address=10.0.0.20,end-address=10.0.0.40,start-address=10.0.0.30")
+	require.NoError(t, err)
+	assert.Equal(t, []DHCPRange{
+		{StartAddress: "10.0.0.10", EndAddress: "10.0.0.20"},
+		{StartAddress: "10.0.0.30", EndAddress: "10.0.0.40"},
+	}, ranges)
+
+	_, err = parseDHCPRanges("start-address=10.0.0.10")
+	require.Error(t, err)
+
+	_, err = parseDHCPRanges("start-address=10.0.0.10,end=10.0.0.20")
+	require.Error(t, err)
+}
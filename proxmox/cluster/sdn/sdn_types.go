@@ -0,0 +1,32 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"encoding/json"
+)
+
+const (
+	// PendingStateNew is the state of an object that has been created but not applied yet.
+	PendingStateNew = "new"
+
+	// PendingStateChanged is the state of an object with changes that have not been applied yet.
+	PendingStateChanged = "changed"
+
+	// PendingStateDeleted is the state of an object, or a value, that has been deleted but not applied yet.
+	PendingStateDeleted = "deleted"
+)
+
+// ApplyResponseBody contains the body from an SDN apply response.
+type ApplyResponseBody struct {
+	Data *string `json:"data,omitempty"`
+}
+
+// pendingResponseBody contains the body from a response including the pending configuration.
+type pendingResponseBody struct {
+	Data json.RawMessage `json:"data,omitempty"`
+}
@@ -0,0 +1,90 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// subnetsPath returns the API path of the subnets of a VNet.
+func (c *Client) subnetsPath(vnet string) string {
+	return c.ExpandPath(fmt.Sprintf("vnets/%s/subnets", url.PathEscape(vnet)))
+}
+
+// GetSubnet retrieves an SDN subnet of a VNet, including the changes that have not been applied yet.
+func (c *Client) GetSubnet(ctx context.Context, vnet string, id string) (*SubnetData, error) {
+	data := &SubnetData{}
+
+	err := c.getPending(ctx, c.subnetsPath(vnet)+"/"+url.PathEscape(id), data)
+	if err != nil {
+		return nil, fmt.Errorf("error reading SDN subnet: %w", err)
+	}
+
+	return data, nil
+}
+
+// ListSubnets lists the SDN subnets of a VNet, including the changes that have not been applied yet.
+func (c *Client) ListSubnets(ctx context.Context, vnet string) ([]SubnetData, error) {
+	var data []SubnetData
+
+	err := c.getPending(ctx, c.subnetsPath(vnet), &data)
+	if err != nil {
+		return nil, fmt.Errorf("error listing SDN subnets: %w", err)
+	}
+
+	sort.Slice(data, func(i, j int) bool {
+		return data[i].ID < data[j].ID
+	})
+
+	return data, nil
+}
+
+// CreateSubnet creates an SDN subnet in a VNet. The subnet is pending until the SDN configuration is applied.
+func (c *Client) CreateSubnet(ctx context.Context, vnet string, data *SubnetCreateRequestBody) error {
+	if data.Type == "" {
+		data.Type = "subnet"
+	}
+
+	err := c.DoRequest(ctx, http.MethodPost, c.subnetsPath(vnet), data, nil)
+	if err != nil {
+		return fmt.Errorf("error creating SDN subnet: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateSubnet updates an SDN subnet of a VNet. The changes are pending until the SDN configuration is applied.
+func (c *Client) UpdateSubnet(ctx context.Context, vnet string, id string, data *SubnetUpdateRequestBody) error {
+	err := c.DoRequest(ctx, http.MethodPut, c.subnetsPath(vnet)+"/"+url.PathEscape(id), data, nil)
+	if err != nil {
+		return fmt.Errorf("error updating SDN subnet: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteSubnet deletes an SDN subnet of a VNet. The subnet is pending deletion until the SDN configuration
+// is applied.
+func (c *Client) DeleteSubnet(ctx context.Context, vnet string, id string) error {
+	err := c.DoRequest(ctx, http.MethodDelete, c.subnetsPath(vnet)+"/"+url.PathEscape(id), nil, nil)
+	if err != nil {
+		return fmt.Errorf("error deleting SDN subnet: %w", err)
+	}
+
+	return nil
+}
+
+// SubnetID returns the identifier of the subnet of a zone with the given network, e.g. `zone1-10.0.0.0-24`
+// for the network `10.0.0.0/24`.
+func SubnetID(zone string, cidr string) string {
+	return fmt.Sprintf("%s-%s", zone, strings.ReplaceAll(cidr, "/", "-"))
+}
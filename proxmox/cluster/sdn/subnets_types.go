@@ -0,0 +1,167 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+// SubnetFields contains the fields of an SDN subnet that can be set on create and update.
+type SubnetFields struct {
+	DHCPDNSServer *string           `json:"dhcp-dns-server,omitempty" url:"dhcp-dns-server,omitempty"`
+	DHCPRanges    DHCPRanges        `json:"dhcp-range,omitempty"      url:"dhcp-range,omitempty"`
+	DNSZonePrefix *string           `json:"dnszoneprefix,omitempty"   url:"dnszoneprefix,omitempty"`
+	Gateway       *string           `json:"gateway,omitempty"         url:"gateway,omitempty"`
+	SNAT          *types.CustomBool `json:"snat,omitempty"            url:"snat,omitempty,int"`
+}
+
+// SubnetData contains the data from an SDN subnet response.
+type SubnetData struct {
+	SubnetFields
+
+	// ID is the identifier of the subnet, with format `<zone>-<network>-<mask>`.
+	ID   string  `json:"subnet"`
+	CIDR *string `json:"cidr,omitempty"`
+	VNet *string `json:"vnet,omitempty"`
+	Zone *string `json:"zone,omitempty"`
+
+	// State is the state of the changes that have not been applied yet, if any.
+	State *string `json:"state,omitempty"`
+}
+
+// SubnetCreateRequestBody contains the body for creating an SDN subnet.
+type SubnetCreateRequestBody struct {
+	SubnetFields
+
+	// CIDR is the network of the subnet, e.g. `10.0.0.0/24`.
+	CIDR string `url:"subnet"`
+	Type string `url:"type"`
+}
+
+// SubnetUpdateRequestBody contains the body for updating an SDN subnet.
+type SubnetUpdateRequestBody struct {
+	SubnetFields
+
+	Delete []string `url:"delete,omitempty,comma"`
+}
+
+// DHCPRange is a range of addresses assigned by the DHCP server of a subnet.
+type DHCPRange struct {
+	StartAddress string `json:"start-address"`
+	EndAddress   string `json:"end-address"`
+}
+
+// DHCPRanges is a list of DHCP ranges of a subnet.
+type DHCPRanges []DHCPRange
+
+// String converts a DHCP range to its property string, as expected by the API.
+func (r DHCPRange) String() string {
+	return fmt.Sprintf("start-address=%s,end-address=%s", r.StartAddress, r.EndAddress)
+}
+
+// EncodeValues encodes the DHCP ranges of a subnet into an URL-encoded set of values.
+func (r DHCPRanges) EncodeValues(key string, v *url.Values) error {
+	for _, dr := range r {
+		v.Add(key, dr.String())
+	}
+
+	return nil
+}
+
+// UnmarshalJSON decodes the DHCP ranges of a subnet. The API reports the ranges either as a list of objects,
+// or as a list of property strings, or as a single comma-separated string for the pending changes.
+func (r *DHCPRanges) UnmarshalJSON(b []byte) error {
+	var raw any
+
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return fmt.Errorf("failed to decode DHCP ranges: %w", err)
+	}
+
+	var props []string
+
+	switch v := raw.(type) {
+	case nil:
+		*r = nil
+
+		return nil
+	case string:
+		props = append(props, v)
+	case []any:
+		for _, item := range v {
+			switch i := item.(type) {
+			case string:
+				props = append(props, i)
+			case map[string]any:
+				start, _ := i["start-address"].(string)
+				end, _ := i["end-address"].(string)
+				props = append(props, DHCPRange{StartAddress: start, EndAddress: end}.String())
+			default:
+				return fmt.Errorf("unexpected DHCP range of type %T", item)
+			}
+		}
+	default:
+		return fmt.Errorf("unexpected DHCP ranges of type %T", raw)
+	}
+
+	ranges := DHCPRanges{}
+
+	for _, prop := range props {
+		parsed, err := parseDHCPRanges(prop)
+		if err != nil {
+			return err
+		}
+
+		ranges = append(ranges, parsed...)
+	}
+
+	*r = ranges
+
+	return nil
+}
+
+// parseDHCPRanges parses property strings of DHCP ranges, which may be joined by commas.
+func parseDHCPRanges(s string) ([]DHCPRange, error) {
+	var ranges []DHCPRange
+
+	for _, kv := range strings.Split(s, ",") {
+		k, v, ok := strings.Cut(strings.TrimSpace(kv), "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid DHCP range %q", s)
+		}
+
+		var field func(dr *DHCPRange) *string
+
+		switch k {
+		case "start-address":
+			field = func(dr *DHCPRange) *string { return &dr.StartAddress }
+		case "end-address":
+			field = func(dr *DHCPRange) *string { return &dr.EndAddress }
+		default:
+			return nil, fmt.Errorf("invalid DHCP range %q: unknown property %q", s, k)
+		}
+
+		// a property that is already set starts the next range
+		if len(ranges) == 0 || *field(&ranges[len(ranges)-1]) != "" {
+			ranges = append(ranges, DHCPRange{})
+		}
+
+		*field(&ranges[len(ranges)-1]) = v
+	}
+
+	for _, dr := range ranges {
+		if dr.StartAddress == "" || dr.EndAddress == "" {
+			return nil, fmt.Errorf("invalid DHCP range %q: both start and end address are required", s)
+		}
+	}
+
+	return ranges, nil
+}
@@ -0,0 +1,73 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+)
+
+// GetVNet retrieves an SDN VNet, including the changes that have not been applied yet.
+func (c *Client) GetVNet(ctx context.Context, id string) (*VNetData, error) {
+	data := &VNetData{}
+
+	err := c.getPending(ctx, c.ExpandPath("vnets/"+url.PathEscape(id)), data)
+	if err != nil {
+		return nil, fmt.Errorf("error reading SDN VNet: %w", err)
+	}
+
+	return data, nil
+}
+
+// ListVNets lists the SDN VNets, including the changes that have not been applied yet.
+func (c *Client) ListVNets(ctx context.Context) ([]VNetData, error) {
+	var data []VNetData
+
+	err := c.getPending(ctx, c.ExpandPath("vnets"), &data)
+	if err != nil {
+		return nil, fmt.Errorf("error listing SDN VNets: %w", err)
+	}
+
+	sort.Slice(data, func(i, j int) bool {
+		return data[i].ID < data[j].ID
+	})
+
+	return data, nil
+}
+
+// CreateVNet creates an SDN VNet. The VNet is pending until the SDN configuration is applied.
+func (c *Client) CreateVNet(ctx context.Context, data *VNetCreateRequestBody) error {
+	err := c.DoRequest(ctx, http.MethodPost, c.ExpandPath("vnets"), data, nil)
+	if err != nil {
+		return fmt.Errorf("error creating SDN VNet: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateVNet updates an SDN VNet. The changes are pending until the SDN configuration is applied.
+func (c *Client) UpdateVNet(ctx context.Context, id string, data *VNetUpdateRequestBody) error {
+	err := c.DoRequest(ctx, http.MethodPut, c.ExpandPath("vnets/"+url.PathEscape(id)), data, nil)
+	if err != nil {
+		return fmt.Errorf("error updating SDN VNet: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteVNet deletes an SDN VNet. The VNet is pending deletion until the SDN configuration is applied.
+func (c *Client) DeleteVNet(ctx context.Context, id string) error {
+	err := c.DoRequest(ctx, http.MethodDelete, c.ExpandPath("vnets/"+url.PathEscape(id)), nil, nil)
+	if err != nil {
+		return fmt.Errorf("error deleting SDN VNet: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+// VNetFields contains the fields of an SDN VNet that can be set on create and update.
+type VNetFields struct {
+	Alias        *string            `json:"alias,omitempty"         url:"alias,omitempty"`
+	IsolatePorts *types.CustomBool  `json:"isolate-ports,omitempty" url:"isolate-ports,omitempty,int"`
+	Tag          *types.CustomInt64 `json:"tag,omitempty"           url:"tag,omitempty"`
+	VLANAware    *types.CustomBool  `json:"vlanaware,omitempty"     url:"vlanaware,omitempty,int"`
+	Zone         *string            `json:"zone,omitempty"          url:"zone,omitempty"`
+}
+
+// VNetData contains the data from an SDN VNet response.
+type VNetData struct {
+	VNetFields
+
+	ID string `json:"vnet"`
+
+	// State is the state of the changes that have not been applied yet, if any.
+	State *string `json:"state,omitempty"`
+}
+
+// VNetCreateRequestBody contains the body for creating an SDN VNet.
+type VNetCreateRequestBody struct {
+	VNetFields
+
+	ID string `url:"vnet"`
+}
+
+// VNetUpdateRequestBody contains the body for updating an SDN VNet.
+type VNetUpdateRequestBody struct {
+	VNetFields
+
+	Delete []string `url:"delete,omitempty,comma"`
+}
@@ -0,0 +1,73 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+)
+
+// GetZone retrieves an SDN zone, including the changes that have not been applied yet.
+func (c *Client) GetZone(ctx context.Context, id string) (*ZoneData, error) {
+	data := &ZoneData{}
+
+	err := c.getPending(ctx, c.ExpandPath("zones/"+url.PathEscape(id)), data)
+	if err != nil {
+		return nil, fmt.Errorf("error reading SDN zone: %w", err)
+	}
+
+	return data, nil
+}
+
+// ListZones lists the SDN zones, including the changes that have not been applied yet.
+func (c *Client) ListZones(ctx context.Context) ([]ZoneData, error) {
+	var data []ZoneData
+
+	err := c.getPending(ctx, c.ExpandPath("zones"), &data)
+	if err != nil {
+		return nil, fmt.Errorf("error listing SDN zones: %w", err)
+	}
+
+	sort.Slice(data, func(i, j int) bool {
+		return data[i].ID < data[j].ID
+	})
+
+	return data, nil
+}
+
+// CreateZone creates an SDN zone. The zone is pending until the SDN configuration is applied.
+func (c *Client) CreateZone(ctx context.Context, data *ZoneCreateRequestBody) error {
+	err := c.DoRequest(ctx, http.MethodPost, c.ExpandPath("zones"), data, nil)
+	if err != nil {
+		return fmt.Errorf("error creating SDN zone: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateZone updates an SDN zone. The changes are pending until the SDN configuration is applied.
+func (c *Client) UpdateZone(ctx context.Context, id string, data *ZoneUpdateRequestBody) error {
+	err := c.DoRequest(ctx, http.MethodPut, c.ExpandPath("zones/"+url.PathEscape(id)), data, nil)
+	if err != nil {
+		return fmt.Errorf("error updating SDN zone: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteZone deletes an SDN zone. The zone is pending deletion until the SDN configuration is applied.
+func (c *Client) DeleteZone(ctx context.Context, id string) error {
+	err := c.DoRequest(ctx, http.MethodDelete, c.ExpandPath("zones/"+url.PathEscape(id)), nil, nil)
+	if err != nil {
+		return fmt.Errorf("error deleting SDN zone: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package sdn
+
+import (
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+const (
+	// ZoneTypeSimple is the type of an isolated zone, with a bridge per VNet on each node.
+	ZoneTypeSimple = "simple"
+
+	// ZoneTypeVLAN is the type of a zone using VLAN tags on an existing bridge.
+	ZoneTypeVLAN = "vlan"
+
+	// ZoneTypeQinQ is the type of a zone stacking VLAN tags (802.1ad) on an existing bridge.
+	ZoneTypeQinQ = "qinq"
+
+	// ZoneTypeVXLAN is the type of a zone using a layer 2 VXLAN overlay between the peers.
+	ZoneTypeVXLAN = "vxlan"
+
+	// ZoneTypeEVPN is the type of a zone using a layer 3 VXLAN overlay routed by a BGP EVPN controller.
+	ZoneTypeEVPN = "evpn"
+)
+
+// ZoneFields contains the fields of an SDN zone that can be set on create and update.
+type ZoneFields struct {
+	DNS        *string            `json:"dns,omitempty"        url:"dns,omitempty"`
+	DNSZone    *string            `json:"dnszone,omitempty"    url:"dnszone,omitempty"`
+	IPAM       *string            `json:"ipam,omitempty"       url:"ipam,omitempty"`
+	MTU        *types.CustomInt64 `json:"mtu,omitempty"        url:"mtu,omitempty"`
+	Nodes      *string            `json:"nodes,omitempty"      url:"nodes,omitempty"`
+	ReverseDNS *string            `json:"reversedns,omitempty" url:"reversedns,omitempty"`
+
+	// simple zone only options
+	DHCP *string `json:"dhcp,omitempty" url:"dhcp,omitempty"`
+
+	// vlan and qinq zone only options
+	Bridge *string `json:"bridge,omitempty" url:"bridge,omitempty"`
+
+	// qinq zone only options
+	ServiceVLAN         *types.CustomInt64 `json:"tag,omitempty"           url:"tag,omitempty"`
+	ServiceVLANProtocol *string            `json:"vlan-protocol,omitempty" url:"vlan-protocol,omitempty"`
+
+	// vxlan zone only options
+	Peers     *string            `json:"peers,omitempty"      url:"peers,omitempty"`
+	VXLANPort *types.CustomInt64 `json:"vxlan-port,omitempty" url:"vxlan-port,omitempty"`
+
+	// evpn zone only options
+	AdvertiseSubnets        *types.CustomBool  `json:"advertise-subnets,omitempty"          url:"advertise-subnets,omitempty,int"`
+	Controller              *string            `json:"controller,omitempty"                 url:"controller,omitempty"`
+	DisableARPNDSuppression *types.CustomBool  `json:"disable-arp-nd-suppression,omitempty" url:"disable-arp-nd-suppression,omitempty,int"`
+	ExitNodes               *string            `json:"exitnodes,omitempty"                  url:"exitnodes,omitempty"`
+	ExitNodesLocalRouting   *types.CustomBool  `json:"exitnodes-local-routing,omitempty"    url:"exitnodes-local-routing,omitempty,int"`
+	ExitNodesPrimary        *string            `json:"exitnodes-primary,omitempty"          url:"exitnodes-primary,omitempty"`
+	MAC                     *string            `json:"mac,omitempty"                        url:"mac,omitempty"`
+	RouteTargetImport       *string            `json:"rt-import,omitempty"                  url:"rt-import,omitempty"`
+	VRFVXLAN                *types.CustomInt64 `json:"vrf-vxlan,omitempty"                  url:"vrf-vxlan,omitempty"`
+}
+
+// ZoneData contains the data from an SDN zone response.
+type ZoneData struct {
+	ZoneFields
+
+	ID   string `json:"zone"`
+	Type string `json:"type"`
+
+	// State is the state of the changes that have not been applied yet, if any.
+	State *string `json:"state,omitempty"`
+}
+
+// ZoneCreateRequestBody contains the body for creating an SDN zone.
+type ZoneCreateRequestBody struct {
+	ZoneFields
+
+	ID   string `url:"zone"`
+	Type string `url:"type"`
+}
+
+// ZoneUpdateRequestBody contains the body for updating an SDN zone.
+type ZoneUpdateRequestBody struct {
+	ZoneFields
+
+	Delete []string `url:"delete,omitempty,comma"`
+}
@@ -0,0 +1,47 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+// GetStatus retrieves the status of the cluster and its nodes.
+func (c *Client) GetStatus(ctx context.Context) ([]*StatusResponseData, error) {
+	resBody := &StatusResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.ExpandPath("status"), nil, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving cluster status: %w", err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data, nil
+}
+
+// ListConfigNodes retrieves the corosync configuration of the cluster nodes.
+func (c *Client) ListConfigNodes(ctx context.Context) ([]*ConfigNodeResponseData, error) {
+	resBody := &ConfigNodesResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, c.ExpandPath("config/nodes"), nil, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error listing cluster config nodes: %w", err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data, nil
+}
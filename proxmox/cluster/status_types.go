@@ -0,0 +1,116 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package cluster
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+// StatusResponseBody contains the body from a cluster status response.
+type StatusResponseBody struct {
+	Data []*StatusResponseData `json:"data,omitempty"`
+}
+
+// StatusResponseData contains the data from a cluster status response. The list holds an entry
+// of the `cluster` type for a cluster, and an entry of the `node` type for each node.
+type StatusResponseData struct {
+	Type    string            `json:"type"`
+	ID      string            `json:"id"`
+	Name    string            `json:"name"`
+	IP      *string           `json:"ip,omitempty"`
+	Level   *string           `json:"level,omitempty"`
+	Local   *types.CustomBool `json:"local,omitempty"`
+	NodeID  *types.CustomInt  `json:"nodeid,omitempty"`
+	Nodes   *types.CustomInt  `json:"nodes,omitempty"`
+	Online  *types.CustomBool `json:"online,omitempty"`
+	Quorate *types.CustomBool `json:"quorate,omitempty"`
+	Version *types.CustomInt  `json:"version,omitempty"`
+}
+
+// ConfigNodesResponseBody contains the body from a cluster config nodes response.
+type ConfigNodesResponseBody struct {
+	Data []*ConfigNodeResponseData `json:"data,omitempty"`
+}
+
+// ConfigNodeResponseData contains the corosync configuration of a cluster node.
+type ConfigNodeResponseData struct {
+	Name        string           `json:"node"`
+	NodeID      *types.CustomInt `json:"nodeid,omitempty"`
+	QuorumVotes *types.CustomInt `json:"quorum_votes,omitempty"`
+
+	// RingAddresses are the addresses of the corosync links (`ring0_addr` to `ring7_addr`), by link number.
+	RingAddresses map[int]string `json:"-"`
+}
+
+// UnmarshalJSON unmarshals the node configuration, collecting the addresses of its corosync links.
+func (d *ConfigNodeResponseData) UnmarshalJSON(b []byte) error {
+	type configNode ConfigNodeResponseData
+
+	var node configNode
+
+	if err := json.Unmarshal(b, &node); err != nil {
+		return fmt.Errorf("failed to unmarshal cluster config node: %w", err)
+	}
+
+	var fields map[string]any
+
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return fmt.Errorf("failed to unmarshal cluster config node: %w", err)
+	}
+
+	for k, v := range fields {
+		link, ok := strings.CutPrefix(k, "ring")
+		if !ok {
+			continue
+		}
+
+		link, ok = strings.CutSuffix(link, "_addr")
+		if !ok {
+			continue
+		}
+
+		n, err := strconv.Atoi(link)
+		if err != nil {
+			continue
+		}
+
+		if addr, ok := v.(string); ok && addr != "" {
+			if node.RingAddresses == nil {
+				node.RingAddresses = map[int]string{}
+			}
+
+			node.RingAddresses[n] = addr
+		}
+	}
+
+	*d = ConfigNodeResponseData(node)
+
+	return nil
+}
+
+// Addresses returns the addresses of the corosync links of the node, ordered by link number.
+func (d *ConfigNodeResponseData) Addresses() []string {
+	links := make([]int, 0, len(d.RingAddresses))
+	for l := range d.RingAddresses {
+		links = append(links, l)
+	}
+
+	sort.Ints(links)
+
+	addrs := make([]string, len(links))
+	for i, l := range links {
+		addrs[i] = d.RingAddresses[l]
+	}
+
+	return addrs
+}
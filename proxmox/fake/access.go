@@ -0,0 +1,364 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package fake
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+func (s *Server) registerAccessRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST "+basePath+"/access/ticket", s.createTicket)
+	mux.HandleFunc("PUT "+basePath+"/access/password", s.changePassword)
+	mux.HandleFunc("GET "+basePath+"/access/users", s.listUsers)
+	mux.HandleFunc("POST "+basePath+"/access/users", s.createUser)
+	mux.HandleFunc("GET "+basePath+"/access/users/{userid}", s.getUser)
+	mux.HandleFunc("PUT "+basePath+"/access/users/{userid}", s.updateUser)
+	mux.HandleFunc("DELETE "+basePath+"/access/users/{userid}", s.deleteUser)
+	mux.HandleFunc("GET "+basePath+"/access/users/{userid}/token", s.listUserTokens)
+	mux.HandleFunc("GET "+basePath+"/access/users/{userid}/token/{tokenid}", s.getUserToken)
+	mux.HandleFunc("POST "+basePath+"/access/users/{userid}/token/{tokenid}", s.createUserToken)
+	mux.HandleFunc("PUT "+basePath+"/access/users/{userid}/token/{tokenid}", s.updateUserToken)
+	mux.HandleFunc("DELETE "+basePath+"/access/users/{userid}/token/{tokenid}", s.deleteUserToken)
+}
+
+func (s *Server) createTicket(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	username := r.FormValue("username")
+	password := r.FormValue("password")
+
+	u, ok := s.users[username]
+
+	switch {
+	case ok && u.password == password:
+	case ok && s.tickets[password] != "" && strings.Split(password, ":")[1] == username:
+		// renewing an existing ticket: the ticket is passed as the password
+	default:
+		writeError(w, http.StatusUnauthorized, "authentication failure")
+		return
+	}
+
+	ticket, csrf := s.newTicket(username)
+
+	writeData(w, map[string]any{
+		"username":            username,
+		"ticket":              ticket,
+		"CSRFPreventionToken": csrf,
+		"clustername":         "fake",
+	})
+}
+
+func (s *Server) changePassword(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, ok := s.users[r.FormValue("userid")]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("user '%s' does not exist", r.FormValue("userid")))
+		return
+	}
+
+	u.password = r.FormValue("password")
+
+	writeData(w, nil)
+}
+
+func (s *Server) listUsers(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list := make([]map[string]any, 0, len(s.users))
+	for _, id := range sortedKeys(s.users) {
+		list = append(list, renderUser(s.users[id], true))
+	}
+
+	writeData(w, list)
+}
+
+func (s *Server) createUser(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	values := formValues(r)
+
+	id := values["userid"]
+	if !strings.Contains(id, "@") {
+		writeParamErrors(w, map[string]string{"userid": "invalid format - value does not look like a valid user ID"})
+		return
+	}
+
+	if _, ok := s.users[id]; ok {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("create user failed: user '%s' already exists", id))
+		return
+	}
+
+	u := newUser(id, values["password"])
+
+	delete(values, "userid")
+	delete(values, "password")
+
+	for k, v := range values {
+		u.fields[k] = v
+	}
+
+	s.users[id] = u
+
+	writeData(w, nil)
+}
+
+func (s *Server) getUser(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, ok := s.users[r.PathValue("userid")]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("no such user ('%s')", r.PathValue("userid")))
+		return
+	}
+
+	writeData(w, renderUser(u, false))
+}
+
+func (s *Server) updateUser(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, ok := s.users[r.PathValue("userid")]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("no such user ('%s')", r.PathValue("userid")))
+		return
+	}
+
+	values := formValues(r)
+
+	for _, k := range strings.Split(values["delete"], ",") {
+		delete(u.fields, strings.TrimSpace(k))
+	}
+
+	delete(values, "delete")
+
+	for k, v := range values {
+		u.fields[k] = v
+	}
+
+	writeData(w, nil)
+}
+
+func (s *Server) deleteUser(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.users[r.PathValue("userid")]; !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("no such user ('%s')", r.PathValue("userid")))
+		return
+	}
+
+	delete(s.users, r.PathValue("userid"))
+
+	writeData(w, nil)
+}
+
+func (s *Server) listUserTokens(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, ok := s.users[r.PathValue("userid")]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("no such user ('%s')", r.PathValue("userid")))
+		return
+	}
+
+	list := make([]map[string]any, 0, len(u.tokens))
+	for _, id := range sortedKeys(u.tokens) {
+		info := renderToken(u.tokens[id])
+		info["tokenid"] = id
+		list = append(list, info)
+	}
+
+	writeData(w, list)
+}
+
+func (s *Server) getUserToken(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.lookupToken(r)
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("no such token '%s'", r.PathValue("tokenid")))
+		return
+	}
+
+	writeData(w, renderToken(t))
+}
+
+func (s *Server) createUserToken(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	userID := r.PathValue("userid")
+	tokenID := r.PathValue("tokenid")
+
+	u, ok := s.users[userID]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("no such user ('%s')", userID))
+		return
+	}
+
+	if _, ok = u.tokens[tokenID]; ok {
+		writeError(w, http.StatusBadRequest, "Token already exists.")
+		return
+	}
+
+	t := &token{
+		id:      tokenID,
+		value:   uuid.NewString(),
+		privsep: r.FormValue("privsep") != "0",
+	}
+
+	applyTokenValues(t, formValues(r))
+
+	u.tokens[tokenID] = t
+
+	writeData(w, map[string]any{
+		"full-tokenid": fmt.Sprintf("%s!%s", userID, tokenID),
+		"info":         renderToken(t),
+		"value":        t.value,
+	})
+}
+
+func (s *Server) updateUserToken(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.lookupToken(r)
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("no such token '%s'", r.PathValue("tokenid")))
+		return
+	}
+
+	applyTokenValues(t, formValues(r))
+
+	writeData(w, renderToken(t))
+}
+
+func (s *Server) deleteUserToken(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.lookupToken(r); !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("no such token '%s'", r.PathValue("tokenid")))
+		return
+	}
+
+	delete(s.users[r.PathValue("userid")].tokens, r.PathValue("tokenid"))
+
+	writeData(w, nil)
+}
+
+func (s *Server) lookupToken(r *http.Request) (*token, bool) {
+	u, ok := s.users[r.PathValue("userid")]
+	if !ok {
+		return nil, false
+	}
+
+	t, ok := u.tokens[r.PathValue("tokenid")]
+
+	return t, ok
+}
+
+func applyTokenValues(t *token, values map[string]string) {
+	if v, ok := values["comment"]; ok {
+		t.comment = v
+	}
+
+	if v, ok := values["expire"]; ok {
+		t.expire, _ = strconv.ParseInt(v, 10, 64)
+	}
+
+	if v, ok := values["privsep"]; ok {
+		t.privsep = v != "0"
+	}
+}
+
+func renderToken(t *token) map[string]any {
+	out := map[string]any{
+		"expire":  t.expire,
+		"privsep": 0,
+	}
+
+	if t.privsep {
+		out["privsep"] = 1
+	}
+
+	if t.comment != "" {
+		out["comment"] = t.comment
+	}
+
+	return out
+}
+
+func renderUser(u *user, withID bool) map[string]any {
+	fields := map[string]string{}
+
+	for k, v := range u.fields {
+		if k != "groups" {
+			fields[k] = v
+		}
+	}
+
+	out := render(fields)
+
+	if withID {
+		out["userid"] = u.id
+	}
+
+	groups := []string{}
+
+	if g := u.fields["groups"]; g != "" {
+		groups = strings.Split(g, ",")
+	}
+
+	out["groups"] = groups
+
+	return out
+}
@@ -0,0 +1,251 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package fake
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+)
+
+//nolint:gochecknoglobals
+var (
+	// backupJobOptions are the options of a backup job supported by the fake server.
+	backupJobOptions = []string{
+		"all", "comment", "compress", "enabled", "exclude", "mailnotification", "mailto", "mode", "node",
+		"notes-template", "notification-mode", "pool", "prune-backups", "repeat-missed", "schedule", "storage", "vmid",
+	}
+
+	backupJobIDPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]+$`)
+)
+
+func (s *Server) registerBackupRoutes(mux *http.ServeMux) {
+	prefix := basePath + "/cluster/backup"
+
+	mux.HandleFunc("GET "+prefix, s.listBackupJobs)
+	mux.HandleFunc("POST "+prefix, s.createBackupJob)
+	mux.HandleFunc("GET "+prefix+"/{id}", s.withBackupJob(s.getBackupJob))
+	mux.HandleFunc("PUT "+prefix+"/{id}", s.withBackupJob(s.updateBackupJob))
+	mux.HandleFunc("DELETE "+prefix+"/{id}", s.withBackupJob(s.deleteBackupJob))
+	mux.HandleFunc("GET "+basePath+"/cluster/backup-info/not-backed-up", s.listNotBackedUpGuests)
+}
+
+// backupJobHandlerFunc handles a request for an existing backup job. It is called with the server lock held.
+type backupJobHandlerFunc func(w http.ResponseWriter, r *http.Request, id string, job map[string]string)
+
+// withBackupJob looks up the backup job from the request path and holds the server lock while the handler runs.
+func (s *Server) withBackupJob(next backupJobHandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := parseForm(r); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		id := r.PathValue("id")
+
+		job, ok := s.backupJobs[id]
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("job '%s' does not exist", id))
+			return
+		}
+
+		next(w, r, id, job)
+	}
+}
+
+func (s *Server) listBackupJobs(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list := []map[string]any{}
+
+	for _, id := range sortedKeys(s.backupJobs) {
+		list = append(list, renderBackupJob(id, s.backupJobs[id]))
+	}
+
+	writeData(w, list)
+}
+
+func (s *Server) createBackupJob(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	values := formValues(r)
+	id := values["id"]
+
+	if !backupJobIDPattern.MatchString(id) {
+		writeParamErrors(w, map[string]string{"id": "invalid format - invalid configuration ID '" + id + "'"})
+		return
+	}
+
+	if _, ok := s.backupJobs[id]; ok {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("Job '%s' already exists", id))
+		return
+	}
+
+	delete(values, "id")
+
+	if values["schedule"] == "" {
+		writeParamErrors(w, map[string]string{"schedule": "property is missing and it is not optional"})
+		return
+	}
+
+	if _, ok := values["enabled"]; !ok {
+		values["enabled"] = "1"
+	}
+
+	if errs := s.validateBackupJob(values); len(errs) > 0 {
+		writeParamErrors(w, errs)
+		return
+	}
+
+	s.backupJobs[id] = values
+
+	writeData(w, nil)
+}
+
+func (s *Server) getBackupJob(w http.ResponseWriter, _ *http.Request, id string, job map[string]string) {
+	writeData(w, renderBackupJob(id, job))
+}
+
+func (s *Server) updateBackupJob(w http.ResponseWriter, r *http.Request, _ string, job map[string]string) {
+	values := formValues(r)
+	updated := maps.Clone(job)
+
+	for _, k := range splitList(values["delete"]) {
+		delete(updated, k)
+	}
+
+	delete(values, "delete")
+	delete(values, "digest")
+
+	maps.Copy(updated, values)
+
+	if errs := s.validateBackupJob(updated); len(errs) > 0 {
+		writeParamErrors(w, errs)
+		return
+	}
+
+	clear(job)
+	maps.Copy(job, updated)
+
+	writeData(w, nil)
+}
+
+func (s *Server) deleteBackupJob(w http.ResponseWriter, _ *http.Request, id string, _ map[string]string) {
+	delete(s.backupJobs, id)
+
+	writeData(w, nil)
+}
+
+func (s *Server) listNotBackedUpGuests(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	vmids := make([]int, 0, len(s.guests))
+	for vmid := range s.guests {
+		vmids = append(vmids, vmid)
+	}
+
+	slices.Sort(vmids)
+
+	list := []map[string]any{}
+
+	for _, vmid := range vmids {
+		g := s.guests[vmid]
+
+		if s.isBackedUp(g) {
+			continue
+		}
+
+		list = append(list, map[string]any{
+			"vmid": vmid,
+			"name": g.name(),
+			"type": g.kind,
+		})
+	}
+
+	writeData(w, list)
+}
+
+// isBackedUp reports whether the guest is included in an enabled backup job.
+func (s *Server) isBackedUp(g *guest) bool {
+	vmid := strconv.Itoa(g.vmid)
+
+	for _, job := range s.backupJobs {
+		if job["enabled"] == "0" {
+			continue
+		}
+
+		if slices.Contains(splitList(job["vmid"]), vmid) {
+			return true
+		}
+
+		if job["all"] == "1" && !slices.Contains(splitList(job["exclude"]), vmid) &&
+			(job["node"] == "" || job["node"] == g.node) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validateBackupJob checks the options and the guest selection of a backup job.
+func (s *Server) validateBackupJob(job map[string]string) map[string]string {
+	errs := map[string]string{}
+
+	for k := range job {
+		if !slices.Contains(backupJobOptions, k) {
+			errs[k] = "property is not defined in schema and the schema does not allow additional properties"
+		}
+	}
+
+	if storage, ok := job["storage"]; ok {
+		if _, exists := s.datastores[storage]; !exists {
+			errs["storage"] = fmt.Sprintf("storage '%s' does not exist", storage)
+		}
+	}
+
+	if job["vmid"] != "" {
+		for _, k := range []string{"all", "exclude", "pool"} {
+			if job[k] != "" && job[k] != "0" {
+				errs[k] = "option conflicts with option 'vmid'"
+			}
+		}
+	} else if job["all"] != "1" && job["pool"] == "" {
+		errs["vmid"] = "property is missing"
+	}
+
+	return errs
+}
+
+func renderBackupJob(id string, job map[string]string) map[string]any {
+	out := render(job)
+
+	// the schedule, the comment and the lists are strings, even when they look like numbers
+	for _, k := range []string{"comment", "exclude", "mailto", "schedule", "vmid"} {
+		if v, ok := job[k]; ok {
+			out[k] = v
+		}
+	}
+
+	out["id"] = id
+	out["type"] = "vzdump"
+
+	return out
+}
@@ -0,0 +1,188 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package fake
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+)
+
+const (
+	minVMID = 100
+	maxVMID = 999999999
+)
+
+func (s *Server) registerClusterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET "+basePath+"/cluster/nextid", s.getNextID)
+	mux.HandleFunc("GET "+basePath+"/cluster/resources", s.listClusterResources)
+	mux.HandleFunc("GET "+basePath+"/cluster/status", s.getClusterStatus)
+	mux.HandleFunc("GET "+basePath+"/cluster/config/nodes", s.listClusterConfigNodes)
+}
+
+func (s *Server) getNextID(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if v := r.FormValue("vmid"); v != "" {
+		vmid, err := strconv.Atoi(v)
+		if err != nil || vmid < minVMID || vmid > maxVMID {
+			writeParamErrors(w, map[string]string{"vmid": "invalid VM ID"})
+			return
+		}
+
+		if _, ok := s.guests[vmid]; ok {
+			writeParamErrors(w, map[string]string{"vmid": fmt.Sprintf("VM %d already exists", vmid)})
+			return
+		}
+
+		writeData(w, strconv.Itoa(vmid))
+
+		return
+	}
+
+	vmid := minVMID
+	for s.guests[vmid] != nil {
+		vmid++
+	}
+
+	writeData(w, strconv.Itoa(vmid))
+}
+
+func (s *Server) listClusterResources(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resourceType := r.FormValue("type")
+	list := []map[string]any{}
+
+	if resourceType == "" || resourceType == "node" {
+		for _, n := range s.nodes {
+			list = append(list, map[string]any{
+				"type":   "node",
+				"id":     "node/" + n,
+				"node":   n,
+				"status": "online",
+				"level":  "",
+			})
+		}
+	}
+
+	if resourceType == "" || resourceType == "vm" {
+		vmids := make([]int, 0, len(s.guests))
+		for vmid := range s.guests {
+			vmids = append(vmids, vmid)
+		}
+
+		sort.Ints(vmids)
+
+		for _, vmid := range vmids {
+			g := s.guests[vmid]
+			list = append(list, map[string]any{
+				"type":     g.kind,
+				"id":       fmt.Sprintf("%s/%d", g.kind, g.vmid),
+				"vmid":     g.vmid,
+				"node":     g.node,
+				"name":     g.name(),
+				"status":   g.status,
+				"template": g.isTemplate(),
+			})
+		}
+	}
+
+	if resourceType == "" || resourceType == "storage" {
+		for _, n := range s.nodes {
+			for _, id := range sortedKeys(s.datastores) {
+				if !s.datastores[id].availableOn(n) {
+					continue
+				}
+
+				list = append(list, map[string]any{
+					"type":       "storage",
+					"id":         fmt.Sprintf("storage/%s/%s", n, id),
+					"node":       n,
+					"storage":    id,
+					"plugintype": s.datastores[id].storageType,
+					"status":     "available",
+				})
+			}
+		}
+	}
+
+	writeData(w, list)
+}
+
+// getClusterStatus reports the nodes with their cluster addresses, 198.51.100.0/24, which differ from
+// the addresses of their bridges, 192.0.2.0/24.
+func (s *Server) getClusterStatus(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list := []map[string]any{
+		{
+			"type":    "cluster",
+			"id":      "cluster",
+			"name":    "fake",
+			"nodes":   len(s.nodes),
+			"quorate": 1,
+			"version": 1,
+		},
+	}
+
+	for i, n := range s.nodes {
+		// the API is served by the first node
+		local := 0
+		if i == 0 {
+			local = 1
+		}
+
+		list = append(list, map[string]any{
+			"type":   "node",
+			"id":     "node/" + n,
+			"name":   n,
+			"ip":     fmt.Sprintf("198.51.100.%d", 10+i),
+			"nodeid": i + 1,
+			"online": 1,
+			"local":  local,
+			"level":  "",
+		})
+	}
+
+	writeData(w, list)
+}
+
+// listClusterConfigNodes reports two corosync links for each node, 198.51.100.0/24 and 203.0.113.0/24.
+func (s *Server) listClusterConfigNodes(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list := make([]map[string]any, 0, len(s.nodes))
+
+	for i, n := range s.nodes {
+		list = append(list, map[string]any{
+			"node":         n,
+			"name":         n,
+			"nodeid":       strconv.Itoa(i + 1),
+			"quorum_votes": "1",
+			"ring0_addr":   fmt.Sprintf("198.51.100.%d", 10+i),
+			"ring1_addr":   fmt.Sprintf("203.0.113.%d", 10+i),
+		})
+	}
+
+	writeData(w, list)
+}
@@ -0,0 +1,752 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package fake
+
+import (
+	"crypto/sha1" //nolint:gosec
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+const (
+	kindQEMU = "qemu"
+	kindLXC  = "lxc"
+
+	defaultGuestMemory = 512
+)
+
+var (
+	qemuDiskKey = regexp.MustCompile(`^(ide|sata|scsi|virtio|efidisk|tpmstate|unused)\d+$`)
+	lxcDiskKey  = regexp.MustCompile(`^(rootfs|mp\d+|unused\d+)$`)
+)
+
+// ignoredConfigKeys are request parameters that are not stored in the guest configuration.
+var ignoredConfigKeys = []string{
+	"background_delay", "delete", "digest", "force", "node", "pool", "revert", "skiplock",
+	"start", "storage", "unique", "vmid", "ostemplate", "password", "ssh-public-keys",
+}
+
+func (g *guest) name() string {
+	if g.kind == kindLXC {
+		return g.config["hostname"]
+	}
+
+	return g.config["name"]
+}
+
+func (g *guest) isTemplate() int {
+	if g.config["template"] == "1" {
+		return 1
+	}
+
+	return 0
+}
+
+func (g *guest) isDiskKey(key string) bool {
+	if g.kind == kindLXC {
+		return lxcDiskKey.MatchString(key)
+	}
+
+	return qemuDiskKey.MatchString(key)
+}
+
+func (g *guest) memoryBytes() int64 {
+	memory := int64(defaultGuestMemory)
+
+	if v, err := strconv.ParseInt(strings.Split(g.config["memory"], ",")[0], 10, 64); err == nil {
+		memory = v
+	}
+
+	return memory * 1024 * 1024
+}
+
+func (g *guest) cpus() int {
+	cores, err := strconv.Atoi(g.config["cores"])
+	if err != nil || cores == 0 {
+		cores = 1
+	}
+
+	sockets, err := strconv.Atoi(g.config["sockets"])
+	if err != nil || sockets == 0 {
+		sockets = 1
+	}
+
+	return cores * sockets
+}
+
+func (g *guest) digest() string {
+	h := sha1.New() //nolint:gosec
+
+	for _, k := range sortedKeys(g.config) {
+		_, _ = fmt.Fprintf(h, "%s: %s\n", k, g.config[k])
+	}
+
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+func (s *Server) registerGuestRoutes(mux *http.ServeMux) {
+	for _, kind := range []string{kindQEMU, kindLXC} {
+		prefix := basePath + "/nodes/{node}/" + kind
+
+		mux.HandleFunc("GET "+prefix, s.withNode(s.listGuests(kind)))
+		mux.HandleFunc("POST "+prefix, s.withNode(s.createGuest(kind)))
+		mux.HandleFunc("GET "+prefix+"/{vmid}", s.withGuest(kind, s.getGuestIndex))
+		mux.HandleFunc("DELETE "+prefix+"/{vmid}", s.withGuest(kind, s.deleteGuest))
+		mux.HandleFunc("DELETE "+prefix+"/{vmid}/{$}", s.withGuest(kind, s.deleteGuest))
+		mux.HandleFunc("GET "+prefix+"/{vmid}/config", s.withGuest(kind, s.getGuestConfig))
+		mux.HandleFunc("PUT "+prefix+"/{vmid}/config", s.withGuest(kind, s.updateGuestConfig(false)))
+		mux.HandleFunc("POST "+prefix+"/{vmid}/config", s.withGuest(kind, s.updateGuestConfig(true)))
+		mux.HandleFunc("GET "+prefix+"/{vmid}/pending", s.withGuest(kind, s.getGuestPending))
+		mux.HandleFunc("GET "+prefix+"/{vmid}/status/current", s.withGuest(kind, s.getGuestStatus))
+		mux.HandleFunc("POST "+prefix+"/{vmid}/status/{action}", s.withGuest(kind, s.changeGuestStatus))
+		mux.HandleFunc("POST "+prefix+"/{vmid}/clone", s.withGuest(kind, s.cloneGuest))
+		mux.HandleFunc("POST "+prefix+"/{vmid}/template", s.withGuest(kind, s.convertGuestToTemplate))
+		mux.HandleFunc("PUT "+prefix+"/{vmid}/resize", s.withGuest(kind, s.resizeGuestDisk))
+		mux.HandleFunc("POST "+prefix+"/{vmid}/migrate", s.withGuest(kind, s.migrateGuest))
+	}
+}
+
+// guestHandlerFunc handles a request for an existing guest. It is called with the server lock held.
+type guestHandlerFunc func(w http.ResponseWriter, r *http.Request, g *guest)
+
+// withGuest looks up the guest from the request path and holds the server lock while the handler runs.
+func (s *Server) withGuest(kind string, next guestHandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := parseForm(r); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		vmid, err := strconv.Atoi(r.PathValue("vmid"))
+		if err != nil {
+			writeParamErrors(w, map[string]string{"vmid": "invalid integer value"})
+			return
+		}
+
+		g, ok := s.guests[vmid]
+		if !ok || g.kind != kind || g.node != r.PathValue("node") {
+			writeError(w, http.StatusNotFound,
+				fmt.Sprintf("Configuration file 'nodes/%s/%s/%d.conf' does not exist", r.PathValue("node"), kind, vmid))
+
+			return
+		}
+
+		next(w, r, g)
+	}
+}
+
+func (s *Server) listGuests(kind string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		vmids := make([]int, 0, len(s.guests))
+
+		for vmid, g := range s.guests {
+			if g.kind == kind && g.node == r.PathValue("node") {
+				vmids = append(vmids, vmid)
+			}
+		}
+
+		sort.Ints(vmids)
+
+		list := make([]map[string]any, 0, len(vmids))
+
+		for _, vmid := range vmids {
+			g := s.guests[vmid]
+			item := map[string]any{
+				"vmid":     g.vmid,
+				"name":     g.name(),
+				"status":   g.status,
+				"template": g.isTemplate(),
+				"maxmem":   g.memoryBytes(),
+				"cpus":     g.cpus(),
+			}
+
+			if tags, ok := g.config["tags"]; ok {
+				item["tags"] = tags
+			}
+
+			list = append(list, item)
+		}
+
+		writeData(w, list)
+	}
+}
+
+func (s *Server) createGuest(kind string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := parseForm(r); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		values := formValues(r)
+
+		vmid, err := strconv.Atoi(values["vmid"])
+		if err != nil || vmid < minVMID || vmid > maxVMID {
+			writeParamErrors(w, map[string]string{"vmid": "property is missing and it is not optional"})
+			return
+		}
+
+		if _, ok := s.guests[vmid]; ok {
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("unable to create VM %d: config file already exists", vmid))
+			return
+		}
+
+		if kind == kindLXC && values["ostemplate"] == "" {
+			writeParamErrors(w, map[string]string{"ostemplate": "property is missing and it is not optional"})
+			return
+		}
+
+		g := &guest{
+			kind:   kind,
+			node:   r.PathValue("node"),
+			vmid:   vmid,
+			config: map[string]string{},
+			status: "stopped",
+		}
+
+		if errs := s.applyGuestConfig(g, values); len(errs) > 0 {
+			writeParamErrors(w, errs)
+			return
+		}
+
+		s.guests[vmid] = g
+
+		taskType := "qmcreate"
+		if kind == kindLXC {
+			taskType = "vzcreate"
+		}
+
+		upid := s.newTask(r, g.node, taskType, strconv.Itoa(vmid))
+
+		if values["start"] == "1" {
+			g.status = "running"
+			g.started = time.Now()
+		}
+
+		writeData(w, upid)
+	}
+}
+
+func (s *Server) getGuestIndex(w http.ResponseWriter, _ *http.Request, g *guest) {
+	index := []map[string]string{{"subdir": "config"}, {"subdir": "pending"}, {"subdir": "status"}}
+
+	if g.kind == kindQEMU {
+		index = append(index, map[string]string{"subdir": "agent"})
+	}
+
+	writeData(w, index)
+}
+
+func (s *Server) deleteGuest(w http.ResponseWriter, r *http.Request, g *guest) {
+	if g.status == "running" {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("VM %d is running - destroy failed", g.vmid))
+		return
+	}
+
+	for k, v := range g.config {
+		if g.isDiskKey(k) {
+			s.freeVolume(v)
+		}
+	}
+
+	delete(s.guests, g.vmid)
+
+	taskType := "qmdestroy"
+	if g.kind == kindLXC {
+		taskType = "vzdestroy"
+	}
+
+	writeData(w, s.newTask(r, g.node, taskType, strconv.Itoa(g.vmid)))
+}
+
+func (s *Server) getGuestConfig(w http.ResponseWriter, _ *http.Request, g *guest) {
+	config := render(g.config)
+	config["digest"] = g.digest()
+
+	writeData(w, config)
+}
+
+func (s *Server) updateGuestConfig(async bool) guestHandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, g *guest) {
+		values := formValues(r)
+
+		if d, ok := values["digest"]; ok && d != g.digest() {
+			writeError(w, http.StatusInternalServerError,
+				"detected modified configuration - file changed by other user? Try again.")
+
+			return
+		}
+
+		for _, k := range strings.Split(values["delete"], ",") {
+			k = strings.TrimSpace(k)
+			if k == "" {
+				continue
+			}
+
+			s.deleteGuestConfigKey(g, k)
+		}
+
+		if errs := s.applyGuestConfig(g, values); len(errs) > 0 {
+			writeParamErrors(w, errs)
+			return
+		}
+
+		if async {
+			writeData(w, s.newTask(r, g.node, "qmconfig", strconv.Itoa(g.vmid)))
+			return
+		}
+
+		writeData(w, nil)
+	}
+}
+
+func (s *Server) getGuestPending(w http.ResponseWriter, _ *http.Request, g *guest) {
+	list := make([]map[string]any, 0, len(g.config))
+
+	for _, k := range sortedKeys(g.config) {
+		list = append(list, map[string]any{"key": k, "value": render(map[string]string{k: g.config[k]})[k]})
+	}
+
+	writeData(w, list)
+}
+
+func (s *Server) getGuestStatus(w http.ResponseWriter, _ *http.Request, g *guest) {
+	status := map[string]any{
+		"vmid":     g.vmid,
+		"name":     g.name(),
+		"status":   g.status,
+		"maxmem":   g.memoryBytes(),
+		"cpus":     g.cpus(),
+		"template": g.isTemplate(),
+	}
+
+	if tags, ok := g.config["tags"]; ok {
+		status["tags"] = tags
+	}
+
+	if g.lock != "" {
+		status["lock"] = g.lock
+	}
+
+	if g.status == "running" {
+		status["uptime"] = int(time.Since(g.started).Seconds())
+		status["pid"] = 1000 + g.vmid
+	}
+
+	if g.kind == kindQEMU {
+		status["qmpstatus"] = g.status
+
+		if strings.HasPrefix(g.config["agent"], "1") || strings.Contains(g.config["agent"], "enabled=1") {
+			status["agent"] = 1
+		}
+	} else {
+		status["type"] = kindLXC
+	}
+
+	writeData(w, status)
+}
+
+func (s *Server) changeGuestStatus(w http.ResponseWriter, r *http.Request, g *guest) {
+	action := r.PathValue("action")
+	prefix := "qm"
+
+	if g.kind == kindLXC {
+		prefix = "vz"
+	}
+
+	switch action {
+	case "start", "resume":
+		if g.isTemplate() == 1 {
+			writeError(w, http.StatusInternalServerError, "you can't start a vm if it's a template")
+			return
+		}
+
+		if g.status == "running" && action == "start" {
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("VM %d already running", g.vmid))
+			return
+		}
+
+		g.status = "running"
+		g.started = time.Now()
+	case "stop", "shutdown", "suspend":
+		g.status = "stopped"
+	case "reboot", "reset":
+		if g.status != "running" {
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("VM %d not running", g.vmid))
+			return
+		}
+
+		g.started = time.Now()
+	default:
+		writeError(w, http.StatusNotImplemented, fmt.Sprintf("status action '%s' is not implemented by the fake server", action))
+		return
+	}
+
+	writeData(w, s.newTask(r, g.node, prefix+action, strconv.Itoa(g.vmid)))
+}
+
+func (s *Server) cloneGuest(w http.ResponseWriter, r *http.Request, g *guest) {
+	values := formValues(r)
+
+	newID, err := strconv.Atoi(values["newid"])
+	if err != nil || newID < minVMID || newID > maxVMID {
+		writeParamErrors(w, map[string]string{"newid": "property is missing and it is not optional"})
+		return
+	}
+
+	if _, ok := s.guests[newID]; ok {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("unable to create VM %d: config file already exists", newID))
+		return
+	}
+
+	target := g.node
+	if t := values["target"]; t != "" {
+		if !s.hasNode(t) {
+			writeParamErrors(w, map[string]string{"target": fmt.Sprintf("no such cluster node '%s'", t)})
+			return
+		}
+
+		target = t
+	}
+
+	clone := &guest{
+		kind:   g.kind,
+		node:   target,
+		vmid:   newID,
+		config: map[string]string{},
+		status: "stopped",
+	}
+
+	for _, k := range sortedKeys(g.config) {
+		v := g.config[k]
+
+		if k == "template" || strings.HasPrefix(k, "unused") {
+			continue
+		}
+
+		if g.isDiskKey(k) {
+			if vol := s.lookupVolume(v); vol != nil {
+				storage := strings.Split(vol.volid, ":")[0]
+				if st := values["storage"]; st != "" {
+					storage = st
+				}
+
+				newVol, errMsg := s.allocateVolume(clone, storage, vol.size, vol.format)
+				if errMsg != "" {
+					writeParamErrors(w, map[string]string{"storage": errMsg})
+					return
+				}
+
+				v = replaceVolume(v, newVol.volid)
+			}
+		}
+
+		clone.config[k] = v
+	}
+
+	nameKey := "name"
+	if g.kind == kindLXC {
+		nameKey = "hostname"
+	}
+
+	if n, ok := values["name"]; ok {
+		clone.config[nameKey] = n
+	} else if n, ok = values["hostname"]; ok {
+		clone.config[nameKey] = n
+	} else {
+		clone.config[nameKey] = fmt.Sprintf("Copy-of-VM-%s", g.name())
+	}
+
+	if d, ok := values["description"]; ok {
+		clone.config["description"] = d
+	}
+
+	s.guests[newID] = clone
+
+	taskType := "qmclone"
+	if g.kind == kindLXC {
+		taskType = "vzclone"
+	}
+
+	writeData(w, s.newTask(r, g.node, taskType, strconv.Itoa(g.vmid)))
+}
+
+func (s *Server) convertGuestToTemplate(w http.ResponseWriter, r *http.Request, g *guest) {
+	if g.status == "running" {
+		writeError(w, http.StatusInternalServerError, "you can't convert a running VM to a template")
+		return
+	}
+
+	g.config["template"] = "1"
+
+	taskType := "qmtemplate"
+	if g.kind == kindLXC {
+		taskType = "vztemplate"
+	}
+
+	writeData(w, s.newTask(r, g.node, taskType, strconv.Itoa(g.vmid)))
+}
+
+func (s *Server) resizeGuestDisk(w http.ResponseWriter, r *http.Request, g *guest) {
+	values := formValues(r)
+	disk := values["disk"]
+
+	spec, ok := g.config[disk]
+	if !ok {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("disk '%s' does not exist", disk))
+		return
+	}
+
+	vol := s.lookupVolume(spec)
+	if vol == nil {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("unable to resize disk '%s'", disk))
+		return
+	}
+
+	size, err := types.ParseDiskSize(strings.TrimPrefix(values["size"], "+"))
+	if err != nil {
+		writeParamErrors(w, map[string]string{"size": err.Error()})
+		return
+	}
+
+	bytes := int64(size)
+	if strings.HasPrefix(values["size"], "+") {
+		bytes += vol.size
+	}
+
+	if bytes < vol.size {
+		writeError(w, http.StatusInternalServerError, "shrinking disks is not supported")
+		return
+	}
+
+	vol.size = bytes
+	g.config[disk] = setSpecOption(spec, "size", formatDiskSize(bytes))
+
+	writeData(w, s.newTask(r, g.node, "resize", strconv.Itoa(g.vmid)))
+}
+
+func (s *Server) migrateGuest(w http.ResponseWriter, r *http.Request, g *guest) {
+	target := formValues(r)["target"]
+	if !s.hasNode(target) {
+		writeParamErrors(w, map[string]string{"target": fmt.Sprintf("no such cluster node '%s'", target)})
+		return
+	}
+
+	source := g.node
+	g.node = target
+
+	taskType := "qmigrate"
+	if g.kind == kindLXC {
+		taskType = "vzmigrate"
+	}
+
+	writeData(w, s.newTask(r, source, taskType, strconv.Itoa(g.vmid)))
+}
+
+// applyGuestConfig stores the request values in the guest configuration, allocating
+// volumes for new disks. It returns parameter errors keyed by the parameter name.
+func (s *Server) applyGuestConfig(g *guest, values map[string]string) map[string]string {
+	errs := map[string]string{}
+
+	for _, k := range sortedKeys(values) {
+		v := values[k]
+
+		if slices.Contains(ignoredConfigKeys, k) {
+			continue
+		}
+
+		if g.isDiskKey(k) {
+			spec, errMsg := s.allocateDisk(g, v)
+			if errMsg != "" {
+				errs[k] = errMsg
+				continue
+			}
+
+			if old, ok := g.config[k]; ok && old != spec {
+				s.freeVolume(old)
+			}
+
+			v = spec
+		}
+
+		g.config[k] = v
+	}
+
+	return errs
+}
+
+// deleteGuestConfigKey removes a key from the guest configuration. QEMU disks are detached
+// to an `unusedN` slot first, following the behaviour of Proxmox VE.
+func (s *Server) deleteGuestConfigKey(g *guest, key string) {
+	v, ok := g.config[key]
+	if !ok {
+		return
+	}
+
+	delete(g.config, key)
+
+	if !g.isDiskKey(key) || s.lookupVolume(v) == nil {
+		return
+	}
+
+	if strings.HasPrefix(key, "unused") {
+		s.freeVolume(v)
+		return
+	}
+
+	for i := 0; ; i++ {
+		unused := fmt.Sprintf("unused%d", i)
+		if _, exists := g.config[unused]; !exists {
+			g.config[unused] = strings.Split(strings.TrimPrefix(v, "file="), ",")[0]
+			return
+		}
+	}
+}
+
+// allocateDisk turns a `<storage>:<size>` disk specification into a reference to a newly allocated
+// volume. Specifications that already reference a volume are returned unchanged.
+func (s *Server) allocateDisk(g *guest, spec string) (string, string) {
+	parts := strings.Split(strings.TrimPrefix(spec, "file="), ",")
+	storage, size, found := strings.Cut(parts[0], ":")
+
+	if !found || !numericValue.MatchString(size) {
+		return spec, ""
+	}
+
+	bytes := mustAtoi64(size) * 1024 * 1024 * 1024
+	format := "raw"
+	options := make([]string, 0, len(parts))
+
+	for _, opt := range parts[1:] {
+		k, v, _ := strings.Cut(opt, "=")
+
+		switch k {
+		case "", "size":
+		case "import-from":
+			src := s.lookupVolume(v)
+			if src == nil {
+				return "", fmt.Sprintf("unable to parse volume ID '%s'", v)
+			}
+
+			if src.size > bytes {
+				bytes = src.size
+			}
+		case "format":
+			format = v
+			options = append(options, opt)
+		default:
+			options = append(options, opt)
+		}
+	}
+
+	if bytes == 0 {
+		bytes = 4 * 1024 * 1024
+	}
+
+	vol, errMsg := s.allocateVolume(g, storage, bytes, format)
+	if errMsg != "" {
+		return "", errMsg
+	}
+
+	return strings.Join(append(append([]string{vol.volid}, options...), "size="+formatDiskSize(bytes)), ","), ""
+}
+
+// allocateVolume creates a new guest image volume on the given datastore.
+func (s *Server) allocateVolume(g *guest, storage string, size int64, format string) (*volume, string) {
+	ds, ok := s.datastores[storage]
+	if !ok {
+		return nil, fmt.Sprintf("storage '%s' does not exist", storage)
+	}
+
+	content := "images"
+	if g.kind == kindLXC {
+		content = "rootdir"
+	}
+
+	for i := 0; ; i++ {
+		volid := fmt.Sprintf("%s:vm-%d-disk-%d", storage, g.vmid, i)
+		if _, exists := ds.volumes[volid]; exists {
+			continue
+		}
+
+		vol := &volume{volid: volid, content: content, format: format, size: size, vmid: g.vmid}
+		ds.volumes[volid] = vol
+
+		return vol, ""
+	}
+}
+
+// lookupVolume returns the volume referenced by a disk specification, if any.
+func (s *Server) lookupVolume(spec string) *volume {
+	volid := strings.Split(strings.TrimPrefix(spec, "file="), ",")[0]
+	storage, _, _ := strings.Cut(volid, ":")
+
+	ds, ok := s.datastores[storage]
+	if !ok {
+		return nil
+	}
+
+	return ds.volumes[volid]
+}
+
+func (s *Server) freeVolume(spec string) {
+	vol := s.lookupVolume(spec)
+	if vol == nil || (vol.content != "images" && vol.content != "rootdir") {
+		return
+	}
+
+	storage, _, _ := strings.Cut(vol.volid, ":")
+	delete(s.datastores[storage].volumes, vol.volid)
+}
+
+func replaceVolume(spec string, volid string) string {
+	parts := strings.Split(strings.TrimPrefix(spec, "file="), ",")
+	parts[0] = volid
+
+	return strings.Join(parts, ",")
+}
+
+func setSpecOption(spec string, key string, value string) string {
+	parts := strings.Split(spec, ",")
+
+	for i, p := range parts {
+		if strings.HasPrefix(p, key+"=") {
+			parts[i] = key + "=" + value
+			return strings.Join(parts, ",")
+		}
+	}
+
+	return strings.Join(append(parts, key+"="+value), ",")
+}
+
+func formatDiskSize(bytes int64) string {
+	size := types.DiskSize(bytes)
+
+	return types.FormatDiskSize(&size)
+}
+
+func mustAtoi64(s string) int64 {
+	v, _ := strconv.ParseInt(s, 10, 64)
+	return v
+}
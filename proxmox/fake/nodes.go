@@ -0,0 +1,121 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package fake
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+)
+
+const (
+	nodeCPUs   = 8
+	nodeMemory = 32 * 1024 * 1024 * 1024
+)
+
+func (s *Server) registerNodeRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET "+basePath+"/nodes", s.listNodes)
+	mux.HandleFunc("GET "+basePath+"/nodes/{node}/status", s.withNode(s.getNodeStatus))
+	mux.HandleFunc("GET "+basePath+"/nodes/{node}/network", s.withNode(s.listNodeNetwork))
+	mux.HandleFunc("GET "+basePath+"/nodes/{node}/time", s.withNode(s.getNodeTime))
+	mux.HandleFunc("GET "+basePath+"/nodes/{node}/version", s.withNode(s.getNodeVersion))
+}
+
+// withNode responds with an error if the node from the request path is not part of the fake cluster.
+func (s *Server) withNode(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		ok := s.hasNode(r.PathValue("node"))
+		s.mu.Unlock()
+
+		if !ok {
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("hostname lookup '%s' failed", r.PathValue("node")))
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+func (s *Server) listNodes(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list := make([]map[string]any, 0, len(s.nodes))
+
+	for _, n := range s.nodes {
+		list = append(list, map[string]any{
+			"node":   n,
+			"status": "online",
+			"maxcpu": nodeCPUs,
+			"cpu":    0.01,
+			"maxmem": nodeMemory,
+			"mem":    nodeMemory / 4,
+			"uptime": 3600,
+			"level":  "",
+		})
+	}
+
+	writeData(w, list)
+}
+
+func (s *Server) getNodeStatus(w http.ResponseWriter, _ *http.Request) {
+	writeData(w, map[string]any{
+		"cpuinfo": map[string]any{
+			"cores":   nodeCPUs,
+			"sockets": 1,
+			"model":   "Fake CPU",
+		},
+		"memory": map[string]any{
+			"total": nodeMemory,
+			"used":  nodeMemory / 4,
+			"free":  nodeMemory / 4 * 3,
+		},
+		"uptime": 3600,
+	})
+}
+
+func (s *Server) listNodeNetwork(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	idx := slices.Index(s.nodes, r.PathValue("node"))
+
+	writeData(w, []map[string]any{
+		{
+			"iface":     "vmbr0",
+			"type":      "bridge",
+			"active":    1,
+			"autostart": 1,
+			"method":    "static",
+			"address":   fmt.Sprintf("192.0.2.%d", 10+idx),
+			"netmask":   "24",
+			"cidr":      fmt.Sprintf("192.0.2.%d/24", 10+idx),
+			"gateway":   "192.0.2.1",
+			"families":  []string{"inet"},
+		},
+	})
+}
+
+func (s *Server) getNodeTime(w http.ResponseWriter, _ *http.Request) {
+	now := time.Now()
+
+	writeData(w, map[string]any{
+		"localtime": now.Unix(),
+		"time":      now.Unix(),
+		"timezone":  "UTC",
+	})
+}
+
+func (s *Server) getNodeVersion(w http.ResponseWriter, _ *http.Request) {
+	writeData(w, map[string]any{
+		"release": Version[:3],
+		"repoid":  "fake",
+		"version": Version,
+	})
+}
@@ -0,0 +1,277 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package fake
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+)
+
+const (
+	// replicationDuration is the duration in seconds of every replication run of the fake server.
+	replicationDuration = 1.5
+
+	// replicationInterval is the interval between the replication runs of the fake server,
+	// the schedule of the jobs is not evaluated.
+	replicationInterval = 15 * time.Minute
+)
+
+//nolint:gochecknoglobals
+var (
+	// replicationJobOptions are the options of a replication job that can be updated.
+	replicationJobOptions = []string{"comment", "disable", "rate", "schedule"}
+
+	replicationJobIDPattern = regexp.MustCompile(`^([1-9]\d{2,8})-(\d{1,9})$`)
+)
+
+func (s *Server) registerReplicationRoutes(mux *http.ServeMux) {
+	prefix := basePath + "/cluster/replication"
+
+	mux.HandleFunc("GET "+prefix, s.listReplicationJobs)
+	mux.HandleFunc("POST "+prefix, s.createReplicationJob)
+	mux.HandleFunc("GET "+prefix+"/{id}", s.withReplicationJob(s.getReplicationJob))
+	mux.HandleFunc("PUT "+prefix+"/{id}", s.withReplicationJob(s.updateReplicationJob))
+	mux.HandleFunc("DELETE "+prefix+"/{id}", s.withReplicationJob(s.deleteReplicationJob))
+	mux.HandleFunc("GET "+basePath+"/nodes/{node}/replication/{id}/status",
+		s.withNode(s.withReplicationJob(s.getReplicationJobStatus)))
+}
+
+// replicationJobHandlerFunc handles a request for an existing replication job.
+// It is called with the server lock held.
+type replicationJobHandlerFunc func(w http.ResponseWriter, r *http.Request, id string, job *replicationJob)
+
+// withReplicationJob looks up the replication job from the request path and holds the server lock
+// while the handler runs.
+func (s *Server) withReplicationJob(next replicationJobHandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := parseForm(r); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		id := r.PathValue("id")
+
+		job, ok := s.replJobs[id]
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("replication job '%s' does not exist", id))
+			return
+		}
+
+		next(w, r, id, job)
+	}
+}
+
+func (s *Server) listReplicationJobs(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list := []map[string]any{}
+
+	for _, id := range sortedKeys(s.replJobs) {
+		list = append(list, s.renderReplicationJob(id, s.replJobs[id]))
+	}
+
+	writeData(w, list)
+}
+
+func (s *Server) createReplicationJob(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	values := formValues(r)
+	id := values["id"]
+
+	m := replicationJobIDPattern.FindStringSubmatch(id)
+	if m == nil {
+		writeParamErrors(w, map[string]string{"id": "invalid format - invalid replication job ID '" + id + "'"})
+		return
+	}
+
+	if _, ok := s.replJobs[id]; ok {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("replication job '%s' already exists", id))
+		return
+	}
+
+	guestID, _ := strconv.Atoi(m[1])
+	jobnum, _ := strconv.Atoi(m[2])
+
+	g, ok := s.guests[guestID]
+	if !ok {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("guest '%d' does not exist", guestID))
+		return
+	}
+
+	errs := map[string]string{}
+
+	if values["type"] != "local" {
+		errs["type"] = "value '" + values["type"] + "' does not have a value in the enumeration 'local'"
+	}
+
+	target := values["target"]
+
+	switch {
+	case target == "":
+		errs["target"] = "property is missing and it is not optional"
+	case !s.hasNode(target):
+		errs["target"] = fmt.Sprintf("no such node '%s'", target)
+	case target == g.node:
+		errs["target"] = fmt.Sprintf("source '%s' and target '%s' node are the same", g.node, target)
+	}
+
+	for _, k := range []string{"id", "type", "target"} {
+		delete(values, k)
+	}
+
+	maps.Copy(errs, validateReplicationJob(values))
+
+	if len(errs) > 0 {
+		writeParamErrors(w, errs)
+		return
+	}
+
+	job := &replicationJob{
+		guest:  guestID,
+		jobnum: jobnum,
+		target: target,
+		config: values,
+	}
+
+	// the first replication run happens right away
+	if values["disable"] != "1" {
+		job.lastSync = time.Now()
+		job.duration = replicationDuration
+	}
+
+	s.replJobs[id] = job
+
+	writeData(w, nil)
+}
+
+func (s *Server) getReplicationJob(w http.ResponseWriter, _ *http.Request, id string, job *replicationJob) {
+	writeData(w, s.renderReplicationJob(id, job))
+}
+
+func (s *Server) updateReplicationJob(w http.ResponseWriter, r *http.Request, _ string, job *replicationJob) {
+	values := formValues(r)
+	updated := maps.Clone(job.config)
+
+	for _, k := range splitList(values["delete"]) {
+		delete(updated, k)
+	}
+
+	delete(values, "delete")
+	delete(values, "digest")
+
+	maps.Copy(updated, values)
+
+	if errs := validateReplicationJob(updated); len(errs) > 0 {
+		writeParamErrors(w, errs)
+		return
+	}
+
+	job.config = updated
+
+	writeData(w, nil)
+}
+
+// deleteReplicationJob removes the job right away, while Proxmox VE marks it for removal
+// and removes it on the next replication run.
+func (s *Server) deleteReplicationJob(w http.ResponseWriter, _ *http.Request, id string, _ *replicationJob) {
+	delete(s.replJobs, id)
+
+	writeData(w, nil)
+}
+
+func (s *Server) getReplicationJobStatus(w http.ResponseWriter, r *http.Request, id string, job *replicationJob) {
+	// the state is only known by the source node of the job
+	if g, ok := s.guests[job.guest]; !ok || g.node != r.PathValue("node") {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("replication job '%s' does not exist on node '%s'",
+			id, r.PathValue("node")))
+
+		return
+	}
+
+	status := map[string]any{
+		"id":         id,
+		"guest":      job.guest,
+		"jobnum":     job.jobnum,
+		"target":     job.target,
+		"type":       "local",
+		"fail_count": job.failCount,
+	}
+
+	if !job.lastSync.IsZero() {
+		status["last_sync"] = job.lastSync.Unix()
+		status["last_try"] = job.lastSync.Unix()
+		status["duration"] = job.duration
+	}
+
+	if job.config["disable"] != "1" {
+		status["next_sync"] = time.Now().Add(replicationInterval).Unix()
+	}
+
+	writeData(w, status)
+}
+
+// validateReplicationJob checks the options of a replication job.
+func validateReplicationJob(job map[string]string) map[string]string {
+	errs := map[string]string{}
+
+	for k := range job {
+		if !slices.Contains(replicationJobOptions, k) {
+			errs[k] = "property is not defined in schema and the schema does not allow additional properties"
+		}
+	}
+
+	if v, ok := job["rate"]; ok {
+		if rate, err := strconv.ParseFloat(v, 64); err != nil || rate < 1 {
+			errs["rate"] = "value must have a minimum value of 1"
+		}
+	}
+
+	return errs
+}
+
+func (s *Server) renderReplicationJob(id string, job *replicationJob) map[string]any {
+	out := render(job.config)
+
+	// the schedule and the comment are strings, even when they look like numbers
+	for _, k := range []string{"comment", "schedule"} {
+		if v, ok := job.config[k]; ok {
+			out[k] = v
+		}
+	}
+
+	if v, ok := job.config["rate"]; ok {
+		out["rate"], _ = strconv.ParseFloat(v, 64)
+	}
+
+	out["id"] = id
+	out["guest"] = job.guest
+	out["jobnum"] = job.jobnum
+	out["target"] = job.target
+	out["type"] = "local"
+
+	if g, ok := s.guests[job.guest]; ok {
+		out["source"] = g.node
+	}
+
+	return out
+}
@@ -0,0 +1,638 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package fake
+
+import (
+	"fmt"
+	"maps"
+	"net"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+const (
+	sdnZones       = "zones"
+	sdnVNets       = "vnets"
+	sdnSubnets     = "subnets"
+	sdnControllers = "controllers"
+	sdnIPAMs       = "ipams"
+	sdnDNS         = "dns"
+)
+
+var (
+	sdnIDPattern       = regexp.MustCompile(`^[a-z][a-z0-9]{0,7}$`)
+	sdnPluginIDPattern = regexp.MustCompile(`^[a-z][a-z0-9_-]*[a-z0-9]$`)
+	sdnZoneTypes       = []string{"evpn", "qinq", "simple", "vlan", "vxlan"}
+	sdnListFields      = []string{"dhcp-range"}
+
+	// sdnPluginTypes are the required parameters of the controllers, IPAM and DNS plugins by type.
+	sdnPluginTypes = map[string]map[string][]string{
+		sdnControllers: {
+			"bgp":  {"asn", "node", "peers"},
+			"evpn": {"asn", "peers"},
+			"isis": {"isis-domain", "isis-ifaces", "isis-net", "node"},
+		},
+		sdnIPAMs: {
+			"netbox":  {"token", "url"},
+			"phpipam": {"section", "token", "url"},
+			"pve":     {},
+		},
+		sdnDNS: {
+			"powerdns": {"key", "url"},
+		},
+	}
+)
+
+// sdnConfig contains the SDN objects by kind and identifier. The server keeps the edited configuration,
+// and the running configuration that was applied last.
+type sdnConfig map[string]map[string]map[string]string
+
+func newSDNConfig() sdnConfig {
+	return sdnConfig{
+		sdnZones:       {},
+		sdnVNets:       {},
+		sdnSubnets:     {},
+		sdnControllers: {},
+		sdnIPAMs:       {},
+		sdnDNS:         {},
+	}
+}
+
+func (c sdnConfig) clone() sdnConfig {
+	out := newSDNConfig()
+
+	for kind, objects := range c {
+		for id, fields := range objects {
+			out[kind][id] = maps.Clone(fields)
+		}
+	}
+
+	return out
+}
+
+func (s *Server) registerSDNRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("PUT "+basePath+"/cluster/sdn", s.applySDN)
+
+	mux.HandleFunc("GET "+basePath+"/cluster/sdn/zones", s.listSDNZones)
+	mux.HandleFunc("POST "+basePath+"/cluster/sdn/zones", s.createSDNZone)
+	mux.HandleFunc("GET "+basePath+"/cluster/sdn/zones/{id}", s.getSDNObject(sdnZones))
+	mux.HandleFunc("PUT "+basePath+"/cluster/sdn/zones/{id}", s.updateSDNObject(sdnZones))
+	mux.HandleFunc("DELETE "+basePath+"/cluster/sdn/zones/{id}", s.deleteSDNObject(sdnZones))
+
+	mux.HandleFunc("GET "+basePath+"/cluster/sdn/vnets", s.listSDNVNets)
+	mux.HandleFunc("POST "+basePath+"/cluster/sdn/vnets", s.createSDNVNet)
+	mux.HandleFunc("GET "+basePath+"/cluster/sdn/vnets/{id}", s.getSDNObject(sdnVNets))
+	mux.HandleFunc("PUT "+basePath+"/cluster/sdn/vnets/{id}", s.updateSDNObject(sdnVNets))
+	mux.HandleFunc("DELETE "+basePath+"/cluster/sdn/vnets/{id}", s.deleteSDNObject(sdnVNets))
+
+	mux.HandleFunc("GET "+basePath+"/cluster/sdn/vnets/{vnet}/subnets", s.listSDNSubnets)
+	mux.HandleFunc("POST "+basePath+"/cluster/sdn/vnets/{vnet}/subnets", s.createSDNSubnet)
+	mux.HandleFunc("GET "+basePath+"/cluster/sdn/vnets/{vnet}/subnets/{id}", s.getSDNObject(sdnSubnets))
+	mux.HandleFunc("PUT "+basePath+"/cluster/sdn/vnets/{vnet}/subnets/{id}", s.updateSDNObject(sdnSubnets))
+	mux.HandleFunc("DELETE "+basePath+"/cluster/sdn/vnets/{vnet}/subnets/{id}", s.deleteSDNObject(sdnSubnets))
+
+	for _, kind := range []string{sdnControllers, sdnIPAMs, sdnDNS} {
+		mux.HandleFunc("GET "+basePath+"/cluster/sdn/"+kind, s.listSDNPlugins(kind))
+		mux.HandleFunc("POST "+basePath+"/cluster/sdn/"+kind, s.createSDNPlugin(kind))
+		mux.HandleFunc("GET "+basePath+"/cluster/sdn/"+kind+"/{id}", s.getSDNObject(kind))
+		mux.HandleFunc("PUT "+basePath+"/cluster/sdn/"+kind+"/{id}", s.updateSDNObject(kind))
+		mux.HandleFunc("DELETE "+basePath+"/cluster/sdn/"+kind+"/{id}", s.deleteSDNObject(kind))
+	}
+}
+
+func (s *Server) applySDN(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sdnRunning = s.sdn.clone()
+
+	writeData(w, s.newTask(r, DefaultNodeName, "reloadnetworkall", "", "applying the SDN configuration"))
+}
+
+func (s *Server) listSDNZones(w http.ResponseWriter, r *http.Request) {
+	s.listSDNObjects(w, r, sdnZones, func(_ map[string]string) bool { return true })
+}
+
+func (s *Server) listSDNVNets(w http.ResponseWriter, r *http.Request) {
+	s.listSDNObjects(w, r, sdnVNets, func(_ map[string]string) bool { return true })
+}
+
+func (s *Server) listSDNSubnets(w http.ResponseWriter, r *http.Request) {
+	vnet := r.PathValue("vnet")
+
+	s.listSDNObjects(w, r, sdnSubnets, func(fields map[string]string) bool { return fields["vnet"] == vnet })
+}
+
+func (s *Server) listSDNPlugins(kind string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.listSDNObjects(w, r, kind, func(_ map[string]string) bool { return true })
+	}
+}
+
+func (s *Server) listSDNObjects(w http.ResponseWriter, r *http.Request, kind string, match func(map[string]string) bool) {
+	if err := parseForm(r); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := map[string]struct{}{}
+
+	for id, fields := range s.sdn[kind] {
+		if match(fields) {
+			ids[id] = struct{}{}
+		}
+	}
+
+	for id, fields := range s.sdnRunning[kind] {
+		if match(fields) {
+			ids[id] = struct{}{}
+		}
+	}
+
+	list := []map[string]any{}
+
+	for _, id := range sortedKeys(ids) {
+		if obj := s.renderSDNObject(r, kind, id); obj != nil {
+			list = append(list, obj)
+		}
+	}
+
+	writeData(w, list)
+}
+
+func (s *Server) createSDNZone(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	values := sdnFormValues(r)
+	id := values["zone"]
+
+	switch {
+	case !sdnIDPattern.MatchString(id):
+		writeParamErrors(w, map[string]string{"zone": "invalid format - invalid SDN zone ID"})
+		return
+	case !slices.Contains(sdnZoneTypes, values["type"]):
+		writeParamErrors(w, map[string]string{"type": fmt.Sprintf("value '%s' does not have a value in the enumeration", values["type"])})
+		return
+	case (values["type"] == "vlan" || values["type"] == "qinq") && values["bridge"] == "":
+		writeParamErrors(w, map[string]string{"bridge": "property is missing and it is not optional"})
+		return
+	case values["type"] == "vxlan" && values["peers"] == "":
+		writeParamErrors(w, map[string]string{"peers": "property is missing and it is not optional"})
+		return
+	case values["type"] == "evpn" && (values["controller"] == "" || values["vrf-vxlan"] == ""):
+		writeParamErrors(w, map[string]string{"controller": "property is missing and it is not optional"})
+		return
+	}
+
+	if _, ok := s.sdn[sdnZones][id]; ok {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("sdn zone object ID '%s' already defined", id))
+		return
+	}
+
+	delete(values, "zone")
+
+	s.sdn[sdnZones][id] = values
+
+	writeData(w, nil)
+}
+
+func (s *Server) createSDNVNet(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	values := sdnFormValues(r)
+	id := values["vnet"]
+
+	if !sdnIDPattern.MatchString(id) {
+		writeParamErrors(w, map[string]string{"vnet": "invalid format - invalid SDN VNet ID"})
+		return
+	}
+
+	if _, ok := s.sdn[sdnZones][values["zone"]]; !ok {
+		writeParamErrors(w, map[string]string{"zone": fmt.Sprintf("zone '%s' does not exist", values["zone"])})
+		return
+	}
+
+	if _, ok := s.sdn[sdnVNets][id]; ok {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("sdn vnet object ID '%s' already defined", id))
+		return
+	}
+
+	delete(values, "vnet")
+
+	values["type"] = "vnet"
+	s.sdn[sdnVNets][id] = values
+
+	writeData(w, nil)
+}
+
+func (s *Server) createSDNSubnet(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	vnetID := r.PathValue("vnet")
+
+	vnet, ok := s.sdn[sdnVNets][vnetID]
+	if !ok {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("sdn vnet '%s' does not exist", vnetID))
+		return
+	}
+
+	values := sdnFormValues(r)
+
+	ip, network, err := net.ParseCIDR(values["subnet"])
+	if err != nil || !ip.Equal(network.IP) {
+		writeParamErrors(w, map[string]string{"subnet": "invalid format - value does not look like a valid CIDR network"})
+		return
+	}
+
+	if values["type"] != "subnet" {
+		writeParamErrors(w, map[string]string{"type": "value must be 'subnet'"})
+		return
+	}
+
+	if gw := values["gateway"]; gw != "" && !network.Contains(net.ParseIP(gw)) {
+		writeParamErrors(w, map[string]string{"gateway": fmt.Sprintf("gateway is not in subnet '%s'", network)})
+		return
+	}
+
+	id := fmt.Sprintf("%s-%s", vnet["zone"], strings.ReplaceAll(network.String(), "/", "-"))
+
+	if _, ok := s.sdn[sdnSubnets][id]; ok {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("sdn subnet object ID '%s' already defined", id))
+		return
+	}
+
+	delete(values, "subnet")
+
+	values["cidr"] = network.String()
+	values["vnet"] = vnetID
+	s.sdn[sdnSubnets][id] = values
+
+	writeData(w, nil)
+}
+
+// createSDNPlugin creates a controller, an IPAM or a DNS plugin, identified by the parameter named after
+// the kind of the plugin.
+func (s *Server) createSDNPlugin(kind string) http.HandlerFunc {
+	idField := map[string]string{sdnControllers: "controller", sdnIPAMs: "ipam", sdnDNS: "dns"}[kind]
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := parseForm(r); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		values := sdnFormValues(r)
+		id := values[idField]
+
+		if !sdnPluginIDPattern.MatchString(id) {
+			writeParamErrors(w, map[string]string{idField: "invalid format - invalid SDN " + idField + " ID"})
+			return
+		}
+
+		required, ok := sdnPluginTypes[kind][values["type"]]
+		if !ok {
+			writeParamErrors(w, map[string]string{"type": fmt.Sprintf("value '%s' does not have a value in the enumeration", values["type"])})
+			return
+		}
+
+		for _, k := range required {
+			if values[k] == "" {
+				writeParamErrors(w, map[string]string{k: "property is missing and it is not optional"})
+				return
+			}
+		}
+
+		if _, ok := s.sdn[kind][id]; ok {
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("sdn %s object ID '%s' already defined", idField, id))
+			return
+		}
+
+		delete(values, idField)
+
+		s.sdn[kind][id] = values
+
+		writeData(w, nil)
+	}
+}
+
+func (s *Server) getSDNObject(kind string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := parseForm(r); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		id := r.PathValue("id")
+
+		if !s.sdnObjectExists(r, kind, id) {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("sdn '%s' does not exist", id))
+			return
+		}
+
+		writeData(w, s.renderSDNObject(r, kind, id))
+	}
+}
+
+func (s *Server) updateSDNObject(kind string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := parseForm(r); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		id := r.PathValue("id")
+
+		fields, ok := s.sdn[kind][id]
+		if !ok || (kind == sdnSubnets && fields["vnet"] != r.PathValue("vnet")) {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("sdn '%s' does not exist", id))
+			return
+		}
+
+		values := sdnFormValues(r)
+
+		for _, k := range []string{"type", "zone", "vnet", "subnet"} {
+			if kind == sdnVNets && k == "zone" {
+				continue
+			}
+
+			if _, ok := values[k]; ok {
+				writeParamErrors(w, map[string]string{k: "property is not defined in schema and the schema does not allow additional properties"})
+				return
+			}
+		}
+
+		if zone, ok := values["zone"]; ok {
+			if _, exists := s.sdn[sdnZones][zone]; !exists {
+				writeParamErrors(w, map[string]string{"zone": fmt.Sprintf("zone '%s' does not exist", zone)})
+				return
+			}
+		}
+
+		for _, k := range strings.Split(values["delete"], ",") {
+			delete(fields, strings.TrimSpace(k))
+		}
+
+		delete(values, "delete")
+		delete(values, "digest")
+
+		maps.Copy(fields, values)
+
+		writeData(w, nil)
+	}
+}
+
+func (s *Server) deleteSDNObject(kind string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		id := r.PathValue("id")
+
+		fields, ok := s.sdn[kind][id]
+		if !ok || (kind == sdnSubnets && fields["vnet"] != r.PathValue("vnet")) {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("sdn '%s' does not exist", id))
+			return
+		}
+
+		if dependents := s.sdnDependents(kind, id); len(dependents) > 0 {
+			writeError(w, http.StatusInternalServerError,
+				fmt.Sprintf("cannot delete '%s', it is used by '%s'", id, strings.Join(dependents, ", ")))
+
+			return
+		}
+
+		delete(s.sdn[kind], id)
+
+		writeData(w, nil)
+	}
+}
+
+// sdnDependents returns the objects that refer to an SDN object.
+func (s *Server) sdnDependents(kind string, id string) []string {
+	var dependents []string
+
+	switch kind {
+	case sdnZones:
+		for _, vnet := range sortedKeys(s.sdn[sdnVNets]) {
+			if s.sdn[sdnVNets][vnet]["zone"] == id {
+				dependents = append(dependents, vnet)
+			}
+		}
+	case sdnVNets:
+		for _, subnet := range sortedKeys(s.sdn[sdnSubnets]) {
+			if s.sdn[sdnSubnets][subnet]["vnet"] == id {
+				dependents = append(dependents, subnet)
+			}
+		}
+	case sdnControllers, sdnIPAMs, sdnDNS:
+		refs := map[string][]string{
+			sdnControllers: {"controller"},
+			sdnIPAMs:       {"ipam"},
+			sdnDNS:         {"dns", "reversedns"},
+		}[kind]
+
+		for _, zone := range sortedKeys(s.sdn[sdnZones]) {
+			for _, ref := range refs {
+				if s.sdn[sdnZones][zone][ref] == id {
+					dependents = append(dependents, zone)
+					break
+				}
+			}
+		}
+	}
+
+	return dependents
+}
+
+func (s *Server) sdnObjectExists(r *http.Request, kind string, id string) bool {
+	fields, ok := s.sdn[kind][id]
+	if !ok && r.FormValue("pending") == "1" {
+		fields, ok = s.sdnRunning[kind][id]
+	}
+
+	if r.FormValue("running") == "1" {
+		fields, ok = s.sdnRunning[kind][id]
+	}
+
+	return ok && (kind != sdnSubnets || fields["vnet"] == r.PathValue("vnet"))
+}
+
+// renderSDNObject renders an SDN object from the edited configuration, or from the running configuration
+// if requested. With `pending=1`, the running configuration is rendered with the pending changes, the same
+// way as Proxmox VE does.
+func (s *Server) renderSDNObject(r *http.Request, kind string, id string) map[string]any {
+	var obj map[string]any
+
+	switch {
+	case r.FormValue("running") == "1":
+		fields, ok := s.sdnRunning[kind][id]
+		if !ok {
+			return nil
+		}
+
+		obj = renderSDNFields(fields)
+	case r.FormValue("pending") == "1":
+		obj = renderSDNPending(s.sdnRunning[kind][id], s.sdn[kind][id])
+	default:
+		fields, ok := s.sdn[kind][id]
+		if !ok {
+			return nil
+		}
+
+		obj = renderSDNFields(fields)
+	}
+
+	switch kind {
+	case sdnZones:
+		obj["zone"] = id
+	case sdnVNets:
+		obj["vnet"] = id
+	case sdnSubnets:
+		obj["subnet"] = id
+
+		zone, _, _ := strings.Cut(id, "-")
+		obj["zone"] = zone
+	case sdnControllers:
+		obj["controller"] = id
+	case sdnIPAMs:
+		obj["ipam"] = id
+	case sdnDNS:
+		obj["dns"] = id
+	}
+
+	return obj
+}
+
+// renderSDNPending renders the running configuration of an SDN object, with the pending changes in
+// the `pending` property and the kind of change in the `state` property.
+func renderSDNPending(running map[string]string, config map[string]string) map[string]any {
+	obj := map[string]any{}
+	pending := map[string]any{}
+	state := ""
+
+	for k, v := range renderSDNFields(running) {
+		obj[k] = v
+
+		if _, ok := config[k]; !ok && config != nil {
+			pending[k] = "deleted"
+			state = "changed"
+		}
+	}
+
+	if running != nil && config == nil {
+		state = "deleted"
+	}
+
+	for k, v := range config {
+		if k == "type" || k == "vnet" {
+			obj[k] = v
+			continue
+		}
+
+		if running[k] != v {
+			pending[k] = encodeSDNPendingValue(k, v)
+
+			if running != nil {
+				state = "changed"
+			}
+		}
+	}
+
+	if running == nil && config != nil {
+		state = "new"
+	}
+
+	if state != "" {
+		obj["state"] = state
+		obj["pending"] = render(stringValues(pending))
+	}
+
+	return obj
+}
+
+// renderSDNFields renders the fields of an SDN object, with list values as JSON arrays.
+func renderSDNFields(fields map[string]string) map[string]any {
+	obj := map[string]any{}
+
+	for k, v := range render(fields) {
+		if slices.Contains(sdnListFields, k) {
+			obj[k] = strings.Split(fields[k], "\n")
+		} else {
+			obj[k] = v
+		}
+	}
+
+	return obj
+}
+
+// encodeSDNPendingValue encodes a pending value the way Proxmox VE does, which joins the list values
+// with commas.
+func encodeSDNPendingValue(key string, value string) string {
+	if slices.Contains(sdnListFields, key) {
+		items := strings.Split(value, "\n")
+		slices.Sort(items)
+
+		return strings.Join(items, ",")
+	}
+
+	return value
+}
+
+// sdnFormValues flattens the request parameters, keeping all the values of the list parameters.
+func sdnFormValues(r *http.Request) map[string]string {
+	values := formValues(r)
+
+	for _, k := range sdnListFields {
+		if v, ok := r.Form[k]; ok {
+			values[k] = strings.Join(v, "\n")
+		}
+	}
+
+	return values
+}
+
+func stringValues(m map[string]any) map[string]string {
+	out := make(map[string]string, len(m))
+
+	for k, v := range m {
+		out[k] = fmt.Sprint(v)
+	}
+
+	return out
+}
@@ -0,0 +1,320 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+// Package fake provides an in-process, in-memory implementation of a subset of the
+// Proxmox VE `/api2/json` API. It is intended for unit tests that need to exercise
+// API clients and resources without access to a real cluster.
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+const (
+	basePath = "/api2/json"
+
+	// DefaultNodeName is the name of the node that is always present in the fake cluster.
+	DefaultNodeName = "pve"
+
+	// DefaultUsername is the user that is always present in the fake cluster.
+	DefaultUsername = "root@pam"
+
+	// DefaultPassword is the password of the DefaultUsername user.
+	DefaultPassword = "password"
+
+	// DefaultAPIToken is an API token for the DefaultUsername user that is always accepted.
+	DefaultAPIToken = "root@pam!fake=00000000-0000-0000-0000-000000000000"
+
+	// Version is the Proxmox VE version reported by the fake server.
+	Version = "8.3.0"
+)
+
+var numericValue = regexp.MustCompile(`^-?(0|[1-9]\d*)$`)
+
+// Server is an in-memory fake of the Proxmox VE API served over TLS.
+type Server struct {
+	*httptest.Server
+
+	mu         sync.Mutex
+	nodes      []string
+	datastores map[string]*datastore
+	backupJobs map[string]map[string]string
+	guests     map[int]*guest
+	replJobs   map[string]*replicationJob
+	tasks      map[string]*task
+	users      map[string]*user
+	tickets    map[string]string
+	sdn        sdnConfig
+	sdnRunning sdnConfig
+	taskPID    int
+}
+
+// Option is a configuration option for the fake server.
+type Option func(s *Server)
+
+// WithNodes adds additional nodes to the fake cluster.
+func WithNodes(names ...string) Option {
+	return func(s *Server) {
+		for _, n := range names {
+			if !slices.Contains(s.nodes, n) {
+				s.nodes = append(s.nodes, n)
+			}
+		}
+	}
+}
+
+// WithDatastore adds a datastore with the given content types to the fake cluster.
+func WithDatastore(id string, storageType string, content ...string) Option {
+	return func(s *Server) {
+		s.datastores[id] = newDatastore(id, storageType, content...)
+	}
+}
+
+// NewServer starts a new fake Proxmox VE API server. The server must be closed
+// by the caller once it is no longer needed.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		nodes: []string{DefaultNodeName},
+		datastores: map[string]*datastore{
+			"local":     newDatastore("local", "dir", "backup", "import", "iso", "snippets", "vztmpl"),
+			"local-lvm": newDatastore("local-lvm", "lvmthin", "images", "rootdir"),
+		},
+		backupJobs: map[string]map[string]string{},
+		guests:     map[int]*guest{},
+		replJobs:   map[string]*replicationJob{},
+		tasks:      map[string]*task{},
+		users:      map[string]*user{DefaultUsername: newUser(DefaultUsername, DefaultPassword)},
+		tickets:    map[string]string{},
+		sdn:        newSDNConfig(),
+		sdnRunning: newSDNConfig(),
+		taskPID:    0x1000,
+	}
+
+	s.datastores["local"].config["path"] = "/var/lib/vz"
+	s.datastores["local-lvm"].config["vgname"] = "pve"
+	s.datastores["local-lvm"].config["thinpool"] = "data"
+
+	s.users[DefaultUsername].tokens["fake"] = &token{
+		id:      "fake",
+		value:   strings.SplitN(DefaultAPIToken, "=", 2)[1],
+		privsep: false,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	mux := http.NewServeMux()
+
+	s.registerAccessRoutes(mux)
+	s.registerClusterRoutes(mux)
+	s.registerBackupRoutes(mux)
+	s.registerNodeRoutes(mux)
+	s.registerSDNRoutes(mux)
+	s.registerGuestRoutes(mux)
+	s.registerReplicationRoutes(mux)
+	s.registerStorageRoutes(mux)
+	s.registerTaskRoutes(mux)
+
+	mux.HandleFunc("GET "+basePath+"/version", func(w http.ResponseWriter, _ *http.Request) {
+		writeData(w, map[string]any{
+			"release": strings.Join(strings.Split(Version, ".")[:2], "."),
+			"repoid":  "fake",
+			"version": Version,
+		})
+	})
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotImplemented,
+			fmt.Sprintf("%s %s is not implemented by the fake server", r.Method, r.URL.Path))
+	})
+
+	s.Server = httptest.NewTLSServer(s.authenticate(mux))
+
+	return s
+}
+
+// Endpoint returns the API endpoint of the fake server, suitable for api.NewConnection.
+func (s *Server) Endpoint() string {
+	return s.URL + "/"
+}
+
+// NewClient returns an API client connected to the fake server and authenticated with DefaultAPIToken.
+func (s *Server) NewClient() (api.Client, error) {
+	creds, err := api.NewCredentials("", "", "", DefaultAPIToken, "", "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create credentials for the fake server: %w", err)
+	}
+
+	conn, err := api.NewConnection(s.Endpoint(), true, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create connection to the fake server: %w", err)
+	}
+
+	return api.NewClient(creds, conn)
+}
+
+// authenticate rejects requests that carry neither a known API token nor a valid ticket.
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.URL.Path == basePath+"/access/ticket" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if r.URL.Path == basePath+"/version" && r.Method == http.MethodGet {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		s.mu.Lock()
+		ok := s.isAuthenticated(r)
+		s.mu.Unlock()
+
+		if !ok {
+			writeError(w, http.StatusUnauthorized, "authentication failure")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) isAuthenticated(r *http.Request) bool {
+	if h := r.Header.Get("Authorization"); strings.HasPrefix(h, "PVEAPIToken=") {
+		full := strings.TrimPrefix(h, "PVEAPIToken=")
+
+		userAndToken, value, found := strings.Cut(full, "=")
+		if !found {
+			return false
+		}
+
+		userID, tokenID, found := strings.Cut(userAndToken, "!")
+		if !found {
+			return false
+		}
+
+		u, ok := s.users[userID]
+		if !ok {
+			return false
+		}
+
+		t, ok := u.tokens[tokenID]
+
+		return ok && t.value == value && (t.expire == 0 || t.expire > time.Now().Unix())
+	}
+
+	cookie, err := r.Cookie("PVEAuthCookie")
+	if err != nil {
+		return false
+	}
+
+	csrf, ok := s.tickets[cookie.Value]
+	if !ok {
+		return false
+	}
+
+	if r.Method != http.MethodGet && r.Header.Get("CSRFPreventionToken") != csrf {
+		return false
+	}
+
+	return true
+}
+
+func (s *Server) newTicket(userID string) (string, string) {
+	ticket := fmt.Sprintf("PVE:%s:%08X::%s", userID, time.Now().Unix(), uuid.NewString())
+	csrf := fmt.Sprintf("%08X:%s", time.Now().Unix(), uuid.NewString())
+
+	s.tickets[ticket] = csrf
+
+	return ticket, csrf
+}
+
+func (s *Server) hasNode(name string) bool {
+	return slices.Contains(s.nodes, name)
+}
+
+// parseForm parses the request parameters from both the query string and the body.
+func parseForm(r *http.Request) error {
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		return r.ParseMultipartForm(32 << 20)
+	}
+
+	return r.ParseForm()
+}
+
+// formValues flattens the request parameters into a single-valued map.
+func formValues(r *http.Request) map[string]string {
+	values := map[string]string{}
+
+	for k, v := range r.Form {
+		if len(v) > 0 {
+			values[k] = v[len(v)-1]
+		}
+	}
+
+	return values
+}
+
+// render converts a string-valued record into a JSON object, turning numeric values into numbers.
+func render(record map[string]string) map[string]any {
+	out := make(map[string]any, len(record))
+
+	for k, v := range record {
+		if numericValue.MatchString(v) {
+			out[k] = json.Number(v)
+		} else {
+			out[k] = v
+		}
+	}
+
+	return out
+}
+
+func writeData(w http.ResponseWriter, data any) {
+	w.Header().Set("Content-Type", "application/json;charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+
+	_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+}
+
+func writeError(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json;charset=UTF-8")
+	w.WriteHeader(code)
+
+	_ = json.NewEncoder(w).Encode(map[string]any{"data": nil, "message": message})
+}
+
+func writeParamErrors(w http.ResponseWriter, errs map[string]string) {
+	w.Header().Set("Content-Type", "application/json;charset=UTF-8")
+	w.WriteHeader(http.StatusBadRequest)
+
+	_ = json.NewEncoder(w).Encode(map[string]any{"data": nil, "errors": errs})
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,236 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package fake
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/containers"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/storage"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/vms"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/version"
+)
+
+func newTestServer(t *testing.T) (*Server, api.Client) {
+	t.Helper()
+
+	srv := NewServer(WithNodes("pve2"))
+	t.Cleanup(srv.Close)
+
+	c, err := srv.NewClient()
+	require.NoError(t, err)
+
+	return srv, c
+}
+
+func TestServerVersion(t *testing.T) {
+	t.Parallel()
+
+	_, c := newTestServer(t)
+
+	v, err := (&version.Client{Client: c}).Version(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, Version, v.Version)
+}
+
+func TestServerAuthentication(t *testing.T) {
+	t.Parallel()
+
+	srv, _ := newTestServer(t)
+
+	tests := []struct {
+		name    string
+		creds   func() (api.Credentials, error)
+		wantErr bool
+	}{
+		{"api token", func() (api.Credentials, error) {
+			return api.NewCredentials("", "", "", DefaultAPIToken, "", "")
+		}, false},
+		{"username and password", func() (api.Credentials, error) {
+			return api.NewCredentials(DefaultUsername, DefaultPassword, "", "", "", "")
+		}, false},
+		{"wrong password", func() (api.Credentials, error) {
+			return api.NewCredentials(DefaultUsername, "wrong", "", "", "", "")
+		}, true},
+		{"unknown token", func() (api.Credentials, error) {
+			return api.NewCredentials("", "", "", "root@pam!other=00000000-0000-0000-0000-000000000001", "", "")
+		}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			creds, err := tt.creds()
+			require.NoError(t, err)
+
+			conn, err := api.NewConnection(srv.Endpoint(), true, "")
+			require.NoError(t, err)
+
+			c, err := api.NewClient(creds, conn)
+			require.NoError(t, err)
+
+			_, err = (&nodes.Client{Client: c}).ListNodes(t.Context())
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestServerVMLifecycle(t *testing.T) {
+	t.Parallel()
+
+	_, c := newTestServer(t)
+	ctx := t.Context()
+
+	nextID, err := (&cluster.Client{Client: c}).GetNextID(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, minVMID, *nextID)
+
+	nc := &nodes.Client{Client: c, NodeName: DefaultNodeName}
+	vm := nc.VM(*nextID)
+
+	body := &vms.CreateRequestBody{
+		VMID:            *nextID,
+		Name:            ptr.Ptr("test"),
+		DedicatedMemory: ptr.Ptr(1024),
+	}
+	body.AddCustomStorageDevice("scsi0", vms.CustomStorageDevice{FileVolume: "local-lvm:8"})
+
+	require.NoError(t, vm.CreateVM(ctx, body))
+
+	cfg, err := vm.GetVM(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "test", *cfg.Name)
+	require.EqualValues(t, 1024, *cfg.DedicatedMemory)
+	require.Contains(t, cfg.StorageDevices, "scsi0")
+	assert.Equal(t, "local-lvm:vm-100-disk-0", cfg.StorageDevices["scsi0"].FileVolume)
+	assert.Equal(t, "8G", cfg.StorageDevices["scsi0"].Size.String())
+
+	err = vm.UpdateVM(ctx, &vms.UpdateRequestBody{Name: ptr.Ptr("renamed")})
+	require.NoError(t, err)
+
+	err = vm.ResizeVMDisk(ctx, &vms.ResizeDiskRequestBody{Disk: "scsi0", Size: *types.DiskSizeFromGigabytes(10)})
+	require.NoError(t, err)
+
+	_, err = vm.StartVM(ctx, 60)
+	require.NoError(t, err)
+
+	status, err := vm.GetVMStatus(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "running", status.Status)
+	assert.Equal(t, "renamed", *status.Name)
+
+	require.NoError(t, vm.StopVM(ctx))
+	require.NoError(t, vm.DeleteVM(ctx))
+
+	_, err = vm.GetVM(ctx)
+	require.ErrorIs(t, err, api.ErrResourceDoesNotExist)
+
+	files, err := (&storage.Client{Client: nc, StorageName: "local-lvm"}).ListDatastoreFiles(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, files, "VM disks must be removed together with the VM")
+}
+
+func TestServerVMClone(t *testing.T) {
+	t.Parallel()
+
+	_, c := newTestServer(t)
+	ctx := t.Context()
+
+	nc := &nodes.Client{Client: c, NodeName: DefaultNodeName}
+
+	body := &vms.CreateRequestBody{VMID: 100}
+	body.AddCustomStorageDevice("scsi0", vms.CustomStorageDevice{FileVolume: "local-lvm:4"})
+
+	require.NoError(t, nc.VM(100).CreateVM(ctx, body))
+
+	require.NoError(t, nc.VM(100).CloneVM(ctx, 1, &vms.CloneRequestBody{
+		VMIDNew:        101,
+		Name:           ptr.Ptr("clone"),
+		TargetNodeName: ptr.Ptr("pve2"),
+	}))
+
+	clone := (&nodes.Client{Client: c, NodeName: "pve2"}).VM(101)
+
+	cfg, err := clone.GetVM(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "clone", *cfg.Name)
+	assert.Equal(t, "local-lvm:vm-101-disk-0", cfg.StorageDevices["scsi0"].FileVolume)
+
+	node, err := (&cluster.Client{Client: c}).GetVMNodeName(ctx, 101)
+	require.NoError(t, err)
+	assert.Equal(t, "pve2", *node)
+}
+
+func TestServerContainer(t *testing.T) {
+	t.Parallel()
+
+	_, c := newTestServer(t)
+	ctx := t.Context()
+
+	ct := (&nodes.Client{Client: c, NodeName: DefaultNodeName}).Container(200)
+
+	err := ct.CreateContainer(ctx, &containers.CreateRequestBody{
+		VMID:                 ptr.Ptr(200),
+		OSTemplateFileVolume: ptr.Ptr("local:vztmpl/debian.tar.zst"),
+		Hostname:             ptr.Ptr("ct"),
+	})
+	require.NoError(t, err)
+
+	cfg, err := ct.GetContainer(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "ct", *cfg.Hostname)
+
+	require.NoError(t, ct.StartContainer(ctx))
+
+	status, err := ct.GetContainerStatus(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "running", status.Status)
+}
+
+func TestServerDatastore(t *testing.T) {
+	t.Parallel()
+
+	_, c := newTestServer(t)
+	ctx := t.Context()
+
+	sc := &storage.Client{
+		Client:      &nodes.Client{Client: c, NodeName: DefaultNodeName},
+		StorageName: "local",
+	}
+
+	err := sc.DownloadFileByURL(ctx, &storage.DownloadURLPostRequestBody{
+		Content:  ptr.Ptr("iso"),
+		FileName: ptr.Ptr("debian.iso"),
+		URL:      ptr.Ptr("https://example.com/debian.iso"),
+	})
+	require.NoError(t, err)
+
+	files, err := sc.ListDatastoreFiles(ctx)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, "local:iso/debian.iso", files[0].VolumeID)
+	assert.EqualValues(t, DownloadedFileSize, files[0].FileSize)
+
+	require.NoError(t, sc.DeleteDatastoreFile(ctx, "iso/debian.iso"))
+
+	files, err = sc.ListDatastoreFiles(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
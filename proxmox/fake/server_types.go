@@ -0,0 +1,99 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package fake
+
+import (
+	"time"
+)
+
+// datastore is a storage definition shared by all nodes of the fake cluster.
+type datastore struct {
+	id          string
+	storageType string
+	content     []string
+	config      map[string]string
+	volumes     map[string]*volume
+}
+
+// volume is a single piece of content stored on a datastore.
+type volume struct {
+	volid   string
+	content string
+	format  string
+	size    int64
+	vmid    int
+}
+
+// guest is a virtual machine (`qemu`) or a container (`lxc`).
+type guest struct {
+	kind    string
+	node    string
+	vmid    int
+	config  map[string]string
+	status  string
+	started time.Time
+	lock    string
+}
+
+// replicationJob is a storage replication job, with the state of its last run.
+type replicationJob struct {
+	guest     int
+	jobnum    int
+	target    string
+	config    map[string]string
+	lastSync  time.Time
+	duration  float64
+	failCount int
+}
+
+// task is a finished worker task identified by its UPID.
+type task struct {
+	upid       string
+	node       string
+	taskType   string
+	id         string
+	user       string
+	startTime  time.Time
+	exitStatus string
+	log        []string
+}
+
+// user is a Proxmox VE user with its API tokens.
+type user struct {
+	id       string
+	password string
+	fields   map[string]string
+	tokens   map[string]*token
+}
+
+// token is an API token of a user.
+type token struct {
+	id      string
+	value   string
+	comment string
+	expire  int64
+	privsep bool
+}
+
+func newDatastore(id string, storageType string, content ...string) *datastore {
+	return &datastore{
+		id:          id,
+		storageType: storageType,
+		content:     content,
+		config:      map[string]string{},
+		volumes:     map[string]*volume{},
+	}
+}
+
+func newUser(id string, password string) *user {
+	return &user{
+		id:       id,
+		password: password,
+		fields:   map[string]string{"enable": "1"},
+		tokens:   map[string]*token{},
+	}
+}
@@ -0,0 +1,269 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package fake
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+)
+
+const (
+	datastoreSize = 100 * 1024 * 1024 * 1024
+
+	// DownloadedFileSize is the size reported for every file downloaded through `download-url`.
+	DownloadedFileSize = 1024 * 1024
+)
+
+func (s *Server) registerStorageRoutes(mux *http.ServeMux) {
+	prefix := basePath + "/nodes/{node}/storage"
+
+	mux.HandleFunc("GET "+prefix, s.withNode(s.listDatastores))
+	mux.HandleFunc("GET "+prefix+"/{storage}/status", s.withDatastore(s.getDatastoreStatus))
+	mux.HandleFunc("GET "+prefix+"/{storage}/content", s.withDatastore(s.listDatastoreContent))
+	mux.HandleFunc("GET "+prefix+"/{storage}/content/{volume}", s.withDatastore(s.getDatastoreVolume))
+	mux.HandleFunc("DELETE "+prefix+"/{storage}/content/{volume}", s.withDatastore(s.deleteDatastoreVolume))
+	mux.HandleFunc("POST "+prefix+"/{storage}/upload", s.withDatastore(s.uploadToDatastore))
+	mux.HandleFunc("POST "+prefix+"/{storage}/download-url", s.withDatastore(s.downloadToDatastore))
+	mux.HandleFunc("GET "+basePath+"/nodes/{node}/query-url-metadata", s.withNode(s.queryURLMetadata))
+
+	s.registerStorageConfigRoutes(mux)
+}
+
+// datastoreHandlerFunc handles a request for an existing datastore. It is called with the server lock held.
+type datastoreHandlerFunc func(w http.ResponseWriter, r *http.Request, ds *datastore)
+
+// withDatastore looks up the datastore from the request path and holds the server lock while the handler runs.
+func (s *Server) withDatastore(next datastoreHandlerFunc) http.HandlerFunc {
+	return s.withNode(func(w http.ResponseWriter, r *http.Request) {
+		if err := parseForm(r); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		ds, ok := s.datastores[r.PathValue("storage")]
+		if !ok {
+			writeError(w, http.StatusInternalServerError,
+				fmt.Sprintf("storage '%s' does not exist", r.PathValue("storage")))
+
+			return
+		}
+
+		next(w, r, ds)
+	})
+}
+
+func (s *Server) listDatastores(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	content := r.FormValue("content")
+	list := []map[string]any{}
+
+	for _, id := range sortedKeys(s.datastores) {
+		ds := s.datastores[id]
+
+		if content != "" && !slices.Contains(ds.content, content) {
+			continue
+		}
+
+		if !ds.availableOn(r.PathValue("node")) {
+			continue
+		}
+
+		status := s.datastoreStatus(ds)
+		status["storage"] = id
+
+		list = append(list, status)
+	}
+
+	writeData(w, list)
+}
+
+func (s *Server) getDatastoreStatus(w http.ResponseWriter, _ *http.Request, ds *datastore) {
+	writeData(w, s.datastoreStatus(ds))
+}
+
+func (s *Server) listDatastoreContent(w http.ResponseWriter, r *http.Request, ds *datastore) {
+	content := r.FormValue("content")
+	list := []map[string]any{}
+
+	for _, volid := range sortedKeys(ds.volumes) {
+		vol := ds.volumes[volid]
+
+		if content != "" && vol.content != content {
+			continue
+		}
+
+		list = append(list, renderVolume(vol))
+	}
+
+	writeData(w, list)
+}
+
+func (s *Server) getDatastoreVolume(w http.ResponseWriter, r *http.Request, ds *datastore) {
+	vol, ok := ds.volumes[qualifiedVolumeID(ds, r.PathValue("volume"))]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("volume '%s' does not exist", r.PathValue("volume")))
+		return
+	}
+
+	info := renderVolume(vol)
+	info["path"] = fmt.Sprintf("/var/lib/vz/%s", strings.SplitN(vol.volid, ":", 2)[1])
+
+	writeData(w, info)
+}
+
+func (s *Server) deleteDatastoreVolume(w http.ResponseWriter, r *http.Request, ds *datastore) {
+	volid := qualifiedVolumeID(ds, r.PathValue("volume"))
+	if _, ok := ds.volumes[volid]; !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("volume '%s' does not exist", r.PathValue("volume")))
+		return
+	}
+
+	delete(ds.volumes, volid)
+
+	writeData(w, s.newTask(r, r.PathValue("node"), "imgdel", volid))
+}
+
+func (s *Server) uploadToDatastore(w http.ResponseWriter, r *http.Request, ds *datastore) {
+	content := r.FormValue("content")
+	if !slices.Contains(ds.content, content) {
+		writeParamErrors(w, map[string]string{"content": fmt.Sprintf("storage '%s' does not support '%s' content", ds.id, content)})
+		return
+	}
+
+	file, header, err := r.FormFile("filename")
+	if err != nil {
+		writeParamErrors(w, map[string]string{"filename": err.Error()})
+		return
+	}
+
+	defer func() { _ = file.Close() }()
+
+	size, err := io.Copy(io.Discard, file)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	vol := s.addContentVolume(ds, content, header.Filename, size)
+
+	writeData(w, s.newTask(r, r.PathValue("node"), "imgcopy", "", "uploaded "+vol.volid))
+}
+
+func (s *Server) downloadToDatastore(w http.ResponseWriter, r *http.Request, ds *datastore) {
+	values := formValues(r)
+
+	content := values["content"]
+	if !slices.Contains(ds.content, content) {
+		writeParamErrors(w, map[string]string{"content": fmt.Sprintf("storage '%s' does not support '%s' content", ds.id, content)})
+		return
+	}
+
+	if values["url"] == "" || values["filename"] == "" {
+		writeParamErrors(w, map[string]string{"url": "property is missing and it is not optional"})
+		return
+	}
+
+	vol := s.addContentVolume(ds, content, values["filename"], DownloadedFileSize)
+
+	writeData(w, s.newTask(r, r.PathValue("node"), "download", "",
+		"downloading "+values["url"], "saved "+vol.volid))
+}
+
+func (s *Server) queryURLMetadata(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	u, err := url.Parse(r.FormValue("url"))
+	if err != nil || u.Host == "" {
+		writeParamErrors(w, map[string]string{"url": "invalid URL"})
+		return
+	}
+
+	writeData(w, map[string]any{
+		"filename": path.Base(u.Path),
+		"mimetype": "application/octet-stream",
+		"size":     DownloadedFileSize,
+	})
+}
+
+func (s *Server) addContentVolume(ds *datastore, content, fileName string, size int64) *volume {
+	vol := &volume{
+		volid:   fmt.Sprintf("%s:%s/%s", ds.id, content, fileName),
+		content: content,
+		format:  strings.TrimPrefix(path.Ext(fileName), "."),
+		size:    size,
+	}
+
+	ds.volumes[vol.volid] = vol
+
+	return vol
+}
+
+func (s *Server) datastoreStatus(ds *datastore) map[string]any {
+	var used int64
+	for _, vol := range ds.volumes {
+		used += vol.size
+	}
+
+	shared := 0
+	if storageTypes[ds.storageType].shared {
+		shared = 1
+	}
+
+	return map[string]any{
+		"type":    ds.storageType,
+		"content": strings.Join(ds.content, ","),
+		"active":  1,
+		"enabled": 1,
+		"shared":  shared,
+		"total":   datastoreSize,
+		"used":    used,
+		"avail":   datastoreSize - used,
+	}
+}
+
+// qualifiedVolumeID prefixes a volume name with the datastore ID, if it is not already present.
+func qualifiedVolumeID(ds *datastore, volume string) string {
+	if strings.HasPrefix(volume, ds.id+":") {
+		return volume
+	}
+
+	return ds.id + ":" + volume
+}
+
+func renderVolume(vol *volume) map[string]any {
+	out := map[string]any{
+		"volid":   vol.volid,
+		"content": vol.content,
+		"format":  vol.format,
+		"size":    vol.size,
+		"used":    vol.size,
+	}
+
+	if vol.vmid != 0 {
+		out["vmid"] = vol.vmid
+	}
+
+	return out
+}
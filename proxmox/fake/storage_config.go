@@ -0,0 +1,333 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package fake
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// storageTypeSpec describes the options of a storage type of the cluster storage configuration.
+type storageTypeSpec struct {
+	options  []string
+	required []string
+	fixed    []string
+	content  []string
+	// shared is set for the network storage types, which PVE always reports as shared
+	shared bool
+}
+
+//nolint:gochecknoglobals
+var (
+	// storageTypes are the storage types supported by the fake server.
+	storageTypes = map[string]storageTypeSpec{
+		"dir": {
+			options:  []string{"path", "shared", "prune-backups", "preallocation"},
+			required: []string{"path"},
+			fixed:    []string{"path"},
+			content:  []string{"images", "rootdir"},
+		},
+		"nfs": {
+			options:  []string{"server", "export", "path", "options", "prune-backups", "preallocation"},
+			required: []string{"server", "export"},
+			fixed:    []string{"server", "export", "path"},
+			content:  []string{"images"},
+			shared:   true,
+		},
+		"cifs": {
+			options: []string{
+				"server", "share", "path", "domain", "smbversion", "subdir", "username", "password", "options",
+				"prune-backups", "preallocation",
+			},
+			required: []string{"server", "share"},
+			fixed:    []string{"server", "share", "path"},
+			content:  []string{"images"},
+			shared:   true,
+		},
+		"lvm": {
+			options:  []string{"vgname", "shared", "saferemove"},
+			required: []string{"vgname"},
+			fixed:    []string{"vgname"},
+			content:  []string{"images"},
+		},
+		"lvmthin": {
+			options:  []string{"vgname", "thinpool"},
+			required: []string{"vgname", "thinpool"},
+			fixed:    []string{"vgname", "thinpool"},
+			content:  []string{"images", "rootdir"},
+		},
+		"zfspool": {
+			options:  []string{"pool", "blocksize", "sparse"},
+			required: []string{"pool"},
+			fixed:    []string{"pool"},
+			content:  []string{"images", "rootdir"},
+		},
+		"rbd": {
+			options: []string{"pool", "monhost", "username", "krbd", "namespace", "keyring"},
+			content: []string{"images"},
+			shared:  true,
+		},
+		"cephfs": {
+			options: []string{"path", "monhost", "username", "subdir", "fs-name", "options", "keyring", "prune-backups"},
+			fixed:   []string{"path"},
+			content: []string{"backup", "iso", "vztmpl"},
+			shared:  true,
+		},
+		"pbs": {
+			options: []string{
+				"server", "datastore", "username", "password", "fingerprint", "encryption-key", "namespace", "port",
+				"prune-backups",
+			},
+			required: []string{"server", "datastore"},
+			fixed:    []string{"server", "datastore"},
+			content:  []string{"backup"},
+			shared:   true,
+		},
+	}
+
+	// storageCommonOptions are the options supported by all storage types.
+	storageCommonOptions = []string{"content", "nodes", "disable"}
+
+	// storageSensitiveOptions are the options that are stored outside the storage configuration
+	// and are never returned by the API.
+	storageSensitiveOptions = []string{"password", "encryption-key", "keyring"}
+
+	storageIDPattern = regexp.MustCompile(`^[a-z][a-z0-9\-_.]*[a-z0-9]$`)
+)
+
+func (s *Server) registerStorageConfigRoutes(mux *http.ServeMux) {
+	prefix := basePath + "/storage"
+
+	mux.HandleFunc("GET "+prefix, s.listStorageConfig)
+	mux.HandleFunc("POST "+prefix, s.createStorageConfig)
+	mux.HandleFunc("GET "+prefix+"/{storage}", s.withStorageConfig(s.getStorageConfig))
+	mux.HandleFunc("PUT "+prefix+"/{storage}", s.withStorageConfig(s.updateStorageConfig))
+	mux.HandleFunc("DELETE "+prefix+"/{storage}", s.withStorageConfig(s.deleteStorageConfig))
+}
+
+// withStorageConfig looks up the datastore from the request path and holds the server lock while the handler runs.
+func (s *Server) withStorageConfig(next datastoreHandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := parseForm(r); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		ds, ok := s.datastores[r.PathValue("storage")]
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("storage '%s' does not exist", r.PathValue("storage")))
+			return
+		}
+
+		next(w, r, ds)
+	}
+}
+
+func (s *Server) listStorageConfig(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	storageType := r.FormValue("type")
+	list := []map[string]any{}
+
+	for _, id := range sortedKeys(s.datastores) {
+		ds := s.datastores[id]
+
+		if storageType != "" && ds.storageType != storageType {
+			continue
+		}
+
+		list = append(list, renderStorageConfig(ds))
+	}
+
+	writeData(w, list)
+}
+
+func (s *Server) createStorageConfig(w http.ResponseWriter, r *http.Request) {
+	if err := parseForm(r); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	values := formValues(r)
+	id := values["storage"]
+
+	if !storageIDPattern.MatchString(id) {
+		writeParamErrors(w, map[string]string{"storage": "invalid format - storage ID '" + id + "' contains illegal characters"})
+		return
+	}
+
+	spec, ok := storageTypes[values["type"]]
+	if !ok {
+		writeParamErrors(w, map[string]string{"type": fmt.Sprintf("value '%s' does not have a value in the enumeration", values["type"])})
+		return
+	}
+
+	for _, k := range spec.required {
+		if values[k] == "" {
+			writeParamErrors(w, map[string]string{k: "property is missing and it is not optional"})
+			return
+		}
+	}
+
+	if _, ok := s.datastores[id]; ok {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("storage ID '%s' already defined", id))
+		return
+	}
+
+	storageType := values["type"]
+
+	delete(values, "storage")
+	delete(values, "type")
+
+	if errs := validateStorageOptions(spec, values); len(errs) > 0 {
+		writeParamErrors(w, errs)
+		return
+	}
+
+	content := spec.content
+	if c, ok := values["content"]; ok {
+		content = splitList(c)
+	}
+
+	delete(values, "content")
+
+	if slices.Contains([]string{"nfs", "cifs", "cephfs"}, storageType) && values["path"] == "" {
+		values["path"] = "/mnt/pve/" + id
+	}
+
+	ds := newDatastore(id, storageType, content...)
+	ds.config = values
+
+	s.datastores[id] = ds
+
+	writeData(w, map[string]any{"storage": id, "type": storageType})
+}
+
+func (s *Server) getStorageConfig(w http.ResponseWriter, _ *http.Request, ds *datastore) {
+	writeData(w, renderStorageConfig(ds))
+}
+
+func (s *Server) updateStorageConfig(w http.ResponseWriter, r *http.Request, ds *datastore) {
+	values := formValues(r)
+	spec := storageTypes[ds.storageType]
+
+	if _, ok := values["type"]; ok {
+		writeParamErrors(w, map[string]string{"type": "property is not defined in schema and the schema does not allow additional properties"})
+		return
+	}
+
+	toDelete := splitList(values["delete"])
+
+	delete(values, "delete")
+	delete(values, "digest")
+
+	if errs := validateStorageOptions(spec, values); len(errs) > 0 {
+		writeParamErrors(w, errs)
+		return
+	}
+
+	for _, k := range spec.fixed {
+		if _, ok := values[k]; ok || slices.Contains(toDelete, k) {
+			writeError(w, http.StatusInternalServerError, fmt.Sprintf("can't change value of fixed parameter '%s'", k))
+			return
+		}
+	}
+
+	for _, k := range toDelete {
+		if k == "content" {
+			ds.content = spec.content
+		}
+
+		delete(ds.config, k)
+	}
+
+	if c, ok := values["content"]; ok {
+		ds.content = splitList(c)
+	}
+
+	delete(values, "content")
+
+	maps.Copy(ds.config, values)
+
+	writeData(w, map[string]any{"storage": ds.id, "type": ds.storageType})
+}
+
+func (s *Server) deleteStorageConfig(w http.ResponseWriter, _ *http.Request, ds *datastore) {
+	delete(s.datastores, ds.id)
+
+	writeData(w, nil)
+}
+
+// validateStorageOptions checks that all the given options are supported by the storage type.
+func validateStorageOptions(spec storageTypeSpec, values map[string]string) map[string]string {
+	errs := map[string]string{}
+
+	for k := range values {
+		if !slices.Contains(storageCommonOptions, k) && !slices.Contains(spec.options, k) {
+			errs[k] = "property is not defined in schema and the schema does not allow additional properties"
+		}
+	}
+
+	return errs
+}
+
+// availableOn reports whether the datastore is available on the given node.
+func (ds *datastore) availableOn(node string) bool {
+	nodes, ok := ds.config["nodes"]
+
+	return !ok || slices.Contains(splitList(nodes), node)
+}
+
+func renderStorageConfig(ds *datastore) map[string]any {
+	out := map[string]any{}
+
+	// the storage configuration is returned as strings, e.g. `smbversion` values like `3` are not numbers
+	for k, v := range ds.config {
+		if !slices.Contains(storageSensitiveOptions, k) {
+			out[k] = v
+		}
+	}
+
+	out["storage"] = ds.id
+	out["type"] = ds.storageType
+	out["content"] = strings.Join(ds.content, ",")
+
+	if storageTypes[ds.storageType].shared {
+		out["shared"] = 1
+	}
+
+	return out
+}
+
+// splitList splits a comma-separated list of the API.
+func splitList(s string) []string {
+	var items []string
+
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
@@ -0,0 +1,173 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package fake
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func (s *Server) registerTaskRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET "+basePath+"/nodes/{node}/tasks", s.listTasks)
+	mux.HandleFunc("GET "+basePath+"/nodes/{node}/tasks/{upid}", s.getTaskIndex)
+	mux.HandleFunc("GET "+basePath+"/nodes/{node}/tasks/{upid}/status", s.getTaskStatus)
+	mux.HandleFunc("GET "+basePath+"/nodes/{node}/tasks/{upid}/log", s.getTaskLog)
+	mux.HandleFunc("DELETE "+basePath+"/nodes/{node}/tasks/{upid}", s.deleteTask)
+}
+
+// newTask records a completed task and returns its UPID. Tasks of the fake server
+// finish synchronously, so the returned UPID is always in the `stopped` state.
+// The caller must hold the server lock.
+func (s *Server) newTask(r *http.Request, node, taskType, id string, log ...string) string {
+	s.taskPID++
+
+	now := time.Now()
+	userID := requestUser(r)
+	upid := fmt.Sprintf("UPID:%s:%08X:%08X:%08X:%s:%s:%s:",
+		node, s.taskPID, s.taskPID*16, now.Unix(), taskType, id, userID)
+
+	s.tasks[upid] = &task{
+		upid:       upid,
+		node:       node,
+		taskType:   taskType,
+		id:         id,
+		user:       userID,
+		startTime:  now,
+		exitStatus: "OK",
+		log:        append(slices.Clone(log), "TASK OK"),
+	}
+
+	return upid
+}
+
+func (s *Server) listTasks(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	node := r.PathValue("node")
+	list := []map[string]any{}
+
+	for _, upid := range sortedKeys(s.tasks) {
+		t := s.tasks[upid]
+		if t.node != node {
+			continue
+		}
+
+		list = append(list, map[string]any{
+			"upid":      t.upid,
+			"node":      t.node,
+			"type":      t.taskType,
+			"id":        t.id,
+			"user":      t.user,
+			"starttime": t.startTime.Unix(),
+			"endtime":   t.startTime.Unix(),
+			"status":    t.exitStatus,
+		})
+	}
+
+	writeData(w, list)
+}
+
+func (s *Server) getTaskIndex(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.tasks[r.PathValue("upid")]; !ok {
+		writeError(w, http.StatusBadRequest, "no such task")
+		return
+	}
+
+	writeData(w, []map[string]string{{"name": "log"}, {"name": "status"}})
+}
+
+func (s *Server) getTaskStatus(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.tasks[r.PathValue("upid")]
+	if !ok {
+		writeError(w, http.StatusBadRequest, "no such task")
+		return
+	}
+
+	writeData(w, map[string]any{
+		"upid":       t.upid,
+		"node":       t.node,
+		"type":       t.taskType,
+		"id":         t.id,
+		"user":       t.user,
+		"starttime":  t.startTime.Unix(),
+		"status":     "stopped",
+		"exitstatus": t.exitStatus,
+		"pid":        0,
+	})
+}
+
+func (s *Server) getTaskLog(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.tasks[r.PathValue("upid")]
+	if !ok {
+		writeError(w, http.StatusBadRequest, "no such task")
+		return
+	}
+
+	// same defaults as the real API
+	start := queryInt(r, "start", 0)
+	limit := queryInt(r, "limit", 50)
+
+	lines := []map[string]any{}
+
+	for i := start; i < len(t.log) && len(lines) < limit; i++ {
+		lines = append(lines, map[string]any{"n": i + 1, "t": t.log[i]})
+	}
+
+	writeData(w, lines)
+}
+
+func (s *Server) deleteTask(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.tasks[r.PathValue("upid")]; !ok {
+		writeError(w, http.StatusNotFound, "no such task")
+		return
+	}
+
+	writeData(w, nil)
+}
+
+// queryInt returns the integer query parameter of the request, or the default value if it is not set.
+func queryInt(r *http.Request, name string, def int) int {
+	v, err := strconv.Atoi(r.URL.Query().Get(name))
+	if err != nil || v < 0 {
+		return def
+	}
+
+	return v
+}
+
+// requestUser returns the user (or token) ID the request was authenticated with.
+func requestUser(r *http.Request) string {
+	if h := r.Header.Get("Authorization"); strings.HasPrefix(h, "PVEAPIToken=") {
+		userAndToken, _, _ := strings.Cut(strings.TrimPrefix(h, "PVEAPIToken="), "=")
+		return userAndToken
+	}
+
+	if cookie, err := r.Cookie("PVEAuthCookie"); err == nil {
+		if parts := strings.Split(cookie.Value, ":"); len(parts) > 1 {
+			return parts[1]
+		}
+	}
+
+	return DefaultUsername
+}
@@ -39,16 +39,18 @@ func (c *Client) APT() *apt.Client {
 // Container returns a client for managing a specific container.
 func (c *Client) Container(vmID int) *containers.Client {
 	return &containers.Client{
-		Client: c,
-		VMID:   vmID,
+		Client:   c,
+		NodeName: c.NodeName,
+		VMID:     vmID,
 	}
 }
 
 // VM returns a client for managing a specific VM.
 func (c *Client) VM(vmID int) *vms.Client {
 	return &vms.Client{
-		Client: c,
-		VMID:   vmID,
+		Client:   c,
+		NodeName: c.NodeName,
+		VMID:     vmID,
 	}
 }
 
@@ -7,6 +7,7 @@
 package containers
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
@@ -18,7 +19,8 @@ import (
 // Client is an interface for accessing the Proxmox container API.
 type Client struct {
 	api.Client
-	VMID int
+	NodeName string
+	VMID     int
 }
 
 func (c *Client) basePath() string {
@@ -35,6 +37,16 @@ func (c *Client) ExpandPath(path string) string {
 	return ep
 }
 
+// acquireTaskSlot waits until a task can be started on the container's node without exceeding the API limits.
+func (c *Client) acquireTaskSlot(ctx context.Context) (func(), error) {
+	release, err := c.AcquireTaskSlot(ctx, c.NodeName)
+	if err != nil {
+		return nil, fmt.Errorf("error waiting to start a task on node %q: %w", c.NodeName, err)
+	}
+
+	return release, nil
+}
+
 // Tasks returns a client for managing container tasks.
 func (c *Client) Tasks() *tasks.Client {
 	return &tasks.Client{
@@ -21,16 +21,51 @@ import (
 
 // CloneContainer clones a container.
 func (c *Client) CloneContainer(ctx context.Context, d *CloneRequestBody) error {
-	err := c.DoRequest(ctx, http.MethodPost, c.ExpandPath("/clone"), d, nil)
+	release, err := c.acquireTaskSlot(ctx)
 	if err != nil {
-		return fmt.Errorf("error cloning container: %w", err)
+		return err
+	}
+
+	defer release()
+
+	taskID, err := c.CloneContainerAsync(ctx, d)
+	if err != nil {
+		return err
+	}
+
+	err = c.Tasks().WaitForTask(ctx, *taskID)
+	if err != nil {
+		return fmt.Errorf("error waiting for container clone: %w", err)
 	}
 
 	return nil
 }
 
+// CloneContainerAsync clones a container asynchronously. Returns ID of the started task.
+func (c *Client) CloneContainerAsync(ctx context.Context, d *CloneRequestBody) (*string, error) {
+	resBody := &CloneResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodPost, c.ExpandPath("/clone"), d, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error cloning container: %w", err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data, nil
+}
+
 // CreateContainer creates a container.
 func (c *Client) CreateContainer(ctx context.Context, d *CreateRequestBody) error {
+	release, err := c.acquireTaskSlot(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer release()
+
 	taskID, err := c.CreateContainerAsync(ctx, d)
 	if err != nil {
 		return err
@@ -62,14 +97,42 @@ func (c *Client) CreateContainerAsync(ctx context.Context, d *CreateRequestBody)
 
 // DeleteContainer deletes a container.
 func (c *Client) DeleteContainer(ctx context.Context) error {
-	err := c.DoRequest(ctx, http.MethodDelete, c.ExpandPath(""), nil, nil)
+	release, err := c.acquireTaskSlot(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer release()
+
+	taskID, err := c.DeleteContainerAsync(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = c.Tasks().WaitForTask(ctx, *taskID)
 	if err != nil {
-		return fmt.Errorf("error deleting container: %w", err)
+		return fmt.Errorf("error waiting for container deletion: %w", err)
 	}
 
 	return nil
 }
 
+// DeleteContainerAsync deletes a container asynchronously. Returns ID of the started task.
+func (c *Client) DeleteContainerAsync(ctx context.Context) (*string, error) {
+	resBody := &DeleteResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodDelete, c.ExpandPath(""), nil, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error deleting container: %w", err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data, nil
+}
+
 // GetContainer retrieves a container.
 func (c *Client) GetContainer(ctx context.Context) (*GetResponseData, error) {
 	resBody := &GetResponseBody{}
@@ -104,24 +167,80 @@ func (c *Client) GetContainerStatus(ctx context.Context) (*GetStatusResponseData
 
 // RebootContainer reboots a container.
 func (c *Client) RebootContainer(ctx context.Context, d *RebootRequestBody) error {
-	err := c.DoRequest(ctx, http.MethodPost, c.ExpandPath("status/reboot"), d, nil)
+	release, err := c.acquireTaskSlot(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer release()
+
+	taskID, err := c.RebootContainerAsync(ctx, d)
 	if err != nil {
-		return fmt.Errorf("error rebooting container: %w", err)
+		return err
+	}
+
+	err = c.Tasks().WaitForTask(ctx, *taskID)
+	if err != nil {
+		return fmt.Errorf("error waiting for container reboot: %w", err)
 	}
 
 	return nil
 }
 
+// RebootContainerAsync reboots a container asynchronously. Returns ID of the started task.
+func (c *Client) RebootContainerAsync(ctx context.Context, d *RebootRequestBody) (*string, error) {
+	resBody := &RebootResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodPost, c.ExpandPath("status/reboot"), d, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error rebooting container: %w", err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data, nil
+}
+
 // ShutdownContainer shuts down a container.
 func (c *Client) ShutdownContainer(ctx context.Context, d *ShutdownRequestBody) error {
-	err := c.DoRequest(ctx, http.MethodPost, c.ExpandPath("status/shutdown"), d, nil)
+	release, err := c.acquireTaskSlot(ctx)
 	if err != nil {
-		return fmt.Errorf("error shutting down container: %w", err)
+		return err
+	}
+
+	defer release()
+
+	taskID, err := c.ShutdownContainerAsync(ctx, d)
+	if err != nil {
+		return err
+	}
+
+	err = c.Tasks().WaitForTask(ctx, *taskID)
+	if err != nil {
+		return fmt.Errorf("error waiting for container shutdown: %w", err)
 	}
 
 	return nil
 }
 
+// ShutdownContainerAsync shuts down a container asynchronously. Returns ID of the started task.
+func (c *Client) ShutdownContainerAsync(ctx context.Context, d *ShutdownRequestBody) (*string, error) {
+	resBody := &ShutdownResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodPost, c.ExpandPath("status/shutdown"), d, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error shutting down container: %w", err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data, nil
+}
+
 // StartContainer starts a container if is not already running.
 func (c *Client) StartContainer(ctx context.Context) error {
 	status, err := c.GetContainerStatus(ctx)
@@ -133,6 +252,13 @@ func (c *Client) StartContainer(ctx context.Context) error {
 		return nil
 	}
 
+	release, err := c.acquireTaskSlot(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer release()
+
 	taskID, err := c.StartContainerAsync(ctx)
 	if err != nil {
 		return fmt.Errorf("error starting container: %w", err)
@@ -170,14 +296,42 @@ func (c *Client) StartContainerAsync(ctx context.Context) (*string, error) {
 
 // StopContainer stops a container immediately.
 func (c *Client) StopContainer(ctx context.Context) error {
-	err := c.DoRequest(ctx, http.MethodPost, c.ExpandPath("status/stop"), nil, nil)
+	release, err := c.acquireTaskSlot(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer release()
+
+	taskID, err := c.StopContainerAsync(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = c.Tasks().WaitForTask(ctx, *taskID)
 	if err != nil {
-		return fmt.Errorf("error stopping container: %w", err)
+		return fmt.Errorf("error waiting for container stop: %w", err)
 	}
 
 	return nil
 }
 
+// StopContainerAsync stops a container immediately and asynchronously. Returns ID of the started task.
+func (c *Client) StopContainerAsync(ctx context.Context) (*string, error) {
+	resBody := &StopResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodPost, c.ExpandPath("status/stop"), nil, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error stopping container: %w", err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	return resBody.Data, nil
+}
+
 // UpdateContainer updates a container.
 func (c *Client) UpdateContainer(ctx context.Context, d *UpdateRequestBody) error {
 	err := c.DoRequest(ctx, http.MethodPut, c.ExpandPath("config"), d, nil)
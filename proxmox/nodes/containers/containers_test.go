@@ -0,0 +1,80 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package containers_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/fake"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/containers"
+)
+
+func TestContainerTasksTakeTaskSlots(t *testing.T) {
+	t.Parallel()
+
+	srv := fake.NewServer()
+	t.Cleanup(srv.Close)
+
+	creds, err := api.NewCredentials("", "", "", fake.DefaultAPIToken, "", "")
+	require.NoError(t, err)
+
+	conn, err := api.NewConnection(srv.Endpoint(), true, "", api.WithLimits(api.Limits{MaxConcurrentTasksPerNode: 1}))
+	require.NoError(t, err)
+
+	client, err := api.NewClient(creds, conn)
+	require.NoError(t, err)
+
+	node := &nodes.Client{Client: client, NodeName: fake.DefaultNodeName}
+	ct := node.Container(200)
+
+	require.NoError(t, ct.CreateContainer(t.Context(), &containers.CreateRequestBody{
+		VMID:                 ptr.Ptr(200),
+		OSTemplateFileVolume: ptr.Ptr("local:vztmpl/debian.tar.zst"),
+		Hostname:             ptr.Ptr("ct"),
+	}))
+
+	tasks := []struct {
+		name string
+		run  func(ctx context.Context) error
+	}{
+		{"clone", func(ctx context.Context) error {
+			return ct.CloneContainer(ctx, &containers.CloneRequestBody{VMIDNew: 201})
+		}},
+		{"start", ct.StartContainer},
+		{"reboot", func(ctx context.Context) error {
+			return ct.RebootContainer(ctx, &containers.RebootRequestBody{})
+		}},
+		{"shutdown", func(ctx context.Context) error {
+			return ct.ShutdownContainer(ctx, &containers.ShutdownRequestBody{})
+		}},
+		{"start again", ct.StartContainer},
+		{"stop", ct.StopContainer},
+		{"delete", node.Container(201).DeleteContainer},
+	}
+
+	for _, task := range tasks {
+		// the task cannot start while another task holds the only slot of the node
+		release, err := client.AcquireTaskSlot(t.Context(), fake.DefaultNodeName)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+
+		require.ErrorIs(t, task.run(ctx), context.DeadlineExceeded, task.name)
+
+		cancel()
+		release()
+
+		require.NoError(t, task.run(t.Context()), task.name)
+	}
+}
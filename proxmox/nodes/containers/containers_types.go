@@ -154,11 +154,21 @@ type CustomStartupBehavior struct {
 	Up    *int `json:"up,omitempty"    url:"up,omitempty"`
 }
 
+// CloneResponseBody contains the body from a container clone response.
+type CloneResponseBody struct {
+	Data *string `json:"data,omitempty"`
+}
+
 // CreateResponseBody contains the body from a container create response.
 type CreateResponseBody struct {
 	Data *string `json:"data,omitempty"`
 }
 
+// DeleteResponseBody contains the body from a container delete response.
+type DeleteResponseBody struct {
+	Data *string `json:"data,omitempty"`
+}
+
 // GetResponseBody contains the body from a user get response.
 type GetResponseBody struct {
 	Data *GetResponseData `json:"data,omitempty"`
@@ -247,12 +257,27 @@ type RebootRequestBody struct {
 	Timeout *int `json:"timeout,omitempty" url:"timeout,omitempty"`
 }
 
+// RebootResponseBody contains the body from a container reboot response.
+type RebootResponseBody struct {
+	Data *string `json:"data,omitempty"`
+}
+
 // ShutdownRequestBody contains the body for a container shutdown request.
 type ShutdownRequestBody struct {
 	ForceStop *types.CustomBool `json:"forceStop,omitempty" url:"forceStop,omitempty,int"`
 	Timeout   *int              `json:"timeout,omitempty"   url:"timeout,omitempty"`
 }
 
+// ShutdownResponseBody contains the body from a container shutdown response.
+type ShutdownResponseBody struct {
+	Data *string `json:"data,omitempty"`
+}
+
+// StopResponseBody contains the body from a container stop response.
+type StopResponseBody struct {
+	Data *string `json:"data,omitempty"`
+}
+
 // UpdateRequestBody contains the data for an user update request.
 type UpdateRequestBody CreateRequestBody
 
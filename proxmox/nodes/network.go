@@ -8,6 +8,7 @@ package nodes
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -19,6 +20,7 @@ import (
 	"github.com/avast/retry-go/v4"
 
 	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/tasks"
 )
 
 const (
@@ -91,7 +93,10 @@ func (c *Client) ReloadNetworkConfiguration(ctx context.Context) error {
 		retry.Delay(1*time.Second),
 		retry.Attempts(3),
 		retry.RetryIf(func(err error) bool {
-			return strings.Contains(err.Error(), "exit code 89")
+			// ifreload exits with 89 when it could not apply the configuration right away
+			var taskErr *tasks.TaskError
+
+			return errors.As(err, &taskErr) && strings.Contains(taskErr.ExitStatus, "exit code 89")
 		}),
 	)
 	if err != nil {
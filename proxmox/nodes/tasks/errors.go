@@ -0,0 +1,37 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package tasks
+
+import (
+	"fmt"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+// TaskError is returned when a task completes with an exit status other than OK.
+// It matches api.ErrTaskFailed, and the error matching the exit status, if any.
+type TaskError struct {
+	UPID       string
+	ExitStatus string
+	// Log contains the last lines of the task log.
+	Log []string
+}
+
+func (err *TaskError) Error() string {
+	return fmt.Sprintf("task %q failed to complete with exit code: %s%s", err.UPID, err.ExitStatus, formatTaskLog(err.Log))
+}
+
+// Unwrap returns api.ErrTaskFailed and the error matching the exit status.
+func (err *TaskError) Unwrap() []error {
+	errs := []error{api.ErrTaskFailed}
+
+	if matched := api.MatchError(err.ExitStatus); matched != nil {
+		errs = append(errs, matched)
+	}
+
+	return errs
+}
@@ -0,0 +1,39 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package tasks
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+func TestTaskError(t *testing.T) {
+	t.Parallel()
+
+	upid := "UPID:pve:000C1A2B:0040C1D2:65A1B2C3:qmresize:100:root@pam:"
+
+	err := fmt.Errorf("error waiting for VM disk resize: %w",
+		&TaskError{UPID: upid, ExitStatus: "can't lock file '/var/lock/qemu-server/lock-100.conf' - got timeout"})
+
+	var taskErr *TaskError
+
+	require.ErrorAs(t, err, &taskErr)
+	assert.Equal(t, upid, taskErr.UPID)
+	require.ErrorIs(t, err, api.ErrTaskFailed)
+	require.ErrorIs(t, err, api.ErrConfigLocked)
+
+	err = &TaskError{UPID: upid, ExitStatus: "command 'qm resize' failed: exit code 2"}
+	require.ErrorIs(t, err, api.ErrTaskFailed)
+	assert.False(t, errors.Is(err, api.ErrConfigLocked))
+	assert.Contains(t, err.Error(), "exit code 2")
+}
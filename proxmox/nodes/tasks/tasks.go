@@ -11,10 +11,13 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/avast/retry-go/v4"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
 )
@@ -69,6 +72,25 @@ func (c *Client) GetTaskLog(ctx context.Context, upid string) ([]string, error)
 	return lines, nil
 }
 
+// getTaskLogLines retrieves up to limit lines of the log of a task, starting after the given line number.
+func (c *Client) getTaskLogLines(ctx context.Context, upid string, start, limit int) ([]*GetTaskLogResponseData, error) {
+	resBody := &GetTaskLogResponseBody{}
+
+	path, err := c.BuildPath(upid, "log")
+	if err != nil {
+		return nil, fmt.Errorf("error building path for task log: %w", err)
+	}
+
+	reqBody := &GetTaskLogRequestBody{Start: &start, Limit: &limit}
+
+	err = c.DoRequest(ctx, http.MethodGet, path, reqBody, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving task log: %w", err)
+	}
+
+	return resBody.Data, nil
+}
+
 // DeleteTask deletes specific task.
 func (c *Client) DeleteTask(ctx context.Context, upid string) error {
 	path, err := c.baseTaskPath(upid)
@@ -88,9 +110,24 @@ func (c *Client) DeleteTask(ctx context.Context, upid string) error {
 	return nil
 }
 
+// errTaskStillRunning is used to retry polling the status of a running task.
+var errTaskStillRunning = errors.New("still running")
+
+const (
+	// taskStopTimeout limits the time spent stopping a task after waiting for it has been interrupted.
+	taskStopTimeout = time.Minute
+
+	// defaultTaskLogTailLines is the number of the last lines of the task log attached to errors.
+	defaultTaskLogTailLines = 20
+
+	// taskLogPageSize is the maximum number of task log lines retrieved with a single request.
+	taskLogPageSize = 500
+)
+
 type taskWaitOptions struct {
 	ignoreWarnings   bool
 	ignoreStatusCode int
+	logTailLines     int
 }
 
 // TaskWaitOption is an option for waiting for a task to complete.
@@ -122,16 +159,87 @@ func (w withIgnoreStatus) apply(opts *taskWaitOptions) {
 	opts.ignoreStatusCode = w.statusCode
 }
 
-// WaitForTask waits for a specific task to complete.
-func (c *Client) WaitForTask(ctx context.Context, upid string, opts ...TaskWaitOption) error {
-	errStillRunning := errors.New("still running")
+type withLogTail struct {
+	lines int
+}
 
-	options := &taskWaitOptions{}
+// WithLogTail is an option to set the number of the last lines of the task log attached to the error
+// when the task fails.
+func WithLogTail(lines int) TaskWaitOption {
+	return withLogTail{lines: lines}
+}
+
+func (w withLogTail) apply(opts *taskWaitOptions) {
+	opts.logTailLines = w.lines
+}
+
+// taskLog follows the log of a running task.
+type taskLog struct {
+	upid     string
+	next     int
+	tail     []string
+	tailSize int
+}
+
+// poll retrieves the lines added to the task log since the last call, and emits them to the Terraform log.
+// The log is not essential for waiting for the task, so errors are only logged.
+func (l *taskLog) poll(ctx context.Context, c *Client) {
+	for {
+		lines, err := c.getTaskLogLines(ctx, l.upid, l.next, taskLogPageSize)
+		if err != nil {
+			tflog.Debug(ctx, "unable to retrieve the task log", map[string]interface{}{
+				"upid":  l.upid,
+				"error": err.Error(),
+			})
+
+			return
+		}
+
+		for _, line := range lines {
+			if line == nil || line.LineNumber <= l.next {
+				// PVE returns a single "no content" line numbered 0 if the log is not available yet
+				continue
+			}
+
+			l.next = line.LineNumber
+
+			tflog.Debug(ctx, "task log", map[string]interface{}{
+				"upid": l.upid,
+				"line": line.LineText,
+			})
+
+			l.tail = append(l.tail, line.LineText)
+			if len(l.tail) > l.tailSize {
+				l.tail = l.tail[len(l.tail)-l.tailSize:]
+			}
+		}
+
+		if len(lines) < taskLogPageSize {
+			return
+		}
+	}
+}
+
+// formatTaskLog formats the task log lines for inclusion in an error message.
+func formatTaskLog(lines []string) string {
+	if len(lines) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("\ntask log (last %d lines):\n\t| %s", len(lines), strings.Join(lines, "\n\t| "))
+}
+
+// WaitForTask waits for a specific task to complete. The task log is emitted to the Terraform log
+// while waiting, and its last lines are attached to the error if the task fails.
+func (c *Client) WaitForTask(ctx context.Context, upid string, opts ...TaskWaitOption) error {
+	options := &taskWaitOptions{logTailLines: defaultTaskLogTailLines}
 
 	for _, opt := range opts {
 		opt.apply(options)
 	}
 
+	log := &taskLog{upid: upid, tailSize: max(options.logTailLines, 0)}
+
 	status, err := retry.DoWithData(
 		func() (*GetTaskStatusResponseData, error) {
 			status, err := c.GetTaskStatus(ctx, upid)
@@ -139,8 +247,10 @@ func (c *Client) WaitForTask(ctx context.Context, upid string, opts ...TaskWaitO
 				return nil, err
 			}
 
+			log.poll(ctx, c)
+
 			if status.Status == "running" {
-				return nil, errStillRunning
+				return nil, errTaskStillRunning
 			}
 
 			return status, err
@@ -161,7 +271,7 @@ func (c *Client) WaitForTask(ctx context.Context, upid string, opts ...TaskWaitO
 				}
 			}
 
-			return errors.Is(err, errStillRunning)
+			return errors.Is(err, errTaskStillRunning)
 		}),
 		retry.LastErrorOnly(true),
 		retry.UntilSucceeded(),
@@ -169,21 +279,171 @@ func (c *Client) WaitForTask(ctx context.Context, upid string, opts ...TaskWaitO
 		retry.Delay(time.Second),
 	)
 
+	stopped := ""
+	// only stop the task if waiting for it failed because the context is done, not if the last poll
+	// has already seen it finished
+	if err != nil && ctx.Err() != nil && c.CancelTasksOnInterrupt() && c.stopTask(ctx, upid) {
+		stopped = ", the task has been stopped"
+	}
+
 	if errors.Is(err, context.DeadlineExceeded) {
-		return fmt.Errorf("timeout while waiting for task %q to complete", upid)
+		return fmt.Errorf("timeout while waiting for task %q to complete%s%s", upid, stopped, formatTaskLog(log.tail))
 	}
 
 	if err != nil {
-		return fmt.Errorf("error while waiting for task %q to complete: %w", upid, err)
+		return fmt.Errorf("error while waiting for task %q to complete%s: %w", upid, stopped, err)
 	}
 
 	if status.ExitCode != "OK" {
 		if options.ignoreWarnings &&
 			strings.HasPrefix(status.ExitCode, "WARNINGS: ") && !strings.Contains(status.ExitCode, "ERROR") {
+			tflog.Warn(ctx, "task completed with warnings", map[string]interface{}{
+				"upid":        upid,
+				"exit_status": status.ExitCode,
+				"log":         strings.Join(log.tail, "\n"),
+			})
+
+			return nil
+		}
+
+		return &TaskError{UPID: upid, ExitStatus: status.ExitCode, Log: log.tail}
+	}
+
+	return nil
+}
+
+// stopTask stops a task after waiting for it has been interrupted, and waits until the task has exited
+// and released its locks. Returns false if the task could not be stopped.
+func (c *Client) stopTask(ctx context.Context, upid string) bool {
+	// the original context is already done
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), taskStopTimeout)
+	defer cancel()
+
+	tflog.Warn(ctx, "stopping the task as waiting for it has been interrupted", map[string]interface{}{
+		"upid": upid,
+	})
+
+	if err := c.DeleteTask(ctx, upid); err != nil {
+		tflog.Error(ctx, "unable to stop the task", map[string]interface{}{
+			"upid":  upid,
+			"error": err.Error(),
+		})
+
+		return false
+	}
+
+	err := retry.Do(
+		func() error {
+			status, err := c.GetTaskStatus(ctx, upid)
+			if err != nil {
+				return err
+			}
+
+			if status.Status == "running" {
+				return errTaskStillRunning
+			}
+
 			return nil
+		},
+		retry.Context(ctx),
+		retry.RetryIf(func(err error) bool {
+			return errors.Is(err, errTaskStillRunning)
+		}),
+		retry.LastErrorOnly(true),
+		retry.UntilSucceeded(),
+		retry.DelayType(retry.FixedDelay),
+		retry.Delay(time.Second),
+	)
+	if err != nil {
+		tflog.Error(ctx, "the stopped task has not exited", map[string]interface{}{
+			"upid":  upid,
+			"error": err.Error(),
+		})
+
+		return false
+	}
+
+	// an aborted task may leave the guest locked for a while, e.g. while its worker cleans up
+	if err = c.waitForGuestUnlock(ctx, upid); err != nil {
+		tflog.Error(ctx, "the guest of the stopped task is still locked", map[string]interface{}{
+			"upid":  upid,
+			"error": err.Error(),
+		})
+	}
+
+	return true
+}
+
+// errGuestLocked is used to retry polling the configuration of a locked guest.
+var errGuestLocked = errors.New("guest is locked")
+
+// guestConfigPaths returns the configuration paths of the guest a task operates on, in the order they
+// should be tried. Returns nil if the task is not related to a guest.
+func guestConfigPaths(tid TaskID) []string {
+	if _, err := strconv.Atoi(tid.ID); err != nil {
+		return nil
+	}
+
+	node := url.PathEscape(tid.NodeName)
+	qemu := fmt.Sprintf("nodes/%s/qemu/%s/config", node, tid.ID)
+	lxc := fmt.Sprintf("nodes/%s/lxc/%s/config", node, tid.ID)
+
+	switch {
+	case tid.Type == "vzdump":
+		// backups are run for both VMs and containers
+		return []string{qemu, lxc}
+	case strings.HasPrefix(tid.Type, "qm"):
+		return []string{qemu}
+	case strings.HasPrefix(tid.Type, "vz"):
+		return []string{lxc}
+	default:
+		return nil
+	}
+}
+
+// waitForGuestUnlock waits until the guest a task operates on has no lock in its configuration.
+// A guest that does not exist (anymore) is not locked.
+func (c *Client) waitForGuestUnlock(ctx context.Context, upid string) error {
+	tid, err := ParseTaskID(upid)
+	if err != nil {
+		return err
+	}
+
+	for _, path := range guestConfigPaths(tid) {
+		err = retry.Do(
+			func() error {
+				resBody := &guestConfigResponseBody{}
+
+				err := c.DoRequest(ctx, http.MethodGet, path, nil, resBody)
+				if err != nil {
+					return err
+				}
+
+				if resBody.Data != nil && resBody.Data.Lock != "" {
+					tflog.Debug(ctx, "waiting for the guest lock to be released", map[string]interface{}{
+						"upid": upid,
+						"lock": resBody.Data.Lock,
+					})
+
+					return errGuestLocked
+				}
+
+				return nil
+			},
+			retry.Context(ctx),
+			retry.RetryIf(func(err error) bool {
+				return errors.Is(err, errGuestLocked)
+			}),
+			retry.LastErrorOnly(true),
+			retry.UntilSucceeded(),
+			retry.DelayType(retry.FixedDelay),
+			retry.Delay(time.Second),
+		)
+		if errors.Is(err, api.ErrResourceDoesNotExist) {
+			continue
 		}
 
-		return fmt.Errorf("task %q failed to complete with exit code: %s", upid, status.ExitCode)
+		return err
 	}
 
 	return nil
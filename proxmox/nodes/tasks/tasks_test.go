@@ -0,0 +1,242 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+)
+
+const testUPID = "UPID:pve:000C1A2B:0040C1D2:65A1B2C3:qmrestore:100:root@pam:"
+
+// taskServer serves a task that writes a log line on every status poll, and fails with the given exit status
+// after the given number of polls, or when it is stopped.
+type taskServer struct {
+	*Client
+
+	mu       sync.Mutex
+	log      []string
+	starts   []int
+	stopped  bool
+	finished bool
+	deletes  int
+
+	// lockedPolls is the number of polls of the guest configuration that still report the lock after
+	// the task has been stopped.
+	lockedPolls int
+	configPolls int
+
+	// onFinish is called when the log is read after a status poll has reported the task as finished.
+	onFinish func()
+}
+
+func newTaskServer(t *testing.T, polls int, exitStatus string, opts ...api.ConnectionOption) *taskServer {
+	t.Helper()
+
+	ts := &taskServer{}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api2/json/nodes/pve/tasks/{upid}/status", func(w http.ResponseWriter, _ *http.Request) {
+		ts.mu.Lock()
+		defer ts.mu.Unlock()
+
+		ts.log = append(ts.log, fmt.Sprintf("line %d", len(ts.log)+1))
+
+		status := map[string]any{"status": "running"}
+		if len(ts.log) >= polls || ts.stopped {
+			status = map[string]any{"status": "stopped", "exitstatus": exitStatus}
+			ts.finished = true
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": status})
+	})
+	mux.HandleFunc("GET /api2/json/nodes/pve/tasks/{upid}/log", func(w http.ResponseWriter, r *http.Request) {
+		ts.mu.Lock()
+		defer ts.mu.Unlock()
+
+		if ts.finished && ts.onFinish != nil {
+			ts.onFinish()
+		}
+
+		start, _ := strconv.Atoi(r.URL.Query().Get("start"))
+		ts.starts = append(ts.starts, start)
+
+		lines := []map[string]any{}
+		for i := start; i < len(ts.log); i++ {
+			lines = append(lines, map[string]any{"n": i + 1, "t": ts.log[i]})
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": lines})
+	})
+	mux.HandleFunc("DELETE /api2/json/nodes/pve/tasks/{upid}", func(w http.ResponseWriter, _ *http.Request) {
+		ts.mu.Lock()
+		defer ts.mu.Unlock()
+
+		ts.stopped = true
+		ts.deletes++
+
+		_, _ = w.Write([]byte(`{"data":null}`))
+	})
+	mux.HandleFunc("GET /api2/json/nodes/pve/qemu/100/config", func(w http.ResponseWriter, _ *http.Request) {
+		ts.mu.Lock()
+		defer ts.mu.Unlock()
+
+		config := map[string]any{"name": "test"}
+
+		if ts.stopped {
+			ts.configPolls++
+		}
+
+		if !ts.stopped || ts.configPolls <= ts.lockedPolls {
+			config["lock"] = "create"
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": config})
+	})
+
+	srv := httptest.NewTLSServer(mux)
+	t.Cleanup(srv.Close)
+
+	conn, err := api.NewConnection(srv.URL, true, "", opts...)
+	require.NoError(t, err)
+
+	creds, err := api.NewCredentials("", "", "", "root@pam!test=00000000-0000-0000-0000-000000000000", "", "")
+	require.NoError(t, err)
+
+	client, err := api.NewClient(creds, conn)
+	require.NoError(t, err)
+
+	ts.Client = &Client{Client: client}
+
+	return ts
+}
+
+func TestWaitForTaskAttachesLogTail(t *testing.T) {
+	t.Parallel()
+
+	c := newTaskServer(t, 3, "unable to restore VM 100 - no such file")
+
+	err := c.WaitForTask(t.Context(), testUPID, WithLogTail(2))
+
+	var taskErr *TaskError
+
+	require.ErrorAs(t, err, &taskErr)
+	assert.Equal(t, []string{"line 2", "line 3"}, taskErr.Log)
+	assert.Contains(t, err.Error(), "task log (last 2 lines):\n\t| line 2\n\t| line 3")
+
+	// the log is read incrementally
+	assert.Equal(t, []int{0, 1, 2}, c.starts)
+}
+
+func TestWaitForTaskIgnoresWarnings(t *testing.T) {
+	t.Parallel()
+
+	c := newTaskServer(t, 1, "WARNINGS: 1")
+
+	require.NoError(t, c.WaitForTask(t.Context(), testUPID, WithIgnoreWarnings()))
+}
+
+func TestWaitForTaskCancelsOnInterrupt(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		enabled bool
+		stopped bool
+	}{
+		{"disabled", false, false},
+		{"enabled", true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := newTaskServer(t, math.MaxInt, "interrupted by signal", api.WithCancelTasksOnInterrupt(tt.enabled))
+
+			ctx, cancel := context.WithTimeout(t.Context(), 1500*time.Millisecond)
+			defer cancel()
+
+			err := c.WaitForTask(ctx, testUPID)
+			require.ErrorContains(t, err, "timeout while waiting for task")
+
+			status, err := c.GetTaskStatus(t.Context(), testUPID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.stopped, status.Status == "stopped")
+		})
+	}
+}
+
+func TestWaitForTaskWaitsForGuestUnlock(t *testing.T) {
+	t.Parallel()
+
+	c := newTaskServer(t, math.MaxInt, "interrupted by signal", api.WithCancelTasksOnInterrupt(true))
+	c.lockedPolls = 2
+
+	ctx, cancel := context.WithTimeout(t.Context(), 1500*time.Millisecond)
+	defer cancel()
+
+	err := c.WaitForTask(ctx, testUPID)
+	require.ErrorContains(t, err, "the task has been stopped")
+
+	// the guest config is polled until the lock is released
+	assert.Equal(t, 3, c.configPolls)
+}
+
+func TestWaitForTaskDoesNotStopFinishedTask(t *testing.T) {
+	t.Parallel()
+
+	c := newTaskServer(t, 2, "OK", api.WithCancelTasksOnInterrupt(true))
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	// the context is cancelled right after the status poll that sees the task finished
+	c.onFinish = cancel
+
+	require.NoError(t, c.WaitForTask(ctx, testUPID))
+	require.Error(t, ctx.Err())
+	assert.Zero(t, c.deletes)
+}
+
+func TestGuestConfigPaths(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		upid  string
+		paths []string
+	}{
+		{testUPID, []string{"nodes/pve/qemu/100/config"}},
+		{"UPID:pve:000C1A2B:0040C1D2:65A1B2C3:vzclone:101:root@pam:", []string{"nodes/pve/lxc/101/config"}},
+		{"UPID:pve:000C1A2B:0040C1D2:65A1B2C3:vzdump:102:root@pam:", []string{
+			"nodes/pve/qemu/102/config",
+			"nodes/pve/lxc/102/config",
+		}},
+		{"UPID:pve:000C1A2B:0040C1D2:65A1B2C3:vzdump::root@pam:", nil},
+		{"UPID:pve:000C1A2B:0040C1D2:65A1B2C3:aptupdate::root@pam:", nil},
+	}
+
+	for _, tt := range tests {
+		tid, err := ParseTaskID(tt.upid)
+		require.NoError(t, err)
+
+		assert.Equal(t, tt.paths, guestConfigPaths(tid), tt.upid)
+	}
+}
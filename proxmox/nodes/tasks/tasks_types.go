@@ -25,6 +25,12 @@ type GetTaskStatusResponseData struct {
 	ExitCode string `json:"exitstatus,omitempty"`
 }
 
+// GetTaskLogRequestBody contains the query parameters for a node get task log request.
+type GetTaskLogRequestBody struct {
+	Start *int `url:"start,omitempty"`
+	Limit *int `url:"limit,omitempty"`
+}
+
 // GetTaskLogResponseBody contains the body from a node get task log response.
 type GetTaskLogResponseBody struct {
 	Data []*GetTaskLogResponseData `json:"data,omitempty"`
@@ -36,6 +42,13 @@ type GetTaskLogResponseData struct {
 	LineText   string `json:"t,omitempty"`
 }
 
+// guestConfigResponseBody contains the part of a guest configuration relevant for waiting for its lock.
+type guestConfigResponseBody struct {
+	Data *struct {
+		Lock string `json:"lock,omitempty"`
+	} `json:"data,omitempty"`
+}
+
 // TaskID contains the components of a PVE task ID.
 type TaskID struct {
 	NodeName  string
@@ -15,6 +15,7 @@ import (
 	"strings"
 
 	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/version"
 )
 
 // StorageInterfaces is a list of supported storage interfaces.
@@ -32,6 +33,7 @@ type CustomStorageDevice struct {
 	Discard                 *string           `json:"discard,omitempty"     url:"discard,omitempty"`
 	FileVolume              string            `json:"file"                  url:"file"`
 	Format                  *string           `json:"format,omitempty"      url:"format,omitempty"`
+	ImportFrom              *string           `json:"-"                     url:"-"`
 	IopsRead                *int              `json:"iops_rd,omitempty"     url:"iops_rd,omitempty"`
 	IopsWrite               *int              `json:"iops_wr,omitempty"     url:"iops_wr,omitempty"`
 	IOThread                *types.CustomBool `json:"iothread,omitempty"    url:"iothread,omitempty,int"`
@@ -48,6 +50,39 @@ type CustomStorageDevice struct {
 	FileID                  *string           `json:"-"                     url:"-"`
 }
 
+// ImportFromVersions are the first Proxmox VE releases supporting the `import-from` option with
+// volumes of the `images` and `import` content types.
+//
+//nolint:gochecknoglobals
+var ImportFromVersions = map[string]version.ProxmoxVersion{
+	"images": {Major: 7, Minor: 2},
+	"import": {Major: 8, Minor: 4},
+}
+
+// CanImportFrom returns true if a disk can be created from the volume with the `import-from` option, instead of
+// importing it over SSH. Only the volumes of the `images` and `import` content types can be imported,
+// e.g. not the disk images stored as ISO images.
+func CanImportFrom(volumeID string, pveVersion version.ProxmoxVersion) bool {
+	_, volume, found := strings.Cut(volumeID, ":")
+	if !found {
+		// absolute paths can only be imported by `root@pam`
+		return false
+	}
+
+	contentType := "images"
+
+	if dir, _, found := strings.Cut(volume, "/"); found {
+		if _, err := strconv.Atoi(dir); err != nil {
+			// not a `<vmid>/<file>` image of a directory storage
+			contentType = dir
+		}
+	}
+
+	minVersion, ok := ImportFromVersions[contentType]
+
+	return ok && pveVersion.AtLeast(minVersion)
+}
+
 // CustomStorageDevices handles map of QEMU storage device per disk interface.
 type CustomStorageDevices map[string]*CustomStorageDevice
 
@@ -210,6 +245,10 @@ func (d *CustomStorageDevice) EncodeValues(key string, v *url.Values) error {
 		values = append(values, fmt.Sprintf("media=%s", *d.Media))
 	}
 
+	if d.ImportFrom != nil {
+		values = append(values, fmt.Sprintf("import-from=%s", *d.ImportFrom))
+	}
+
 	if d.Size != nil {
 		values = append(values, fmt.Sprintf("size=%d", *d.Size))
 	}
@@ -7,6 +7,7 @@
 package vms
 
 import (
+	"net/url"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/version"
 )
 
 func TestCustomStorageDevice_UnmarshalJSON(t *testing.T) {
@@ -119,3 +121,47 @@ func TestCustomStorageDevice_IsCloudInitDrive(t *testing.T) {
 		})
 	}
 }
+
+func TestCustomStorageDevice_EncodeValues_ImportFrom(t *testing.T) {
+	t.Parallel()
+
+	d := CustomStorageDevice{
+		FileVolume: "local-lvm:0",
+		Format:     ptr.Ptr("qcow2"),
+		ImportFrom: ptr.Ptr("local:import/noble.qcow2"),
+	}
+
+	values := url.Values{}
+	require.NoError(t, d.EncodeValues("scsi0", &values))
+	assert.Contains(t, values.Get("scsi0"), "file=local-lvm:0,format=qcow2,import-from=local:import/noble.qcow2")
+}
+
+func TestCanImportFrom(t *testing.T) {
+	t.Parallel()
+
+	pve7 := version.ProxmoxVersion{Major: 7, Minor: 4}
+	pve8 := version.ProxmoxVersion{Major: 8, Minor: 4, Patch: 1}
+
+	tests := []struct {
+		name       string
+		volumeID   string
+		pveVersion version.ProxmoxVersion
+		want       bool
+	}{
+		{"import volume", "local:import/noble.qcow2", pve8, true},
+		{"import volume on older release", "local:import/noble.qcow2", pve7, false},
+		{"image volume", "local-lvm:vm-100-disk-0", pve7, true},
+		{"image volume of a directory storage", "local:100/vm-100-disk-0.qcow2", pve7, true},
+		{"image volume on older release", "local-lvm:vm-100-disk-0", version.ProxmoxVersion{Major: 7, Minor: 1}, false},
+		{"iso volume", "local:iso/noble.img", pve8, false},
+		{"absolute path", "/var/lib/vz/images/noble.img", pve8, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, CanImportFrom(tt.volumeID, tt.pveVersion))
+		})
+	}
+}
@@ -26,7 +26,12 @@ import (
 
 // CloneVM clones a virtual machine.
 func (c *Client) CloneVM(ctx context.Context, retries int, d *CloneRequestBody) error {
-	var err error
+	release, err := c.acquireTaskSlot(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer release()
 
 	resBody := &MoveDiskResponseBody{}
 
@@ -63,6 +68,13 @@ func (c *Client) CloneVM(ctx context.Context, retries int, d *CloneRequestBody)
 
 // CreateVM creates a virtual machine.
 func (c *Client) CreateVM(ctx context.Context, d *CreateRequestBody) error {
+	release, err := c.acquireTaskSlot(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer release()
+
 	taskID, err := c.CreateVMAsync(ctx, d)
 	if err != nil {
 		return err
@@ -87,7 +99,7 @@ func (c *Client) CreateVMAsync(ctx context.Context, d *CreateRequestBody) (*stri
 	err := retry.Do(
 		func() error {
 			err := c.DoRequest(ctx, http.MethodPost, c.basePath(), d, resBody)
-			if err != nil && retrying && strings.Contains(err.Error(), "already exists") {
+			if err != nil && retrying && errors.Is(err, api.ErrAlreadyExists) {
 				return nil
 			}
 
@@ -122,6 +134,13 @@ func (c *Client) CreateVMAsync(ctx context.Context, d *CreateRequestBody) (*stri
 
 // DeleteVM creates a virtual machine.
 func (c *Client) DeleteVM(ctx context.Context) error {
+	release, err := c.acquireTaskSlot(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer release()
+
 	taskID, err := c.DeleteVMAsync(ctx)
 	if err != nil {
 		return err
@@ -209,6 +228,13 @@ func (c *Client) GetVMStatus(ctx context.Context) (*GetStatusResponseData, error
 
 // MigrateVM migrates a virtual machine.
 func (c *Client) MigrateVM(ctx context.Context, d *MigrateRequestBody) error {
+	release, err := c.acquireTaskSlot(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer release()
+
 	taskID, err := c.MigrateVMAsync(ctx, d)
 	if err != nil {
 		return err
@@ -240,6 +266,13 @@ func (c *Client) MigrateVMAsync(ctx context.Context, d *MigrateRequestBody) (*st
 
 // MoveVMDisk moves a virtual machine disk.
 func (c *Client) MoveVMDisk(ctx context.Context, d *MoveDiskRequestBody) error {
+	release, err := c.acquireTaskSlot(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer release()
+
 	taskID, err := c.MoveVMDiskAsync(ctx, d)
 	if err != nil {
 		if strings.Contains(err.Error(), "you can't move to the same storage with same format") {
@@ -274,6 +307,32 @@ func (c *Client) MoveVMDiskAsync(ctx context.Context, d *MoveDiskRequestBody) (*
 	return resBody.Data, nil
 }
 
+// ImportVMDisk creates a virtual machine disk from an existing volume with the `import-from` option,
+// and waits for the import to complete. The device file volume is the target datastore, e.g. `local-lvm:0`.
+func (c *Client) ImportVMDisk(ctx context.Context, iface string, device CustomStorageDevice) error {
+	release, err := c.acquireTaskSlot(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer release()
+
+	d := &UpdateRequestBody{}
+	d.AddCustomStorageDevice(iface, device)
+
+	taskID, err := c.UpdateVMAsync(ctx, d)
+	if err != nil {
+		return fmt.Errorf("error importing VM disk %s: %w", iface, err)
+	}
+
+	err = c.Tasks().WaitForTask(ctx, *taskID)
+	if err != nil {
+		return fmt.Errorf("error waiting for VM disk %s import: %w", iface, err)
+	}
+
+	return nil
+}
+
 // ListVMs retrieves a list of virtual machines.
 func (c *Client) ListVMs(ctx context.Context) ([]*ListResponseData, error) {
 	resBody := &ListResponseBody{}
@@ -292,6 +351,13 @@ func (c *Client) ListVMs(ctx context.Context) ([]*ListResponseData, error) {
 
 // RebootVM reboots a virtual machine.
 func (c *Client) RebootVM(ctx context.Context, d *RebootRequestBody) error {
+	release, err := c.acquireTaskSlot(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer release()
+
 	taskID, err := c.RebootVMAsync(ctx, d)
 	if err != nil {
 		return err
@@ -323,7 +389,14 @@ func (c *Client) RebootVMAsync(ctx context.Context, d *RebootRequestBody) (*stri
 
 // ResizeVMDisk resizes a virtual machine disk.
 func (c *Client) ResizeVMDisk(ctx context.Context, d *ResizeDiskRequestBody) error {
-	err := retry.Do(
+	release, err := c.acquireTaskSlot(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer release()
+
+	err = retry.Do(
 		func() error {
 			taskID, err := c.ResizeVMDiskAsync(ctx, d)
 			if err != nil {
@@ -337,7 +410,7 @@ func (c *Client) ResizeVMDisk(ctx context.Context, d *ResizeDiskRequestBody) err
 		retry.Delay(1*time.Second),
 		retry.LastErrorOnly(false),
 		retry.RetryIf(func(err error) bool {
-			return strings.Contains(err.Error(), "got timeout")
+			return errors.Is(err, api.ErrConfigLocked)
 		}),
 	)
 	if err != nil {
@@ -365,6 +438,13 @@ func (c *Client) ResizeVMDiskAsync(ctx context.Context, d *ResizeDiskRequestBody
 
 // ShutdownVM shuts down a virtual machine.
 func (c *Client) ShutdownVM(ctx context.Context, d *ShutdownRequestBody) error {
+	release, err := c.acquireTaskSlot(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer release()
+
 	taskID, err := c.ShutdownVMAsync(ctx, d)
 	if err != nil {
 		return err
@@ -397,6 +477,13 @@ func (c *Client) ShutdownVMAsync(ctx context.Context, d *ShutdownRequestBody) (*
 // StartVM starts a virtual machine.
 // Returns the task log if the VM had warnings at startup, or fails to start.
 func (c *Client) StartVM(ctx context.Context, timeoutSec int) ([]string, error) {
+	release, err := c.acquireTaskSlot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer release()
+
 	taskID, err := c.StartVMAsync(ctx, timeoutSec)
 	if err != nil {
 		return nil, err
@@ -404,21 +491,16 @@ func (c *Client) StartVM(ctx context.Context, timeoutSec int) ([]string, error)
 
 	err = c.Tasks().WaitForTask(ctx, *taskID, tasks.WithIgnoreStatus(599))
 	if err != nil {
-		log, e := c.Tasks().GetTaskLog(ctx, *taskID)
-		if e != nil {
-			tflog.Error(ctx, "error retrieving task log", map[string]interface{}{
-				"task_id": *taskID,
-				"error":   e.Error(),
-			})
-
-			log = []string{}
+		var taskErr *tasks.TaskError
+		if !errors.As(err, &taskErr) {
+			return nil, fmt.Errorf("error waiting for VM start: %w", err)
 		}
 
-		if strings.Contains(err.Error(), "WARNING") && len(log) > 0 {
-			return log, nil
+		if strings.Contains(taskErr.ExitStatus, "WARNING") && len(taskErr.Log) > 0 {
+			return taskErr.Log, nil
 		}
 
-		return log, fmt.Errorf("error waiting for VM start: %w", err)
+		return taskErr.Log, fmt.Errorf("error waiting for VM start: %w", err)
 	}
 
 	return nil, nil
@@ -462,6 +544,13 @@ func (c *Client) StartVMAsync(ctx context.Context, timeoutSec int) (*string, err
 
 // StopVM stops a virtual machine.
 func (c *Client) StopVM(ctx context.Context) error {
+	release, err := c.acquireTaskSlot(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer release()
+
 	taskID, err := c.StopVMAsync(ctx)
 	if err != nil {
 		return err
@@ -491,20 +580,10 @@ func (c *Client) StopVMAsync(ctx context.Context) (*string, error) {
 	return resBody.Data, nil
 }
 
-// UpdateVM updates a virtual machine.
+// UpdateVM updates a virtual machine. A request rejected because the configuration is locked is retried
+// according to the retry policy of the API client.
 func (c *Client) UpdateVM(ctx context.Context, d *UpdateRequestBody) error {
-	err := retry.Do(
-		func() error {
-			return c.DoRequest(ctx, http.MethodPut, c.ExpandPath("config"), d, nil)
-		},
-		retry.Context(ctx),
-		retry.Attempts(3),
-		retry.Delay(1*time.Second),
-		retry.LastErrorOnly(true),
-		retry.RetryIf(func(err error) bool {
-			return strings.Contains(err.Error(), "got timeout")
-		}),
-	)
+	err := c.DoRequest(ctx, http.MethodPut, c.ExpandPath("config"), d, nil)
 	if err != nil {
 		return fmt.Errorf("error updating VM: %w", err)
 	}
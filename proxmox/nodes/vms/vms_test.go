@@ -0,0 +1,110 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package vms
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/fake"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes/storage"
+)
+
+// nodeClient scopes the API client to a node, as the nodes client does.
+type nodeClient struct {
+	api.Client
+	nodeName string
+}
+
+func (c *nodeClient) ExpandPath(path string) string {
+	return fmt.Sprintf("nodes/%s/%s", c.nodeName, path)
+}
+
+func TestImportVMDisk(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	srv := fake.NewServer()
+	t.Cleanup(srv.Close)
+
+	apiClient, err := srv.NewClient()
+	require.NoError(t, err)
+
+	nc := &nodeClient{Client: apiClient, nodeName: fake.DefaultNodeName}
+
+	err = (&storage.Client{Client: nc, StorageName: "local"}).DownloadFileByURL(ctx, &storage.DownloadURLPostRequestBody{
+		Content:  ptr.Ptr("import"),
+		FileName: ptr.Ptr("noble.qcow2"),
+		URL:      ptr.Ptr("https://cloud-images.example.com/noble.qcow2"),
+	})
+	require.NoError(t, err)
+
+	vm := &Client{Client: nc, NodeName: fake.DefaultNodeName, VMID: 100}
+	require.NoError(t, vm.CreateVM(ctx, &CreateRequestBody{VMID: 100}))
+
+	err = vm.ImportVMDisk(ctx, "scsi0", CustomStorageDevice{
+		FileVolume: "local-lvm:0",
+		Format:     ptr.Ptr("raw"),
+		ImportFrom: ptr.Ptr("local:import/noble.qcow2"),
+	})
+	require.NoError(t, err)
+
+	data, err := vm.GetVM(ctx)
+	require.NoError(t, err)
+	require.Contains(t, data.StorageDevices, "scsi0")
+	assert.Equal(t, "local-lvm:vm-100-disk-0", data.StorageDevices["scsi0"].FileVolume)
+
+	err = vm.ImportVMDisk(ctx, "scsi1", CustomStorageDevice{
+		FileVolume: "local-lvm:0",
+		ImportFrom: ptr.Ptr("local:import/missing.qcow2"),
+	})
+	require.Error(t, err)
+}
+
+func TestUpdateVMLocked(t *testing.T) {
+	t.Parallel()
+
+	var puts atomic.Int32
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			puts.Add(1)
+		}
+
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"errors":{"config":"can't lock file '/var/lock/qemu-server/lock-100.conf' - got timeout"}}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	conn, err := api.NewConnection(srv.URL, true, "",
+		api.WithRetryPolicy(api.RetryPolicy{Attempts: 2, InitialDelay: time.Millisecond}),
+	)
+	require.NoError(t, err)
+
+	creds, err := api.NewCredentials("", "", "", fake.DefaultAPIToken, "", "")
+	require.NoError(t, err)
+
+	apiClient, err := api.NewClient(creds, conn)
+	require.NoError(t, err)
+
+	vm := &Client{Client: &nodeClient{Client: apiClient, nodeName: fake.DefaultNodeName}, VMID: 100}
+
+	err = vm.UpdateVM(context.Background(), &UpdateRequestBody{})
+	require.ErrorIs(t, err, api.ErrConfigLocked)
+	assert.Equal(t, int32(2), puts.Load(), "only the retry policy of the API client must apply")
+}
@@ -0,0 +1,57 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package proxmox
+
+import (
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/ssh"
+)
+
+// SharedState is the state shared by the SDK and framework providers served by the same provider instance,
+// so the providers do not duplicate the work of each other, e.g. run the credentials command twice, or keep two
+// SSH connections to each node, and apply the API limits together. A nil SharedState does not share anything.
+type SharedState struct {
+	credentialsCommands *api.CredentialsCommands
+	limiters            *api.SharedLimiters
+	sshPool             *ssh.SharedPool
+}
+
+// NewSharedState creates the state to share between the providers of a provider instance.
+func NewSharedState() *SharedState {
+	return &SharedState{
+		credentialsCommands: api.NewCredentialsCommands(),
+		limiters:            api.NewSharedLimiters(),
+		sshPool:             ssh.NewSharedPool(),
+	}
+}
+
+// CredentialsCommands returns the runner of the credentials commands, nil if the state is not shared.
+func (s *SharedState) CredentialsCommands() *api.CredentialsCommands {
+	if s == nil {
+		return nil
+	}
+
+	return s.credentialsCommands
+}
+
+// Limiters returns the state of the API limits of the endpoints, nil if the state is not shared.
+func (s *SharedState) Limiters() *api.SharedLimiters {
+	if s == nil {
+		return nil
+	}
+
+	return s.limiters
+}
+
+// SSHPool returns the pool of the SSH connections to the nodes, nil if the state is not shared.
+func (s *SharedState) SSHPool() *ssh.SharedPool {
+	if s == nil {
+		return nil
+	}
+
+	return s.sshPool
+}
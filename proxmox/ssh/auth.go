@@ -0,0 +1,117 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package ssh
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// WithPrivateKeyPassphrase sets the passphrase the private key is encrypted with.
+func WithPrivateKeyPassphrase(passphrase string) ClientOption {
+	return func(c *client) error {
+		c.privateKeyPassphrase = passphrase
+
+		return nil
+	}
+}
+
+// WithCertificate sets the OpenSSH user certificate, in the authorized_keys format of `id_*-cert.pub` files,
+// signed by a CA trusted by the nodes. The certificate is used with the private key, or with the matching
+// key of the SSH agent.
+func WithCertificate(certificate string) ClientOption {
+	return func(c *client) error {
+		if certificate == "" {
+			return nil
+		}
+
+		pub, _, _, _, err := ssh.ParseAuthorizedKey([]byte(certificate))
+		if err != nil {
+			return fmt.Errorf("failed to parse the SSH certificate: %w", err)
+		}
+
+		cert, ok := pub.(*ssh.Certificate)
+		if !ok {
+			return fmt.Errorf("the SSH certificate is a %s public key, not a certificate", pub.Type())
+		}
+
+		if cert.CertType != ssh.UserCert {
+			return errors.New("the SSH certificate is not a user certificate")
+		}
+
+		c.certificate = cert
+
+		return nil
+	}
+}
+
+// privateKeySigner returns the signer of the private key, using the certificate if configured.
+func (c *client) privateKeySigner() (ssh.Signer, error) {
+	var (
+		signer ssh.Signer
+		err    error
+	)
+
+	if c.privateKeyPassphrase != "" {
+		signer, err = ssh.ParsePrivateKeyWithPassphrase([]byte(c.privateKey), []byte(c.privateKeyPassphrase))
+	} else {
+		signer, err = ssh.ParsePrivateKey([]byte(c.privateKey))
+	}
+
+	if err != nil {
+		var missing *ssh.PassphraseMissingError
+		if errors.As(err, &missing) {
+			return nil, errors.New("the private key is encrypted, but no passphrase is configured")
+		}
+
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+
+	if c.certificate == nil {
+		return signer, nil
+	}
+
+	certSigner, err := ssh.NewCertSigner(c.certificate, signer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to use the SSH certificate with the private key: %w", err)
+	}
+
+	return certSigner, nil
+}
+
+// agentSigners returns the signers of the SSH agent. If a certificate is configured, the agent keys matching
+// the certificate are offered with it first, followed by the agent keys as they are.
+func (c *client) agentSigners(signers func() ([]ssh.Signer, error)) func() ([]ssh.Signer, error) {
+	return func() ([]ssh.Signer, error) {
+		agentSigners, err := signers()
+		if err != nil || c.certificate == nil {
+			return agentSigners, err //nolint:wrapcheck
+		}
+
+		certKey := c.certificate.Key.Marshal()
+
+		var result []ssh.Signer
+
+		for _, s := range agentSigners {
+			if !bytes.Equal(s.PublicKey().Marshal(), certKey) {
+				continue
+			}
+
+			certSigner, err := ssh.NewCertSigner(c.certificate, s)
+			if err != nil {
+				return nil, fmt.Errorf("failed to use the SSH certificate with the agent key: %w", err)
+			}
+
+			result = append(result, certSigner)
+		}
+
+		return append(result, agentSigners...), nil
+	}
+}
@@ -0,0 +1,192 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package ssh
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+const testPassphrase = "correct horse battery staple"
+
+type testUserKey struct {
+	priv ed25519.PrivateKey
+	// encrypted is the private key in PEM format, encrypted with testPassphrase
+	encrypted string
+	// certificate is the user certificate of the key, in the authorized_keys format
+	certificate string
+}
+
+// newTestUserKey generates a user key, and its certificate signed by the CA.
+func newTestUserKey(t *testing.T, ca ssh.Signer) testUserKey {
+	t.Helper()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	block, err := ssh.MarshalPrivateKeyWithPassphrase(priv, "", []byte(testPassphrase))
+	require.NoError(t, err)
+
+	sshPub, err := ssh.NewPublicKey(pub)
+	require.NoError(t, err)
+
+	cert := &ssh.Certificate{
+		Key:             sshPub,
+		KeyId:           "test",
+		CertType:        ssh.UserCert,
+		ValidPrincipals: []string{testUsername},
+		ValidBefore:     ssh.CertTimeInfinity,
+	}
+	require.NoError(t, cert.SignCert(rand.Reader, ca))
+
+	return testUserKey{
+		priv:        priv,
+		encrypted:   string(pem.EncodeToMemory(block)),
+		certificate: string(ssh.MarshalAuthorizedKey(cert)),
+	}
+}
+
+func newTestCA(t *testing.T) ssh.Signer {
+	t.Helper()
+
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	signer, err := ssh.NewSignerFromKey(priv)
+	require.NoError(t, err)
+
+	return signer
+}
+
+// serveAgent serves an SSH agent holding the key, and returns the path of its socket.
+func serveAgent(t *testing.T, key ed25519.PrivateKey) string {
+	t.Helper()
+
+	keyring := agent.NewKeyring()
+	require.NoError(t, keyring.Add(agent.AddedKey{PrivateKey: key}))
+
+	// the socket path must be short, so t.TempDir is not used
+	dir, err := os.MkdirTemp("", "agent")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	socket := filepath.Join(dir, "agent.sock")
+
+	l, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = l.Close() })
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				_ = agent.ServeAgent(keyring, conn)
+			}()
+		}
+	}()
+
+	return socket
+}
+
+func TestCertificateAuthentication(t *testing.T) {
+	t.Parallel()
+
+	ca := newTestCA(t)
+	key := newTestUserKey(t, ca)
+
+	tests := []struct {
+		name      string
+		newClient func(node *testServer) (Client, error)
+	}{
+		{"encrypted private key", func(node *testServer) (Client, error) {
+			return NewClient(
+				testUsername, "", false, "", key.encrypted, "", "", "",
+				staticResolver{"pve": node.node()},
+				WithHostKeyVerification(HostKeyVerificationTOFU, filepath.Join(t.TempDir(), "known_hosts"), ""),
+				WithPrivateKeyPassphrase(testPassphrase),
+				WithCertificate(key.certificate),
+			)
+		}},
+		{"agent key", func(node *testServer) (Client, error) {
+			return NewClient(
+				testUsername, "", true, serveAgent(t, key.priv), "", "", "", "",
+				staticResolver{"pve": node.node()},
+				WithHostKeyVerification(HostKeyVerificationTOFU, filepath.Join(t.TempDir(), "known_hosts"), ""),
+				WithCertificate(key.certificate),
+			)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// the node only accepts the certificates signed by the CA, not the plain key
+			node := newTestServer(t, ca.PublicKey())
+
+			c, err := tt.newClient(node)
+			require.NoError(t, err)
+
+			out, err := c.ExecuteNodeCommands(t.Context(), "pve", []string{"echo hello"})
+			require.NoError(t, err)
+			assert.Equal(t, `executed: /bin/bash -c 'echo hello'`, string(out))
+		})
+	}
+}
+
+func TestPrivateKeySigner(t *testing.T) {
+	t.Parallel()
+
+	key := newTestUserKey(t, newTestCA(t))
+	other := newTestUserKey(t, newTestCA(t))
+
+	c := &client{privateKey: key.encrypted}
+	_, err := c.privateKeySigner()
+	require.ErrorContains(t, err, "no passphrase is configured")
+
+	require.NoError(t, WithPrivateKeyPassphrase(testPassphrase)(c))
+	signer, err := c.privateKeySigner()
+	require.NoError(t, err)
+	assert.Equal(t, ssh.KeyAlgoED25519, signer.PublicKey().Type())
+
+	require.NoError(t, WithCertificate(key.certificate)(c))
+	signer, err = c.privateKeySigner()
+	require.NoError(t, err)
+	assert.Equal(t, ssh.CertAlgoED25519v01, signer.PublicKey().Type())
+
+	// the certificate of another key
+	require.NoError(t, WithCertificate(other.certificate)(c))
+	_, err = c.privateKeySigner()
+	require.ErrorContains(t, err, "failed to use the SSH certificate")
+}
+
+func TestWithCertificateValidation(t *testing.T) {
+	t.Parallel()
+
+	require.Error(t, WithCertificate("not a certificate")(&client{}))
+
+	plain := string(ssh.MarshalAuthorizedKey(newHostKey(t)))
+	require.ErrorContains(t, WithCertificate(plain)(&client{}), "not a certificate")
+
+	c := &client{}
+	require.NoError(t, WithCertificate("")(c))
+	assert.Nil(t, c.certificate)
+}
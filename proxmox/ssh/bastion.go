@@ -0,0 +1,199 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package ssh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+// Bastion is an SSH jump host the connections to the nodes are tunneled through, like OpenSSH's ProxyJump.
+type Bastion struct {
+	Address string
+	Port    int32
+	// Username defaults to the username of the connections to the nodes.
+	Username   string
+	Password   string
+	PrivateKey string
+	Agent      bool
+
+	// the verification of the bastion host key, see WithHostKeyVerification
+	HostKeyVerification HostKeyVerification
+	KnownHostsFile      string
+	KnownHosts          string
+}
+
+type bastion struct {
+	Bastion
+
+	hostKeys hostKeyPolicy
+}
+
+// WithBastions tunnels the connections to the nodes through the bastions, in the given order: the first bastion
+// is dialed directly, or through the SOCKS5 proxy if configured, and each next host through the previous one.
+func WithBastions(bastions ...Bastion) ClientOption {
+	return func(c *client) error {
+		for _, b := range bastions {
+			if b.Address == "" {
+				return errors.New("the bastion address is required")
+			}
+
+			if b.Port == 0 {
+				b.Port = 22
+			}
+
+			if b.Password == "" && b.PrivateKey == "" && !b.Agent {
+				return fmt.Errorf("no authentication method is configured for the bastion %s", b.Address)
+			}
+
+			policy, err := newHostKeyPolicy(b.HostKeyVerification, b.KnownHostsFile, b.KnownHosts)
+			if err != nil {
+				return fmt.Errorf("invalid host key verification of the bastion %s: %w", b.Address, err)
+			}
+
+			c.bastions = append(c.bastions, bastion{Bastion: b, hostKeys: policy})
+		}
+
+		return nil
+	}
+}
+
+// connectViaBastions connects to the SSH host through the chain of bastions. The connections to the
+// bastions are closed once the connection to the host is closed.
+func (c *client) connectViaBastions(
+	ctx context.Context,
+	sshHost string,
+	sshConfig *ssh.ClientConfig,
+) (*ssh.Client, error) {
+	hops := make([]*ssh.Client, 0, len(c.bastions))
+
+	closeHops := func() {
+		for i := len(hops) - 1; i >= 0; i-- {
+			_ = hops[i].Close()
+		}
+	}
+
+	for i, b := range c.bastions {
+		addr := net.JoinHostPort(b.Address, strconv.Itoa(int(b.Port)))
+
+		cfg, err := c.bastionConfig(ctx, b, addr)
+		if err != nil {
+			closeHops()
+
+			return nil, err
+		}
+
+		var hop *ssh.Client
+
+		if i == 0 {
+			hop, err = c.dial(ctx, addr, cfg)
+		} else {
+			hop, err = dialThrough(hops[i-1], addr, cfg)
+		}
+
+		if err != nil {
+			closeHops()
+
+			return nil, fmt.Errorf("failed to connect to the bastion %s: %w", addr, err)
+		}
+
+		tflog.Debug(ctx, "SSH connection to bastion established", map[string]interface{}{
+			"host": addr,
+			"user": cfg.User,
+		})
+
+		hops = append(hops, hop)
+	}
+
+	sshClient, err := dialThrough(hops[len(hops)-1], sshHost, sshConfig)
+	if err != nil {
+		closeHops()
+
+		return nil, fmt.Errorf("failed to dial %s via bastion: %w", sshHost, err)
+	}
+
+	tflog.Debug(ctx, "SSH connection via bastion established", map[string]interface{}{
+		"host": sshHost,
+		"user": sshConfig.User,
+	})
+
+	go func() {
+		_ = sshClient.Wait()
+
+		closeHops()
+	}()
+
+	return sshClient, nil
+}
+
+// bastionConfig returns the SSH client configuration for the bastion.
+func (c *client) bastionConfig(ctx context.Context, b bastion, addr string) (*ssh.ClientConfig, error) {
+	cb, algos, err := b.hostKeys.callback(ctx, "", ProxmoxNode{Address: b.Address, Port: b.Port}, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	var auth []ssh.AuthMethod
+
+	if b.Agent {
+		conn, err := dialSocket(c.agentSocket)
+		if err != nil {
+			return nil, fmt.Errorf("failed connecting to SSH auth socket '%s': %w", c.agentSocket, err)
+		}
+
+		auth = append(auth, ssh.PublicKeysCallback(agent.NewClient(conn).Signers))
+	}
+
+	if b.PrivateKey != "" {
+		privateKey, err := ssh.ParsePrivateKey([]byte(b.PrivateKey))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse private key of the bastion %s: %w", b.Address, err)
+		}
+
+		auth = append(auth, ssh.PublicKeys(privateKey))
+	}
+
+	if b.Password != "" {
+		auth = append(auth, ssh.Password(b.Password))
+	}
+
+	username := b.Username
+	if username == "" {
+		username = c.username
+	}
+
+	return &ssh.ClientConfig{
+		User:              username,
+		Auth:              auth,
+		HostKeyCallback:   cb,
+		HostKeyAlgorithms: algos,
+	}, nil
+}
+
+// dialThrough connects to the SSH host through an established SSH connection.
+func dialThrough(via *ssh.Client, sshHost string, sshConfig *ssh.ClientConfig) (*ssh.Client, error) {
+	conn, err := via.Dial("tcp", sshHost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open a tunnel to %s: %w", sshHost, err)
+	}
+
+	sshConn, ch, reqs, err := ssh.NewClientConn(conn, sshHost, sshConfig)
+	if err != nil {
+		_ = conn.Close()
+
+		return nil, fmt.Errorf("failed to create SSH client connection: %w", err)
+	}
+
+	return ssh.NewClient(sshConn, ch, reqs), nil
+}
@@ -0,0 +1,108 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package ssh
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/skeema/knownhosts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecuteNodeCommandsViaBastions(t *testing.T) {
+	t.Parallel()
+
+	node := newTestServer(t)
+	jump1 := newTestServer(t)
+	jump2 := newTestServer(t)
+
+	// each host has its own host key policy
+	jump2KnownHosts := knownhosts.Line([]string{jump2.addr}, jump2.hostKey)
+
+	c, err := NewClient(
+		testUsername, testPassword, false, "", "", "", "", "",
+		staticResolver{"pve": node.node()},
+		WithHostKeyVerification(HostKeyVerificationTOFU, filepath.Join(t.TempDir(), "known_hosts"), ""),
+		WithBastions(
+			Bastion{
+				Address:             jump1.node().Address,
+				Port:                jump1.node().Port,
+				Password:            testPassword,
+				HostKeyVerification: HostKeyVerificationTOFU,
+				KnownHostsFile:      filepath.Join(t.TempDir(), "known_hosts"),
+			},
+			Bastion{
+				Address:             jump2.node().Address,
+				Port:                jump2.node().Port,
+				Username:            testUsername,
+				Password:            testPassword,
+				HostKeyVerification: HostKeyVerificationStrict,
+				KnownHosts:          jump2KnownHosts,
+			},
+		),
+	)
+	require.NoError(t, err)
+
+	out, err := c.ExecuteNodeCommands(t.Context(), "pve", []string{"echo hello"})
+	require.NoError(t, err)
+	assert.Equal(t, `executed: /bin/bash -c 'echo hello'`, string(out))
+
+	assert.EqualValues(t, 1, jump1.connections.Load())
+	assert.EqualValues(t, 1, jump2.connections.Load())
+	assert.EqualValues(t, 1, node.connections.Load())
+
+	// the connections to the bastions are closed with the pooled connection to the node
+	require.NoError(t, c.(*client).pool.nodes["pve"].conn.Close())
+	assert.Eventually(t, func() bool {
+		return jump1.active.Load() == 0 && jump2.active.Load() == 0 && node.active.Load() == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestBastionHostKeyVerification(t *testing.T) {
+	t.Parallel()
+
+	node := newTestServer(t)
+	jump := newTestServer(t)
+	other := newTestServer(t)
+
+	c, err := NewClient(
+		testUsername, testPassword, false, "", "", "", "", "",
+		staticResolver{"pve": node.node()},
+		WithHostKeyVerification(HostKeyVerificationTOFU, filepath.Join(t.TempDir(), "known_hosts"), ""),
+		WithBastions(Bastion{
+			Address:             jump.node().Address,
+			Port:                jump.node().Port,
+			Password:            testPassword,
+			HostKeyVerification: HostKeyVerificationStrict,
+			KnownHosts:          knownhosts.Line([]string{jump.addr}, other.hostKey),
+		}),
+	)
+	require.NoError(t, err)
+
+	_, err = c.ExecuteNodeCommands(t.Context(), "pve", []string{"echo hello"})
+	require.ErrorContains(t, err, "does not match the known hosts")
+	assert.EqualValues(t, 0, node.connections.Load())
+}
+
+func TestWithBastionsValidation(t *testing.T) {
+	t.Parallel()
+
+	require.Error(t, WithBastions(Bastion{Password: testPassword})(&client{}))
+	require.Error(t, WithBastions(Bastion{Address: "10.0.0.1"})(&client{}))
+	require.Error(t, WithBastions(Bastion{
+		Address:             "10.0.0.1",
+		Password:            testPassword,
+		HostKeyVerification: HostKeyVerificationKnownHostsFile,
+	})(&client{}))
+
+	c := &client{}
+	require.NoError(t, WithBastions(Bastion{Address: "10.0.0.1", Agent: true})(c))
+	assert.EqualValues(t, 22, c.bastions[0].Port)
+}
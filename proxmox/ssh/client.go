@@ -11,9 +11,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"net"
 	"os"
-	"path"
 	"path/filepath"
 	"runtime"
 	"strconv"
@@ -21,13 +19,11 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/pkg/sftp"
-	"github.com/skeema/knownhosts"
 	"golang.org/x/crypto/ssh"
 	"golang.org/x/crypto/ssh/agent"
 	"golang.org/x/net/proxy"
 
 	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
-	"github.com/bpg/terraform-provider-proxmox/utils"
 )
 
 const (
@@ -69,6 +65,17 @@ type client struct {
 	socks5Username string
 	socks5Password string
 	nodeResolver   NodeResolver
+
+	// privateKeyPassphrase and certificate complete the key authentication, see auth.go
+	privateKeyPassphrase string
+	certificate          *ssh.Certificate
+
+	// hostKeys is the verification of the host keys of the nodes, see hostkeys.go
+	hostKeys hostKeyPolicy
+	// bastions are the jump hosts the connections are tunneled through, see bastion.go
+	bastions []bastion
+	// pool keeps the connections to the nodes, see pool.go
+	pool *connPool
 }
 
 // NewClient creates a new SSH client.
@@ -78,6 +85,7 @@ func NewClient(
 	privateKey string,
 	socks5Server string, socks5Username string, socks5Password string,
 	nodeResolver NodeResolver,
+	opts ...ClientOption,
 ) (Client, error) {
 	if agent &&
 		runtime.GOOS != "linux" &&
@@ -98,7 +106,7 @@ func NewClient(
 		return nil, errors.New("node resolver is required")
 	}
 
-	return &client{
+	c := &client{
 		username:       username,
 		password:       password,
 		agent:          agent,
@@ -108,7 +116,20 @@ func NewClient(
 		socks5Username: socks5Username,
 		socks5Password: socks5Password,
 		nodeResolver:   nodeResolver,
-	}, nil
+		hostKeys:       hostKeyPolicy{mode: HostKeyVerificationTOFU},
+		pool: newConnPool(PoolConfig{
+			MaxSessionsPerNode: DefaultMaxSessionsPerNode,
+			KeepAliveInterval:  DefaultKeepAliveInterval,
+		}),
+	}
+
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
 }
 
 func (c *client) Username() string {
@@ -128,21 +149,13 @@ func (c *client) ExecuteNodeCommands(ctx context.Context, nodeName string, comma
 		"commands":     commands,
 	})
 
-	sshClient, err := c.openNodeShell(ctx, node)
-	if err != nil {
-		return nil, err
-	}
+	var output []byte
 
-	defer func(sshClient *ssh.Client) {
-		e := sshClient.Close()
-		if e != nil {
-			tflog.Warn(ctx, "failed to close SSH client", map[string]interface{}{
-				"error": e,
-			})
-		}
-	}(sshClient)
+	err = c.withNodeConnection(ctx, nodeName, node, func(sshClient *ssh.Client) error {
+		output, err = c.executeCommands(ctx, sshClient, commands)
 
-	output, err := c.executeCommands(ctx, sshClient, commands)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -207,26 +220,25 @@ func (c *client) NodeUpload(
 
 	fileSize := fileInfo.Size()
 
-	sshClient, err := c.openNodeShell(ctx, ip)
-	if err != nil {
-		return fmt.Errorf("failed to open SSH client: %w", err)
-	}
-
-	defer func(sshClient *ssh.Client) {
-		e := sshClient.Close()
-		if e != nil {
-			tflog.Warn(ctx, "failed to close SSH client", map[string]interface{}{
-				"error": e,
-			})
-		}
-	}(sshClient)
-
 	if d.ContentType != "" {
 		remoteFileDir = filepath.Join(remoteFileDir, d.ContentType)
 	}
 
 	remoteFilePath := strings.ReplaceAll(filepath.Join(remoteFileDir, d.FileName), `\`, "/")
 
+	return c.withNodeConnection(ctx, nodeName, ip, func(sshClient *ssh.Client) error {
+		return c.sftpUpload(ctx, sshClient, d, remoteFileDir, remoteFilePath, fileSize)
+	})
+}
+
+func (c *client) sftpUpload(
+	ctx context.Context,
+	sshClient *ssh.Client,
+	d *api.FileUploadRequest,
+	remoteFileDir string,
+	remoteFilePath string,
+	fileSize int64,
+) error {
 	sftpClient, err := sftp.NewClient(sshClient)
 	if err != nil {
 		return fmt.Errorf("failed to create SFTP client: %w", err)
@@ -303,27 +315,25 @@ func (c *client) NodeStreamUpload(
 
 	fileSize := fileInfo.Size()
 
-	sshClient, err := c.openNodeShell(ctx, ip)
-	if err != nil {
-		return fmt.Errorf("failed to open SSH client: %w", err)
-	}
-
-	defer func(sshClient *ssh.Client) {
-		e := sshClient.Close()
-		if e != nil {
-			tflog.Warn(ctx, "failed to close SSH client", map[string]interface{}{
-				"error": e,
-			})
-		}
-	}(sshClient)
-
 	if d.ContentType != "" {
 		remoteFileDir = filepath.Join(remoteFileDir, d.ContentType)
 	}
 
 	remoteFilePath := strings.ReplaceAll(filepath.Join(remoteFileDir, d.FileName), `\`, "/")
 
-	err = c.uploadFile(ctx, sshClient, d, remoteFilePath)
+	return c.withNodeConnection(ctx, nodeName, ip, func(sshClient *ssh.Client) error {
+		return c.streamUpload(ctx, sshClient, d, remoteFilePath, fileSize)
+	})
+}
+
+func (c *client) streamUpload(
+	ctx context.Context,
+	sshClient *ssh.Client,
+	d *api.FileUploadRequest,
+	remoteFilePath string,
+	fileSize int64,
+) error {
+	err := c.uploadFile(ctx, sshClient, d, remoteFilePath)
 	if err != nil {
 		return err
 	}
@@ -466,13 +476,9 @@ func (c *client) changeModeUploadedFile(
 	return nil
 }
 
-// openNodeShell establishes a new SSH connection to a node.
-func (c *client) openNodeShell(ctx context.Context, node ProxmoxNode) (*ssh.Client, error) {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return nil, fmt.Errorf("failed to determine the home directory: %w", err)
-	}
-
+// openNodeShell establishes a new SSH connection to a node. The connections are reused through the pool,
+// see withNodeConnection.
+func (c *client) openNodeShell(ctx context.Context, nodeName string, node ProxmoxNode) (*ssh.Client, error) {
 	var sshHost string
 	if strings.Contains(node.Address, ":") {
 		// IPv6
@@ -482,61 +488,16 @@ func (c *client) openNodeShell(ctx context.Context, node ProxmoxNode) (*ssh.Clie
 		sshHost = fmt.Sprintf("%s:%d", node.Address, node.Port)
 	}
 
-	sshPath := path.Join(homeDir, ".ssh")
-	if _, err = os.Stat(sshPath); os.IsNotExist(err) {
-		e := os.Mkdir(sshPath, 0o700)
-		if e != nil && !os.IsExist(e) {
-			return nil, fmt.Errorf("failed to create %s: %w", sshPath, e)
-		}
-	}
-
-	khPath := path.Join(sshPath, "known_hosts")
-	if _, err = os.Stat(khPath); os.IsNotExist(err) {
-		e := os.WriteFile(khPath, []byte{}, 0o600)
-		if e != nil {
-			return nil, fmt.Errorf("failed to create %s: %w", khPath, e)
-		}
-	}
-
-	kh, err := knownhosts.New(khPath)
+	cb, algos, err := c.hostKeys.callback(ctx, nodeName, node, sshHost)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read %s: %w", khPath, err)
+		return nil, err
 	}
 
-	// Create a custom permissive host key callback which still errors on hosts
-	// with changed keys, but allows unknown hosts and adds them to known_hosts
-	cb := ssh.HostKeyCallback(func(hostname string, remote net.Addr, key ssh.PublicKey) error {
-		khErr := kh(hostname, remote, key)
-		if knownhosts.IsHostKeyChanged(khErr) {
-			return fmt.Errorf("REMOTE HOST IDENTIFICATION HAS CHANGED for host %s! This may indicate a MitM attack", hostname)
-		}
-
-		if knownhosts.IsHostUnknown(khErr) {
-			f, fErr := os.OpenFile(khPath, os.O_APPEND|os.O_WRONLY, 0o600)
-			if fErr == nil {
-				defer utils.CloseOrLogError(ctx)(f)
-				fErr = knownhosts.WriteKnownHost(f, hostname, remote, key)
-			}
-
-			if fErr == nil {
-				tflog.Info(ctx, fmt.Sprintf("Added host %s to known_hosts", hostname))
-			} else {
-				tflog.Error(ctx, fmt.Sprintf("Failed to add host %s to known_hosts", hostname), map[string]interface{}{
-					"error": khErr,
-				})
-			}
-
-			return nil
-		}
-
-		return khErr
-	})
-
 	tflog.Info(ctx, fmt.Sprintf("agent is set to %t", c.agent))
 
 	var sshClient *ssh.Client
 	if c.agent {
-		sshClient, err = c.createSSHClientAgent(ctx, cb, kh, sshHost)
+		sshClient, err = c.createSSHClientAgent(ctx, cb, algos, sshHost)
 		if err == nil {
 			return sshClient, nil
 		}
@@ -548,7 +509,7 @@ func (c *client) openNodeShell(ctx context.Context, node ProxmoxNode) (*ssh.Clie
 	}
 
 	if c.privateKey != "" {
-		sshClient, err = c.createSSHClientWithPrivateKey(ctx, cb, kh, sshHost)
+		sshClient, err = c.createSSHClientWithPrivateKey(ctx, cb, algos, sshHost)
 		if err == nil {
 			return sshClient, nil
 		}
@@ -561,7 +522,7 @@ func (c *client) openNodeShell(ctx context.Context, node ProxmoxNode) (*ssh.Clie
 
 	tflog.Info(ctx, "Falling back to password authentication for SSH connection")
 
-	sshClient, err = c.createSSHClient(ctx, cb, kh, sshHost)
+	sshClient, err = c.createSSHClient(ctx, cb, algos, sshHost)
 	if err != nil {
 		return nil, fmt.Errorf("unable to authenticate user %q over SSH to %q. Please verify that ssh-agent is "+
 			"correctly loaded with an authorized key via 'ssh-add -L' (NOTE: configurations in ~/.ssh/config are "+
@@ -574,7 +535,7 @@ func (c *client) openNodeShell(ctx context.Context, node ProxmoxNode) (*ssh.Clie
 func (c *client) createSSHClient(
 	ctx context.Context,
 	cb ssh.HostKeyCallback,
-	kh knownhosts.HostKeyCallback,
+	hostKeyAlgorithms []string,
 	sshHost string,
 ) (*ssh.Client, error) {
 	if c.password == "" {
@@ -585,7 +546,7 @@ func (c *client) createSSHClient(
 		User:              c.username,
 		Auth:              []ssh.AuthMethod{ssh.Password(c.password)},
 		HostKeyCallback:   cb,
-		HostKeyAlgorithms: kh.HostKeyAlgorithms(sshHost),
+		HostKeyAlgorithms: hostKeyAlgorithms,
 	}
 
 	return c.connect(ctx, sshHost, sshConfig)
@@ -595,7 +556,7 @@ func (c *client) createSSHClient(
 func (c *client) createSSHClientAgent(
 	ctx context.Context,
 	cb ssh.HostKeyCallback,
-	kh knownhosts.HostKeyCallback,
+	hostKeyAlgorithms []string,
 	sshHost string,
 ) (*ssh.Client, error) {
 	conn, err := dialSocket(c.agentSocket)
@@ -607,9 +568,9 @@ func (c *client) createSSHClientAgent(
 
 	sshConfig := &ssh.ClientConfig{
 		User:              c.username,
-		Auth:              []ssh.AuthMethod{ssh.PublicKeysCallback(ag.Signers), ssh.Password(c.password)},
+		Auth:              []ssh.AuthMethod{ssh.PublicKeysCallback(c.agentSigners(ag.Signers)), ssh.Password(c.password)},
 		HostKeyCallback:   cb,
-		HostKeyAlgorithms: kh.HostKeyAlgorithms(sshHost),
+		HostKeyAlgorithms: hostKeyAlgorithms,
 	}
 
 	return c.connect(ctx, sshHost, sshConfig)
@@ -618,25 +579,34 @@ func (c *client) createSSHClientAgent(
 func (c *client) createSSHClientWithPrivateKey(
 	ctx context.Context,
 	cb ssh.HostKeyCallback,
-	kh knownhosts.HostKeyCallback,
+	hostKeyAlgorithms []string,
 	sshHost string,
 ) (*ssh.Client, error) {
-	privateKey, err := ssh.ParsePrivateKey([]byte(c.privateKey))
+	privateKey, err := c.privateKeySigner()
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse private key: %w", err)
+		return nil, err
 	}
 
 	sshConfig := &ssh.ClientConfig{
 		User:              c.username,
 		Auth:              []ssh.AuthMethod{ssh.PublicKeys(privateKey)},
 		HostKeyCallback:   cb,
-		HostKeyAlgorithms: kh.HostKeyAlgorithms(sshHost),
+		HostKeyAlgorithms: hostKeyAlgorithms,
 	}
 
 	return c.connect(ctx, sshHost, sshConfig)
 }
 
 func (c *client) connect(ctx context.Context, sshHost string, sshConfig *ssh.ClientConfig) (*ssh.Client, error) {
+	if len(c.bastions) > 0 {
+		return c.connectViaBastions(ctx, sshHost, sshConfig)
+	}
+
+	return c.dial(ctx, sshHost, sshConfig)
+}
+
+// dial connects to the SSH host directly, or through the SOCKS5 proxy if configured.
+func (c *client) dial(ctx context.Context, sshHost string, sshConfig *ssh.ClientConfig) (*ssh.Client, error) {
 	if c.socks5Server != "" {
 		sshClient, err := c.socks5SSHClient(sshHost, sshConfig)
 		if err != nil {
@@ -646,7 +616,7 @@ func (c *client) connect(ctx context.Context, sshHost string, sshConfig *ssh.Cli
 		tflog.Debug(ctx, "SSH connection via SOCKS5 established", map[string]interface{}{
 			"host":          sshHost,
 			"socks5_server": c.socks5Server,
-			"user":          c.username,
+			"user":          sshConfig.User,
 		})
 
 		return sshClient, nil
@@ -659,7 +629,7 @@ func (c *client) connect(ctx context.Context, sshHost string, sshConfig *ssh.Cli
 
 	tflog.Debug(ctx, "SSH connection established", map[string]interface{}{
 		"host": sshHost,
-		"user": c.username,
+		"user": sshConfig.User,
 	})
 
 	return sshClient, nil
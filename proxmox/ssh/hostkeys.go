@@ -0,0 +1,262 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package ssh
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path"
+	"slices"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/skeema/knownhosts"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/bpg/terraform-provider-proxmox/utils"
+)
+
+// HostKeyVerification is the mode of verifying the host keys of the nodes.
+type HostKeyVerification string
+
+const (
+	// HostKeyVerificationTOFU trusts the key of an unknown host on first use, and adds it to the known hosts file.
+	HostKeyVerificationTOFU HostKeyVerification = "tofu"
+
+	// HostKeyVerificationKnownHostsFile only accepts the keys listed in the known hosts file.
+	HostKeyVerificationKnownHostsFile HostKeyVerification = "known_hosts_file"
+
+	// HostKeyVerificationStrict only accepts the keys of the cluster, as listed in its known hosts,
+	// e.g. the content of `/etc/pve/priv/known_hosts` of a cluster node. The known hosts must be supplied:
+	// the API does not expose the SSH host keys of the nodes, its node fingerprints are those of the TLS
+	// certificates of the API, so they cannot be fetched from the cluster.
+	HostKeyVerificationStrict HostKeyVerification = "strict"
+)
+
+// HostKeyVerificationModes lists the supported host key verification modes.
+var HostKeyVerificationModes = []string{
+	string(HostKeyVerificationTOFU),
+	string(HostKeyVerificationKnownHostsFile),
+	string(HostKeyVerificationStrict),
+}
+
+// ClientOption is an option for configuring the SSH client.
+type ClientOption func(*client) error
+
+// WithHostKeyVerification sets the mode of verifying the host keys of the nodes. The known hosts file is
+// required by the `known_hosts_file` mode, and replaces `~/.ssh/known_hosts` in the `tofu` mode. The cluster
+// known hosts are the known_hosts content the `strict` mode checks the keys against.
+func WithHostKeyVerification(mode HostKeyVerification, knownHostsFile string, clusterKnownHosts string) ClientOption {
+	return func(c *client) error {
+		policy, err := newHostKeyPolicy(mode, knownHostsFile, clusterKnownHosts)
+		if err != nil {
+			return err
+		}
+
+		c.hostKeys = policy
+
+		return nil
+	}
+}
+
+// hostKeyPolicy defines how the host keys of the SSH servers are verified.
+type hostKeyPolicy struct {
+	mode              HostKeyVerification
+	knownHostsFile    string
+	clusterKnownHosts knownhosts.HostKeyCallback
+}
+
+// newHostKeyPolicy validates the host key verification settings, the mode defaults to trust on first use.
+func newHostKeyPolicy(mode HostKeyVerification, knownHostsFile string, clusterKnownHosts string) (hostKeyPolicy, error) {
+	if mode == "" {
+		mode = HostKeyVerificationTOFU
+	}
+
+	policy := hostKeyPolicy{mode: mode, knownHostsFile: knownHostsFile}
+
+	switch mode {
+	case HostKeyVerificationTOFU:
+	case HostKeyVerificationKnownHostsFile:
+		if knownHostsFile == "" {
+			return policy, fmt.Errorf("the known hosts file is required for the %q host key verification", mode)
+		}
+	case HostKeyVerificationStrict:
+		if clusterKnownHosts == "" {
+			return policy, fmt.Errorf("the known hosts of the cluster are required for the %q host key verification", mode)
+		}
+
+		kh, err := parseKnownHosts(clusterKnownHosts)
+		if err != nil {
+			return policy, err
+		}
+
+		policy.clusterKnownHosts = kh
+	default:
+		return policy, fmt.Errorf("unsupported host key verification %q, must be one of %v", mode, HostKeyVerificationModes)
+	}
+
+	return policy, nil
+}
+
+// parseKnownHosts parses known_hosts content.
+func parseKnownHosts(content string) (knownhosts.HostKeyCallback, error) {
+	// the known hosts can only be loaded from files, the content is kept in memory once loaded
+	f, err := os.CreateTemp("", "known_hosts")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a temporary known hosts file: %w", err)
+	}
+
+	defer func() {
+		_ = os.Remove(f.Name())
+	}()
+
+	_, err = f.WriteString(content)
+	if e := f.Close(); err == nil {
+		err = e
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to write a temporary known hosts file: %w", err)
+	}
+
+	kh, err := knownhosts.New(f.Name())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the known hosts of the cluster: %w", err)
+	}
+
+	return kh, nil
+}
+
+// callback returns the callback verifying the host key of the server, and the host key algorithms
+// to negotiate with it. The name is an alternative name of the server the keys may be listed by, e.g. the node name.
+func (p hostKeyPolicy) callback(
+	ctx context.Context,
+	name string,
+	node ProxmoxNode,
+	sshHost string,
+) (ssh.HostKeyCallback, []string, error) {
+	switch p.mode {
+	case HostKeyVerificationKnownHostsFile:
+		kh, err := knownhosts.New(p.knownHostsFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read %s: %w", p.knownHostsFile, err)
+		}
+
+		return verifyKnownHost(kh, name, node), hostKeyAlgorithms(kh, name, node, sshHost), nil
+	case HostKeyVerificationStrict:
+		kh := p.clusterKnownHosts
+
+		return verifyKnownHost(kh, name, node), hostKeyAlgorithms(kh, name, node, sshHost), nil
+	default:
+		return p.trustOnFirstUse(ctx, sshHost)
+	}
+}
+
+// verifyKnownHost returns a callback that only accepts host keys listed for the node address or its name,
+// as the known hosts of a Proxmox VE cluster list the nodes by both.
+func verifyKnownHost(kh knownhosts.HostKeyCallback, nodeName string, node ProxmoxNode) ssh.HostKeyCallback {
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		err := kh(hostname, remote, key)
+		if knownhosts.IsHostUnknown(err) && nodeName != "" {
+			err = kh(net.JoinHostPort(nodeName, strconv.Itoa(int(node.Port))), remote, key)
+		}
+
+		switch {
+		case err == nil:
+			return nil
+		case knownhosts.IsHostKeyChanged(err):
+			return fmt.Errorf("the host key of %s does not match the known hosts! This may indicate a MitM attack: %w",
+				hostname, err)
+		case knownhosts.IsHostUnknown(err):
+			return fmt.Errorf("the host key of %s is not in the known hosts, and unknown hosts are not trusted: %w",
+				hostname, err)
+		default:
+			return err //nolint:wrapcheck
+		}
+	}
+}
+
+// hostKeyAlgorithms returns the algorithms of the keys known for the node address or its name.
+func hostKeyAlgorithms(kh knownhosts.HostKeyCallback, nodeName string, node ProxmoxNode, sshHost string) []string {
+	algos := kh.HostKeyAlgorithms(sshHost)
+
+	if nodeName != "" {
+		for _, a := range kh.HostKeyAlgorithms(net.JoinHostPort(nodeName, strconv.Itoa(int(node.Port)))) {
+			if !slices.Contains(algos, a) {
+				algos = append(algos, a)
+			}
+		}
+	}
+
+	return algos
+}
+
+// trustOnFirstUse returns a permissive callback which still errors on hosts with changed keys, but allows
+// unknown hosts and adds them to the known hosts file.
+func (p hostKeyPolicy) trustOnFirstUse(ctx context.Context, sshHost string) (ssh.HostKeyCallback, []string, error) {
+	khPath := p.knownHostsFile
+
+	if khPath == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to determine the home directory: %w", err)
+		}
+
+		sshPath := path.Join(homeDir, ".ssh")
+		if _, err = os.Stat(sshPath); os.IsNotExist(err) {
+			e := os.Mkdir(sshPath, 0o700)
+			if e != nil && !os.IsExist(e) {
+				return nil, nil, fmt.Errorf("failed to create %s: %w", sshPath, e)
+			}
+		}
+
+		khPath = path.Join(sshPath, "known_hosts")
+	}
+
+	if _, err := os.Stat(khPath); os.IsNotExist(err) {
+		e := os.WriteFile(khPath, []byte{}, 0o600)
+		if e != nil {
+			return nil, nil, fmt.Errorf("failed to create %s: %w", khPath, e)
+		}
+	}
+
+	kh, err := knownhosts.New(khPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read %s: %w", khPath, err)
+	}
+
+	cb := ssh.HostKeyCallback(func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		khErr := kh(hostname, remote, key)
+		if knownhosts.IsHostKeyChanged(khErr) {
+			return fmt.Errorf("REMOTE HOST IDENTIFICATION HAS CHANGED for host %s! This may indicate a MitM attack", hostname)
+		}
+
+		if knownhosts.IsHostUnknown(khErr) {
+			f, fErr := os.OpenFile(khPath, os.O_APPEND|os.O_WRONLY, 0o600)
+			if fErr == nil {
+				defer utils.CloseOrLogError(ctx)(f)
+				fErr = knownhosts.WriteKnownHost(f, hostname, remote, key)
+			}
+
+			if fErr == nil {
+				tflog.Info(ctx, fmt.Sprintf("Added host %s to known_hosts", hostname))
+			} else {
+				tflog.Error(ctx, fmt.Sprintf("Failed to add host %s to known_hosts", hostname), map[string]interface{}{
+					"error": khErr,
+				})
+			}
+
+			return nil
+		}
+
+		return khErr
+	})
+
+	return cb, kh.HostKeyAlgorithms(sshHost), nil
+}
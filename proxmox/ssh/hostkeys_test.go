@@ -0,0 +1,126 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package ssh
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/skeema/knownhosts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+func newHostKey(t *testing.T) ssh.PublicKey {
+	t.Helper()
+
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	key, err := ssh.NewPublicKey(pub)
+	require.NoError(t, err)
+
+	return key
+}
+
+func TestHostKeyVerification(t *testing.T) {
+	t.Parallel()
+
+	pve1Key := newHostKey(t)
+	pve2Key := newHostKey(t)
+	otherKey := newHostKey(t)
+
+	// the cluster known hosts list the nodes by name and by address
+	clusterKnownHosts := knownhosts.Line([]string{"pve1"}, pve1Key) + "\n" +
+		knownhosts.Line([]string{"10.0.0.2"}, pve2Key) + "\n"
+
+	knownHostsFile := filepath.Join(t.TempDir(), "known_hosts")
+	require.NoError(t, os.WriteFile(knownHostsFile, []byte(clusterKnownHosts), 0o600))
+
+	tests := []struct {
+		name     string
+		nodeName string
+		address  string
+		key      ssh.PublicKey
+		wantErr  string
+	}{
+		{"known by name", "pve1", "10.0.0.1", pve1Key, ""},
+		{"known by address", "pve2", "10.0.0.2", pve2Key, ""},
+		{"changed key", "pve1", "10.0.0.1", otherKey, "does not match the known hosts"},
+		{"unknown host", "pve3", "10.0.0.3", otherKey, "is not in the known hosts"},
+	}
+
+	for _, opt := range []ClientOption{
+		WithHostKeyVerification(HostKeyVerificationStrict, "", clusterKnownHosts),
+		WithHostKeyVerification(HostKeyVerificationKnownHostsFile, knownHostsFile, ""),
+	} {
+		c := &client{}
+		require.NoError(t, opt(c))
+
+		for _, tt := range tests {
+			t.Run(string(c.hostKeys.mode)+" "+tt.name, func(t *testing.T) {
+				t.Parallel()
+
+				node := ProxmoxNode{Address: tt.address, Port: 22}
+				host := net.JoinHostPort(tt.address, "22")
+
+				cb, _, err := c.hostKeys.callback(t.Context(), tt.nodeName, node, host)
+				require.NoError(t, err)
+
+				err = cb(host, &net.TCPAddr{IP: net.ParseIP(tt.address), Port: 22}, tt.key)
+				if tt.wantErr == "" {
+					require.NoError(t, err)
+				} else {
+					require.ErrorContains(t, err, tt.wantErr)
+				}
+			})
+		}
+	}
+}
+
+func TestHostKeyVerificationTOFU(t *testing.T) {
+	t.Parallel()
+
+	knownHostsFile := filepath.Join(t.TempDir(), "known_hosts")
+
+	c := &client{}
+	require.NoError(t, WithHostKeyVerification(HostKeyVerificationTOFU, knownHostsFile, "")(c))
+
+	key := newHostKey(t)
+	host := "10.0.0.1:22"
+	remote := &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 22}
+
+	cb, _, err := c.hostKeys.callback(t.Context(), "pve1", ProxmoxNode{Address: "10.0.0.1", Port: 22}, host)
+	require.NoError(t, err)
+	require.NoError(t, cb(host, remote, key))
+
+	// the key has been trusted on first use
+	kh, err := knownhosts.New(knownHostsFile)
+	require.NoError(t, err)
+	require.NoError(t, kh(host, remote, key))
+
+	cb, _, err = c.hostKeys.callback(t.Context(), "pve1", ProxmoxNode{Address: "10.0.0.1", Port: 22}, host)
+	require.NoError(t, err)
+	assert.ErrorContains(t, cb(host, remote, newHostKey(t)), "REMOTE HOST IDENTIFICATION HAS CHANGED")
+}
+
+func TestWithHostKeyVerificationValidation(t *testing.T) {
+	t.Parallel()
+
+	require.Error(t, WithHostKeyVerification(HostKeyVerificationKnownHostsFile, "", "")(&client{}))
+	require.Error(t, WithHostKeyVerification(HostKeyVerificationStrict, "", "")(&client{}))
+	require.Error(t, WithHostKeyVerification("insecure", "", "")(&client{}))
+
+	c := &client{}
+	require.NoError(t, WithHostKeyVerification("", "", "")(c))
+	assert.Equal(t, HostKeyVerificationTOFU, c.hostKeys.mode)
+}
@@ -0,0 +1,265 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package ssh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	// DefaultMaxSessionsPerNode matches the default `MaxSessions` of OpenSSH servers.
+	DefaultMaxSessionsPerNode = 10
+
+	// DefaultKeepAliveInterval is the default interval of the keepalives sent over the pooled connections.
+	DefaultKeepAliveInterval = 30 * time.Second
+
+	// keepAliveTimeout is the time a keepalive, or the liveness check of a pooled connection, waits for the reply.
+	keepAliveTimeout = 15 * time.Second
+)
+
+// PoolConfig configures the pool of SSH connections to the nodes.
+type PoolConfig struct {
+	// MaxSessionsPerNode is the maximum number of concurrent sessions, i.e. command executions and file
+	// transfers, on the connection to a single node. It must not exceed the `MaxSessions` of the SSH server.
+	MaxSessionsPerNode int
+	// KeepAliveInterval is the interval of the keepalives sent over the connections, zero disables them.
+	KeepAliveInterval time.Duration
+}
+
+// WithConnectionPool configures the pool of SSH connections to the nodes.
+func WithConnectionPool(cfg PoolConfig) ClientOption {
+	return WithSharedConnectionPool(nil, cfg)
+}
+
+// WithSharedConnectionPool configures the pool of SSH connections to the nodes, which is shared with the other
+// clients using the same shared pool and configuration. A nil shared pool is not shared.
+func WithSharedConnectionPool(shared *SharedPool, cfg PoolConfig) ClientOption {
+	return func(c *client) error {
+		if cfg.MaxSessionsPerNode < 0 || cfg.KeepAliveInterval < 0 {
+			return errors.New("SSH connection pool settings must not be negative")
+		}
+
+		if cfg.MaxSessionsPerNode == 0 {
+			cfg.MaxSessionsPerNode = DefaultMaxSessionsPerNode
+		}
+
+		c.pool = shared.pool(cfg)
+
+		return nil
+	}
+}
+
+// SharedPool shares the connections to the nodes between several clients, e.g. the clients of the SDK and
+// framework providers of a provider instance, so that there is a single connection per node for all of them.
+type SharedPool struct {
+	mu    sync.Mutex
+	pools map[PoolConfig]*connPool
+}
+
+// NewSharedPool creates a new pool of SSH connections to share between clients.
+func NewSharedPool() *SharedPool {
+	return &SharedPool{pools: map[PoolConfig]*connPool{}}
+}
+
+func (s *SharedPool) pool(cfg PoolConfig) *connPool {
+	if s == nil {
+		return newConnPool(cfg)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.pools[cfg]
+	if !ok {
+		p = newConnPool(cfg)
+		s.pools[cfg] = p
+	}
+
+	return p
+}
+
+// connPool keeps a single SSH connection per node, which is reused by all the sessions to the node, so that
+// the provider does not go through a full SSH handshake for each command or file upload.
+type connPool struct {
+	cfg PoolConfig
+
+	mu    sync.Mutex
+	nodes map[string]*nodeConn
+}
+
+// nodeConn is the pooled connection to a node.
+type nodeConn struct {
+	// sessions limits the number of concurrent sessions on the connection
+	sessions chan struct{}
+
+	// mu guards the connection, and serializes connecting to the node
+	mu   sync.Mutex
+	conn *pooledConn
+}
+
+// pooledConn is an established SSH connection, done is closed once the connection is closed.
+type pooledConn struct {
+	*ssh.Client
+
+	done chan struct{}
+}
+
+func newConnPool(cfg PoolConfig) *connPool {
+	return &connPool{cfg: cfg, nodes: map[string]*nodeConn{}}
+}
+
+func (p *connPool) node(nodeName string) *nodeConn {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	nc, ok := p.nodes[nodeName]
+	if !ok {
+		nc = &nodeConn{sessions: make(chan struct{}, p.cfg.MaxSessionsPerNode)}
+		p.nodes[nodeName] = nc
+	}
+
+	return nc
+}
+
+// withNodeConnection runs fn with the pooled connection to the node, reconnecting if the connection is broken.
+// fn holds one of the sessions of the connection, so it must not open more than one session at a time.
+func (c *client) withNodeConnection(
+	ctx context.Context,
+	nodeName string,
+	node ProxmoxNode,
+	fn func(sshClient *ssh.Client) error,
+) error {
+	nc := c.pool.node(nodeName)
+
+	select {
+	case nc.sessions <- struct{}{}:
+	case <-ctx.Done():
+		return fmt.Errorf("failed to wait for an SSH session on node %s: %w", nodeName, ctx.Err())
+	}
+
+	defer func() { <-nc.sessions }()
+
+	conn, err := c.nodeConnection(ctx, nc, nodeName, node)
+	if err != nil {
+		return err
+	}
+
+	return fn(conn.Client)
+}
+
+// nodeConnection returns the connection to the node, and establishes a new one if there is none,
+// or the previous one is broken.
+func (c *client) nodeConnection(
+	ctx context.Context,
+	nc *nodeConn,
+	nodeName string,
+	node ProxmoxNode,
+) (*pooledConn, error) {
+	nc.mu.Lock()
+	defer nc.mu.Unlock()
+
+	if nc.conn != nil {
+		err := nc.conn.check()
+		if err == nil {
+			return nc.conn, nil
+		}
+
+		tflog.Warn(ctx, "pooled SSH connection is broken, reconnecting", map[string]interface{}{
+			"node":  nodeName,
+			"error": err,
+		})
+
+		_ = nc.conn.Close()
+		nc.conn = nil
+	}
+
+	sshClient, err := c.openNodeShell(ctx, nodeName, node)
+	if err != nil {
+		return nil, err
+	}
+
+	// the connection outlives the request establishing it, so its context only keeps the logger of the request,
+	// and is cancelled once the connection is closed
+	connCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+
+	conn := &pooledConn{Client: sshClient, done: make(chan struct{})}
+
+	go func() {
+		_ = sshClient.Wait()
+
+		cancel()
+		close(conn.done)
+	}()
+
+	if c.pool.cfg.KeepAliveInterval > 0 {
+		go conn.keepAlive(connCtx, nodeName, c.pool.cfg.KeepAliveInterval)
+	}
+
+	nc.conn = conn
+
+	return conn, nil
+}
+
+// check verifies that the connection is still usable, with a keepalive round trip.
+func (pc *pooledConn) check() error {
+	select {
+	case <-pc.done:
+		return errors.New("the connection is closed")
+	default:
+	}
+
+	result := make(chan error, 1)
+
+	go func() {
+		_, _, err := pc.SendRequest("keepalive@openssh.com", true, nil)
+		result <- err
+	}()
+
+	select {
+	case err := <-result:
+		if err != nil {
+			return fmt.Errorf("keepalive failed: %w", err)
+		}
+
+		return nil
+	case <-pc.done:
+		return errors.New("the connection is closed")
+	case <-time.After(keepAliveTimeout):
+		return errors.New("keepalive timed out")
+	}
+}
+
+// keepAlive sends keepalives until the context of the connection is done, and closes the connection once they fail.
+func (pc *pooledConn) keepAlive(ctx context.Context, nodeName string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := pc.check(); err != nil {
+				tflog.Warn(ctx, "SSH keepalive failed, closing the connection", map[string]interface{}{
+					"node":  nodeName,
+					"error": err,
+				})
+
+				_ = pc.Close()
+
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,191 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package ssh
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPoolTestClient(t *testing.T, node *testServer, cfg PoolConfig) Client {
+	t.Helper()
+
+	return newSharedPoolTestClient(t, node, nil, cfg)
+}
+
+func newSharedPoolTestClient(t *testing.T, node *testServer, shared *SharedPool, cfg PoolConfig) Client {
+	t.Helper()
+
+	c, err := NewClient(
+		testUsername, testPassword, false, "", "", "", "", "",
+		staticResolver{"pve": node.node()},
+		WithHostKeyVerification(HostKeyVerificationTOFU, filepath.Join(t.TempDir(), "known_hosts"), ""),
+		WithSharedConnectionPool(shared, cfg),
+	)
+	require.NoError(t, err)
+
+	return c
+}
+
+func TestConnectionPoolReusesConnection(t *testing.T) {
+	t.Parallel()
+
+	node := newTestServer(t)
+	node.execDelay = 20 * time.Millisecond
+
+	c := newPoolTestClient(t, node, PoolConfig{MaxSessionsPerNode: 3})
+
+	var wg sync.WaitGroup
+
+	errs := make(chan error, 12)
+
+	for i := range 12 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			out, err := c.ExecuteNodeCommands(t.Context(), "pve", []string{fmt.Sprintf("echo %d", i)})
+			if err == nil && string(out) != fmt.Sprintf(`executed: /bin/bash -c 'echo %d'`, i) {
+				err = fmt.Errorf("unexpected output %q", out)
+			}
+
+			errs <- err
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	assert.EqualValues(t, 1, node.connections.Load())
+	assert.LessOrEqual(t, node.maxSessions.Load(), int32(3))
+}
+
+func TestConnectionPoolReconnects(t *testing.T) {
+	t.Parallel()
+
+	node := newTestServer(t)
+	c := newPoolTestClient(t, node, PoolConfig{})
+
+	_, err := c.ExecuteNodeCommands(t.Context(), "pve", []string{"echo hello"})
+	require.NoError(t, err)
+
+	node.dropConnections()
+
+	_, err = c.ExecuteNodeCommands(t.Context(), "pve", []string{"echo hello"})
+	require.NoError(t, err)
+
+	assert.EqualValues(t, 2, node.connections.Load())
+}
+
+func TestConnectionPoolKeepAlive(t *testing.T) {
+	t.Parallel()
+
+	node := newTestServer(t)
+	c := newPoolTestClient(t, node, PoolConfig{KeepAliveInterval: 10 * time.Millisecond})
+
+	_, err := c.ExecuteNodeCommands(t.Context(), "pve", []string{"echo hello"})
+	require.NoError(t, err)
+
+	conn := c.(*client).pool.nodes["pve"].conn
+
+	// the keepalive closes the connection once the transport is broken
+	node.dropConnections()
+
+	select {
+	case <-conn.done:
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "the broken connection has not been closed")
+	}
+}
+
+func TestConnectionPoolKeepAliveOutlivesRequest(t *testing.T) {
+	t.Parallel()
+
+	node := newTestServer(t)
+	c := newPoolTestClient(t, node, PoolConfig{KeepAliveInterval: 10 * time.Millisecond})
+
+	ctx, cancel := context.WithCancel(t.Context())
+
+	_, err := c.ExecuteNodeCommands(ctx, "pve", []string{"echo hello"})
+	require.NoError(t, err)
+
+	// the request establishing the connection is over
+	cancel()
+
+	conn := c.(*client).pool.nodes["pve"].conn
+
+	node.dropConnections()
+
+	select {
+	case <-conn.done:
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "the keepalive must outlive the request establishing the connection")
+	}
+}
+
+func TestSharedConnectionPool(t *testing.T) {
+	t.Parallel()
+
+	node := newTestServer(t)
+	shared := NewSharedPool()
+
+	// the clients of the SDK and framework providers
+	c1 := newSharedPoolTestClient(t, node, shared, PoolConfig{MaxSessionsPerNode: 3})
+	c2 := newSharedPoolTestClient(t, node, shared, PoolConfig{MaxSessionsPerNode: 3})
+
+	for _, c := range []Client{c1, c2, c1} {
+		_, err := c.ExecuteNodeCommands(t.Context(), "pve", []string{"echo hello"})
+		require.NoError(t, err)
+	}
+
+	assert.EqualValues(t, 1, node.connections.Load(), "the clients must share the connection to the node")
+	assert.Same(t, c1.(*client).pool, c2.(*client).pool)
+
+	// a client with another configuration does not share the pool
+	c3 := newSharedPoolTestClient(t, node, shared, PoolConfig{MaxSessionsPerNode: 1})
+	assert.NotSame(t, c1.(*client).pool, c3.(*client).pool)
+}
+
+func TestConnectionPoolWaitsForSession(t *testing.T) {
+	t.Parallel()
+
+	node := newTestServer(t)
+	c := newPoolTestClient(t, node, PoolConfig{MaxSessionsPerNode: 1})
+
+	// occupy the only session
+	c.(*client).pool.node("pve").sessions <- struct{}{}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := c.ExecuteNodeCommands(ctx, "pve", []string{"echo hello"})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.EqualValues(t, 0, node.connections.Load())
+}
+
+func TestWithConnectionPoolValidation(t *testing.T) {
+	t.Parallel()
+
+	require.Error(t, WithConnectionPool(PoolConfig{MaxSessionsPerNode: -1})(&client{}))
+	require.Error(t, WithConnectionPool(PoolConfig{KeepAliveInterval: -time.Second})(&client{}))
+
+	c := &client{}
+	require.NoError(t, WithConnectionPool(PoolConfig{})(c))
+	assert.Equal(t, DefaultMaxSessionsPerNode, c.pool.cfg.MaxSessionsPerNode)
+}
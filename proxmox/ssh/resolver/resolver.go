@@ -0,0 +1,315 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+// Package resolver resolves the addresses used for the SSH connections to the nodes with the Proxmox VE API.
+package resolver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/ssh"
+)
+
+// AddressSource is the source of the node addresses.
+type AddressSource string
+
+const (
+	// AddressSourceCluster uses the addresses of the cluster membership: the node IPs of `/cluster/status`,
+	// then the corosync link addresses of `/cluster/config/nodes`.
+	AddressSourceCluster AddressSource = "cluster"
+
+	// AddressSourceInterface uses the addresses of the node network interfaces: the interfaces with a gateway
+	// first, then any interface.
+	AddressSourceInterface AddressSource = "interface"
+
+	// AddressSourceDNS uses the addresses the node name resolves to.
+	AddressSourceDNS AddressSource = "dns"
+)
+
+// AddressSources lists the address sources, in the order they are tried.
+var AddressSources = []string{
+	string(AddressSourceCluster),
+	string(AddressSourceInterface),
+	string(AddressSourceDNS),
+}
+
+const defaultSSHPort = 22
+
+// APIResolver resolves the node addresses from the source it is configured with, falling back to the next
+// sources in the order of AddressSources. The resolved addresses are cached, as they are not expected
+// to change while the provider runs.
+type APIResolver struct {
+	client    api.Client
+	sources   []AddressSource
+	preferred []*net.IPNet
+
+	// lookupIP resolves the node names for the `dns` source
+	lookupIP func(host string) ([]net.IP, error)
+
+	mu       sync.Mutex
+	resolved map[string]ssh.ProxmoxNode
+}
+
+// New creates a resolver using the address source, which defaults to `cluster`. The addresses within the
+// preferred CIDRs are used first, the other addresses only if none of the sources has a preferred address.
+func New(client api.Client, source AddressSource, preferredCIDRs []string) (*APIResolver, error) {
+	if source == "" {
+		source = AddressSourceCluster
+	}
+
+	idx := slices.Index(AddressSources, string(source))
+	if idx < 0 {
+		return nil, fmt.Errorf("unsupported node address source %q, must be one of %v", source, AddressSources)
+	}
+
+	r := &APIResolver{
+		client:   client,
+		lookupIP: net.LookupIP,
+		resolved: map[string]ssh.ProxmoxNode{},
+	}
+
+	for _, s := range AddressSources[idx:] {
+		r.sources = append(r.sources, AddressSource(s))
+	}
+
+	for _, c := range preferredCIDRs {
+		_, ipNet, err := net.ParseCIDR(c)
+		if err != nil {
+			return nil, fmt.Errorf("invalid preferred node address CIDR %q: %w", c, err)
+		}
+
+		r.preferred = append(r.preferred, ipNet)
+	}
+
+	return r, nil
+}
+
+// Resolve returns the address of the node for the SSH connection.
+func (r *APIResolver) Resolve(ctx context.Context, nodeName string) (ssh.ProxmoxNode, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if node, ok := r.resolved[nodeName]; ok {
+		return node, nil
+	}
+
+	address, err := r.resolve(ctx, nodeName)
+	if err != nil {
+		return ssh.ProxmoxNode{}, err
+	}
+
+	node := ssh.ProxmoxNode{Address: address, Port: defaultSSHPort}
+	r.resolved[nodeName] = node
+
+	return node, nil
+}
+
+func (r *APIResolver) resolve(ctx context.Context, nodeName string) (string, error) {
+	var (
+		fallback string
+		errs     []error
+	)
+
+	for _, source := range r.sources {
+		candidates, err := r.candidates(ctx, source, nodeName)
+		if err != nil {
+			tflog.Debug(ctx, "Failed to get the node addresses", map[string]interface{}{
+				"node":   nodeName,
+				"source": source,
+				"error":  err,
+			})
+
+			errs = append(errs, err)
+
+			continue
+		}
+
+		tflog.Debug(ctx, "Found node addresses", map[string]interface{}{
+			"node":       nodeName,
+			"source":     source,
+			"candidates": candidates,
+		})
+
+		if len(candidates) == 0 {
+			continue
+		}
+
+		if len(r.preferred) == 0 {
+			return candidates[0], nil
+		}
+
+		for _, c := range candidates {
+			if r.isPreferred(c) {
+				return c, nil
+			}
+		}
+
+		if fallback == "" {
+			fallback = candidates[0]
+		}
+	}
+
+	if fallback != "" {
+		tflog.Warn(ctx, "None of the node addresses is in the preferred CIDRs, using the first one found", map[string]interface{}{
+			"node":    nodeName,
+			"address": fallback,
+		})
+
+		return fallback, nil
+	}
+
+	return "", fmt.Errorf("failed to determine the IP address of node %q: %w", nodeName, errors.Join(errs...))
+}
+
+func (r *APIResolver) isPreferred(address string) bool {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false
+	}
+
+	for _, n := range r.preferred {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// candidates returns the addresses of the node from the source, in the order of preference.
+func (r *APIResolver) candidates(ctx context.Context, source AddressSource, nodeName string) ([]string, error) {
+	switch source {
+	case AddressSourceCluster:
+		return r.clusterAddresses(ctx, nodeName)
+	case AddressSourceInterface:
+		return r.interfaceAddresses(ctx, nodeName)
+	default:
+		return r.dnsAddresses(nodeName)
+	}
+}
+
+func (r *APIResolver) clusterAddresses(ctx context.Context, nodeName string) ([]string, error) {
+	cc := &cluster.Client{Client: r.client}
+
+	var addrs []string
+
+	status, err := cc.GetStatus(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the cluster status: %w", err)
+	}
+
+	for _, s := range status {
+		if s.Type == "node" && s.Name == nodeName && s.IP != nil && *s.IP != "" {
+			addrs = appendAddress(addrs, *s.IP)
+		}
+	}
+
+	// the corosync configuration is only available in a cluster, and requires the `Sys.Audit` privilege
+	configNodes, err := cc.ListConfigNodes(ctx)
+	if err != nil {
+		tflog.Debug(ctx, "Failed to list the cluster config nodes", map[string]interface{}{
+			"error": err,
+		})
+
+		return addrs, nil
+	}
+
+	for _, n := range configNodes {
+		if n.Name == nodeName {
+			for _, a := range n.Addresses() {
+				addrs = appendAddress(addrs, a)
+			}
+		}
+	}
+
+	return addrs, nil
+}
+
+func (r *APIResolver) interfaceAddresses(ctx context.Context, nodeName string) ([]string, error) {
+	nc := &nodes.Client{Client: r.client, NodeName: nodeName}
+
+	networkDevices, err := nc.ListNetworkInterfaces(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list network devices of node %q: %w", nodeName, err)
+	}
+
+	var addrs []string
+
+	// IPv4 address on the interface with IPv4 gateway
+	for _, d := range networkDevices {
+		if d.Gateway != nil && d.Address != nil {
+			addrs = appendAddress(addrs, *d.Address)
+		}
+	}
+
+	// IPv6 address on the interface with IPv6 gateway
+	for _, d := range networkDevices {
+		if d.Gateway6 != nil && d.Address6 != nil {
+			addrs = appendAddress(addrs, *d.Address6)
+		}
+	}
+
+	// any other address
+	for _, d := range networkDevices {
+		if d.Address != nil {
+			addrs = appendAddress(addrs, *d.Address)
+		}
+	}
+
+	for _, d := range networkDevices {
+		if d.Address6 != nil {
+			addrs = appendAddress(addrs, *d.Address6)
+		}
+	}
+
+	return addrs, nil
+}
+
+func (r *APIResolver) dnsAddresses(nodeName string) ([]string, error) {
+	ips, err := r.lookupIP(nodeName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to do a DNS lookup of node %q: %w", nodeName, err)
+	}
+
+	var addrs []string
+
+	// IPv4 addresses first
+	for _, ip := range ips {
+		if ip.To4() != nil {
+			addrs = appendAddress(addrs, ip.String())
+		}
+	}
+
+	for _, ip := range ips {
+		if ip.To4() == nil {
+			addrs = appendAddress(addrs, ip.String())
+		}
+	}
+
+	return addrs, nil
+}
+
+// appendAddress appends the address without its prefix length, unless already present.
+func appendAddress(addrs []string, address string) []string {
+	address, _, _ = strings.Cut(address, "/")
+
+	if address == "" || slices.Contains(addrs, address) {
+		return addrs
+	}
+
+	return append(addrs, address)
+}
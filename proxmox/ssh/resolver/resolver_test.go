@@ -0,0 +1,126 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package resolver
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/fake"
+)
+
+func newFakeClient(t *testing.T) api.Client {
+	t.Helper()
+
+	srv := fake.NewServer(fake.WithNodes("pve2"))
+	t.Cleanup(srv.Close)
+
+	client, err := srv.NewClient()
+	require.NoError(t, err)
+
+	return client
+}
+
+func lookupIP(ips ...string) func(string) ([]net.IP, error) {
+	return func(host string) ([]net.IP, error) {
+		if len(ips) == 0 {
+			return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+		}
+
+		result := make([]net.IP, len(ips))
+		for i, ip := range ips {
+			result[i] = net.ParseIP(ip)
+		}
+
+		return result, nil
+	}
+}
+
+func TestResolve(t *testing.T) {
+	t.Parallel()
+
+	client := newFakeClient(t)
+
+	// the fake nodes have the cluster addresses 198.51.100.x and 203.0.113.x, and the bridge address 192.0.2.x
+	tests := []struct {
+		name      string
+		node      string
+		source    AddressSource
+		preferred []string
+		dns       []string
+		want      string
+	}{
+		{"cluster by default", "pve2", "", nil, nil, "198.51.100.11"},
+		{"cluster with preferred corosync link", "pve2", AddressSourceCluster, []string{"203.0.113.0/24"}, nil, "203.0.113.11"},
+		{"interface", "pve2", AddressSourceInterface, nil, nil, "192.0.2.11"},
+		{"preferred interface address", "pve", AddressSourceCluster, []string{"192.0.2.0/24"}, nil, "192.0.2.10"},
+		{"dns with IPv4 first", "pve", AddressSourceDNS, nil, []string{"2001:db8::1", "10.0.0.1"}, "10.0.0.1"},
+		{"preferred dns address", "pve", AddressSourceCluster, []string{"2001:db8::/32"}, []string{"10.0.0.1", "2001:db8::1"}, "2001:db8::1"},
+		{"no preferred address", "pve", AddressSourceCluster, []string{"10.1.0.0/16"}, nil, "198.51.100.10"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r, err := New(client, tt.source, tt.preferred)
+			require.NoError(t, err)
+
+			r.lookupIP = lookupIP(tt.dns...)
+
+			node, err := r.Resolve(t.Context(), tt.node)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, node.Address)
+			assert.EqualValues(t, 22, node.Port)
+		})
+	}
+}
+
+func TestResolveCachesAddresses(t *testing.T) {
+	t.Parallel()
+
+	r, err := New(newFakeClient(t), AddressSourceDNS, nil)
+	require.NoError(t, err)
+
+	r.lookupIP = lookupIP("10.0.0.1")
+
+	node, err := r.Resolve(t.Context(), "pve")
+	require.NoError(t, err)
+
+	r.lookupIP = func(string) ([]net.IP, error) { return nil, errors.New("unexpected lookup") }
+
+	cached, err := r.Resolve(t.Context(), "pve")
+	require.NoError(t, err)
+	assert.Equal(t, node, cached)
+}
+
+func TestResolveUnknownNode(t *testing.T) {
+	t.Parallel()
+
+	r, err := New(newFakeClient(t), AddressSourceCluster, nil)
+	require.NoError(t, err)
+
+	r.lookupIP = lookupIP()
+
+	_, err = r.Resolve(t.Context(), "unknown")
+	require.ErrorContains(t, err, `failed to determine the IP address of node "unknown"`)
+}
+
+func TestNewValidation(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(nil, "hosts", nil)
+	require.ErrorContains(t, err, "unsupported node address source")
+
+	_, err = New(nil, AddressSourceCluster, []string{"10.0.0.1"})
+	require.ErrorContains(t, err, "invalid preferred node address CIDR")
+}
@@ -0,0 +1,248 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package ssh
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	testUsername = "root"
+	testPassword = "secret"
+)
+
+// testServer is an SSH server that echoes the executed commands and forwards TCP connections,
+// so it can act as both a node and a bastion.
+type testServer struct {
+	addr    string
+	hostKey ssh.PublicKey
+
+	// connections is the number of SSH connections accepted, active the number of open ones
+	connections atomic.Int32
+	active      atomic.Int32
+
+	// execDelay delays the output of the executed commands, so that the sessions overlap
+	execDelay time.Duration
+	// sessions is the number of open sessions, maxSessions the highest number of sessions open at the same time
+	sessions    atomic.Int32
+	maxSessions atomic.Int32
+
+	mu    sync.Mutex
+	conns []*ssh.ServerConn
+}
+
+// newTestServer starts a test server accepting the password authentication, and the certificates signed
+// by the user CAs if any.
+func newTestServer(t *testing.T, userCAs ...ssh.PublicKey) *testServer {
+	t.Helper()
+
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	signer, err := ssh.NewSignerFromKey(priv)
+	require.NoError(t, err)
+
+	cfg := &ssh.ServerConfig{
+		PasswordCallback: func(c ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			if c.User() == testUsername && string(password) == testPassword {
+				return &ssh.Permissions{}, nil
+			}
+
+			return nil, errors.New("permission denied")
+		},
+	}
+
+	if len(userCAs) > 0 {
+		checker := &ssh.CertChecker{
+			IsUserAuthority: func(auth ssh.PublicKey) bool {
+				for _, ca := range userCAs {
+					if bytes.Equal(auth.Marshal(), ca.Marshal()) {
+						return true
+					}
+				}
+
+				return false
+			},
+		}
+		cfg.PublicKeyCallback = checker.Authenticate
+	}
+	cfg.AddHostKey(signer)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = l.Close() })
+
+	s := &testServer{addr: l.Addr().String(), hostKey: signer.PublicKey()}
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+
+			go s.serve(conn, cfg)
+		}
+	}()
+
+	return s
+}
+
+// node returns the address of the server as a node.
+func (s *testServer) node() ProxmoxNode {
+	host, port, _ := net.SplitHostPort(s.addr)
+	p, _ := strconv.Atoi(port)
+
+	return ProxmoxNode{Address: host, Port: int32(p)}
+}
+
+func (s *testServer) serve(conn net.Conn, cfg *ssh.ServerConfig) {
+	sconn, chans, reqs, err := ssh.NewServerConn(conn, cfg)
+	if err != nil {
+		return
+	}
+
+	s.connections.Add(1)
+	s.active.Add(1)
+
+	s.mu.Lock()
+	s.conns = append(s.conns, sconn)
+	s.mu.Unlock()
+
+	defer s.active.Add(-1)
+	defer sconn.Close()
+
+	go ssh.DiscardRequests(reqs)
+
+	for newCh := range chans {
+		switch newCh.ChannelType() {
+		case "session":
+			go s.handleSession(newCh)
+		case "direct-tcpip":
+			go s.handleForward(newCh)
+		default:
+			_ = newCh.Reject(ssh.UnknownChannelType, "unsupported channel type")
+		}
+	}
+}
+
+func (s *testServer) handleSession(newCh ssh.NewChannel) {
+	ch, reqs, err := newCh.Accept()
+	if err != nil {
+		return
+	}
+
+	defer ch.Close()
+
+	n := s.sessions.Add(1)
+	defer s.sessions.Add(-1)
+
+	for {
+		current := s.maxSessions.Load()
+		if n <= current || s.maxSessions.CompareAndSwap(current, n) {
+			break
+		}
+	}
+
+	for req := range reqs {
+		if req.Type != "exec" {
+			_ = req.Reply(false, nil)
+
+			continue
+		}
+
+		var payload struct{ Command string }
+
+		_ = ssh.Unmarshal(req.Payload, &payload)
+		_ = req.Reply(true, nil)
+
+		time.Sleep(s.execDelay)
+
+		_, _ = fmt.Fprintf(ch, "executed: %s", payload.Command)
+		_, _ = ch.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{0}))
+
+		return
+	}
+}
+
+// dropConnections closes the SSH connections on the server side, like a node reboot.
+func (s *testServer) dropConnections() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, conn := range s.conns {
+		_ = conn.Close()
+	}
+
+	s.conns = nil
+}
+
+func (s *testServer) handleForward(newCh ssh.NewChannel) {
+	var payload struct {
+		Host     string
+		Port     uint32
+		OrigHost string
+		OrigPort uint32
+	}
+
+	if err := ssh.Unmarshal(newCh.ExtraData(), &payload); err != nil {
+		_ = newCh.Reject(ssh.ConnectionFailed, err.Error())
+
+		return
+	}
+
+	target, err := net.Dial("tcp", net.JoinHostPort(payload.Host, strconv.Itoa(int(payload.Port))))
+	if err != nil {
+		_ = newCh.Reject(ssh.ConnectionFailed, err.Error())
+
+		return
+	}
+
+	ch, reqs, err := newCh.Accept()
+	if err != nil {
+		_ = target.Close()
+
+		return
+	}
+
+	go ssh.DiscardRequests(reqs)
+
+	go func() {
+		_, _ = io.Copy(target, ch)
+		_ = target.Close()
+	}()
+
+	_, _ = io.Copy(ch, target)
+	_ = ch.Close()
+}
+
+// staticResolver resolves the node names from a map.
+type staticResolver map[string]ProxmoxNode
+
+func (r staticResolver) Resolve(_ context.Context, nodeName string) (ProxmoxNode, error) {
+	node, ok := r[nodeName]
+	if !ok {
+		return ProxmoxNode{}, fmt.Errorf("unknown node %q", nodeName)
+	}
+
+	return node, nil
+}
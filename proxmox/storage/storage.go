@@ -11,6 +11,9 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"sort"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
 )
 
 // GetDatastore retrieves information about a datastore.
@@ -52,3 +55,54 @@ func (c *Client) GetDatastore(
 
 	return resBody.Data, nil
 }
+
+// ListDatastores lists the datastores of the cluster storage configuration.
+func (c *Client) ListDatastores(ctx context.Context) ([]*DatastoreGetResponseData, error) {
+	resBody := &DatastoreListResponseBody{}
+
+	err := c.DoRequest(ctx, http.MethodGet, "storage", nil, resBody)
+	if err != nil {
+		return nil, fmt.Errorf("error listing datastores: %w", err)
+	}
+
+	if resBody.Data == nil {
+		return nil, api.ErrNoDataObjectInResponse
+	}
+
+	sort.Slice(resBody.Data, func(i, j int) bool {
+		return *resBody.Data[i].Storage < *resBody.Data[j].Storage
+	})
+
+	return resBody.Data, nil
+}
+
+// CreateDatastore creates a datastore in the cluster storage configuration.
+func (c *Client) CreateDatastore(ctx context.Context, data *DatastoreCreateRequestBody) error {
+	err := c.DoRequest(ctx, http.MethodPost, "storage", data, nil)
+	if err != nil {
+		return fmt.Errorf("error creating datastore %s: %w", data.ID, err)
+	}
+
+	return nil
+}
+
+// UpdateDatastore updates a datastore of the cluster storage configuration.
+func (c *Client) UpdateDatastore(ctx context.Context, datastoreID string, data *DatastoreUpdateRequestBody) error {
+	err := c.DoRequest(ctx, http.MethodPut, fmt.Sprintf("storage/%s", url.PathEscape(datastoreID)), data, nil)
+	if err != nil {
+		return fmt.Errorf("error updating datastore %s: %w", datastoreID, err)
+	}
+
+	return nil
+}
+
+// DeleteDatastore removes a datastore from the cluster storage configuration. The content of the datastore
+// is not deleted.
+func (c *Client) DeleteDatastore(ctx context.Context, datastoreID string) error {
+	err := c.DoRequest(ctx, http.MethodDelete, fmt.Sprintf("storage/%s", url.PathEscape(datastoreID)), nil, nil)
+	if err != nil {
+		return fmt.Errorf("error deleting datastore %s: %w", datastoreID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/fake"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/helpers/ptr"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
+)
+
+func TestPruneBackups(t *testing.T) {
+	t.Parallel()
+
+	var pb PruneBackups
+
+	require.NoError(t, json.Unmarshal([]byte(`"keep-last=3,keep-daily=7,keep-all=0"`), &pb))
+	assert.False(t, bool(*pb.KeepAll))
+	assert.Equal(t, int64(3), *pb.KeepLast)
+	assert.Equal(t, int64(7), *pb.KeepDaily)
+	assert.Nil(t, pb.KeepWeekly)
+
+	v := url.Values{}
+	require.NoError(t, pb.EncodeValues("prune-backups", &v))
+	assert.Equal(t, "keep-all=0,keep-last=3,keep-daily=7", v.Get("prune-backups"))
+
+	require.Error(t, json.Unmarshal([]byte(`"keep-last=many"`), &PruneBackups{}))
+}
+
+func TestDatastores(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	srv := fake.NewServer()
+	t.Cleanup(srv.Close)
+
+	apiClient, err := srv.NewClient()
+	require.NoError(t, err)
+
+	c := &Client{Client: apiClient}
+
+	require.NoError(t, c.CreateDatastore(ctx, &DatastoreCreateRequestBody{
+		ID:   "backups",
+		Type: TypeNFS,
+		DatastoreFields: DatastoreFields{
+			Content: types.CustomCommaSeparatedList{"backup", "iso"},
+			Server:  ptr.Ptr("10.0.0.10"),
+			Export:  ptr.Ptr("/export/backups"),
+			Nodes:   types.CustomCommaSeparatedList{fake.DefaultNodeName},
+			PruneBackups: &PruneBackups{
+				KeepLast:  ptr.Ptr(int64(3)),
+				KeepDaily: ptr.Ptr(int64(7)),
+			},
+		},
+	}))
+
+	ds, err := c.GetDatastore(ctx, "backups")
+	require.NoError(t, err)
+	assert.Equal(t, TypeNFS, *ds.Type)
+	assert.Equal(t, types.CustomCommaSeparatedList{"backup", "iso"}, ds.Content)
+	assert.Equal(t, types.CustomCommaSeparatedList{fake.DefaultNodeName}, ds.Nodes)
+	assert.Equal(t, "/mnt/pve/backups", *ds.Path, "the mount point must default to the datastore ID")
+	assert.Equal(t, int64(3), *ds.PruneBackups.KeepLast)
+	assert.Equal(t, int64(7), *ds.PruneBackups.KeepDaily)
+
+	err = c.UpdateDatastore(ctx, "backups", &DatastoreUpdateRequestBody{
+		DatastoreFields: DatastoreFields{Server: ptr.Ptr("10.0.0.11")},
+	})
+	require.Error(t, err, "the server of an NFS datastore is fixed")
+
+	require.NoError(t, c.UpdateDatastore(ctx, "backups", &DatastoreUpdateRequestBody{
+		DatastoreFields: DatastoreFields{
+			Disable: types.CustomBool(true).Pointer(),
+			Options: ptr.Ptr("vers=4.2"),
+		},
+		Delete: []string{"prune-backups", "nodes"},
+	}))
+
+	ds, err = c.GetDatastore(ctx, "backups")
+	require.NoError(t, err)
+	assert.True(t, bool(*ds.Disable))
+	assert.Equal(t, "vers=4.2", *ds.Options)
+	assert.Nil(t, ds.PruneBackups)
+	assert.Empty(t, ds.Nodes)
+
+	require.NoError(t, c.CreateDatastore(ctx, &DatastoreCreateRequestBody{
+		ID:   "pbs",
+		Type: TypePBS,
+		DatastoreFields: DatastoreFields{
+			Server:    ptr.Ptr("pbs.example.com"),
+			Datastore: ptr.Ptr("store1"),
+			Username:  ptr.Ptr("backup@pbs"),
+			Password:  ptr.Ptr("secret"),
+		},
+	}))
+
+	ds, err = c.GetDatastore(ctx, "pbs")
+	require.NoError(t, err)
+	assert.Equal(t, types.CustomCommaSeparatedList{"backup"}, ds.Content)
+	assert.Nil(t, ds.Password, "the password must not be returned")
+
+	list, err := c.ListDatastores(ctx)
+	require.NoError(t, err)
+
+	ids := make([]string, 0, len(list))
+	for _, d := range list {
+		ids = append(ids, *d.Storage)
+	}
+
+	assert.Equal(t, []string{"backups", "local", "local-lvm", "pbs"}, ids)
+
+	require.NoError(t, c.DeleteDatastore(ctx, "backups"))
+
+	_, err = c.GetDatastore(ctx, "backups")
+	require.ErrorIs(t, err, api.ErrResourceDoesNotExist)
+}
@@ -7,9 +7,132 @@
 package storage
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
 	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
 )
 
+const (
+	// TypeDir is the type of a datastore backed by a local directory.
+	TypeDir = "dir"
+
+	// TypeNFS is the type of a datastore backed by an NFS export.
+	TypeNFS = "nfs"
+
+	// TypeCIFS is the type of a datastore backed by a CIFS (SMB) share.
+	TypeCIFS = "cifs"
+
+	// TypeLVM is the type of a datastore backed by an LVM volume group.
+	TypeLVM = "lvm"
+
+	// TypeLVMThin is the type of a datastore backed by an LVM thin pool.
+	TypeLVMThin = "lvmthin"
+
+	// TypeZFSPool is the type of a datastore backed by a local ZFS pool.
+	TypeZFSPool = "zfspool"
+
+	// TypeRBD is the type of a datastore backed by a Ceph RADOS block device pool.
+	TypeRBD = "rbd"
+
+	// TypeCephFS is the type of a datastore backed by a CephFS file system.
+	TypeCephFS = "cephfs"
+
+	// TypePBS is the type of a datastore backed by a Proxmox Backup Server datastore.
+	TypePBS = "pbs"
+)
+
+// DatastoreFields contains the fields of a datastore definition that can be set on create and update.
+type DatastoreFields struct {
+	Content      types.CustomCommaSeparatedList `json:"content,omitempty"       url:"content,omitempty,comma"`
+	Disable      *types.CustomBool              `json:"disable,omitempty"       url:"disable,omitempty,int"`
+	Nodes        types.CustomCommaSeparatedList `json:"nodes,omitempty"         url:"nodes,omitempty,comma"`
+	PruneBackups *PruneBackups                  `json:"prune-backups,omitempty" url:"prune-backups,omitempty"`
+	Shared       *types.CustomBool              `json:"shared,omitempty"        url:"shared,omitempty,int"`
+
+	// dir, nfs and cifs options
+	Preallocation *string `json:"preallocation,omitempty" url:"preallocation,omitempty"`
+
+	// dir, nfs, cifs and cephfs options
+	Path *string `json:"path,omitempty" url:"path,omitempty"`
+
+	// nfs, cifs and cephfs options
+	Options *string `json:"options,omitempty" url:"options,omitempty"`
+
+	// nfs, cifs and pbs options
+	Server *string `json:"server,omitempty" url:"server,omitempty"`
+
+	// nfs only options
+	Export *string `json:"export,omitempty" url:"export,omitempty"`
+
+	// cifs only options
+	Domain     *string `json:"domain,omitempty"     url:"domain,omitempty"`
+	Share      *string `json:"share,omitempty"      url:"share,omitempty"`
+	SMBVersion *string `json:"smbversion,omitempty" url:"smbversion,omitempty"`
+
+	// cifs and cephfs options
+	Subdir *string `json:"subdir,omitempty" url:"subdir,omitempty"`
+
+	// cifs, pbs, rbd and cephfs options
+	Username *string `json:"username,omitempty" url:"username,omitempty"`
+
+	// cifs and pbs options, never returned by the API
+	Password *string `json:"password,omitempty" url:"password,omitempty"`
+
+	// lvm and lvmthin options
+	VGName *string `json:"vgname,omitempty" url:"vgname,omitempty"`
+
+	// lvm only options
+	SafeRemove *types.CustomBool `json:"saferemove,omitempty" url:"saferemove,omitempty,int"`
+
+	// lvmthin only options
+	ThinPool *string `json:"thinpool,omitempty" url:"thinpool,omitempty"`
+
+	// zfspool and rbd options
+	Pool *string `json:"pool,omitempty" url:"pool,omitempty"`
+
+	// zfspool only options
+	BlockSize *string           `json:"blocksize,omitempty" url:"blocksize,omitempty"`
+	Sparse    *types.CustomBool `json:"sparse,omitempty"    url:"sparse,omitempty,int"`
+
+	// rbd and cephfs options
+	MonHost *string `json:"monhost,omitempty" url:"monhost,omitempty"`
+
+	// rbd and cephfs options, never returned by the API
+	Keyring *string `json:"keyring,omitempty" url:"keyring,omitempty"`
+
+	// rbd only options
+	KRBD *types.CustomBool `json:"krbd,omitempty" url:"krbd,omitempty,int"`
+
+	// rbd and pbs options
+	Namespace *string `json:"namespace,omitempty" url:"namespace,omitempty"`
+
+	// cephfs only options
+	FSName *string `json:"fs-name,omitempty" url:"fs-name,omitempty"`
+
+	// pbs only options
+	Datastore   *string            `json:"datastore,omitempty"   url:"datastore,omitempty"`
+	Fingerprint *string            `json:"fingerprint,omitempty" url:"fingerprint,omitempty"`
+	Port        *types.CustomInt64 `json:"port,omitempty"        url:"port,omitempty"`
+
+	// pbs only options, never returned by the API
+	EncryptionKey *string `json:"encryption-key,omitempty" url:"encryption-key,omitempty"`
+}
+
+// PruneBackups contains the retention options of the backups stored on a datastore.
+type PruneBackups struct {
+	KeepAll     *types.CustomBool `json:"keep-all,omitempty"`
+	KeepLast    *int64            `json:"keep-last,omitempty"`
+	KeepHourly  *int64            `json:"keep-hourly,omitempty"`
+	KeepDaily   *int64            `json:"keep-daily,omitempty"`
+	KeepWeekly  *int64            `json:"keep-weekly,omitempty"`
+	KeepMonthly *int64            `json:"keep-monthly,omitempty"`
+	KeepYearly  *int64            `json:"keep-yearly,omitempty"`
+}
+
 // DatastoreGetResponseBody contains the body from a datastore get response.
 type DatastoreGetResponseBody struct {
 	Data *DatastoreGetResponseData `json:"data,omitempty"`
@@ -17,10 +140,109 @@ type DatastoreGetResponseBody struct {
 
 // DatastoreGetResponseData contains the data from a datastore get response.
 type DatastoreGetResponseData struct {
-	Content types.CustomCommaSeparatedList `json:"content,omitempty" url:"content,omitempty,comma"`
-	Digest  *string                        `json:"digest,omitempty"`
-	Path    *string                        `json:"path,omitempty"`
-	Shared  *types.CustomBool              `json:"shared,omitempty"`
-	Storage *string                        `json:"storage,omitempty"`
-	Type    *string                        `json:"type,omitempty"`
+	DatastoreFields
+
+	Digest  *string `json:"digest,omitempty"`
+	Storage *string `json:"storage,omitempty"`
+	Type    *string `json:"type,omitempty"`
+}
+
+// DatastoreListResponseBody contains the body from a datastore list response.
+type DatastoreListResponseBody struct {
+	Data []*DatastoreGetResponseData `json:"data,omitempty"`
+}
+
+// DatastoreCreateRequestBody contains the body for creating a datastore.
+type DatastoreCreateRequestBody struct {
+	DatastoreFields
+
+	ID   string `url:"storage"`
+	Type string `url:"type"`
+}
+
+// DatastoreUpdateRequestBody contains the body for updating a datastore.
+// The options that are fixed for the type of the datastore must not be set.
+type DatastoreUpdateRequestBody struct {
+	DatastoreFields
+
+	Delete []string `url:"delete,omitempty,comma"`
+}
+
+// EncodeValues converts a PruneBackups struct to a URL value.
+func (r *PruneBackups) EncodeValues(key string, v *url.Values) error {
+	var values []string
+
+	if r.KeepAll != nil {
+		if *r.KeepAll {
+			values = append(values, "keep-all=1")
+		} else {
+			values = append(values, "keep-all=0")
+		}
+	}
+
+	for _, kv := range []struct {
+		name  string
+		value *int64
+	}{
+		{"keep-last", r.KeepLast},
+		{"keep-hourly", r.KeepHourly},
+		{"keep-daily", r.KeepDaily},
+		{"keep-weekly", r.KeepWeekly},
+		{"keep-monthly", r.KeepMonthly},
+		{"keep-yearly", r.KeepYearly},
+	} {
+		if kv.value != nil {
+			values = append(values, fmt.Sprintf("%s=%d", kv.name, *kv.value))
+		}
+	}
+
+	v.Add(key, strings.Join(values, ","))
+
+	return nil
+}
+
+// UnmarshalJSON unmarshals a PruneBackups struct from JSON.
+func (r *PruneBackups) UnmarshalJSON(b []byte) error {
+	var s string
+
+	err := json.Unmarshal(b, &s)
+	if err != nil {
+		return fmt.Errorf("error unmarshaling json: %w", err)
+	}
+
+	for _, p := range strings.Split(s, ",") {
+		k, v, ok := strings.Cut(strings.TrimSpace(p), "=")
+		if !ok {
+			continue
+		}
+
+		if k == "keep-all" {
+			keepAll := types.CustomBool(v == "1")
+			r.KeepAll = &keepAll
+
+			continue
+		}
+
+		i, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return fmt.Errorf("error converting %s to int: %w", k, err)
+		}
+
+		switch k {
+		case "keep-last":
+			r.KeepLast = &i
+		case "keep-hourly":
+			r.KeepHourly = &i
+		case "keep-daily":
+			r.KeepDaily = &i
+		case "keep-weekly":
+			r.KeepWeekly = &i
+		case "keep-monthly":
+			r.KeepMonthly = &i
+		case "keep-yearly":
+			r.KeepYearly = &i
+		}
+	}
+
+	return nil
 }
@@ -65,6 +65,11 @@ func (r CustomBool) MarshalJSON() ([]byte, error) {
 // UnmarshalJSON converts a JSON value to a boolean.
 func (r *CustomBool) UnmarshalJSON(b []byte) error {
 	s := string(b)
+
+	if strings.HasPrefix(s, "\"") && strings.HasSuffix(s, "\"") {
+		s = s[1 : len(s)-1]
+	}
+
 	*r = s == "1" || s == "true"
 
 	return nil
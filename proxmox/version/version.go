@@ -29,3 +29,13 @@ func (c *Client) Version(ctx context.Context) (*ResponseData, error) {
 
 	return resBody.Data, nil
 }
+
+// GetProxmoxVersion retrieves the parsed version of the Proxmox VE release.
+func (c *Client) GetProxmoxVersion(ctx context.Context) (ProxmoxVersion, error) {
+	data, err := c.Version(ctx)
+	if err != nil {
+		return ProxmoxVersion{}, err
+	}
+
+	return ParseVersion(data.Version)
+}
@@ -6,6 +6,12 @@
 
 package version
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
 // ResponseBody contains the body from a version response.
 type ResponseBody struct {
 	Data *ResponseData `json:"data,omitempty"`
@@ -18,3 +24,51 @@ type ResponseData struct {
 	RepositoryID string `json:"repoid"`
 	Version      string `json:"version"`
 }
+
+// ProxmoxVersion is a Proxmox VE release version.
+type ProxmoxVersion struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+// ParseVersion parses a Proxmox VE version, e.g. `8.3.0`, or `7.4-3` of the older releases.
+func ParseVersion(s string) (ProxmoxVersion, error) {
+	var v ProxmoxVersion
+
+	parts := strings.FieldsFunc(s, func(r rune) bool { return r == '.' || r == '-' || r == '~' })
+	if len(parts) < 2 {
+		return v, fmt.Errorf("invalid Proxmox VE version %q", s)
+	}
+
+	fields := []*int{&v.Major, &v.Minor, &v.Patch}
+
+	for i, p := range parts[:min(len(parts), len(fields))] {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return v, fmt.Errorf("invalid Proxmox VE version %q: %w", s, err)
+		}
+
+		*fields[i] = n
+	}
+
+	return v, nil
+}
+
+// AtLeast returns true if the version is the same as, or newer than the other version.
+func (v ProxmoxVersion) AtLeast(other ProxmoxVersion) bool {
+	if v.Major != other.Major {
+		return v.Major > other.Major
+	}
+
+	if v.Minor != other.Minor {
+		return v.Minor > other.Minor
+	}
+
+	return v.Patch >= other.Patch
+}
+
+// String returns the version in the `major.minor.patch` format.
+func (v ProxmoxVersion) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
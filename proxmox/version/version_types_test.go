@@ -0,0 +1,58 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		version string
+		want    ProxmoxVersion
+		wantErr bool
+	}{
+		{"8.3.0", ProxmoxVersion{8, 3, 0}, false},
+		{"7.4-3", ProxmoxVersion{7, 4, 3}, false},
+		{"9.0.0~11", ProxmoxVersion{9, 0, 0}, false},
+		{"8.4", ProxmoxVersion{8, 4, 0}, false},
+		{"8", ProxmoxVersion{}, true},
+		{"eight.one", ProxmoxVersion{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			t.Parallel()
+
+			v, err := ParseVersion(tt.version)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, v)
+		})
+	}
+}
+
+func TestProxmoxVersionAtLeast(t *testing.T) {
+	t.Parallel()
+
+	v := ProxmoxVersion{8, 3, 2}
+
+	assert.True(t, v.AtLeast(ProxmoxVersion{8, 3, 2}))
+	assert.True(t, v.AtLeast(ProxmoxVersion{8, 3, 0}))
+	assert.True(t, v.AtLeast(ProxmoxVersion{7, 4, 9}))
+	assert.False(t, v.AtLeast(ProxmoxVersion{8, 4, 0}))
+	assert.False(t, v.AtLeast(ProxmoxVersion{9, 0, 0}))
+}
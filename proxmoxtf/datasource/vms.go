@@ -124,7 +124,7 @@ func vmsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Di
 		listData, e := api.Node(nodeName).VM(0).ListVMs(ctx)
 		if e != nil {
 			var httpError *proxmoxapi.HTTPError
-			if errors.As(e, &httpError) && httpError.Code == 595 {
+			if errors.As(e, &httpError) && httpError.Code == proxmoxapi.StatusNoRouteToHost {
 				diags = append(diags, diag.Diagnostic{
 					Severity: diag.Warning,
 					Summary:  fmt.Sprintf("node %q is not available - VM list may be incomplete", nodeName),
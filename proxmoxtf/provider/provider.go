@@ -8,35 +8,43 @@ package provider
 
 import (
 	"context"
-	"fmt"
-	"net"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
+	"github.com/bpg/terraform-provider-proxmox/proxmox"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster"
-	"github.com/bpg/terraform-provider-proxmox/proxmox/nodes"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/ssh"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/ssh/resolver"
 	"github.com/bpg/terraform-provider-proxmox/proxmoxtf"
 	"github.com/bpg/terraform-provider-proxmox/utils"
+	"github.com/bpg/terraform-provider-proxmox/utils/profile"
 )
 
-// ProxmoxVirtualEnvironment returns the object for this provider.
-func ProxmoxVirtualEnvironment() *schema.Provider {
+// ProxmoxVirtualEnvironment returns the object for this provider. The shared state is shared with the framework
+// provider served by the same provider instance, it may be nil. The connection options are applied to the API
+// connection after the provider configuration, e.g. to record and replay the API interactions in tests.
+func ProxmoxVirtualEnvironment(shared *proxmox.SharedState, opts ...api.ConnectionOption) *schema.Provider {
 	return &schema.Provider{
-		ConfigureContextFunc: providerConfigure,
-		DataSourcesMap:       createDatasourceMap(),
-		ResourcesMap:         createResourceMap(),
-		Schema:               createSchema(),
+		ConfigureContextFunc: func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+			return providerConfigure(ctx, d, shared, opts)
+		},
+		DataSourcesMap: createDatasourceMap(),
+		ResourcesMap:   createResourceMap(),
+		Schema:         createSchema(),
 	}
 }
 
-func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
-	var err error
-
+func providerConfigure(
+	ctx context.Context,
+	d *schema.ResourceData,
+	shared *proxmox.SharedState,
+	connOpts []api.ConnectionOption,
+) (interface{}, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
 	var apiClient api.Client
@@ -49,22 +57,58 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 
 	tflog.Info(ctx, "Configuring the SDK Proxmox provider...")
 
-	// Check environment variables
-	endpoint := utils.GetAnyStringEnv("PROXMOX_VE_ENDPOINT", "PM_VE_ENDPOINT")
-	insecure := utils.GetAnyBoolEnv("PROXMOX_VE_INSECURE", "PM_VE_INSECURE")
-	minTLS := utils.GetAnyStringEnv("PROXMOX_VE_MIN_TLS", "PM_VE_MIN_TLS")
+	profileName := utils.GetAnyStringEnv("PROXMOX_VE_PROFILE")
+
+	if v, ok := d.GetOk(mkProviderProfile); ok {
+		profileName = v.(string)
+	}
+
+	prof, err := profile.Load(profileName)
+	if err != nil {
+		return nil, diag.Errorf("error loading configuration profile: %s", err)
+	}
+
+	if prof != nil {
+		tflog.Info(ctx, "Using configuration profile", map[string]interface{}{
+			"profile":  prof.Name,
+			"settings": prof.Keys(),
+		})
+	}
+
+	// Check environment variables, then the configuration profile
+	endpoint := prof.String(profile.KeyEndpoint, "PROXMOX_VE_ENDPOINT", "PM_VE_ENDPOINT")
+	endpoints := prof.Strings(profile.KeyEndpoints, "PROXMOX_VE_ENDPOINTS")
+	insecure := prof.Bool(profile.KeyInsecure, "PROXMOX_VE_INSECURE", "PM_VE_INSECURE")
+	minTLS := prof.String(profile.KeyMinTLS, "PROXMOX_VE_MIN_TLS", "PM_VE_MIN_TLS")
+	caCertificate := prof.String(profile.KeyCACertificate, "PROXMOX_VE_CA_CERTIFICATE")
+	tlsFingerprint := prof.String(profile.KeyTLSFingerprint, "PROXMOX_VE_TLS_FINGERPRINT")
+	cancelTasks := utils.GetAnyBoolEnv("PROXMOX_VE_CANCEL_TASKS_ON_INTERRUPT")
 	authTicket := utils.GetAnyStringEnv("PROXMOX_VE_AUTH_TICKET", "PM_VE_AUTH_TICKET")
 	csrfPreventionToken := utils.GetAnyStringEnv("PROXMOX_VE_CSRF_PREVENTION_TOKEN", "PM_VE_CSRF_PREVENTION_TOKEN")
 	apiToken := utils.GetAnyStringEnv("PROXMOX_VE_API_TOKEN", "PM_VE_API_TOKEN")
 	otp := utils.GetAnyStringEnv("PROXMOX_VE_OTP", "PM_VE_OTP")
 	username := utils.GetAnyStringEnv("PROXMOX_VE_USERNAME", "PM_VE_USERNAME")
 	password := utils.GetAnyStringEnv("PROXMOX_VE_PASSWORD", "PM_VE_PASSWORD")
+	credentialsCommand := strings.Fields(utils.GetAnyStringEnv("PROXMOX_VE_CREDENTIALS_COMMAND"))
 
 	if v, ok := d.GetOk(mkProviderEndpoint); ok {
 		endpoint = v.(string)
 	}
 
-	if v, ok := d.GetOk(mkProviderInsecure); ok {
+	if v, ok := d.GetOk(mkProviderEndpoints); ok {
+		endpoints = nil
+
+		for _, e := range v.([]interface{}) {
+			endpoints = append(endpoints, e.(string))
+		}
+	}
+
+	if endpoint == "" && len(endpoints) > 0 {
+		endpoint, endpoints = endpoints[0], endpoints[1:]
+	}
+
+	//nolint:staticcheck //using GetOkExists to allow overriding the environment variable and the profile with false
+	if v, ok := d.GetOkExists(mkProviderInsecure); ok {
 		insecure = v.(bool)
 	}
 
@@ -72,6 +116,19 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 		minTLS = v.(string)
 	}
 
+	if v, ok := d.GetOk(mkProviderCACertificate); ok {
+		caCertificate = v.(string)
+	}
+
+	if v, ok := d.GetOk(mkProviderTLSFingerprint); ok {
+		tlsFingerprint = v.(string)
+	}
+
+	//nolint:staticcheck //using GetOkExists to allow overriding the environment variable with false
+	if v, ok := d.GetOkExists(mkProviderCancelTasks); ok {
+		cancelTasks = v.(bool)
+	}
+
 	if v, ok := d.GetOk(mkProviderAuthTicket); ok {
 		authTicket = v.(string)
 	}
@@ -99,10 +156,71 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 		password = v.(string)
 	}
 
-	creds, err = api.NewCredentials(username, password, otp, apiToken, authTicket, csrfPreventionToken)
+	if v, ok := d.GetOk(mkProviderCredentialsCommand); ok {
+		credentialsCommand = nil
+
+		for _, c := range v.([]interface{}) {
+			credentialsCommand = append(credentialsCommand, c.(string))
+		}
+	}
+
+	// The credentials of the profile are only used if no others are set, they are never mixed with them.
+	credentials := prof.CredentialsOverride(profile.Credentials{
+		APIToken:            apiToken,
+		Username:            username,
+		Password:            password,
+		AuthTicket:          authTicket,
+		CSRFPreventionToken: csrfPreventionToken,
+		CredentialsCommand:  credentialsCommand,
+	})
+	apiToken, username, password = credentials.APIToken, credentials.Username, credentials.Password
+	credentialsCommand = credentials.CredentialsCommand
+
+	var commandCreds api.CredentialsCommandOutput
+
+	if len(credentialsCommand) > 0 {
+		commandCreds, err = shared.CredentialsCommands().Run(ctx, credentialsCommand)
+		if err != nil {
+			return nil, diag.Errorf("error getting credentials: %s", err)
+		}
+	}
+
+	if commandCreds.HasAPICredentials() {
+		creds, err = shared.CredentialsCommands().NewCredentials(credentialsCommand, commandCreds)
+	} else {
+		creds, err = api.NewCredentials(username, password, otp, apiToken, authTicket, csrfPreventionToken)
+	}
+
 	diags = append(diags, diag.FromErr(err)...)
 
-	conn, err = api.NewConnection(endpoint, insecure, minTLS)
+	retryPolicy := api.DefaultRetryPolicy()
+
+	if retryBlock := d.Get(mkProviderAPIRetry).([]interface{}); len(retryBlock) > 0 && retryBlock[0] != nil {
+		applyAPIRetryConfig(d, &retryPolicy)
+	}
+
+	var limits api.Limits
+
+	if limitsBlock := d.Get(mkProviderAPILimits).([]interface{}); len(limitsBlock) > 0 && limitsBlock[0] != nil {
+		limitsConf := limitsBlock[0].(map[string]interface{})
+		limits.MaxConcurrentRequests = limitsConf[mkProviderAPILimitsMaxConcurrentRequests].(int)
+		limits.MaxConcurrentTasksPerNode = limitsConf[mkProviderAPILimitsMaxConcurrentTasksPerNode].(int)
+		limits.RequestsPerSecond = limitsConf[mkProviderAPILimitsRequestsPerSecond].(float64)
+	}
+
+	conn, err = api.NewConnection(
+		endpoint,
+		insecure,
+		minTLS,
+		append([]api.ConnectionOption{
+			api.WithRetryPolicy(retryPolicy),
+			api.WithFailoverEndpoints(endpoints...),
+			api.WithCACertificate(caCertificate),
+			api.WithTLSFingerprint(tlsFingerprint),
+			api.WithCancelTasksOnInterrupt(cancelTasks),
+			api.WithSharedLimits(shared.Limiters(), limits),
+		}, connOpts...)...,
+	)
 	diags = append(diags, diag.FromErr(err)...)
 
 	if diags.HasError() {
@@ -123,14 +241,37 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 		sshConf = sshBlock[0].(map[string]interface{})
 	}
 
-	sshUsername := utils.GetAnyStringEnv("PROXMOX_VE_SSH_USERNAME", "PM_VE_SSH_USERNAME")
-	sshPassword := utils.GetAnyStringEnv("PROXMOX_VE_SSH_PASSWORD", "PM_VE_SSH_PASSWORD")
-	sshAgent := utils.GetAnyBoolEnv("PROXMOX_VE_SSH_AGENT", "PM_VE_SSH_AGENT")
-	sshAgentSocket := utils.GetAnyStringEnv("SSH_AUTH_SOCK", "PROXMOX_VE_SSH_AUTH_SOCK", "PM_VE_SSH_AUTH_SOCK")
-	sshPrivateKey := utils.GetAnyStringEnv("PROXMOX_VE_SSH_PRIVATE_KEY")
-	sshSocks5Server := utils.GetAnyStringEnv("PROXMOX_VE_SSH_SOCKS5_SERVER")
-	sshSocks5Username := utils.GetAnyStringEnv("PROXMOX_VE_SSH_SOCKS5_USERNAME")
-	sshSocks5Password := utils.GetAnyStringEnv("PROXMOX_VE_SSH_SOCKS5_PASSWORD")
+	sshUsername := prof.String(profile.KeySSHUsername, "PROXMOX_VE_SSH_USERNAME", "PM_VE_SSH_USERNAME")
+	sshPassword := prof.String(profile.KeySSHPassword, "PROXMOX_VE_SSH_PASSWORD", "PM_VE_SSH_PASSWORD")
+	sshAgent := prof.Bool(profile.KeySSHAgent, "PROXMOX_VE_SSH_AGENT", "PM_VE_SSH_AGENT")
+	sshAgentSocket := prof.String(profile.KeySSHAgentSocket,
+		"SSH_AUTH_SOCK", "PROXMOX_VE_SSH_AUTH_SOCK", "PM_VE_SSH_AUTH_SOCK")
+	sshPrivateKey := prof.String(profile.KeySSHPrivateKey, "PROXMOX_VE_SSH_PRIVATE_KEY")
+	sshPrivateKeyPassphrase := prof.String(profile.KeySSHPrivateKeyPassphrase, "PROXMOX_VE_SSH_PRIVATE_KEY_PASSPHRASE")
+	sshCertificate := prof.String(profile.KeySSHCertificate, "PROXMOX_VE_SSH_CERTIFICATE")
+	sshSocks5Server := prof.String(profile.KeySSHSocks5Server, "PROXMOX_VE_SSH_SOCKS5_SERVER")
+	sshSocks5Username := prof.String(profile.KeySSHSocks5Username, "PROXMOX_VE_SSH_SOCKS5_USERNAME")
+	sshSocks5Password := prof.String(profile.KeySSHSocks5Password, "PROXMOX_VE_SSH_SOCKS5_PASSWORD")
+	sshHostKeyVerification := prof.String(profile.KeySSHHostKeyVerification, "PROXMOX_VE_SSH_HOST_KEY_VERIFICATION")
+	sshKnownHostsFile := prof.String(profile.KeySSHKnownHostsFile, "PROXMOX_VE_SSH_KNOWN_HOSTS_FILE")
+	sshKnownHosts := prof.String(profile.KeySSHKnownHosts, "PROXMOX_VE_SSH_KNOWN_HOSTS")
+	sshNodeAddressSource := prof.String(profile.KeySSHNodeAddressSource, "PROXMOX_VE_SSH_NODE_ADDRESS_SOURCE")
+
+	if commandCreds.SSHUsername != "" {
+		sshConf[mkProviderSSHUsername] = commandCreds.SSHUsername
+	}
+
+	if commandCreds.SSHPassword != "" {
+		sshConf[mkProviderSSHPassword] = commandCreds.SSHPassword
+	}
+
+	if commandCreds.SSHPrivateKey != "" {
+		sshConf[mkProviderSSHPrivateKey] = commandCreds.SSHPrivateKey
+	}
+
+	if commandCreds.SSHPrivateKeyPassphrase != "" {
+		sshConf[mkProviderSSHPrivateKeyPass] = commandCreds.SSHPrivateKeyPassphrase
+	}
 
 	if v, ok := sshConf[mkProviderSSHUsername]; !ok || v.(string) == "" {
 		switch {
@@ -166,6 +307,14 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 		sshConf[mkProviderSSHPrivateKey] = sshPrivateKey
 	}
 
+	if v, ok := sshConf[mkProviderSSHPrivateKeyPass]; !ok || v.(string) == "" {
+		sshConf[mkProviderSSHPrivateKeyPass] = sshPrivateKeyPassphrase
+	}
+
+	if v, ok := sshConf[mkProviderSSHCertificate]; !ok || v.(string) == "" {
+		sshConf[mkProviderSSHCertificate] = sshCertificate
+	}
+
 	if v, ok := sshConf[mkProviderSSHSocks5Server]; !ok || v.(string) == "" {
 		sshConf[mkProviderSSHSocks5Server] = sshSocks5Server
 	}
@@ -178,7 +327,42 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 		sshConf[mkProviderSSHSocks5Password] = sshSocks5Password
 	}
 
-	nodeOverrides := map[string]ssh.ProxmoxNode{}
+	if v, ok := sshConf[mkProviderSSHHostKeyVerify]; !ok || v.(string) == "" {
+		sshConf[mkProviderSSHHostKeyVerify] = sshHostKeyVerification
+	}
+
+	if v, ok := sshConf[mkProviderSSHKnownHostsFile]; !ok || v.(string) == "" {
+		sshConf[mkProviderSSHKnownHostsFile] = sshKnownHostsFile
+	}
+
+	if v, ok := sshConf[mkProviderSSHKnownHosts]; !ok || v.(string) == "" {
+		sshConf[mkProviderSSHKnownHosts] = sshKnownHosts
+	}
+
+	if v, ok := sshConf[mkProviderSSHNodeAddressSource]; !ok || v.(string) == "" {
+		sshConf[mkProviderSSHNodeAddressSource] = sshNodeAddressSource
+	}
+
+	var configuredCIDRs []string
+
+	if cidrs, ok := sshConf[mkProviderSSHNodeAddressCIDRs].([]interface{}); ok {
+		for _, c := range cidrs {
+			configuredCIDRs = append(configuredCIDRs, c.(string))
+		}
+	}
+
+	sshNodeAddressCIDRs := prof.StringsOverride(profile.KeySSHNodeAddressCIDRs, configuredCIDRs)
+
+	nodeResolver, err := resolver.New(
+		apiClient,
+		resolver.AddressSource(sshConf[mkProviderSSHNodeAddressSource].(string)),
+		sshNodeAddressCIDRs,
+	)
+	if err != nil {
+		return nil, diag.Errorf("error creating node address resolver: %s", err)
+	}
+
+	nodeOverrides := prof.Nodes()
 
 	if ns, ok := sshConf[mkProviderSSHNode]; ok {
 		for _, n := range ns.([]interface{}) {
@@ -191,6 +375,38 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 		}
 	}
 
+	var bastions []ssh.Bastion
+
+	if bs, ok := sshConf[mkProviderSSHBastion]; ok {
+		for _, b := range bs.([]interface{}) {
+			bastion := b.(map[string]interface{})
+			bastions = append(bastions, ssh.Bastion{
+				Address:             bastion[mkProviderSSHBastionAddress].(string),
+				Port:                int32(bastion[mkProviderSSHBastionPort].(int)),
+				Username:            bastion[mkProviderSSHBastionUsername].(string),
+				Password:            bastion[mkProviderSSHBastionPassword].(string),
+				PrivateKey:          bastion[mkProviderSSHBastionPrivateKey].(string),
+				Agent:               bastion[mkProviderSSHBastionAgent].(bool),
+				HostKeyVerification: ssh.HostKeyVerification(bastion[mkProviderSSHBastionHostKeyVerification].(string)),
+				KnownHostsFile:      bastion[mkProviderSSHBastionKnownHostsFile].(string),
+				KnownHosts:          bastion[mkProviderSSHBastionKnownHosts].(string),
+			})
+		}
+	}
+
+	pool := ssh.PoolConfig{
+		MaxSessionsPerNode: ssh.DefaultMaxSessionsPerNode,
+		KeepAliveInterval:  ssh.DefaultKeepAliveInterval,
+	}
+
+	if v, ok := sshConf[mkProviderSSHMaxSessions]; ok && v.(int) > 0 {
+		pool.MaxSessionsPerNode = v.(int)
+	}
+
+	if v, ok := sshConf[mkProviderSSHKeepAlive]; ok && v.(string) != "" {
+		pool.KeepAliveInterval, _ = time.ParseDuration(v.(string))
+	}
+
 	sshClient, err = ssh.NewClient(
 		sshConf[mkProviderSSHUsername].(string),
 		sshConf[mkProviderSSHPassword].(string),
@@ -201,16 +417,25 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 		sshConf[mkProviderSSHSocks5Username].(string),
 		sshConf[mkProviderSSHSocks5Password].(string),
 		&apiResolverWithOverrides{
-			ar:        apiResolver{c: apiClient},
+			ar:        nodeResolver,
 			overrides: nodeOverrides,
 		},
+		ssh.WithHostKeyVerification(
+			ssh.HostKeyVerification(sshConf[mkProviderSSHHostKeyVerify].(string)),
+			sshConf[mkProviderSSHKnownHostsFile].(string),
+			sshConf[mkProviderSSHKnownHosts].(string),
+		),
+		ssh.WithPrivateKeyPassphrase(sshConf[mkProviderSSHPrivateKeyPass].(string)),
+		ssh.WithCertificate(sshConf[mkProviderSSHCertificate].(string)),
+		ssh.WithBastions(bastions...),
+		ssh.WithSharedConnectionPool(shared.SSHPool(), pool),
 	)
 	if err != nil {
 		return nil, diag.Errorf("error creating SSH client: %s", err)
 	}
 
 	// Intentionally use 'PROXMOX_VE_TMPDIR' with 'TMP' instead of 'TEMP', to match os.TempDir's use of $TMPDIR
-	tmpDirOverride := utils.GetAnyStringEnv("PROXMOX_VE_TMPDIR", "PM_VE_TMPDIR")
+	tmpDirOverride := prof.String(profile.KeyTmpDir, "PROXMOX_VE_TMPDIR", "PM_VE_TMPDIR")
 
 	if v, ok := d.GetOk(mkProviderTmpDir); ok {
 		tmpDirOverride = v.(string)
@@ -238,83 +463,44 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 	return config, nil
 }
 
-type apiResolver struct {
-	c api.Client
-}
-
-func (r *apiResolver) Resolve(ctx context.Context, nodeName string) (ssh.ProxmoxNode, error) {
-	nc := &nodes.Client{Client: r.c, NodeName: nodeName}
+// applyAPIRetryConfig overrides the fields of the retry policy set in the `api_retry` block.
+// The durations are already validated by the schema.
+func applyAPIRetryConfig(d *schema.ResourceData, policy *api.RetryPolicy) {
+	prefix := mkProviderAPIRetry + ".0."
 
-	networkDevices, err := nc.ListNetworkInterfaces(ctx)
-	if err != nil {
-		return ssh.ProxmoxNode{}, fmt.Errorf("failed to list network devices of node %q: %w", nc.NodeName, err)
+	if v, ok := d.GetOk(prefix + mkProviderAPIRetryAttempts); ok {
+		policy.Attempts = uint(v.(int))
 	}
 
-	nodeAddress := ""
-
-	// try IPv4 address on the interface with IPv4 gateway
-	tflog.Debug(ctx, "Attempting to find interfaces with both a static IPV4 address and gateway.")
-
-	for _, d := range networkDevices {
-		if d.Gateway != nil && d.Address != nil {
-			nodeAddress = *d.Address
-			break
-		}
+	if v, ok := d.GetOk(prefix + mkProviderAPIRetryInitialDelay); ok {
+		policy.InitialDelay, _ = time.ParseDuration(v.(string))
 	}
 
-	if nodeAddress == "" {
-		// fallback 1: try IPv6 address on the interface with IPv6 gateway
-		tflog.Debug(ctx, "Attempting to find interfaces with both a static IPV6 address and gateway.")
-
-		for _, d := range networkDevices {
-			if d.Gateway6 != nil && d.Address6 != nil {
-				nodeAddress = *d.Address6
-				break
-			}
-		}
+	if v, ok := d.GetOk(prefix + mkProviderAPIRetryMaxDelay); ok {
+		policy.MaxDelay, _ = time.ParseDuration(v.(string))
 	}
 
-	if nodeAddress == "" {
-		// fallback 2: use first interface with any IPv4 address
-		tflog.Debug(ctx, "Attempting to find interfaces with at least a static IPV4 address.")
-
-		for _, d := range networkDevices {
-			if d.Address != nil {
-				nodeAddress = *d.Address
-				break
-			}
-		}
+	//nolint:staticcheck // using GetOkExists to tell `false` from an unset value
+	if v, ok := d.GetOkExists(prefix + mkProviderAPIRetryJitter); ok {
+		policy.Jitter = v.(bool)
 	}
 
-	if nodeAddress == "" {
-		// fallback 3: do a good old DNS lookup
-		tflog.Debug(ctx, fmt.Sprintf("Attempting a DNS lookup of node %q.", nc.NodeName))
+	if v, ok := d.GetOk(prefix + mkProviderAPIRetryRetryableStatusCodes); ok {
+		codes := v.([]interface{})
 
-		ips, err := net.LookupIP(nodeName)
-		if err == nil {
-			for _, ip := range ips {
-				if ipv4 := ip.To4(); ipv4 != nil {
-					nodeAddress = ipv4.String()
-					break
-				}
-			}
-		} else {
-			tflog.Debug(ctx, fmt.Sprintf("Failed to do a DNS lookup of the node: %s", err.Error()))
+		policy.RetryableStatusCodes = make([]int, len(codes))
+		for i, c := range codes {
+			policy.RetryableStatusCodes[i] = c.(int)
 		}
 	}
 
-	if nodeAddress == "" {
-		return ssh.ProxmoxNode{}, fmt.Errorf("failed to determine the IP address of node \"%s\"", nc.NodeName)
+	if v, ok := d.GetOk(prefix + mkProviderAPIRetryRetryNonIdempotent); ok {
+		policy.RetryNonIdempotent = v.(bool)
 	}
-
-	nodeAddressParts := strings.Split(nodeAddress, "/")
-	node := ssh.ProxmoxNode{Address: nodeAddressParts[0], Port: 22}
-
-	return node, nil
 }
 
 type apiResolverWithOverrides struct {
-	ar        apiResolver
+	ar        ssh.NodeResolver
 	overrides map[string]ssh.ProxmoxNode
 }
 
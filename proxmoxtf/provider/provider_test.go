@@ -7,10 +7,16 @@
 package provider
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/require"
 
+	"github.com/bpg/terraform-provider-proxmox/proxmox/fake"
+	"github.com/bpg/terraform-provider-proxmox/proxmoxtf"
 	"github.com/bpg/terraform-provider-proxmox/proxmoxtf/test"
 )
 
@@ -18,7 +24,7 @@ import (
 func TestProviderInstantiation(t *testing.T) {
 	t.Parallel()
 
-	s := ProxmoxVirtualEnvironment()
+	s := ProxmoxVirtualEnvironment(nil)
 	if s == nil {
 		t.Fatalf("Cannot instantiate ProxmoxVirtualEnvironment")
 	}
@@ -28,7 +34,7 @@ func TestProviderInstantiation(t *testing.T) {
 func TestProviderSchema(t *testing.T) {
 	t.Parallel()
 
-	s := ProxmoxVirtualEnvironment().Schema
+	s := ProxmoxVirtualEnvironment(nil).Schema
 
 	test.AssertOptionalArguments(t, s, []string{
 		mkProviderEndpoint,
@@ -57,3 +63,67 @@ func TestProviderSchema(t *testing.T) {
 	// do not limit number of nodes in the cluster
 	test.AssertListMaxItems(t, providerSSHSchema, mkProviderSSHNode, 0)
 }
+
+// TestProviderProfilePrecedence tests that the provider configuration takes precedence over the profile.
+//
+//nolint:paralleltest // the test sets environment variables
+func TestProviderProfilePrecedence(t *testing.T) {
+	srv := fake.NewServer()
+	t.Cleanup(srv.Close)
+
+	configFile := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(configFile, []byte(fmt.Sprintf(`
+[fake]
+endpoint = %s
+insecure = true
+api_token = root@pam!unknown=00000000-0000-0000-0000-000000000000
+`, srv.Endpoint())), 0o600))
+
+	t.Setenv("PROXMOX_VE_CONFIG", configFile)
+
+	for _, env := range []string{
+		"PROXMOX_VE_ENDPOINT", "PROXMOX_VE_INSECURE", "PROXMOX_VE_API_TOKEN", "PROXMOX_VE_USERNAME",
+		"PROXMOX_VE_PASSWORD", "PROXMOX_VE_AUTH_TICKET", "PROXMOX_VE_CSRF_PREVENTION_TOKEN",
+		"PROXMOX_VE_CREDENTIALS_COMMAND",
+	} {
+		t.Setenv(env, "")
+	}
+
+	listUsers := func(t *testing.T, raw map[string]any) error {
+		t.Helper()
+
+		d := schema.TestResourceDataRaw(t, createSchema(), raw)
+
+		meta, diags := providerConfigure(t.Context(), d, nil, nil)
+		require.False(t, diags.HasError(), "%v", diags)
+
+		cfg, ok := meta.(proxmoxtf.ProviderConfiguration)
+		require.True(t, ok)
+
+		client, err := cfg.GetClient()
+		require.NoError(t, err)
+
+		_, err = client.Access().ListUsers(t.Context())
+
+		return err //nolint:wrapcheck
+	}
+
+	// the profile API token is not used with the configured username and password
+	require.NoError(t, listUsers(t, map[string]any{
+		mkProviderProfile:  "fake",
+		mkProviderUsername: fake.DefaultUsername,
+		mkProviderPassword: fake.DefaultPassword,
+	}))
+
+	// the profile API token is used when no other credentials are configured, and it is unknown to the server
+	require.Error(t, listUsers(t, map[string]any{mkProviderProfile: "fake"}))
+
+	// an explicit `insecure = false` overrides the profile, the certificate of the fake server is not trusted
+	err := listUsers(t, map[string]any{
+		mkProviderProfile:  "fake",
+		mkProviderInsecure: false,
+		mkProviderUsername: fake.DefaultUsername,
+		mkProviderPassword: fake.DefaultPassword,
+	})
+	require.ErrorContains(t, err, "certificate")
+}
@@ -7,35 +7,79 @@
 package provider
 
 import (
+	"fmt"
 	"os"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/ssh"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/ssh/resolver"
 )
 
 const (
-	mkProviderEndpoint            = "endpoint"
-	mkProviderInsecure            = "insecure"
-	mkProviderMinTLS              = "min_tls"
-	mkProviderAuthTicket          = "auth_ticket"
-	mkProviderCSRFPreventionToken = "csrf_prevention_token" // #nosec G101
-	mkProviderAPIToken            = "api_token"
-	mkProviderOTP                 = "otp"
-	mkProviderPassword            = "password"
-	mkProviderUsername            = "username"
-	mkProviderTmpDir              = "tmp_dir"
-	mkProviderRandomVMIDs         = "random_vm_ids"
-	mkProviderRandomVMIDStart     = "random_vm_id_start"
-	mkProviderRandomVMIDEnd       = "random_vm_id_end"
-	mkProviderSSH                 = "ssh"
-	mkProviderSSHUsername         = "username"
-	mkProviderSSHPassword         = "password"
-	mkProviderSSHAgent            = "agent"
-	mkProviderSSHAgentSocket      = "agent_socket"
-	mkProviderSSHPrivateKey       = "private_key"
-	mkProviderSSHSocks5Server     = "socks5_server"
-	mkProviderSSHSocks5Username   = "socks5_username"
-	mkProviderSSHSocks5Password   = "socks5_password"
+	mkProviderEndpoint             = "endpoint"
+	mkProviderEndpoints            = "endpoints"
+	mkProviderInsecure             = "insecure"
+	mkProviderMinTLS               = "min_tls"
+	mkProviderCACertificate        = "ca_certificate"
+	mkProviderTLSFingerprint       = "tls_fingerprint"
+	mkProviderCancelTasks          = "cancel_tasks_on_interrupt"
+	mkProviderAuthTicket           = "auth_ticket"
+	mkProviderCSRFPreventionToken  = "csrf_prevention_token" // #nosec G101
+	mkProviderCredentialsCommand   = "credentials_command"
+	mkProviderAPIToken             = "api_token"
+	mkProviderOTP                  = "otp"
+	mkProviderPassword             = "password"
+	mkProviderUsername             = "username"
+	mkProviderProfile              = "profile"
+	mkProviderTmpDir               = "tmp_dir"
+	mkProviderRandomVMIDs          = "random_vm_ids"
+	mkProviderRandomVMIDStart      = "random_vm_id_start"
+	mkProviderRandomVMIDEnd        = "random_vm_id_end"
+	mkProviderAPILimits            = "api_limits"
+	mkProviderAPIRetry             = "api_retry"
+	mkProviderSSH                  = "ssh"
+	mkProviderSSHUsername          = "username"
+	mkProviderSSHPassword          = "password"
+	mkProviderSSHAgent             = "agent"
+	mkProviderSSHAgentSocket       = "agent_socket"
+	mkProviderSSHPrivateKey        = "private_key"
+	mkProviderSSHPrivateKeyPass    = "private_key_passphrase"
+	mkProviderSSHCertificate       = "certificate"
+	mkProviderSSHSocks5Server      = "socks5_server"
+	mkProviderSSHSocks5Username    = "socks5_username"
+	mkProviderSSHSocks5Password    = "socks5_password"
+	mkProviderSSHHostKeyVerify     = "host_key_verification"
+	mkProviderSSHKnownHostsFile    = "known_hosts_file"
+	mkProviderSSHKnownHosts        = "known_hosts"
+	mkProviderSSHMaxSessions       = "max_sessions"
+	mkProviderSSHNodeAddressSource = "node_address_source"
+	mkProviderSSHNodeAddressCIDRs  = "node_address_cidrs"
+	mkProviderSSHKeepAlive         = "keepalive_interval"
+
+	mkProviderAPILimitsMaxConcurrentRequests     = "max_concurrent_requests"
+	mkProviderAPILimitsMaxConcurrentTasksPerNode = "max_concurrent_tasks_per_node"
+	mkProviderAPILimitsRequestsPerSecond         = "requests_per_second"
+
+	mkProviderAPIRetryAttempts             = "attempts"
+	mkProviderAPIRetryInitialDelay         = "initial_delay"
+	mkProviderAPIRetryMaxDelay             = "max_delay"
+	mkProviderAPIRetryJitter               = "jitter"
+	mkProviderAPIRetryRetryableStatusCodes = "retryable_status_codes"
+	mkProviderAPIRetryRetryNonIdempotent   = "retry_non_idempotent"
+
+	mkProviderSSHBastion                    = "bastion"
+	mkProviderSSHBastionAddress             = "address"
+	mkProviderSSHBastionPort                = "port"
+	mkProviderSSHBastionUsername            = "username"
+	mkProviderSSHBastionPassword            = "password"
+	mkProviderSSHBastionPrivateKey          = "private_key"
+	mkProviderSSHBastionAgent               = "agent"
+	mkProviderSSHBastionHostKeyVerification = "host_key_verification"
+	mkProviderSSHBastionKnownHostsFile      = "known_hosts_file"
+	mkProviderSSHBastionKnownHosts          = "known_hosts"
 
 	mkProviderSSHNode        = "node"
 	mkProviderSSHNodeName    = "name"
@@ -51,6 +95,17 @@ func createSchema() map[string]*schema.Schema {
 			Description:  "The endpoint for the Proxmox VE API.",
 			ValidateFunc: validation.IsURLWithHTTPorHTTPS,
 		},
+		mkProviderEndpoints: {
+			Type:     schema.TypeList,
+			Optional: true,
+			Description: "The endpoints for the Proxmox VE API on other cluster nodes, used for failover " +
+				"when the current endpoint is unavailable. If `endpoint` is not set, the first " +
+				"endpoint in the list is the primary one.",
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+		},
 		mkProviderInsecure: {
 			Type:        schema.TypeBool,
 			Optional:    true,
@@ -62,6 +117,28 @@ func createSchema() map[string]*schema.Schema {
 			Description: "The minimum required TLS version for API calls." +
 				"Supported values: `1.0|1.1|1.2|1.3`. Defaults to `1.3`.",
 		},
+		mkProviderCACertificate: {
+			Type:     schema.TypeString,
+			Optional: true,
+			Description: "The CA certificate (PEM content or a path to a PEM file) to trust in addition to the " +
+				"system trust store, e.g. the Proxmox VE cluster CA from `/etc/pve/pve-root-ca.pem`.",
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+		mkProviderTLSFingerprint: {
+			Type:     schema.TypeString,
+			Optional: true,
+			Description: "The SHA-256 fingerprint of the Proxmox VE API certificate, as shown in the certificate " +
+				"details of the web UI. When set, a certificate matching the fingerprint is trusted without " +
+				"further verification, and any other certificate is rejected.",
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+		mkProviderCancelTasks: {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Description: "Whether to stop running Proxmox VE tasks, e.g. a VM clone, when Terraform is interrupted " +
+				"or an operation times out, so that they do not keep the guest configuration locked. " +
+				"Defaults to `false`.",
+		},
 		mkProviderAuthTicket: {
 			Type:         schema.TypeString,
 			Optional:     true,
@@ -69,6 +146,23 @@ func createSchema() map[string]*schema.Schema {
 			Description:  "The pre-authenticated Ticket for the Proxmox VE API.",
 			ValidateFunc: validation.StringIsNotEmpty,
 		},
+		mkProviderCredentialsCommand: {
+			Type:     schema.TypeList,
+			Optional: true,
+			MinItems: 1,
+			Description: "The command printing the credentials as a JSON object, run instead of storing the " +
+				"credentials in the configuration, e.g. a wrapper of a password manager CLI. The first item is the " +
+				"executable, the other items are its arguments. The object has either the `api_token`, or the " +
+				"`username` and `password` fields, and optionally the `ssh_username`, `ssh_password`, " +
+				"`ssh_private_key` and `ssh_private_key_passphrase` fields. The credentials printed by the command " +
+				"take precedence over the other sources, and the command is run again when the API rejects them. " +
+				"The `PROXMOX_VE_CREDENTIALS_COMMAND` environment variable and the profile setting are split on " +
+				"whitespace without quoting, so their executable and arguments cannot contain spaces.",
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
 		mkProviderCSRFPreventionToken: {
 			Type:         schema.TypeString,
 			Optional:     true,
@@ -103,6 +197,86 @@ func createSchema() map[string]*schema.Schema {
 			Description: "The password for the Proxmox VE API.",
 			// note: we allow empty string as a valid value, as it is used to unset the password in tests
 		},
+		mkProviderAPILimits: {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Client-side limits for the load put on the Proxmox VE API.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					mkProviderAPILimitsMaxConcurrentRequests: {
+						Type:     schema.TypeInt,
+						Optional: true,
+						Description: "The maximum number of concurrent API requests. " +
+							"Defaults to `0` (unlimited).",
+						ValidateFunc: validation.IntAtLeast(0),
+					},
+					mkProviderAPILimitsMaxConcurrentTasksPerNode: {
+						Type:     schema.TypeInt,
+						Optional: true,
+						Description: "The maximum number of concurrent long-running tasks, e.g. VM clones, started by the " +
+							"provider on a single node. Defaults to `0` (unlimited).",
+						ValidateFunc: validation.IntAtLeast(0),
+					},
+					mkProviderAPILimitsRequestsPerSecond: {
+						Type:     schema.TypeFloat,
+						Optional: true,
+						Description: "The maximum number of API requests per second, e.g. `2.5`. " +
+							"Defaults to `0` (unlimited).",
+						ValidateFunc: validation.FloatAtLeast(0),
+					},
+				},
+			},
+		},
+		mkProviderAPIRetry: {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "The retry policy for failed Proxmox VE API requests.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					mkProviderAPIRetryAttempts: {
+						Type:     schema.TypeInt,
+						Optional: true,
+						Description: "The maximum number of attempts for a request, including the initial one. " +
+							"Defaults to `3`.",
+						ValidateFunc: validation.IntAtLeast(1),
+					},
+					mkProviderAPIRetryInitialDelay: {
+						Type:     schema.TypeString,
+						Optional: true,
+						Description: "The delay before the first retry, e.g. `500ms`. The delay doubles " +
+							"with each subsequent retry. Defaults to `500ms`.",
+						ValidateFunc: validateDuration,
+					},
+					mkProviderAPIRetryMaxDelay: {
+						Type:         schema.TypeString,
+						Optional:     true,
+						Description:  "The maximum delay between retries, e.g. `5s`. Defaults to `5s`.",
+						ValidateFunc: validateDuration,
+					},
+					mkProviderAPIRetryJitter: {
+						Type:        schema.TypeBool,
+						Optional:    true,
+						Description: "Whether to randomize the delay between retries. Defaults to `true`.",
+					},
+					mkProviderAPIRetryRetryableStatusCodes: {
+						Type:     schema.TypeList,
+						Optional: true,
+						Description: "The HTTP status codes that cause a request to be retried. " +
+							"Defaults to `[502, 503, 504, 595]`.",
+						Elem: &schema.Schema{Type: schema.TypeInt},
+					},
+					mkProviderAPIRetryRetryNonIdempotent: {
+						Type:     schema.TypeBool,
+						Optional: true,
+						Description: "Whether to retry requests that may modify resources (`POST`, `PUT`, " +
+							"`DELETE`). Requests that failed to acquire a configuration lock are always " +
+							"retried. Defaults to `false`.",
+					},
+				},
+			},
+		},
 		mkProviderSSH: {
 			Type:        schema.TypeList,
 			Optional:    true,
@@ -168,9 +342,24 @@ func createSchema() map[string]*schema.Schema {
 						Type:      schema.TypeString,
 						Optional:  true,
 						Sensitive: true,
-						Description: "The unencrypted private key (in PEM format) used for the SSH connection. " +
+						Description: "The private key (in PEM format) used for the SSH connection. An encrypted key " +
+							"requires the `private_key_passphrase`. " +
 							"Defaults to the value of the `PROXMOX_VE_SSH_PRIVATE_KEY` environment variable.",
 					},
+					mkProviderSSHPrivateKeyPass: {
+						Type:      schema.TypeString,
+						Optional:  true,
+						Sensitive: true,
+						Description: "The passphrase of the encrypted `private_key`. " +
+							"Defaults to the value of the `PROXMOX_VE_SSH_PRIVATE_KEY_PASSPHRASE` environment variable.",
+					},
+					mkProviderSSHCertificate: {
+						Type:     schema.TypeString,
+						Optional: true,
+						Description: "The OpenSSH user certificate, e.g. the content of an `id_ed25519-cert.pub` file, used with the " +
+							"`private_key`, or with the matching key of the SSH agent. " +
+							"Defaults to the value of the `PROXMOX_VE_SSH_CERTIFICATE` environment variable.",
+					},
 					mkProviderSSHSocks5Server: {
 						Type:     schema.TypeString,
 						Optional: true,
@@ -205,6 +394,131 @@ func createSchema() map[string]*schema.Schema {
 						),
 						ValidateFunc: validation.StringIsNotEmpty,
 					},
+					mkProviderSSHHostKeyVerify: {
+						Type:     schema.TypeString,
+						Optional: true,
+						Description: "The host key verification of the SSH connection: `tofu` trusts the host key of an unknown " +
+							"node on first use and adds it to the known hosts file, `known_hosts_file` only accepts the " +
+							"host keys listed in the `known_hosts_file`, and `strict` only accepts the host keys of the " +
+							"cluster listed in `known_hosts`. Defaults to the value of the " +
+							"`PROXMOX_VE_SSH_HOST_KEY_VERIFICATION` environment variable, or `tofu` if not set.",
+						ValidateFunc: validation.StringInSlice(ssh.HostKeyVerificationModes, false),
+					},
+					mkProviderSSHKnownHostsFile: {
+						Type:     schema.TypeString,
+						Optional: true,
+						Description: "The path to the known hosts file used by the `known_hosts_file` host key verification. " +
+							"With the `tofu` host key verification, the file replaces `~/.ssh/known_hosts`. Defaults to " +
+							"the value of the `PROXMOX_VE_SSH_KNOWN_HOSTS_FILE` environment variable.",
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+					mkProviderSSHKnownHosts: {
+						Type:     schema.TypeString,
+						Optional: true,
+						Description: "The known hosts of the cluster, e.g. the content of `/etc/pve/priv/known_hosts` of a " +
+							"cluster node, used by the `strict` host key verification. The nodes are matched by their " +
+							"address and their name. Defaults to the value of the `PROXMOX_VE_SSH_KNOWN_HOSTS` " +
+							"environment variable.",
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+					mkProviderSSHNodeAddressSource: {
+						Type:     schema.TypeString,
+						Optional: true,
+						Description: "The source of the node addresses used for the SSH connections: `cluster` uses the node IPs and " +
+							"corosync link addresses of the cluster, `interface` the addresses of the node network interfaces, and " +
+							"`dns` the addresses the node name resolves to. The next sources are tried if no address is found. " +
+							"Defaults to the value of the `PROXMOX_VE_SSH_NODE_ADDRESS_SOURCE` environment variable, or `cluster` if not set.",
+						ValidateFunc: validation.StringInSlice(resolver.AddressSources, false),
+					},
+					mkProviderSSHNodeAddressCIDRs: {
+						Type:     schema.TypeList,
+						Optional: true,
+						Description: "The CIDRs of the preferred node addresses, e.g. of the management network. Addresses outside " +
+							"of the CIDRs are only used if no preferred address is found.",
+						Elem: &schema.Schema{
+							Type:         schema.TypeString,
+							ValidateFunc: validation.IsCIDR,
+						},
+					},
+					mkProviderSSHMaxSessions: {
+						Type:     schema.TypeInt,
+						Optional: true,
+						Description: "The maximum number of concurrent SSH sessions, e.g. command executions and file uploads, " +
+							"on the connection to a single node. Must not exceed the `MaxSessions` setting of the SSH server. " +
+							"Defaults to `10`.",
+						ValidateFunc: validation.IntAtLeast(1),
+					},
+					mkProviderSSHKeepAlive: {
+						Type:     schema.TypeString,
+						Optional: true,
+						Description: "The interval of the keepalives sent over the SSH connections to the nodes, e.g. `30s`. " +
+							"`0s` disables the keepalives. Defaults to `30s`.",
+						ValidateFunc: validateDuration,
+					},
+					mkProviderSSHBastion: {
+						Type:     schema.TypeList,
+						Optional: true,
+						Description: "A jump host the SSH connections to the nodes are tunneled through. Can be specified multiple " +
+							"times to chain the connections through several jump hosts, in the given order.",
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								mkProviderSSHBastionAddress: {
+									Type:         schema.TypeString,
+									Required:     true,
+									Description:  "The address of the jump host.",
+									ValidateFunc: validation.StringIsNotEmpty,
+								},
+								mkProviderSSHBastionPort: {
+									Type:         schema.TypeInt,
+									Optional:     true,
+									Description:  "The SSH port of the jump host. Defaults to `22`.",
+									Default:      22,
+									ValidateFunc: validation.IsPortNumber,
+								},
+								mkProviderSSHBastionUsername: {
+									Type:     schema.TypeString,
+									Optional: true,
+									Description: "The username used for the SSH connection to the jump host. Defaults to the " +
+										"username of the SSH connection to the nodes.",
+								},
+								mkProviderSSHBastionPassword: {
+									Type:        schema.TypeString,
+									Optional:    true,
+									Sensitive:   true,
+									Description: "The password used for the SSH connection to the jump host.",
+								},
+								mkProviderSSHBastionPrivateKey: {
+									Type:        schema.TypeString,
+									Optional:    true,
+									Sensitive:   true,
+									Description: "The unencrypted private key (in PEM format) used for the SSH connection to the jump host.",
+								},
+								mkProviderSSHBastionAgent: {
+									Type:        schema.TypeBool,
+									Optional:    true,
+									Description: "Whether to use the SSH agent for the authentication to the jump host. Defaults to `false`.",
+								},
+								mkProviderSSHBastionHostKeyVerification: {
+									Type:     schema.TypeString,
+									Optional: true,
+									Description: "The verification of the jump host key: `tofu`, `known_hosts_file` or `strict`. " +
+										"Defaults to `tofu`.",
+									ValidateFunc: validation.StringInSlice(ssh.HostKeyVerificationModes, false),
+								},
+								mkProviderSSHBastionKnownHostsFile: {
+									Type:     schema.TypeString,
+									Optional: true,
+									Description: "The path to the known hosts file used by the `known_hosts_file` host key verification " +
+										"of the jump host.",
+								},
+								mkProviderSSHBastionKnownHosts: {
+									Type:        schema.TypeString,
+									Optional:    true,
+									Description: "The known hosts content used by the `strict` host key verification of the jump host.",
+								},
+							},
+						},
+					},
 					mkProviderSSHNode: {
 						Type:        schema.TypeList,
 						Optional:    true,
@@ -237,6 +551,15 @@ func createSchema() map[string]*schema.Schema {
 				},
 			},
 		},
+		mkProviderProfile: {
+			Type:     schema.TypeString,
+			Optional: true,
+			Description: "The name of the profile of the configuration file to use, i.e. `PROXMOX_VE_CONFIG` " +
+				"or `~/.config/proxmox/config`. The profile provides the values of the settings " +
+				"that are set neither in the provider configuration, nor with the environment variables. " +
+				"Defaults to the `default` profile, if present.",
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
 		mkProviderTmpDir: {
 			Type:         schema.TypeString,
 			Optional:     true,
@@ -262,3 +585,21 @@ func createSchema() map[string]*schema.Schema {
 		},
 	}
 }
+
+func validateDuration(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return nil, []error{fmt.Errorf("%s must be a duration, e.g. `500ms` or `1m30s`: %w", k, err)}
+	}
+
+	if d < 0 {
+		return nil, []error{fmt.Errorf("%s must not be negative", k)}
+	}
+
+	return nil, nil
+}
@@ -16,6 +16,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -178,6 +179,11 @@ const (
 	mkVMID                              = "vm_id"
 )
 
+const (
+	mkInitializationUserAccountPasswordWO        = "password_wo"
+	mkInitializationUserAccountPasswordWOVersion = "password_wo_version"
+)
+
 // Container returns a resource that manages a container.
 func Container() *schema.Resource {
 	return &schema.Resource{
@@ -551,6 +557,25 @@ func Container() *schema.Resource {
 												strings.ReplaceAll(oldVal, "*", "") == ""
 										},
 									},
+									mkInitializationUserAccountPasswordWO: {
+										Type: schema.TypeString,
+										Description: "The SSH password, which is not stored in the state " +
+											"(requires Terraform 1.11 or later)",
+										Optional:  true,
+										Sensitive: true,
+										WriteOnly: true,
+										ConflictsWith: []string{
+											mkInitialization + ".0." + mkInitializationUserAccount + ".0." +
+												mkInitializationUserAccountPassword,
+										},
+									},
+									mkInitializationUserAccountPasswordWOVersion: {
+										Type: schema.TypeInt,
+										Description: "The version of the write-only SSH password, the container is " +
+											"re-created with the new password when the version changes",
+										Optional: true,
+										ForceNew: true,
+									},
 								},
 							},
 							MaxItems: 1,
@@ -1239,6 +1264,10 @@ func containerCreateClone(ctx context.Context, d *schema.ResourceData, m interfa
 
 			initializationUserAccountPassword := initializationUserAccountBlock[mkInitializationUserAccountPassword].(string)
 
+			if passwordWO := initializationUserAccountPasswordWO(d); passwordWO != "" {
+				initializationUserAccountPassword = passwordWO
+			}
+
 			if initializationUserAccountPassword != dvInitializationUserAccountPassword {
 				updateBody.Password = &initializationUserAccountPassword
 			} else {
@@ -1584,6 +1613,10 @@ func containerCreateCustom(ctx context.Context, d *schema.ResourceData, m interf
 			}
 
 			initializationUserAccountPassword = initializationUserAccountBlock[mkInitializationUserAccountPassword].(string)
+
+			if passwordWO := initializationUserAccountPasswordWO(d); passwordWO != "" {
+				initializationUserAccountPassword = passwordWO
+			}
 		}
 	}
 
@@ -3398,3 +3431,10 @@ func parseImportIDWithNodeName(id string) (string, string, error) {
 
 	return nodeName, id, nil
 }
+
+// initializationUserAccountPasswordWO returns the write-only password of the user account, if set.
+func initializationUserAccountPasswordWO(d *schema.ResourceData) string {
+	return structure.GetWriteOnlyString(d, cty.GetAttrPath(mkInitialization).IndexInt(0).
+		GetAttr(mkInitializationUserAccount).IndexInt(0).
+		GetAttr(mkInitializationUserAccountPasswordWO))
+}
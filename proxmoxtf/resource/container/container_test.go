@@ -226,11 +226,15 @@ func TestContainerSchema(t *testing.T) {
 	test.AssertOptionalArguments(t, initializationUserAccountSchema, []string{
 		mkInitializationUserAccountKeys,
 		mkInitializationUserAccountPassword,
+		mkInitializationUserAccountPasswordWO,
+		mkInitializationUserAccountPasswordWOVersion,
 	})
 
 	test.AssertValueTypes(t, initializationUserAccountSchema, map[string]schema.ValueType{
-		mkInitializationUserAccountKeys:     schema.TypeList,
-		mkInitializationUserAccountPassword: schema.TypeString,
+		mkInitializationUserAccountKeys:              schema.TypeList,
+		mkInitializationUserAccountPassword:          schema.TypeString,
+		mkInitializationUserAccountPasswordWO:        schema.TypeString,
+		mkInitializationUserAccountPasswordWOVersion: schema.TypeInt,
 	})
 
 	memorySchema := test.AssertNestedSchemaExistence(t, s, mkMemory)
@@ -10,7 +10,6 @@ import (
 	"bytes"
 	"context"
 	"crypto/sha256"
-	"crypto/tls"
 	"errors"
 	"fmt"
 	"io"
@@ -411,12 +410,14 @@ func fileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag
 				return diag.FromErr(e)
 			}
 
+			// trust the CA certificates configured for the provider
+			tlsConfig := capi.API().TLSConfig()
+			tlsConfig.MinVersion = version
+			tlsConfig.InsecureSkipVerify = sourceFileInsecure
+
 			httpClient := http.Client{
 				Transport: &http.Transport{
-					TLSClientConfig: &tls.Config{
-						MinVersion:         version,
-						InsecureSkipVerify: sourceFileInsecure,
-					},
+					TLSClientConfig: tlsConfig,
 				},
 			}
 
@@ -764,7 +765,12 @@ func fileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.D
 
 	readFileAttrs := readFile
 	if fileIsURL(d) {
-		readFileAttrs = readURL(capi.API().HTTP())
+		readFileAttrs = readURL(&http.Client{
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: capi.API().TLSConfig(),
+			},
+		})
 	}
 
 	var diags diag.Diagnostics
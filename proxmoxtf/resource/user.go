@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -20,6 +21,7 @@ import (
 	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
 	"github.com/bpg/terraform-provider-proxmox/proxmox/types"
 	"github.com/bpg/terraform-provider-proxmox/proxmoxtf"
+	"github.com/bpg/terraform-provider-proxmox/proxmoxtf/structure"
 )
 
 const (
@@ -30,16 +32,18 @@ const (
 	dvResourceVirtualEnvironmentUserKeys      = ""
 	dvResourceVirtualEnvironmentUserLastName  = ""
 
-	mkResourceVirtualEnvironmentUserComment        = "comment"
-	mkResourceVirtualEnvironmentUserEmail          = "email"
-	mkResourceVirtualEnvironmentUserEnabled        = "enabled"
-	mkResourceVirtualEnvironmentUserExpirationDate = "expiration_date"
-	mkResourceVirtualEnvironmentUserFirstName      = "first_name"
-	mkResourceVirtualEnvironmentUserGroups         = "groups"
-	mkResourceVirtualEnvironmentUserKeys           = "keys"
-	mkResourceVirtualEnvironmentUserLastName       = "last_name"
-	mkResourceVirtualEnvironmentUserPassword       = "password"
-	mkResourceVirtualEnvironmentUserUserID         = "user_id"
+	mkResourceVirtualEnvironmentUserComment           = "comment"
+	mkResourceVirtualEnvironmentUserEmail             = "email"
+	mkResourceVirtualEnvironmentUserEnabled           = "enabled"
+	mkResourceVirtualEnvironmentUserExpirationDate    = "expiration_date"
+	mkResourceVirtualEnvironmentUserFirstName         = "first_name"
+	mkResourceVirtualEnvironmentUserGroups            = "groups"
+	mkResourceVirtualEnvironmentUserKeys              = "keys"
+	mkResourceVirtualEnvironmentUserLastName          = "last_name"
+	mkResourceVirtualEnvironmentUserPassword          = "password"
+	mkResourceVirtualEnvironmentUserPasswordWO        = "password_wo"
+	mkResourceVirtualEnvironmentUserPasswordWOVersion = "password_wo_version"
+	mkResourceVirtualEnvironmentUserUserID            = "user_id"
 )
 
 // User returns a resource that manages a user in the Proxmox VE access control list.
@@ -99,10 +103,25 @@ func User() *schema.Resource {
 				Default:     dvResourceVirtualEnvironmentUserLastName,
 			},
 			mkResourceVirtualEnvironmentUserPassword: {
-				Type:        schema.TypeString,
-				Description: "The user's password",
-				Optional:    true,
-				Sensitive:   true,
+				Type:          schema.TypeString,
+				Description:   "The user's password",
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{mkResourceVirtualEnvironmentUserPasswordWO},
+			},
+			mkResourceVirtualEnvironmentUserPasswordWO: {
+				Type:          schema.TypeString,
+				Description:   "The user's password, which is not stored in the state (requires Terraform 1.11 or later)",
+				Optional:      true,
+				Sensitive:     true,
+				WriteOnly:     true,
+				ConflictsWith: []string{mkResourceVirtualEnvironmentUserPassword},
+			},
+			mkResourceVirtualEnvironmentUserPasswordWOVersion: {
+				Type:         schema.TypeInt,
+				Description:  "The version of the write-only password, the password is changed when the version changes",
+				Optional:     true,
+				RequiredWith: []string{mkResourceVirtualEnvironmentUserPasswordWO},
 			},
 			mkResourceVirtualEnvironmentUserUserID: {
 				Type:        schema.TypeString,
@@ -166,6 +185,11 @@ func userCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag
 		password = &passwordVal
 	}
 
+	passwordWO := structure.GetWriteOnlyString(d, cty.GetAttrPath(mkResourceVirtualEnvironmentUserPasswordWO))
+	if passwordWO != "" {
+		password = &passwordWO
+	}
+
 	body := &access.UserCreateRequestBody{
 		Comment:        &comment,
 		Email:          &email,
@@ -333,6 +357,16 @@ func userUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag
 		}
 	}
 
+	if d.HasChange(mkResourceVirtualEnvironmentUserPasswordWOVersion) {
+		password := structure.GetWriteOnlyString(d, cty.GetAttrPath(mkResourceVirtualEnvironmentUserPasswordWO))
+		if password != "" {
+			err = client.Access().ChangeUserPassword(ctx, userID, password)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
 	return userRead(ctx, d, m)
 }
 
@@ -7,10 +7,27 @@
 package resource
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
 	"testing"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/cluster"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/fake"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/ssh"
+	"github.com/bpg/terraform-provider-proxmox/proxmoxtf"
 	"github.com/bpg/terraform-provider-proxmox/proxmoxtf/test"
 )
 
@@ -44,18 +61,192 @@ func TestUserSchema(t *testing.T) {
 		mkResourceVirtualEnvironmentUserKeys,
 		mkResourceVirtualEnvironmentUserLastName,
 		mkResourceVirtualEnvironmentUserPassword,
+		mkResourceVirtualEnvironmentUserPasswordWO,
+		mkResourceVirtualEnvironmentUserPasswordWOVersion,
 	})
 
 	test.AssertValueTypes(t, s, map[string]schema.ValueType{
-		mkResourceVirtualEnvironmentUserComment:        schema.TypeString,
-		mkResourceVirtualEnvironmentUserEmail:          schema.TypeString,
-		mkResourceVirtualEnvironmentUserEnabled:        schema.TypeBool,
-		mkResourceVirtualEnvironmentUserExpirationDate: schema.TypeString,
-		mkResourceVirtualEnvironmentUserFirstName:      schema.TypeString,
-		mkResourceVirtualEnvironmentUserGroups:         schema.TypeSet,
-		mkResourceVirtualEnvironmentUserKeys:           schema.TypeString,
-		mkResourceVirtualEnvironmentUserLastName:       schema.TypeString,
-		mkResourceVirtualEnvironmentUserPassword:       schema.TypeString,
-		mkResourceVirtualEnvironmentUserUserID:         schema.TypeString,
+		mkResourceVirtualEnvironmentUserComment:           schema.TypeString,
+		mkResourceVirtualEnvironmentUserEmail:             schema.TypeString,
+		mkResourceVirtualEnvironmentUserEnabled:           schema.TypeBool,
+		mkResourceVirtualEnvironmentUserExpirationDate:    schema.TypeString,
+		mkResourceVirtualEnvironmentUserFirstName:         schema.TypeString,
+		mkResourceVirtualEnvironmentUserGroups:            schema.TypeSet,
+		mkResourceVirtualEnvironmentUserKeys:              schema.TypeString,
+		mkResourceVirtualEnvironmentUserLastName:          schema.TypeString,
+		mkResourceVirtualEnvironmentUserPassword:          schema.TypeString,
+		mkResourceVirtualEnvironmentUserPasswordWO:        schema.TypeString,
+		mkResourceVirtualEnvironmentUserPasswordWOVersion: schema.TypeInt,
+		mkResourceVirtualEnvironmentUserUserID:            schema.TypeString,
 	})
 }
+
+// passwordChanges records the passwords sent to the password change endpoint of the API.
+type passwordChanges struct {
+	next http.RoundTripper
+
+	mu        sync.Mutex
+	passwords []string
+}
+
+func (c *passwordChanges) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method == http.MethodPut && strings.HasSuffix(req.URL.Path, "/access/password") {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+
+		req.Body = io.NopCloser(bytes.NewReader(body))
+
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+
+		c.mu.Lock()
+		c.passwords = append(c.passwords, values.Get("password"))
+		c.mu.Unlock()
+	}
+
+	return c.next.RoundTrip(req) //nolint:wrapcheck
+}
+
+func (c *passwordChanges) get() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]string(nil), c.passwords...)
+}
+
+// noSSHResolver fails to resolve the nodes, the user resource does not use SSH.
+type noSSHResolver struct{}
+
+func (noSSHResolver) Resolve(context.Context, string) (ssh.ProxmoxNode, error) {
+	return ssh.ProxmoxNode{}, errors.New("SSH is not available")
+}
+
+// userData returns the data of the user resource for the given configuration, with the write-only password
+// only in the raw configuration, as Terraform does not pass write-only values in the planned state.
+func userData(
+	t *testing.T,
+	r *schema.Resource,
+	state *terraform.InstanceState,
+	raw map[string]any,
+	passwordWO string,
+) *schema.ResourceData {
+	t.Helper()
+
+	diff, err := r.Diff(t.Context(), state, terraform.NewResourceConfigRaw(raw), nil)
+	require.NoError(t, err)
+
+	if diff == nil {
+		diff = &terraform.InstanceDiff{}
+	}
+
+	diff.RawConfig = cty.ObjectVal(map[string]cty.Value{
+		mkResourceVirtualEnvironmentUserPasswordWO: cty.StringVal(passwordWO),
+	})
+
+	d, err := schema.InternalMap(r.Schema).Data(state, diff)
+	require.NoError(t, err)
+
+	return d
+}
+
+// assertNotInState asserts that the secret is not stored in any attribute of the state.
+func assertNotInState(t *testing.T, d *schema.ResourceData, secret string) {
+	t.Helper()
+
+	state := d.State()
+	require.NotNil(t, state)
+
+	assert.NotContains(t, state.Attributes, mkResourceVirtualEnvironmentUserPasswordWO)
+
+	for k, v := range state.Attributes {
+		assert.NotContains(t, v, secret, "the secret must not be stored in %q", k)
+	}
+}
+
+// TestUserPasswordWO tests that the write-only password is set on creation, changed when its version changes,
+// and never stored in the state.
+func TestUserPasswordWO(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	srv := fake.NewServer()
+	t.Cleanup(srv.Close)
+
+	changes := &passwordChanges{}
+
+	conn, err := api.NewConnection(srv.Endpoint(), true, "", api.WithTransport(func(next http.RoundTripper) http.RoundTripper {
+		changes.next = next
+
+		return changes
+	}))
+	require.NoError(t, err)
+
+	creds, err := api.NewCredentials("", "", "", fake.DefaultAPIToken, "", "")
+	require.NoError(t, err)
+
+	apiClient, err := api.NewClient(creds, conn)
+	require.NoError(t, err)
+
+	sshClient, err := ssh.NewClient("root", "", false, "", "", "", "", "", noSSHResolver{})
+	require.NoError(t, err)
+
+	meta, err := proxmoxtf.NewProviderConfiguration(apiClient, sshClient, "", cluster.IDGeneratorConfig{})
+	require.NoError(t, err)
+
+	// login checks whether the user can log in with the password
+	login := func(password string) error {
+		creds, err := api.NewCredentials("wo@pve", password, "", "", "", "")
+		require.NoError(t, err)
+
+		conn, err := api.NewConnection(srv.Endpoint(), true, "")
+		require.NoError(t, err)
+
+		c, err := api.NewClient(creds, conn)
+		require.NoError(t, err)
+
+		return c.DoRequest(ctx, http.MethodGet, "version", nil, nil) //nolint:wrapcheck
+	}
+
+	r := User()
+
+	raw := map[string]any{
+		mkResourceVirtualEnvironmentUserUserID:            "wo@pve",
+		mkResourceVirtualEnvironmentUserPasswordWOVersion: 1,
+	}
+
+	d := userData(t, r, nil, raw, "first-secret")
+
+	diags := r.CreateContext(ctx, d, meta)
+	require.False(t, diags.HasError(), "%v", diags)
+	require.NoError(t, login("first-secret"), "the user must be created with the write-only password")
+	assertNotInState(t, d, "first-secret")
+
+	// a new password without a new version is not applied
+	raw[mkResourceVirtualEnvironmentUserComment] = "updated"
+
+	d = userData(t, r, d.State(), raw, "ignored-secret")
+
+	diags = r.UpdateContext(ctx, d, meta)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Empty(t, changes.get(), "the password must only be changed with its version")
+	require.NoError(t, login("first-secret"))
+	assertNotInState(t, d, "ignored-secret")
+
+	raw[mkResourceVirtualEnvironmentUserPasswordWOVersion] = 2
+
+	d = userData(t, r, d.State(), raw, "second-secret")
+	require.True(t, d.HasChange(mkResourceVirtualEnvironmentUserPasswordWOVersion))
+
+	diags = r.UpdateContext(ctx, d, meta)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, []string{"second-secret"}, changes.get())
+	require.NoError(t, login("second-secret"), "the password must be changed when its version changes")
+	require.Error(t, login("first-secret"))
+	assertNotInState(t, d, "second-secret")
+	assert.Equal(t, 2, d.Get(mkResourceVirtualEnvironmentUserPasswordWOVersion))
+}
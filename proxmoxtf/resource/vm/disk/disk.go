@@ -322,6 +322,85 @@ func createCustomDisk(
 		fileFormat = *disk.Format
 	}
 
+	if canImportFromAPI(ctx, client, *disk.FileID) {
+		err := importCustomDisk(ctx, client, nodeName, vmID, iface, disk, fileFormat)
+		if err != nil {
+			return err
+		}
+	} else {
+		err := importCustomDiskSSH(ctx, client, nodeName, vmID, iface, disk, fileFormat)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := client.Node(nodeName).VM(vmID).ResizeVMDisk(ctx, &vms.ResizeDiskRequestBody{
+		Disk: iface,
+		Size: *disk.Size,
+	})
+	if err != nil {
+		return fmt.Errorf("resizing disk: %w", err)
+	}
+
+	return nil
+}
+
+// canImportFromAPI returns true if the disk image can be imported with the `import-from` option of the API,
+// which does not require SSH access to the node.
+func canImportFromAPI(ctx context.Context, client proxmox.Client, fileID string) bool {
+	pveVersion, err := client.Version().GetProxmoxVersion(ctx)
+	if err != nil {
+		tflog.Warn(ctx, "failed to get the Proxmox VE version, importing the disk over SSH", map[string]interface{}{
+			"error": err,
+		})
+
+		return false
+	}
+
+	return vms.CanImportFrom(fileID, pveVersion)
+}
+
+// importCustomDisk creates the disk from the image with the `import-from` option of the API.
+func importCustomDisk(
+	ctx context.Context,
+	client proxmox.Client,
+	nodeName string,
+	vmID int,
+	iface string,
+	disk vms.CustomStorageDevice,
+	fileFormat string,
+) error {
+	device := disk
+	device.FileID = nil
+	device.FileVolume = fmt.Sprintf("%s:0", *disk.DatastoreID)
+	device.Format = &fileFormat
+	device.ImportFrom = disk.FileID
+	device.Size = nil
+
+	tflog.Debug(ctx, "importing custom disk through the API", map[string]interface{}{
+		"file_id":   *disk.FileID,
+		"interface": iface,
+	})
+
+	err := client.Node(nodeName).VM(vmID).ImportVMDisk(ctx, iface, device)
+	if err != nil {
+		return fmt.Errorf("creating custom disk: %w", err)
+	}
+
+	return nil
+}
+
+// importCustomDiskSSH creates the disk from the image with `qm importdisk` on the node, for the releases
+// or volumes not supported by the `import-from` option.
+func importCustomDiskSSH(
+	ctx context.Context,
+	client proxmox.Client,
+	nodeName string,
+	vmID int,
+	iface string,
+	disk vms.CustomStorageDevice,
+	fileFormat string,
+) error {
 	//nolint:lll
 	commands := []string{
 		`set -e`,
@@ -351,14 +430,6 @@ func createCustomDisk(
 		"output": string(out),
 	})
 
-	err = client.Node(nodeName).VM(vmID).ResizeVMDisk(ctx, &vms.ResizeDiskRequestBody{
-		Disk: iface,
-		Size: *disk.Size,
-	})
-	if err != nil {
-		return fmt.Errorf("resizing disk: %w", err)
-	}
-
 	return nil
 }
 
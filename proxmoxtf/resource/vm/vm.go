@@ -19,6 +19,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/uuid"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
@@ -296,6 +297,11 @@ const (
 	mkWatchdogAction  = "action"
 )
 
+const (
+	mkInitializationUserAccountPasswordWO        = "password_wo"
+	mkInitializationUserAccountPasswordWOVersion = "password_wo_version"
+)
+
 // VM returns a resource that manages VMs.
 func VM() *schema.Resource {
 	s := map[string]*schema.Schema{
@@ -872,6 +878,25 @@ func VM() *schema.Resource {
 											strings.ReplaceAll(oldVal, "*", "") == ""
 									},
 								},
+								mkInitializationUserAccountPasswordWO: {
+									Type: schema.TypeString,
+									Description: "The SSH password, which is not stored in the state " +
+										"(requires Terraform 1.11 or later)",
+									Optional:  true,
+									Sensitive: true,
+									WriteOnly: true,
+									ConflictsWith: []string{
+										mkInitialization + ".0." + mkInitializationUserAccount + ".0." +
+											mkInitializationUserAccountPassword,
+									},
+								},
+								mkInitializationUserAccountPasswordWOVersion: {
+									Type: schema.TypeInt,
+									Description: "The version of the write-only SSH password, the VM is " +
+										"re-created with the new password when the version changes",
+									Optional: true,
+									ForceNew: true,
+								},
 								mkInitializationUserAccountUsername: {
 									Type:        schema.TypeString,
 									Description: "The SSH username",
@@ -2846,6 +2871,13 @@ func vmGetAudioDeviceList(d *schema.ResourceData) vms.CustomAudioDevices {
 	return list
 }
 
+// initializationUserAccountPasswordWO returns the write-only password of the user account, if set.
+func initializationUserAccountPasswordWO(d *schema.ResourceData) string {
+	return structure.GetWriteOnlyString(d, cty.GetAttrPath(mkInitialization).IndexInt(0).
+		GetAttr(mkInitializationUserAccount).IndexInt(0).
+		GetAttr(mkInitializationUserAccountPasswordWO))
+}
+
 func vmGetCloudInitConfig(d *schema.ResourceData) *vms.CustomCloudInitConfig {
 	initialization := d.Get(mkInitialization).([]interface{})
 
@@ -2938,6 +2970,10 @@ func vmGetCloudInitConfig(d *schema.ResourceData) *vms.CustomCloudInitConfig {
 		}
 
 		password := initializationUserAccountBlock[mkInitializationUserAccountPassword].(string)
+		if passwordWO := initializationUserAccountPasswordWO(d); passwordWO != "" {
+			password = passwordWO
+		}
+
 		if password != "" {
 			initializationConfig.Password = &password
 		}
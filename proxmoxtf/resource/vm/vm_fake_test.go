@@ -0,0 +1,99 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package resource_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bpg/terraform-provider-proxmox/fwprovider/test"
+	"github.com/bpg/terraform-provider-proxmox/proxmox/api"
+	"github.com/bpg/terraform-provider-proxmox/proxmoxtf/resource/vm"
+)
+
+// TestVMFakeServer runs the CRUD functions of the VM resource against the fake server.
+func TestVMFakeServer(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	te := test.InitFakeEnvironment(t)
+	meta := te.ProviderConfiguration()
+	r := resource.VM()
+
+	raw := map[string]any{
+		"node_name":   te.NodeName,
+		"name":        "test",
+		"description": "created",
+		"started":     false,
+		"tags":        []any{"a", "b"},
+	}
+
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+
+	diags := r.CreateContext(ctx, d, meta)
+	require.False(t, diags.HasError(), "%v", diags)
+	require.NotEmpty(t, d.Id())
+
+	vmID, err := strconv.Atoi(d.Id())
+	require.NoError(t, err)
+
+	vmClient := te.NodeClient().VM(vmID)
+
+	config, err := vmClient.GetVM(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, config.Name)
+	assert.Equal(t, "test", *config.Name)
+
+	// read the VM into a fresh state, as after an import
+	imported := r.Data(&terraform.InstanceState{ID: d.Id()})
+	require.NoError(t, imported.Set("node_name", te.NodeName))
+
+	diags = r.ReadContext(ctx, imported, meta)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "test", imported.Get("name"))
+	assert.Equal(t, "created", imported.Get("description"))
+	assert.Equal(t, []any{"a", "b"}, imported.Get("tags"))
+	assert.Equal(t, vmID, imported.Get("vm_id"))
+
+	raw["name"] = "renamed"
+	raw["description"] = "updated"
+
+	diff, err := r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(raw), meta)
+	require.NoError(t, err)
+
+	updated, err := schema.InternalMap(r.Schema).Data(d.State(), diff)
+	require.NoError(t, err)
+
+	diags = r.UpdateContext(ctx, updated, meta)
+	require.False(t, diags.HasError(), "%v", diags)
+
+	config, err = vmClient.GetVM(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, config.Name)
+	require.NotNil(t, config.Description)
+	assert.Equal(t, "renamed", *config.Name)
+	assert.Equal(t, "updated", *config.Description)
+
+	diags = r.DeleteContext(ctx, updated, meta)
+	require.False(t, diags.HasError(), "%v", diags)
+
+	_, err = vmClient.GetVM(ctx)
+	require.ErrorIs(t, err, api.ErrResourceDoesNotExist)
+
+	// a VM deleted outside of Terraform is removed from the state
+	deleted := r.Data(d.State())
+
+	diags = r.ReadContext(ctx, deleted, meta)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Empty(t, deleted.Id())
+}
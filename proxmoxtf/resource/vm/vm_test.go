@@ -318,13 +318,17 @@ func TestVMSchema(t *testing.T) {
 	test.AssertOptionalArguments(t, initializationUserAccountSchema, []string{
 		mkInitializationUserAccountKeys,
 		mkInitializationUserAccountPassword,
+		mkInitializationUserAccountPasswordWO,
+		mkInitializationUserAccountPasswordWOVersion,
 		mkInitializationUserAccountUsername,
 	})
 
 	test.AssertValueTypes(t, initializationUserAccountSchema, map[string]schema.ValueType{
-		mkInitializationUserAccountKeys:     schema.TypeList,
-		mkInitializationUserAccountPassword: schema.TypeString,
-		mkInitializationUserAccountUsername: schema.TypeString,
+		mkInitializationUserAccountKeys:              schema.TypeList,
+		mkInitializationUserAccountPassword:          schema.TypeString,
+		mkInitializationUserAccountPasswordWO:        schema.TypeString,
+		mkInitializationUserAccountPasswordWOVersion: schema.TypeInt,
+		mkInitializationUserAccountUsername:          schema.TypeString,
 	})
 
 	memorySchema := test.AssertNestedSchemaExistence(t, s, mkMemory)
@@ -12,6 +12,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/bpg/terraform-provider-proxmox/utils"
@@ -81,6 +82,18 @@ func GetSchemaBlock(
 	return resourceBlock, nil
 }
 
+// GetWriteOnlyString returns the value of a write-only string attribute from the raw configuration,
+// as write-only attributes are not available with Get. An empty string is returned if the attribute,
+// or the block it is nested in, is not set.
+func GetWriteOnlyString(d *schema.ResourceData, p cty.Path) string {
+	v, err := p.Apply(d.GetRawConfig())
+	if err != nil || v.IsNull() || !v.IsKnown() || !v.Type().Equals(cty.String) {
+		return ""
+	}
+
+	return v.AsString()
+}
+
 // SuppressIfListsAreEqualIgnoringOrder is a customdiff.SuppressionFunc that suppresses
 // changes to a list if the old and new lists are equal, ignoring the order of the
 // elements.
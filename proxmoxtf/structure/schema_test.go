@@ -0,0 +1,143 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+package structure
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetWriteOnlyString(t *testing.T) {
+	t.Parallel()
+
+	s := map[string]*schema.Schema{
+		"password_wo": {
+			Type:      schema.TypeString,
+			Optional:  true,
+			WriteOnly: true,
+		},
+		"account": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"password_wo": {
+						Type:      schema.TypeString,
+						Optional:  true,
+						WriteOnly: true,
+					},
+				},
+			},
+		},
+	}
+
+	accountType := cty.Object(map[string]cty.Type{"password_wo": cty.String})
+
+	config := func(password cty.Value, accounts cty.Value) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{
+			"id":          cty.NullVal(cty.String),
+			"password_wo": password,
+			"account":     accounts,
+		})
+	}
+
+	account := func(password cty.Value) cty.Value {
+		return cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{"password_wo": password})})
+	}
+
+	topLevel := cty.GetAttrPath("password_wo")
+	nested := cty.GetAttrPath("account").IndexInt(0).GetAttr("password_wo")
+
+	tests := []struct {
+		name      string
+		rawConfig cty.Value
+		path      cty.Path
+		expected  string
+	}{
+		{
+			"top-level attribute",
+			config(cty.StringVal("secret"), cty.ListValEmpty(accountType)),
+			topLevel,
+			"secret",
+		},
+		{
+			"null top-level attribute",
+			config(cty.NullVal(cty.String), cty.ListValEmpty(accountType)),
+			topLevel,
+			"",
+		},
+		{
+			"unknown top-level attribute",
+			config(cty.UnknownVal(cty.String), cty.ListValEmpty(accountType)),
+			topLevel,
+			"",
+		},
+		{
+			"nested attribute",
+			config(cty.NullVal(cty.String), account(cty.StringVal("nested secret"))),
+			nested,
+			"nested secret",
+		},
+		{
+			"null nested attribute",
+			config(cty.NullVal(cty.String), account(cty.NullVal(cty.String))),
+			nested,
+			"",
+		},
+		{
+			"missing block",
+			config(cty.StringVal("secret"), cty.ListValEmpty(accountType)),
+			nested,
+			"",
+		},
+		{
+			"null block",
+			config(cty.StringVal("secret"), cty.NullVal(cty.List(accountType))),
+			nested,
+			"",
+		},
+		{
+			"attribute that is not a string",
+			config(cty.NullVal(cty.String), account(cty.NullVal(cty.String))),
+			cty.GetAttrPath("account"),
+			"",
+		},
+		{
+			"attribute that is not in the schema",
+			config(cty.StringVal("secret"), cty.ListValEmpty(accountType)),
+			cty.GetAttrPath("missing"),
+			"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			d, err := schema.InternalMap(s).Data(nil, &terraform.InstanceDiff{RawConfig: tt.rawConfig})
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expected, GetWriteOnlyString(d, tt.path))
+		})
+	}
+
+	t.Run("no configuration", func(t *testing.T) {
+		t.Parallel()
+
+		d, err := schema.InternalMap(s).Data(nil, nil)
+		require.NoError(t, err)
+
+		assert.Empty(t, GetWriteOnlyString(d, topLevel))
+		assert.Empty(t, GetWriteOnlyString(d, nested))
+	})
+}
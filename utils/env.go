@@ -9,6 +9,7 @@ package utils
 import (
 	"os"
 	"strconv"
+	"strings"
 )
 
 // GetAnyStringEnv returns the first non-empty string value from the environment variables.
@@ -48,3 +49,21 @@ func GetAnyIntEnv(ks ...string) int {
 
 	return 0
 }
+
+// GetAnyStringSliceEnv returns the items of the first non-empty comma-separated list from the environment variables.
+func GetAnyStringSliceEnv(ks ...string) []string {
+	v := GetAnyStringEnv(ks...)
+	if v == "" {
+		return nil
+	}
+
+	var items []string
+
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
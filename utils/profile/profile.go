@@ -0,0 +1,504 @@
+/*
+ * This Source Code Form is subject to the terms of the Mozilla Public
+ * License, v. 2.0. If a copy of the MPL was not distributed with this
+ * file, You can obtain one at https://mozilla.org/MPL/2.0/.
+ */
+
+// Package profile loads the named provider configuration profiles of the Proxmox VE configuration file.
+//
+// The file has an INI-like format, with a section per profile:
+//
+//	[default]
+//	endpoint = https://pve.example.com:8006/
+//	api_token = terraform@pve!provider=00000000-0000-0000-0000-000000000000
+//
+//	[lab]
+//	endpoint = https://lab.example.com:8006/
+//	ca_certificate = ~/.config/proxmox/lab-ca.pem
+//	ssh_username = terraform
+//	ssh_private_key = ~/.ssh/id_lab
+//	ssh_node.pve1 = 10.0.0.11
+//	ssh_node.pve2 = 10.0.0.12:2222
+//
+// The values of the profile are used when neither the provider configuration nor the environment variables
+// set them, i.e. the precedence is: provider attribute, environment variable, profile, default.
+// The credentials are the exception, see Profile.CredentialsOverride.
+package profile
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bpg/terraform-provider-proxmox/proxmox/ssh"
+	"github.com/bpg/terraform-provider-proxmox/utils"
+)
+
+// DefaultName is the name of the profile used when no profile is selected.
+const DefaultName = "default"
+
+// Keys of the profile settings, named after the provider attributes. The SSH settings are prefixed with `ssh_`.
+const (
+	KeyEndpoint                = "endpoint"
+	KeyEndpoints               = "endpoints"
+	KeyInsecure                = "insecure"
+	KeyMinTLS                  = "min_tls"
+	KeyCACertificate           = "ca_certificate"
+	KeyTLSFingerprint          = "tls_fingerprint"
+	KeyAPIToken                = "api_token"
+	KeyUsername                = "username"
+	KeyPassword                = "password"
+	KeyCredentialsCommand      = "credentials_command"
+	KeyTmpDir                  = "tmp_dir"
+	KeySSHUsername             = "ssh_username"
+	KeySSHPassword             = "ssh_password"
+	KeySSHAgent                = "ssh_agent"
+	KeySSHAgentSocket          = "ssh_agent_socket"
+	KeySSHPrivateKey           = "ssh_private_key"
+	KeySSHPrivateKeyPassphrase = "ssh_private_key_passphrase"
+	KeySSHCertificate          = "ssh_certificate"
+	KeySSHSocks5Server         = "ssh_socks5_server"
+	KeySSHSocks5Username       = "ssh_socks5_username"
+	KeySSHSocks5Password       = "ssh_socks5_password"
+	KeySSHHostKeyVerification  = "ssh_host_key_verification"
+	KeySSHKnownHostsFile       = "ssh_known_hosts_file"
+	KeySSHKnownHosts           = "ssh_known_hosts"
+	KeySSHNodeAddressSource    = "ssh_node_address_source"
+	KeySSHNodeAddressCIDRs     = "ssh_node_address_cidrs"
+
+	// keySSHNodePrefix prefixes the node overrides, e.g. `ssh_node.pve1 = 10.0.0.11:22`.
+	keySSHNodePrefix = "ssh_node."
+)
+
+type valueKind int
+
+const (
+	kindString valueKind = iota
+	kindBool
+	kindList
+	// kindFile is the content of a file, or the content itself if it matches the content marker.
+	kindFile
+	// kindPath is a path, with `~/` expanded to the home directory.
+	kindPath
+)
+
+//nolint:gochecknoglobals
+var keys = map[string]valueKind{
+	KeyEndpoint:                kindString,
+	KeyEndpoints:               kindList,
+	KeyInsecure:                kindBool,
+	KeyMinTLS:                  kindString,
+	KeyCACertificate:           kindPath,
+	KeyTLSFingerprint:          kindString,
+	KeyAPIToken:                kindString,
+	KeyUsername:                kindString,
+	KeyPassword:                kindString,
+	KeyCredentialsCommand:      kindString,
+	KeyTmpDir:                  kindPath,
+	KeySSHUsername:             kindString,
+	KeySSHPassword:             kindString,
+	KeySSHAgent:                kindBool,
+	KeySSHAgentSocket:          kindPath,
+	KeySSHPrivateKey:           kindFile,
+	KeySSHPrivateKeyPassphrase: kindString,
+	KeySSHCertificate:          kindFile,
+	KeySSHSocks5Server:         kindString,
+	KeySSHSocks5Username:       kindString,
+	KeySSHSocks5Password:       kindString,
+	KeySSHHostKeyVerification:  kindString,
+	KeySSHKnownHostsFile:       kindPath,
+	KeySSHKnownHosts:           kindString,
+	KeySSHNodeAddressSource:    kindString,
+	KeySSHNodeAddressCIDRs:     kindList,
+}
+
+// contentMarkers tell the inline content of the file settings from the paths to the files.
+//
+//nolint:gochecknoglobals
+var contentMarkers = map[string]string{
+	KeySSHPrivateKey:  "-----BEGIN",
+	KeySSHCertificate: "-cert-v01@openssh.com ",
+}
+
+// Profile is a named set of provider settings. A nil profile has no settings.
+type Profile struct {
+	Name string
+
+	values map[string]string
+	nodes  map[string]ssh.ProxmoxNode
+}
+
+// Path returns the path of the configuration file: `PROXMOX_VE_CONFIG` if set,
+// otherwise `proxmox/config` in `XDG_CONFIG_HOME`, which defaults to `~/.config`.
+func Path() (string, error) {
+	if p := utils.GetAnyStringEnv("PROXMOX_VE_CONFIG"); p != "" {
+		return expandHome(p)
+	}
+
+	configHome := utils.GetAnyStringEnv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to determine the home directory: %w", err)
+		}
+
+		configHome = filepath.Join(home, ".config")
+	}
+
+	return filepath.Join(configHome, "proxmox", "config"), nil
+}
+
+// Load loads the profile from the configuration file at Path. The `default` profile is used when no name is
+// given, and is optional: nil is returned if there is no configuration file, or no `default` profile in it.
+func Load(name string) (*Profile, error) {
+	p, err := Path()
+	if err != nil {
+		return nil, err
+	}
+
+	return LoadFile(p, name)
+}
+
+// LoadFile loads the profile from the configuration file, see Load.
+func LoadFile(path string, name string) (*Profile, error) {
+	optional := name == ""
+	if optional {
+		name = DefaultName
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		if optional && errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to open the configuration file: %w", err)
+	}
+
+	defer func() { _ = f.Close() }()
+
+	sections, err := parse(bufio.NewScanner(f))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the configuration file %s: %w", path, err)
+	}
+
+	values, ok := sections[name]
+	if !ok {
+		if optional {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("profile %q is not found in the configuration file %s", name, path)
+	}
+
+	profile, err := newProfile(name, values)
+	if err != nil {
+		return nil, fmt.Errorf("invalid profile %q in the configuration file %s: %w", name, path, err)
+	}
+
+	return profile, nil
+}
+
+// parse parses the sections of the configuration file, with a `key = value` pair per line.
+// The lines starting with `#` or `;` are comments.
+func parse(scanner *bufio.Scanner) (map[string]map[string]string, error) {
+	sections := map[string]map[string]string{}
+
+	var section map[string]string
+
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("line %d: invalid section header %q", n, line)
+			}
+
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			if name == "" {
+				return nil, fmt.Errorf("line %d: empty profile name", n)
+			}
+
+			if _, exists := sections[name]; exists {
+				return nil, fmt.Errorf("line %d: duplicate profile %q", n, name)
+			}
+
+			section = map[string]string{}
+			sections[name] = section
+
+			continue
+		}
+
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			return nil, fmt.Errorf("line %d: expected `key = value`", n)
+		}
+
+		if section == nil {
+			return nil, fmt.Errorf("line %d: setting outside of a profile section", n)
+		}
+
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+
+		if unquoted, err := strconv.Unquote(value); err == nil && strings.HasPrefix(value, `"`) {
+			value = unquoted
+		}
+
+		if _, exists := section[key]; exists {
+			return nil, fmt.Errorf("line %d: duplicate setting %q", n, key)
+		}
+
+		section[key] = value
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read the configuration file: %w", err)
+	}
+
+	return sections, nil
+}
+
+func newProfile(name string, values map[string]string) (*Profile, error) {
+	p := &Profile{
+		Name:   name,
+		values: map[string]string{},
+		nodes:  map[string]ssh.ProxmoxNode{},
+	}
+
+	for key, value := range values {
+		if nodeName, ok := strings.CutPrefix(key, keySSHNodePrefix); ok {
+			node, err := parseNode(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", key, err)
+			}
+
+			p.nodes[nodeName] = node
+
+			continue
+		}
+
+		kind, ok := keys[key]
+		if !ok {
+			return nil, fmt.Errorf("unsupported setting %q", key)
+		}
+
+		switch kind {
+		case kindBool:
+			if _, err := strconv.ParseBool(value); err != nil {
+				return nil, fmt.Errorf("%s: invalid boolean %q", key, value)
+			}
+		case kindFile:
+			if value != "" && !strings.Contains(value, contentMarkers[key]) {
+				content, err := readFile(value)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", key, err)
+				}
+
+				value = content
+			}
+		case kindPath:
+			expanded, err := expandHome(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", key, err)
+			}
+
+			value = expanded
+		case kindString, kindList:
+		}
+
+		p.values[key] = value
+	}
+
+	return p, nil
+}
+
+// parseNode parses a node override of the `address[:port]` form.
+func parseNode(value string) (ssh.ProxmoxNode, error) {
+	host, port, err := net.SplitHostPort(value)
+	if err != nil {
+		// no port, the address may still be a bracketed IPv6 address
+		host, port = strings.TrimSuffix(strings.TrimPrefix(value, "["), "]"), "22"
+	}
+
+	if host == "" {
+		return ssh.ProxmoxNode{}, errors.New("empty address")
+	}
+
+	p, err := strconv.ParseInt(port, 10, 32)
+	if err != nil || p < 1 || p > 65535 {
+		return ssh.ProxmoxNode{}, fmt.Errorf("invalid port %q", port)
+	}
+
+	return ssh.ProxmoxNode{Address: host, Port: int32(p)}, nil
+}
+
+func readFile(path string) (string, error) {
+	path, err := expandHome(path)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the file: %w", err)
+	}
+
+	return string(data), nil
+}
+
+func expandHome(path string) (string, error) {
+	rest, ok := strings.CutPrefix(path, "~/")
+	if !ok {
+		return path, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine the home directory: %w", err)
+	}
+
+	return filepath.Join(home, rest), nil
+}
+
+// String returns the first non-empty value of the environment variables, or the value of the profile setting.
+func (p *Profile) String(key string, envs ...string) string {
+	if v := utils.GetAnyStringEnv(envs...); v != "" {
+		return v
+	}
+
+	if p == nil {
+		return ""
+	}
+
+	return p.values[key]
+}
+
+// Bool returns the first non-empty boolean value of the environment variables,
+// or the value of the profile setting.
+func (p *Profile) Bool(key string, envs ...string) bool {
+	if utils.GetAnyStringEnv(envs...) != "" {
+		return utils.GetAnyBoolEnv(envs...)
+	}
+
+	if p == nil {
+		return false
+	}
+
+	v, _ := strconv.ParseBool(p.values[key])
+
+	return v
+}
+
+// Strings returns the items of the first non-empty comma-separated list of the environment variables,
+// or of the profile setting.
+func (p *Profile) Strings(key string, envs ...string) []string {
+	if v := utils.GetAnyStringSliceEnv(envs...); v != nil {
+		return v
+	}
+
+	if p == nil || p.values[key] == "" {
+		return nil
+	}
+
+	var items []string
+
+	for _, item := range strings.Split(p.values[key], ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// StringsOverride returns the items configured in the provider configuration, or the items of the profile setting
+// if none are configured. An empty list counts as not configured, as the SDK provider cannot tell it from an unset
+// one, so both providers apply the same rule.
+func (p *Profile) StringsOverride(key string, configured []string) []string {
+	if len(configured) > 0 {
+		return configured
+	}
+
+	return p.Strings(key)
+}
+
+// Credentials are the API credentials of the provider: the API token, the username and password, the
+// authentication ticket, or the credentials command.
+type Credentials struct {
+	APIToken            string
+	Username            string
+	Password            string
+	AuthTicket          string
+	CSRFPreventionToken string
+	CredentialsCommand  []string
+}
+
+// IsEmpty returns true if none of the credentials is set.
+func (c Credentials) IsEmpty() bool {
+	return c.APIToken == "" &&
+		c.Username == "" &&
+		c.Password == "" &&
+		c.AuthTicket == "" &&
+		c.CSRFPreventionToken == "" &&
+		len(c.CredentialsCommand) == 0
+}
+
+// CredentialsOverride returns the credentials set in the provider configuration or the environment variables,
+// or the credentials of the profile if none are set. The credentials are used as a group: the profile never
+// completes the configured credentials, e.g. its API token is ignored when a username and password are set.
+func (p *Profile) CredentialsOverride(configured Credentials) Credentials {
+	if !configured.IsEmpty() || p == nil {
+		return configured
+	}
+
+	return Credentials{
+		APIToken:           p.values[KeyAPIToken],
+		Username:           p.values[KeyUsername],
+		Password:           p.values[KeyPassword],
+		CredentialsCommand: strings.Fields(p.values[KeyCredentialsCommand]),
+	}
+}
+
+// Nodes returns the SSH node address overrides of the profile, which the `node` blocks of the `ssh`
+// provider configuration override by node name.
+func (p *Profile) Nodes() map[string]ssh.ProxmoxNode {
+	nodes := map[string]ssh.ProxmoxNode{}
+
+	if p == nil {
+		return nodes
+	}
+
+	for name, n := range p.nodes {
+		nodes[name] = n
+	}
+
+	return nodes
+}
+
+// Keys returns the names of the settings of the profile, without the node overrides.
+func (p *Profile) Keys() []string {
+	if p == nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(p.values))
+	for k := range p.values {
+		names = append(names, k)
+	}
+
+	sort.Strings(names)
+
+	return names
+}